@@ -0,0 +1,151 @@
+/*
+Capture is a tool to capture a snapshot of selected objects on a cluster into a YAML file, so helpers can be unit tested
+offline against real-world data. Snapshots are loaded into the eco-goinfra fake client in unit tests using
+snapshot.NewTestClient from the internal/snapshot package.
+
+Every object of the selected resources is captured across all namespaces. Resources whose CRD is not installed on the
+cluster are skipped. Managed fields, resource versions, and last applied configuration annotations are removed so
+snapshots diff cleanly when recaptured. Snapshots are not otherwise redacted, so they should be reviewed before being
+committed.
+
+Upon success the exit code is 0. If any error occurs, it will be logged to stderr and the exit code will be 1.
+
+Usage:
+
+	capture [flags]
+
+The flags are:
+
+	-h, -help
+		Print this help message
+
+	-k, -kubeconfig string
+		Path to the kubeconfig of the cluster. Defaults to the KUBECONFIG environment variable
+
+	-o, -output string
+		File to write the snapshot to. Use - for stdout. Defaults to -
+
+	-r, -resources string
+		Comma-separated list of resources to capture. Defaults to every resource
+
+	-v int
+		Log level verbosity for klog. Use 100 for logging all messages or leave blank for none
+
+The resources that can be captured are nodes, clusterversions, clusterserviceversions, ptpconfigs,
+sriovnetworknodestates, sriovnetworknodepolicies, nodenetworkstates, and policies.
+*/
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/snapshot"
+	"k8s.io/klog/v2"
+)
+
+var (
+	help       bool
+	kubeconfig string
+	output     string
+	resources  string
+)
+
+//nolint:gochecknoinits // This is a main package so init is fine.
+func init() {
+	const (
+		helpUsage       = "Print this help message"
+		kubeconfigUsage = "Path to the kubeconfig of the cluster. Defaults to the KUBECONFIG environment variable"
+		outputUsage     = "File to write the snapshot to. Use - for stdout"
+		resourcesUsage  = "Comma-separated list of resources to capture. Defaults to every resource"
+
+		defaultHelp       = false
+		defaultKubeconfig = ""
+		defaultOutput     = "-"
+		defaultResources  = ""
+
+		shorthand = " (shorthand)"
+	)
+
+	klog.InitFlags(nil)
+
+	_ = flag.Set("logtostderr", "true")
+
+	flag.BoolVar(&help, "help", defaultHelp, helpUsage)
+	flag.BoolVar(&help, "h", defaultHelp, helpUsage+shorthand)
+
+	flag.StringVar(&kubeconfig, "kubeconfig", defaultKubeconfig, kubeconfigUsage)
+	flag.StringVar(&kubeconfig, "k", defaultKubeconfig, kubeconfigUsage+shorthand)
+
+	flag.StringVar(&output, "output", defaultOutput, outputUsage)
+	flag.StringVar(&output, "o", defaultOutput, outputUsage+shorthand)
+
+	flag.StringVar(&resources, "resources", defaultResources, resourcesUsage)
+	flag.StringVar(&resources, "r", defaultResources, resourcesUsage+shorthand)
+}
+
+func main() {
+	flag.Parse()
+
+	if help {
+		flag.Usage()
+
+		return
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	selectedResources, err := snapshot.LookupResources(splitList(resources)...)
+	if err != nil {
+		klog.Errorf("Invalid resources: %v", err)
+
+		os.Exit(1)
+	}
+
+	apiClient := clients.New(kubeconfig)
+	if apiClient == nil {
+		klog.Errorf("Failed to load kubeconfig %q", kubeconfig)
+
+		os.Exit(1)
+	}
+
+	objects, err := snapshot.Capture(ctx, apiClient, selectedResources)
+	if err != nil {
+		klog.Errorf("Failed to capture snapshot: %v", err)
+
+		os.Exit(1)
+	}
+
+	if output == "-" {
+		err = snapshot.Write(os.Stdout, objects)
+	} else {
+		err = snapshot.WriteFile(output, objects)
+	}
+
+	if err != nil {
+		klog.Errorf("Failed to write snapshot: %v", err)
+
+		os.Exit(1)
+	}
+
+	klog.V(90).Infof("Captured %d objects", len(objects))
+}
+
+// splitList splits a comma-separated list, ignoring surrounding whitespace and empty elements.
+func splitList(list string) []string {
+	var elements []string
+
+	for element := range strings.SplitSeq(list, ",") {
+		element = strings.TrimSpace(element)
+		if element != "" {
+			elements = append(elements, element)
+		}
+	}
+
+	return elements
+}
@@ -0,0 +1,261 @@
+/*
+Configdoc is a tool to document every environment variable read by the suite configs. It parses the config structs
+listed in registry.go from source, following embedded structs, and collects the envconfig, yaml, default, and validate
+tags of every field along with the default values from default.yaml and the description from the doc comment or suite
+README.
+
+It can write a JSON Schema describing the environment of a test run, a Markdown reference with a table per suite, or
+both. An env file, such as one generated from a CI job definition, can also be validated against the schema to catch
+unknown variables and invalid values before starting a run.
+
+Upon success the exit code is 0. If any error occurs or the env file is invalid, it will be logged to stderr and the
+exit code will be 1.
+
+Usage:
+
+	configdoc [flags]
+
+The flags are:
+
+	-h, -help
+		Print this help message
+
+	-e, -env-file string
+		Env file of KEY=VALUE lines to validate against the schema. Will not be validated if left blank
+
+	-m, -markdown string
+		File to write the Markdown reference to. Use - for stdout. Defaults to - if no other output
+
+	-r, -root string
+		Root of the eco-gotests repo. Defaults to the current directory
+
+	-s, -schema string
+		File to write the JSON Schema to. Use - for stdout. Will not be written if left blank
+
+	-v int
+		Log level verbosity for klog. Use 100 for logging all messages or leave blank for none
+*/
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"flag"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// unknownEnvPrefix is the prefix of environment variables that are reported as unknown when validating an env file.
+const unknownEnvPrefix = "ECO_"
+
+var (
+	help     bool
+	envFile  string
+	markdown string
+	root     string
+	schema   string
+)
+
+//nolint:gochecknoinits // This is a main package so init is fine.
+func init() {
+	const (
+		helpUsage     = "Print this help message"
+		envFileUsage  = "Env file of KEY=VALUE lines to validate against the schema. Will not be validated if left blank"
+		markdownUsage = "File to write the Markdown reference to. Use - for stdout. Defaults to - if no other output"
+		rootUsage     = "Root of the eco-gotests repo. Defaults to the current directory"
+		schemaUsage   = "File to write the JSON Schema to. Use - for stdout. Will not be written if left blank"
+
+		defaultHelp     = false
+		defaultEnvFile  = ""
+		defaultMarkdown = ""
+		defaultRoot     = "."
+		defaultSchema   = ""
+
+		shorthand = " (shorthand)"
+	)
+
+	klog.InitFlags(nil)
+
+	_ = flag.Set("logtostderr", "true")
+
+	flag.BoolVar(&help, "help", defaultHelp, helpUsage)
+	flag.BoolVar(&help, "h", defaultHelp, helpUsage+shorthand)
+
+	flag.StringVar(&envFile, "env-file", defaultEnvFile, envFileUsage)
+	flag.StringVar(&envFile, "e", defaultEnvFile, envFileUsage+shorthand)
+
+	flag.StringVar(&markdown, "markdown", defaultMarkdown, markdownUsage)
+	flag.StringVar(&markdown, "m", defaultMarkdown, markdownUsage+shorthand)
+
+	flag.StringVar(&root, "root", defaultRoot, rootUsage)
+	flag.StringVar(&root, "r", defaultRoot, rootUsage+shorthand)
+
+	flag.StringVar(&schema, "schema", defaultSchema, schemaUsage)
+	flag.StringVar(&schema, "s", defaultSchema, schemaUsage+shorthand)
+}
+
+func main() {
+	flag.Parse()
+
+	if help {
+		flag.Usage()
+
+		return
+	}
+
+	parser := NewParser(root)
+
+	suiteConfigs, err := parser.ParseConfigs()
+	if err != nil {
+		klog.Errorf("Failed to parse configs: %v", err)
+
+		os.Exit(1)
+	}
+
+	warnUnregistered(root, parser.ParsedDirs())
+
+	runnerConfig, err := parser.ParseRunner()
+	if err != nil {
+		klog.Errorf("Failed to parse test runner: %v", err)
+
+		os.Exit(1)
+	}
+
+	suiteConfigs = append([]*SuiteConfig{runnerConfig}, suiteConfigs...)
+
+	configSchema := NewSchema(suiteConfigs)
+
+	if schema != "" {
+		err = writeOutput(schema, configSchema.Write)
+		if err != nil {
+			klog.Errorf("Failed to write schema to %s: %v", schema, err)
+
+			os.Exit(1)
+		}
+	}
+
+	if markdown != "" || (schema == "" && envFile == "") {
+		err = writeOutput(cmp.Or(markdown, "-"), func(writer io.Writer) error {
+			return WriteMarkdown(writer, suiteConfigs)
+		})
+		if err != nil {
+			klog.Errorf("Failed to write Markdown to %s: %v", markdown, err)
+
+			os.Exit(1)
+		}
+	}
+
+	if envFile == "" {
+		return
+	}
+
+	env, err := readEnvFile(envFile)
+	if err != nil {
+		klog.Errorf("Failed to read env file %s: %v", envFile, err)
+
+		os.Exit(1)
+	}
+
+	problems := configSchema.ValidateEnv(env, unknownEnvPrefix)
+	for _, problem := range problems {
+		klog.Errorf("Invalid env file %s: %s", envFile, problem)
+	}
+
+	if len(problems) > 0 {
+		os.Exit(1)
+	}
+}
+
+// writeOutput calls write with stdout if fileName is - or with the file otherwise, creating or truncating it.
+func writeOutput(fileName string, write func(writer io.Writer) error) error {
+	if fileName == "-" {
+		return write(os.Stdout)
+	}
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+
+	err = write(file)
+	if err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	return file.Close()
+}
+
+// readEnvFile reads an env file with one KEY=VALUE per line. Blank lines and lines starting with # are ignored, an
+// optional export prefix is removed, and values may be wrapped in single or double quotes.
+func readEnvFile(fileName string) (map[string]string, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	env := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !ok {
+			klog.V(100).Infof("Skipping line without = in env file: %s", line)
+
+			continue
+		}
+
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		} else if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+			value = value[1 : len(value)-1]
+		}
+
+		env[strings.TrimSpace(name)] = value
+	}
+
+	return env, scanner.Err()
+}
+
+// warnUnregistered logs a warning for every config package under tests/ that declares envconfig tags but was not
+// parsed, meaning it is missing from registeredConfigs.
+func warnUnregistered(root string, parsedDirs []string) {
+	testsDir := filepath.Join(root, "tests")
+
+	_ = filepath.WalkDir(testsDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		dir, err := filepath.Rel(root, filepath.Dir(path))
+		if err != nil || slices.Contains(parsedDirs, dir) || !strings.Contains(dir, "config") {
+			return nil
+		}
+
+		contents, err := os.ReadFile(path)
+		if err == nil && strings.Contains(string(contents), `envconfig:"ECO_`) {
+			klog.Warningf("Package in %s has envconfig tags but is not in registeredConfigs", dir)
+
+			parsedDirs = append(parsedDirs, dir)
+		}
+
+		return nil
+	})
+}
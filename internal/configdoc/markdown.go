@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// WriteMarkdown writes a section for each suite config containing a table of the environment variables it reads. The
+// variables of included configs are not repeated but linked to instead.
+func WriteMarkdown(writer io.Writer, suiteConfigs []*SuiteConfig) error {
+	builder := &strings.Builder{}
+
+	builder.WriteString("# Configuration reference\n\n")
+	builder.WriteString("Generated by `go run ./internal/configdoc`. Each suite config is loaded from the default.yaml " +
+		"next to it, then the file in `ECO_CONFIG_OVERLAY_FILE`, then the environment variables below.\n")
+
+	for _, suiteConfig := range suiteConfigs {
+		fmt.Fprintf(builder, "\n## %s\n\n", suiteConfig.Suite)
+
+		if suiteConfig.Type == "" {
+			fmt.Fprintf(builder, "Read by `%s`.\n", suiteConfig.Dir)
+		} else {
+			fmt.Fprintf(builder, "Loaded into `%s` from `%s`.\n",
+				suiteConfig.Type, filepath.Join(suiteConfig.Dir, "default.yaml"))
+		}
+
+		if len(suiteConfig.Includes) > 0 {
+			links := make([]string, 0, len(suiteConfig.Includes))
+			for _, suite := range suiteConfig.Includes {
+				links = append(links, fmt.Sprintf("[%s](#%s)", suite, markdownAnchor(suite)))
+			}
+
+			fmt.Fprintf(builder, "Also reads the variables of %s.\n", strings.Join(links, ", "))
+		}
+
+		if len(suiteConfig.Vars) == 0 {
+			continue
+		}
+
+		builder.WriteString("\n| Variable | YAML key | Type | Default | Description |\n")
+		builder.WriteString("|----------|----------|------|---------|-------------|\n")
+
+		for _, envVar := range suiteConfig.Vars {
+			fmt.Fprintf(builder, "| `%s` | %s | `%s` | %s | %s |\n",
+				envVar.Name,
+				markdownCode(envVar.YAMLKey),
+				envVar.Type,
+				markdownCode(envVar.Default),
+				escapeMarkdownCell(envVar.Description))
+		}
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+
+	return err
+}
+
+// markdownCode formats value as inline code, or _(empty)_ if it is empty, matching the tables in the suite READMEs.
+func markdownCode(value string) string {
+	if value == "" {
+		return "_(empty)_"
+	}
+
+	return "`" + escapeMarkdownCell(value) + "`"
+}
+
+// escapeMarkdownCell escapes pipes and removes newlines so value can be placed in a single table cell.
+func escapeMarkdownCell(value string) string {
+	value = strings.ReplaceAll(value, "\n", " ")
+
+	return strings.ReplaceAll(value, "|", `\|`)
+}
+
+// markdownAnchor returns the anchor GitHub generates for a heading with the provided text.
+func markdownAnchor(heading string) string {
+	return strings.Map(func(char rune) rune {
+		switch {
+		case char >= 'a' && char <= 'z', char >= '0' && char <= '9', char == '-', char == '_':
+			return char
+		case char >= 'A' && char <= 'Z':
+			return char - 'A' + 'a'
+		case char == ' ':
+			return '-'
+		default:
+			return -1
+		}
+	}, heading)
+}
@@ -0,0 +1,697 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// modulePath is the import path of the repo module. Only types from packages in this module are parsed.
+	modulePath = "github.com/rh-ecosystem-edge/eco-gotests"
+	// runnerEnvFile is the path of the file of the test runner that reads its environment variables, relative to the
+	// repo root. They are documented in the top level README.
+	runnerEnvFile = "internal/runner/env.go"
+)
+
+// listDescriptionRegexp matches list items in a README that describe an environment variable.
+var listDescriptionRegexp = regexp.MustCompile("^- `(ECO_[A-Z0-9_]+)`: (.+)$")
+
+// runnerEnvRegexp matches the environment variables used by the test runner.
+var runnerEnvRegexp = regexp.MustCompile(`ECO_[A-Z0-9_]+`)
+
+// decoderMethods are the methods that cause envconfig to decode a struct field from a single environment variable
+// rather than recursing into it.
+var decoderMethods = []string{"Decode", "Set", "UnmarshalText", "UnmarshalBinary"}
+
+// EnvVar is a single environment variable read by a config struct along with everything known about it.
+type EnvVar struct {
+	Name  string
+	Field string
+	// YAMLKey is the key in default.yaml, with nested keys separated by dots. It is empty if the field cannot be set
+	// from YAML.
+	YAMLKey string
+	// Type is the Go type of the field as written in the source.
+	Type    string
+	Default string
+	// Description comes from the doc comment of the field if it has one, otherwise from the README of the suite.
+	Description string
+	// Validate is the validate tag of the field, used by the config loader.
+	Validate string
+}
+
+// SuiteConfig is a RegisteredConfig along with all the environment variables it reads.
+type SuiteConfig struct {
+	RegisteredConfig
+	// Includes lists the suites of the registered configs embedded in this one. Their variables are not repeated in
+	// Vars.
+	Includes []string
+	// Vars are in the order the fields are declared, with embedded structs expanded in place.
+	Vars []EnvVar
+}
+
+// Parser parses config structs from source rather than importing them. Most config packages are internal to the suite
+// that uses them, so they cannot be imported from here, and importing them would run the cluster initialization in
+// inittools anyway.
+type Parser struct {
+	root       string
+	packages   map[string]*configPackage
+	registered map[typeKey]string
+}
+
+// typeKey identifies a type by the directory of its package, relative to the repo root, and its name.
+type typeKey struct {
+	dir  string
+	name string
+}
+
+// configPackage contains everything parsed from a single package directory.
+type configPackage struct {
+	dir   string
+	types map[string]*typeDecl
+	// decoders are the names of types with a method in decoderMethods.
+	decoders map[string]bool
+	// defaults is the contents of default.yaml, if the package has one.
+	defaults map[string]any
+	// descriptions maps environment variables to their description from the tables in the suite README.
+	descriptions map[string]string
+	// getenvs are the environment variables read directly using os.Getenv with a literal name, in the order they
+	// appear.
+	getenvs []string
+}
+
+// typeDecl is a single type declaration along with the imports of the file declaring it, mapping package name to
+// import path.
+type typeDecl struct {
+	expr    ast.Expr
+	imports map[string]string
+}
+
+// NewParser returns a Parser for the repo at root. It does not parse anything until ParseConfigs is called.
+func NewParser(root string) *Parser {
+	parser := &Parser{
+		root:       root,
+		packages:   make(map[string]*configPackage),
+		registered: make(map[typeKey]string),
+	}
+
+	for _, config := range registeredConfigs {
+		parser.registered[typeKey{dir: config.Dir, name: config.Type}] = config.Suite
+	}
+
+	return parser
+}
+
+// ParseConfigs parses every config in registeredConfigs and returns them in the same order.
+func (parser *Parser) ParseConfigs() ([]*SuiteConfig, error) {
+	var suiteConfigs []*SuiteConfig
+
+	for _, config := range registeredConfigs {
+		suiteConfig, err := parser.parseConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse config for suite %s: %w", config.Suite, err)
+		}
+
+		suiteConfigs = append(suiteConfigs, suiteConfig)
+	}
+
+	return suiteConfigs, nil
+}
+
+// ParseRunner returns a SuiteConfig for the variables read by the test runner. It has no Type since the variables are
+// not read into a config struct.
+func (parser *Parser) ParseRunner() (*SuiteConfig, error) {
+	contents, err := os.ReadFile(filepath.Join(parser.root, runnerEnvFile))
+	if err != nil {
+		return nil, err
+	}
+
+	descriptions, err := readDescriptions(filepath.Join(parser.root, "README.md"))
+	if err != nil {
+		return nil, err
+	}
+
+	suiteConfig := &SuiteConfig{RegisteredConfig: RegisteredConfig{Suite: "test-runner", Dir: filepath.Dir(runnerEnvFile)}}
+
+	for _, name := range runnerEnvRegexp.FindAllString(string(contents), -1) {
+		if !slices.ContainsFunc(suiteConfig.Vars, func(envVar EnvVar) bool { return envVar.Name == name }) {
+			suiteConfig.Vars = append(suiteConfig.Vars, EnvVar{Name: name, Type: "string", Description: descriptions[name]})
+		}
+	}
+
+	return suiteConfig, nil
+}
+
+// ParsedDirs returns the sorted directories of every package parsed so far, including those of embedded types.
+func (parser *Parser) ParsedDirs() []string {
+	var dirs []string
+	for dir := range parser.packages {
+		dirs = append(dirs, dir)
+	}
+
+	slices.Sort(dirs)
+
+	return dirs
+}
+
+// parseConfig parses a single registered config, expanding any embedded structs that are not registered themselves.
+func (parser *Parser) parseConfig(config RegisteredConfig) (*SuiteConfig, error) {
+	klog.V(100).Infof("Parsing config %s in %s", config.Type, config.Dir)
+
+	configPackage, err := parser.loadPackage(config.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	decl, ok := configPackage.types[config.Type]
+	if !ok {
+		return nil, fmt.Errorf("type %s not found in %s", config.Type, config.Dir)
+	}
+
+	structType, ok := decl.expr.(*ast.StructType)
+	if !ok {
+		return nil, fmt.Errorf("type %s in %s is not a struct", config.Type, config.Dir)
+	}
+
+	suiteConfig := &SuiteConfig{RegisteredConfig: config}
+
+	err = parser.collectFields(suiteConfig, configPackage, decl, structType, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range configPackage.getenvs {
+		if !slices.ContainsFunc(suiteConfig.Vars, func(envVar EnvVar) bool { return envVar.Name == name }) {
+			suiteConfig.Vars = append(suiteConfig.Vars,
+				EnvVar{Name: name, Type: "string", Description: configPackage.descriptions[name]})
+		}
+	}
+
+	return suiteConfig, nil
+}
+
+// collectFields adds an EnvVar to suiteConfig for every field of structType with an envconfig tag. Embedded structs
+// and nested structs with a yaml tag are recursed into. The yamlPath is the list of YAML keys leading to structType
+// and is used for finding the default values.
+func (parser *Parser) collectFields(
+	suiteConfig *SuiteConfig, configPackage *configPackage, decl *typeDecl, structType *ast.StructType, yamlPath []string,
+) error {
+	for _, field := range structType.Fields.List {
+		var tag reflect.StructTag
+
+		if field.Tag != nil {
+			unquoted, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return err
+			}
+
+			tag = reflect.StructTag(unquoted)
+		}
+
+		if ignored, _ := strconv.ParseBool(tag.Get("ignored")); ignored {
+			continue
+		}
+
+		if len(field.Names) == 0 {
+			err := parser.collectEmbedded(suiteConfig, configPackage, decl, field, yamlPath)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		yamlKey, _, _ := strings.Cut(tag.Get("yaml"), ",")
+		if yamlKey == "-" {
+			yamlKey = ""
+		}
+
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+
+			envKey := tag.Get("envconfig")
+			if envKey != "" {
+				suiteConfig.Vars = append(suiteConfig.Vars,
+					configPackage.newEnvVar(name.Name, envKey, yamlKey, yamlPath, tag, field))
+
+				continue
+			}
+
+			if yamlKey == "" {
+				continue
+			}
+
+			fieldPackage, fieldDecl, fieldStruct := parser.resolveStruct(configPackage, decl, field.Type)
+			if fieldStruct == nil {
+				continue
+			}
+
+			fieldPath := append(slices.Clone(yamlPath), yamlKey)
+
+			err := parser.collectFields(suiteConfig, fieldPackage, fieldDecl, fieldStruct, fieldPath)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// collectEmbedded handles an embedded field. Registered configs are added to the includes while other structs have
+// their fields collected as if they were declared in the embedding struct.
+func (parser *Parser) collectEmbedded(
+	suiteConfig *SuiteConfig, configPackage *configPackage, decl *typeDecl, field *ast.Field, yamlPath []string,
+) error {
+	fieldPackage, typeName, ok := parser.resolve(configPackage, decl, field.Type)
+	if !ok {
+		return nil
+	}
+
+	if suite, ok := parser.registered[typeKey{dir: fieldPackage.dir, name: typeName}]; ok {
+		suiteConfig.Includes = append(suiteConfig.Includes, suite)
+
+		return nil
+	}
+
+	fieldDecl := fieldPackage.types[typeName]
+	if fieldStruct, ok := fieldDecl.expr.(*ast.StructType); ok {
+		return parser.collectFields(suiteConfig, fieldPackage, fieldDecl, fieldStruct, yamlPath)
+	}
+
+	return nil
+}
+
+// resolveStruct resolves expr to a struct declared in the module. It returns a nil struct if expr is not such a
+// struct or if envconfig would decode it directly.
+func (parser *Parser) resolveStruct(
+	configPackage *configPackage, decl *typeDecl, expr ast.Expr) (*configPackage, *typeDecl, *ast.StructType) {
+	fieldPackage, typeName, ok := parser.resolve(configPackage, decl, expr)
+	if !ok || fieldPackage.decoders[typeName] {
+		return nil, nil, nil
+	}
+
+	fieldDecl := fieldPackage.types[typeName]
+
+	fieldStruct, ok := fieldDecl.expr.(*ast.StructType)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	return fieldPackage, fieldDecl, fieldStruct
+}
+
+// resolve finds the package and name of the type referred to by expr, ignoring any pointers. It returns false if expr
+// does not refer to a named type declared in the module.
+func (parser *Parser) resolve(
+	configPackage *configPackage, decl *typeDecl, expr ast.Expr) (*configPackage, string, bool) {
+	for {
+		star, ok := expr.(*ast.StarExpr)
+		if !ok {
+			break
+		}
+
+		expr = star.X
+	}
+
+	switch typed := expr.(type) {
+	case *ast.Ident:
+		_, ok := configPackage.types[typed.Name]
+
+		return configPackage, typed.Name, ok
+	case *ast.SelectorExpr:
+		packageIdent, ok := typed.X.(*ast.Ident)
+		if !ok {
+			return nil, "", false
+		}
+
+		importPath, ok := decl.imports[packageIdent.Name]
+		if !ok || !strings.HasPrefix(importPath, modulePath+"/") {
+			return nil, "", false
+		}
+
+		fieldPackage, err := parser.loadPackage(strings.TrimPrefix(importPath, modulePath+"/"))
+		if err != nil {
+			klog.V(100).Infof("Failed to load package %s: %v", importPath, err)
+
+			return nil, "", false
+		}
+
+		_, ok = fieldPackage.types[typed.Sel.Name]
+
+		return fieldPackage, typed.Sel.Name, ok
+	default:
+		return nil, "", false
+	}
+}
+
+// loadPackage parses all the non-test Go files in dir, which is relative to the repo root, along with its default.yaml
+// and suite README. Packages are cached so each one is only parsed once.
+func (parser *Parser) loadPackage(dir string) (*configPackage, error) {
+	if configPackage, ok := parser.packages[dir]; ok {
+		return configPackage, nil
+	}
+
+	klog.V(100).Infof("Loading package in %s", dir)
+
+	absDir := filepath.Join(parser.root, dir)
+
+	entries, err := os.ReadDir(absDir)
+	if err != nil {
+		return nil, err
+	}
+
+	configPackage := &configPackage{
+		dir:      dir,
+		types:    make(map[string]*typeDecl),
+		decoders: make(map[string]bool),
+	}
+
+	fileSet := token.NewFileSet()
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		file, err := parser.parseFile(fileSet, filepath.Join(absDir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		configPackage.addFile(file)
+	}
+
+	configPackage.defaults, err = readDefaults(filepath.Join(absDir, "default.yaml"))
+	if err != nil {
+		return nil, err
+	}
+
+	configPackage.descriptions, err = readDescriptions(filepath.Join(parser.root, suiteReadme(dir)))
+	if err != nil {
+		return nil, err
+	}
+
+	parser.packages[dir] = configPackage
+
+	return configPackage, nil
+}
+
+// parseFile parses a single Go file, including its comments.
+func (parser *Parser) parseFile(fileSet *token.FileSet, fileName string) (*ast.File, error) {
+	return goparser.ParseFile(fileSet, fileName, nil, goparser.ParseComments|goparser.SkipObjectResolution)
+}
+
+// addFile adds the type declarations, decoder methods, and calls to os.Getenv from file to the package.
+func (configPackage *configPackage) addFile(file *ast.File) {
+	imports := make(map[string]string)
+
+	for _, importSpec := range file.Imports {
+		importPath, err := strconv.Unquote(importSpec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		name := path.Base(importPath)
+		if importSpec.Name != nil {
+			name = importSpec.Name.Name
+		}
+
+		imports[name] = importPath
+	}
+
+	for _, decl := range file.Decls {
+		switch typed := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range typed.Specs {
+				if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+					configPackage.types[typeSpec.Name.Name] = &typeDecl{expr: typeSpec.Type, imports: imports}
+				}
+			}
+		case *ast.FuncDecl:
+			if typed.Recv == nil || len(typed.Recv.List) == 0 || !slices.Contains(decoderMethods, typed.Name.Name) {
+				continue
+			}
+
+			receiver := typed.Recv.List[0].Type
+			if star, ok := receiver.(*ast.StarExpr); ok {
+				receiver = star.X
+			}
+
+			if ident, ok := receiver.(*ast.Ident); ok {
+				configPackage.decoders[ident.Name] = true
+			}
+		}
+	}
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		if name, ok := getenvName(node, imports); ok && !slices.Contains(configPackage.getenvs, name) {
+			configPackage.getenvs = append(configPackage.getenvs, name)
+		}
+
+		return true
+	})
+}
+
+// getenvName returns the name of the environment variable if node is a call to os.Getenv with a string literal
+// starting with ECO_.
+func getenvName(node ast.Node, imports map[string]string) (string, bool) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return "", false
+	}
+
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != "Getenv" {
+		return "", false
+	}
+
+	packageIdent, ok := selector.X.(*ast.Ident)
+	if !ok || imports[packageIdent.Name] != "os" {
+		return "", false
+	}
+
+	literal, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || literal.Kind != token.STRING {
+		return "", false
+	}
+
+	name, err := strconv.Unquote(literal.Value)
+	if err != nil || !strings.HasPrefix(name, "ECO_") {
+		return "", false
+	}
+
+	return name, true
+}
+
+// newEnvVar creates an EnvVar for a field of a struct in the package.
+func (configPackage *configPackage) newEnvVar(
+	name, envKey, yamlKey string, yamlPath []string, tag reflect.StructTag, field *ast.Field) EnvVar {
+	envVar := EnvVar{
+		Name:     envKey,
+		Field:    name,
+		Type:     types.ExprString(field.Type),
+		Validate: tag.Get("validate"),
+	}
+
+	if yamlKey != "" {
+		fullPath := append(slices.Clone(yamlPath), yamlKey)
+		envVar.YAMLKey = strings.Join(fullPath, ".")
+		envVar.Default = lookupDefault(configPackage.defaults, fullPath)
+	}
+
+	if defaultValue, ok := tag.Lookup("default"); ok {
+		envVar.Default = defaultValue
+	}
+
+	// Text drops directives such as nolint comments, so a field with only a directive uses the README description.
+	switch {
+	case field.Doc != nil && field.Doc.Text() != "":
+		envVar.Description = strings.Join(strings.Fields(field.Doc.Text()), " ")
+	case field.Comment != nil && field.Comment.Text() != "":
+		envVar.Description = strings.Join(strings.Fields(field.Comment.Text()), " ")
+	default:
+		envVar.Description = configPackage.descriptions[envKey]
+	}
+
+	return envVar
+}
+
+// readDefaults reads the YAML file at fileName. It returns nil without an error if the file does not exist.
+func readDefaults(fileName string) (map[string]any, error) {
+	contents, err := os.ReadFile(fileName)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var defaults map[string]any
+
+	err = yaml.Unmarshal(contents, &defaults)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", fileName, err)
+	}
+
+	return defaults, nil
+}
+
+// lookupDefault follows the keys in yamlPath through defaults and formats the value found the way envconfig expects
+// it: lists are separated by commas and maps use colons between keys and values. Anything more complex is formatted as
+// JSON. It returns an empty string if there is no default.
+func lookupDefault(defaults map[string]any, yamlPath []string) string {
+	var value any = defaults
+
+	for _, key := range yamlPath {
+		mapping, ok := value.(map[string]any)
+		if !ok {
+			return ""
+		}
+
+		value = mapping[key]
+	}
+
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case []any:
+		elements := make([]string, 0, len(typed))
+
+		for _, element := range typed {
+			if !isScalar(element) {
+				return formatJSON(value)
+			}
+
+			elements = append(elements, fmt.Sprint(element))
+		}
+
+		return strings.Join(elements, ",")
+	case map[string]any:
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+
+		slices.Sort(keys)
+
+		pairs := make([]string, 0, len(typed))
+
+		for _, key := range keys {
+			if !isScalar(typed[key]) {
+				return formatJSON(value)
+			}
+
+			pairs = append(pairs, fmt.Sprintf("%s:%v", key, typed[key]))
+		}
+
+		return strings.Join(pairs, ",")
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// isScalar returns true if value is not a list or map.
+func isScalar(value any) bool {
+	switch value.(type) {
+	case []any, map[string]any:
+		return false
+	default:
+		return true
+	}
+}
+
+// formatJSON formats value as compact JSON, falling back to the default format if it cannot be encoded.
+func formatJSON(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(encoded)
+}
+
+// suiteReadme returns the README documenting the config package in dir, which is the README in the parent of the
+// internal directory. This matches scripts/check-envvar-docs.sh.
+func suiteReadme(dir string) string {
+	if index := strings.LastIndex(dir, "/internal/"); index >= 0 {
+		dir = dir[:index]
+	}
+
+	return filepath.Join(dir, "README.md")
+}
+
+// readDescriptions reads the Markdown tables and lists in the README at fileName and returns the description of every
+// environment variable in them. Table rows are only used if their first cell is an environment variable in backticks
+// and the table has a Description column. List items are only used if they start with an environment variable in
+// backticks followed by a colon. It returns nil without an error if the file does not exist.
+func readDescriptions(fileName string) (map[string]string, error) {
+	readme, err := os.Open(fileName)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = readme.Close()
+	}()
+
+	descriptions := make(map[string]string)
+	descriptionColumn := -1
+	scanner := bufio.NewScanner(readme)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "|") {
+			descriptionColumn = -1
+
+			if match := listDescriptionRegexp.FindStringSubmatch(line); match != nil {
+				descriptions[match[1]] = match[2]
+			}
+
+			continue
+		}
+
+		cells := strings.Split(strings.Trim(line, "|"), "|")
+		for index, cell := range cells {
+			cells[index] = strings.TrimSpace(cell)
+		}
+
+		if descriptionColumn < 0 {
+			descriptionColumn = slices.IndexFunc(cells, func(cell string) bool {
+				return strings.EqualFold(cell, "Description")
+			})
+
+			continue
+		}
+
+		name := strings.Trim(cells[0], "`")
+		if descriptionColumn < len(cells) && strings.HasPrefix(name, "ECO_") && name != cells[0] {
+			descriptions[name] = cells[descriptionColumn]
+		}
+	}
+
+	return descriptions, scanner.Err()
+}
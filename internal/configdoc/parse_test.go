@@ -0,0 +1,330 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testConfigDir    = "tests/example/internal/exampleconfig"
+	testIncludedDir  = "tests/internal/includedconfig"
+	testIncludedType = "IncludedConfig"
+)
+
+// writeTestFiles writes files, keyed by their path relative to root, creating any parent directories.
+func writeTestFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, contents := range files {
+		fileName := filepath.Join(root, name)
+
+		require.NoError(t, os.MkdirAll(filepath.Dir(fileName), 0o755))
+		require.NoError(t, os.WriteFile(fileName, []byte(contents), 0o600))
+	}
+}
+
+// newTestParser returns a Parser for a temporary repo containing files. Only the included config is registered, so
+// the real registeredConfigs do not affect the result.
+func newTestParser(t *testing.T, files map[string]string) *Parser {
+	t.Helper()
+
+	root := t.TempDir()
+	writeTestFiles(t, root, files)
+
+	parser := NewParser(root)
+	parser.registered = map[typeKey]string{{dir: testIncludedDir, name: testIncludedType}: "included"}
+
+	return parser
+}
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		name             string
+		source           string
+		defaults         string
+		readme           string
+		expectedVars     []EnvVar
+		expectedIncludes []string
+	}{
+		{
+			name: "tags",
+			source: "type ExampleConfig struct {\n" +
+				"\tName string `yaml:\"name\" envconfig:\"ECO_EXAMPLE_NAME\"`\n" +
+				"\tCount int `yaml:\"count,omitempty\" envconfig:\"ECO_EXAMPLE_COUNT\" validate:\"min=1\"`\n" +
+				"\tNoYAML string `yaml:\"-\" envconfig:\"ECO_EXAMPLE_NO_YAML\"`\n" +
+				"\tIgnored string `envconfig:\"ECO_EXAMPLE_IGNORED\" ignored:\"true\"`\n" +
+				"\tNoEnv string `yaml:\"no_env\"`\n" +
+				"\tunexported string `envconfig:\"ECO_EXAMPLE_UNEXPORTED\"`\n" +
+				"}\n",
+			expectedVars: []EnvVar{
+				{Name: "ECO_EXAMPLE_NAME", Field: "Name", YAMLKey: "name", Type: "string"},
+				{Name: "ECO_EXAMPLE_COUNT", Field: "Count", YAMLKey: "count", Type: "int", Validate: "min=1"},
+				{Name: "ECO_EXAMPLE_NO_YAML", Field: "NoYAML", Type: "string"},
+			},
+		},
+		{
+			name: "descriptions",
+			source: "type ExampleConfig struct {\n" +
+				"\t// Doc describes\n\t// the field.\n" +
+				"\tDoc string `envconfig:\"ECO_EXAMPLE_DOC\"`\n" +
+				"\tComment string `envconfig:\"ECO_EXAMPLE_COMMENT\"` // Comment describes the field.\n" +
+				"\t//nolint:lll\n" +
+				"\tTable string `envconfig:\"ECO_EXAMPLE_TABLE\"`\n" +
+				"\tList string `envconfig:\"ECO_EXAMPLE_LIST\"`\n" +
+				"}\n\n" +
+				"func init() {\n\t_ = os.Getenv(\"ECO_EXAMPLE_GETENV\")\n\t_ = os.Getenv(\"KUBECONFIG\")\n}\n",
+			readme: "| Variable | Type | Description |\n" +
+				"|----------|------|-------------|\n" +
+				"| `ECO_EXAMPLE_TABLE` | string | Table describes the field. |\n" +
+				"| ECO_EXAMPLE_LIST | string | Not in backticks. |\n" +
+				"\n" +
+				"- `ECO_EXAMPLE_LIST`: List describes the field.\n" +
+				"- `ECO_EXAMPLE_GETENV`: Getenv is read directly.\n",
+			expectedVars: []EnvVar{
+				{Name: "ECO_EXAMPLE_DOC", Field: "Doc", Type: "string", Description: "Doc describes the field."},
+				{Name: "ECO_EXAMPLE_COMMENT", Field: "Comment", Type: "string", Description: "Comment describes the field."},
+				{Name: "ECO_EXAMPLE_TABLE", Field: "Table", Type: "string", Description: "Table describes the field."},
+				{Name: "ECO_EXAMPLE_LIST", Field: "List", Type: "string", Description: "List describes the field."},
+				{Name: "ECO_EXAMPLE_GETENV", Type: "string", Description: "Getenv is read directly."},
+			},
+		},
+		{
+			name: "nested structs",
+			source: "type ExampleConfig struct {\n" +
+				"\tNested NestedConfig `yaml:\"nested\"`\n" +
+				"\tPointer *NestedConfig `yaml:\"pointer\"`\n" +
+				"\tNoYAML NestedConfig\n" +
+				"\tDecoded DecodedConfig `yaml:\"decoded\"`\n" +
+				"}\n\n" +
+				"type NestedConfig struct {\n" +
+				"\tAddress string `yaml:\"address\" envconfig:\"ECO_EXAMPLE_ADDRESS\"`\n" +
+				"\tInner InnerConfig `yaml:\"inner\"`\n" +
+				"}\n\n" +
+				"type InnerConfig struct {\n" +
+				"\tPort int `yaml:\"port\" envconfig:\"ECO_EXAMPLE_PORT\"`\n" +
+				"}\n\n" +
+				"type DecodedConfig struct {\n" +
+				"\tValue string `yaml:\"value\" envconfig:\"ECO_EXAMPLE_DECODED\"`\n" +
+				"}\n\n" +
+				"func (config *DecodedConfig) Decode(value string) error {\n\treturn nil\n}\n",
+			defaults: "nested:\n  address: 10.0.0.1\n  inner:\n    port: 8080\npointer:\n  address: 10.0.0.2\n",
+			expectedVars: []EnvVar{
+				{Name: "ECO_EXAMPLE_ADDRESS", Field: "Address", YAMLKey: "nested.address", Type: "string", Default: "10.0.0.1"},
+				{Name: "ECO_EXAMPLE_PORT", Field: "Port", YAMLKey: "nested.inner.port", Type: "int", Default: "8080"},
+				{Name: "ECO_EXAMPLE_ADDRESS", Field: "Address", YAMLKey: "pointer.address", Type: "string", Default: "10.0.0.2"},
+				{Name: "ECO_EXAMPLE_PORT", Field: "Port", YAMLKey: "pointer.inner.port", Type: "int"},
+			},
+		},
+		{
+			name: "embedded structs",
+			source: "type ExampleConfig struct {\n" +
+				"\t*includedconfig.IncludedConfig\n" +
+				"\tEmbeddedConfig\n" +
+				"\tincludedconfig.SharedConfig\n" +
+				"\tName string `yaml:\"name\" envconfig:\"ECO_EXAMPLE_NAME\"`\n" +
+				"}\n\n" +
+				"type EmbeddedConfig struct {\n" +
+				"\tRegion string `yaml:\"region\" envconfig:\"ECO_EXAMPLE_REGION\"`\n" +
+				"}\n",
+			defaults: "region: east\nshared: value\n",
+			expectedVars: []EnvVar{
+				{Name: "ECO_EXAMPLE_REGION", Field: "Region", YAMLKey: "region", Type: "string", Default: "east"},
+				{Name: "ECO_SHARED", Field: "Shared", YAMLKey: "shared", Type: "string", Default: "from tag"},
+				{Name: "ECO_EXAMPLE_NAME", Field: "Name", YAMLKey: "name", Type: "string"},
+			},
+			expectedIncludes: []string{"included"},
+		},
+		{
+			name: "defaults",
+			source: "type ExampleConfig struct {\n" +
+				"\tNodes []string `yaml:\"nodes\" envconfig:\"ECO_EXAMPLE_NODES\"`\n" +
+				"\tLabels map[string]string `yaml:\"labels\" envconfig:\"ECO_EXAMPLE_LABELS\"`\n" +
+				"\tTagged int `yaml:\"tagged\" envconfig:\"ECO_EXAMPLE_TAGGED\" default:\"5\"`\n" +
+				"\tEnabled bool `yaml:\"enabled\" envconfig:\"ECO_EXAMPLE_ENABLED\"`\n" +
+				"\tMissing string `yaml:\"missing\" envconfig:\"ECO_EXAMPLE_MISSING\"`\n" +
+				"}\n",
+			defaults: "nodes: [master-0, master-1]\nlabels:\n  zone: a\n  role: worker\ntagged: 3\nenabled: true\n",
+			expectedVars: []EnvVar{
+				{Name: "ECO_EXAMPLE_NODES", Field: "Nodes", YAMLKey: "nodes", Type: "[]string", Default: "master-0,master-1"},
+				{
+					Name: "ECO_EXAMPLE_LABELS", Field: "Labels", YAMLKey: "labels", Type: "map[string]string",
+					Default: "role:worker,zone:a",
+				},
+				{Name: "ECO_EXAMPLE_TAGGED", Field: "Tagged", YAMLKey: "tagged", Type: "int", Default: "5"},
+				{Name: "ECO_EXAMPLE_ENABLED", Field: "Enabled", YAMLKey: "enabled", Type: "bool", Default: "true"},
+				{Name: "ECO_EXAMPLE_MISSING", Field: "Missing", YAMLKey: "missing", Type: "string"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			files := map[string]string{
+				testConfigDir + "/config.go": "package exampleconfig\n\n" +
+					"import (\n\t\"os\"\n\n\t\"" + modulePath + "/" + testIncludedDir + "\"\n)\n\n" + testCase.source,
+				testIncludedDir + "/config.go": "package includedconfig\n\n" +
+					"type " + testIncludedType + " struct {\n" +
+					"\tIncluded string `yaml:\"included\" envconfig:\"ECO_INCLUDED\"`\n" +
+					"}\n\n" +
+					"type SharedConfig struct {\n" +
+					"\tShared string `yaml:\"shared\" envconfig:\"ECO_SHARED\" default:\"from tag\"`\n" +
+					"}\n",
+			}
+
+			if testCase.defaults != "" {
+				files[testConfigDir+"/default.yaml"] = testCase.defaults
+			}
+
+			if testCase.readme != "" {
+				files["tests/example/README.md"] = testCase.readme
+			}
+
+			parser := newTestParser(t, files)
+			config := RegisteredConfig{Suite: "example", Dir: testConfigDir, Type: "ExampleConfig"}
+
+			suiteConfig, err := parser.parseConfig(config)
+			require.NoError(t, err)
+
+			assert.Equal(t, config, suiteConfig.RegisteredConfig)
+			assert.Equal(t, testCase.expectedVars, suiteConfig.Vars)
+			assert.Equal(t, testCase.expectedIncludes, suiteConfig.Includes)
+		})
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		source        string
+		typeName      string
+		expectedError string
+	}{
+		{
+			name:          "missing type",
+			source:        "type OtherConfig struct{}\n",
+			typeName:      "ExampleConfig",
+			expectedError: "type ExampleConfig not found in " + testConfigDir,
+		},
+		{
+			name:          "not a struct",
+			source:        "type ExampleConfig string\n",
+			typeName:      "ExampleConfig",
+			expectedError: "type ExampleConfig in " + testConfigDir + " is not a struct",
+		},
+		{
+			name:          "invalid source",
+			source:        "type ExampleConfig struct {\n\tName string \"yaml:\\\"name\\\"\\q\"\n}\n",
+			typeName:      "ExampleConfig",
+			expectedError: "unknown escape sequence",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			parser := newTestParser(t, map[string]string{
+				testConfigDir + "/config.go": "package exampleconfig\n\n" + testCase.source,
+			})
+
+			_, err := parser.parseConfig(RegisteredConfig{Suite: "example", Dir: testConfigDir, Type: testCase.typeName})
+			assert.ErrorContains(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestLookupDefault(t *testing.T) {
+	defaults := map[string]any{
+		"string":  "value",
+		"number":  3,
+		"boolean": false,
+		"list":    []any{"a", 1, true},
+		"map":     map[string]any{"b": 2, "a": "one"},
+		"nested": map[string]any{
+			"list": []any{map[string]any{"name": "first"}},
+			"map":  map[string]any{"inner": []any{"x", "y"}},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		yamlPath []string
+		expected string
+	}{
+		{name: "string", yamlPath: []string{"string"}, expected: "value"},
+		{name: "number", yamlPath: []string{"number"}, expected: "3"},
+		{name: "boolean", yamlPath: []string{"boolean"}, expected: "false"},
+		{name: "list of scalars", yamlPath: []string{"list"}, expected: "a,1,true"},
+		{name: "map of scalars", yamlPath: []string{"map"}, expected: "a:one,b:2"},
+		{name: "list of maps", yamlPath: []string{"nested", "list"}, expected: `[{"name":"first"}]`},
+		{name: "map of lists", yamlPath: []string{"nested", "map"}, expected: `{"inner":["x","y"]}`},
+		{name: "missing key", yamlPath: []string{"missing"}, expected: ""},
+		{name: "missing nested key", yamlPath: []string{"nested", "missing"}, expected: ""},
+		{name: "through scalar", yamlPath: []string{"string", "missing"}, expected: ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, lookupDefault(defaults, testCase.yamlPath))
+		})
+	}
+
+	assert.Empty(t, lookupDefault(nil, []string{"string"}))
+}
+
+func TestReadDefaults(t *testing.T) {
+	testCases := []struct {
+		name          string
+		contents      string
+		expected      map[string]any
+		expectedError string
+	}{
+		{name: "missing file", expected: nil},
+		{
+			name:     "nested",
+			contents: "name: example\nnested:\n  port: 8080\n",
+			expected: map[string]any{"name": "example", "nested": map[string]any{"port": 8080}},
+		},
+		{name: "invalid", contents: "name: [example\n", expectedError: "failed to parse"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			fileName := filepath.Join(t.TempDir(), "default.yaml")
+
+			if testCase.contents != "" {
+				require.NoError(t, os.WriteFile(fileName, []byte(testCase.contents), 0o600))
+			}
+
+			defaults, err := readDefaults(fileName)
+			if testCase.expectedError != "" {
+				assert.ErrorContains(t, err, testCase.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, defaults)
+		})
+	}
+}
+
+func TestSuiteReadme(t *testing.T) {
+	testCases := []struct {
+		dir      string
+		expected string
+	}{
+		{dir: "tests/cnf/ran/internal/ranconfig", expected: "tests/cnf/ran/README.md"},
+		{dir: "tests/internal/config", expected: "tests/README.md"},
+		{dir: "tests/ptp", expected: "tests/ptp/README.md"},
+		{dir: "tests/cnf/internal/ranconfig/internal/nested", expected: "tests/cnf/internal/ranconfig/README.md"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.dir, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, suiteReadme(testCase.dir))
+		})
+	}
+}
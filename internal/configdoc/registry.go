@@ -0,0 +1,69 @@
+package main
+
+// RegisteredConfig is a config struct that is documented by this program. Each one is typically the type returned by
+// the New function of a config package and loaded from the default.yaml in the same directory.
+type RegisteredConfig struct {
+	// Suite is the name used for the config in the output. It is usually the path of the suite relative to tests/,
+	// but configs shared by several suites are named after what they are shared by.
+	Suite string
+	// Dir is the directory of the package declaring the config relative to the repo root.
+	Dir string
+	// Type is the name of the config struct.
+	Type string
+}
+
+// registeredConfigs lists every config struct, grouped by the top level directory of the suite. When adding a new
+// config package, it should be added here so its environment variables show up in the schema and docs.
+var registeredConfigs = []RegisteredConfig{
+	{Suite: "general", Dir: "tests/internal/config", Type: "GeneralConfig"},
+
+	{Suite: "accel", Dir: "tests/accel/internal/accelconfig", Type: "AccelConfig"},
+
+	{Suite: "assisted", Dir: "tests/assisted/internal/assistedconfig", Type: "AssistedConfig"},
+	{Suite: "assisted/ztp", Dir: "tests/assisted/ztp/internal/ztpconfig", Type: "ZTPConfig"},
+
+	{Suite: "cnf", Dir: "tests/cnf/internal/cnfconfig", Type: "CNFConfig"},
+	{Suite: "cnf/core", Dir: "tests/cnf/core/internal/coreconfig", Type: "CoreConfig"},
+	{Suite: "cnf/core/network", Dir: "tests/cnf/core/network/internal/netconfig", Type: "NetworkConfig"},
+	{Suite: "cnf/ran", Dir: "tests/cnf/ran/internal/ranconfig", Type: "RANConfig"},
+	{Suite: "cnf/ran-deployment", Dir: "tests/cnf/ran-deployment/internal/ranconfig", Type: "RANConfig"},
+
+	{Suite: "hw-accel", Dir: "tests/hw-accel/internal/hwaccelconfig", Type: "HwAccelConfig"},
+	{Suite: "hw-accel/amdgpu", Dir: "tests/hw-accel/amdgpu/internal/amdgpuconfig", Type: "AMDConfig"},
+	{Suite: "hw-accel/kmm", Dir: "tests/hw-accel/kmm/internal/kmmconfig", Type: "ModulesConfig"},
+	{Suite: "hw-accel/neuron", Dir: "tests/hw-accel/neuron/internal/neuronconfig", Type: "NeuronConfig"},
+	{Suite: "hw-accel/nfd", Dir: "tests/hw-accel/nfd/internal/nfdconfig", Type: "NfdConfig"},
+	{Suite: "hw-accel/nvidiagpu", Dir: "tests/hw-accel/nvidiagpu/internal/nvidiagpuconfig", Type: "NvidiaGPUConfig"},
+
+	{Suite: "lca", Dir: "tests/lca/internal/lcaconfig", Type: "LCAConfig"},
+	{Suite: "lca/imagebasedinstall", Dir: "tests/lca/imagebasedinstall/internal/ibiconfig", Type: "IBIConfig"},
+	{Suite: "lca/imagebasedinstall/mgmt", Dir: "tests/lca/imagebasedinstall/mgmt/internal/mgmtconfig", Type: "MGMTConfig"},
+	{Suite: "lca/imagebasedupgrade", Dir: "tests/lca/imagebasedupgrade/internal/ibuconfig", Type: "IBUConfig"},
+	{Suite: "lca/imagebasedupgrade/cnf", Dir: "tests/lca/imagebasedupgrade/cnf/internal/cnfconfig", Type: "CNFConfig"},
+	{Suite: "lca/imagebasedupgrade/mgmt", Dir: "tests/lca/imagebasedupgrade/mgmt/internal/mgmtconfig", Type: "MGMTConfig"},
+	{Suite: "lca/ipchange", Dir: "tests/lca/ipchange/internal/ipcconfig", Type: "IPCConfig"},
+	{
+		Suite: "lca/seedgeneration",
+		Dir:   "tests/lca/seedgeneration/internal/seedgenerationconfig",
+		Type:  "SeedGenerationConfig",
+	},
+
+	{Suite: "ocp", Dir: "tests/ocp/internal/ocpconfig", Type: "OcpConfig"},
+	{Suite: "ocp/hwol", Dir: "tests/ocp/hwol/internal/ocphwolconfig", Type: "HwolOcpConfig"},
+	{Suite: "ocp/sriov", Dir: "tests/ocp/sriov/internal/ocpsriovconfig", Type: "SriovOcpConfig"},
+
+	{Suite: "rhwa", Dir: "tests/rhwa/internal/rhwaconfig", Type: "RHWAConfig"},
+
+	{Suite: "system-tests", Dir: "tests/system-tests/internal/systemtestsconfig", Type: "SystemTestsConfig"},
+	{
+		Suite: "system-tests/diskencryption",
+		Dir:   "tests/system-tests/diskencryption/internal/config",
+		Type:  "DiskEncrptionConfig",
+	},
+	{Suite: "system-tests/ipsec", Dir: "tests/system-tests/ipsec/internal/ipsecconfig", Type: "IpsecConfig"},
+	{Suite: "system-tests/o-cloud", Dir: "tests/system-tests/o-cloud/internal/ocloudconfig", Type: "OCloudConfig"},
+	{Suite: "system-tests/ran-du", Dir: "tests/system-tests/ran-du/internal/randuconfig", Type: "RanDuConfig"},
+	{Suite: "system-tests/rdscore", Dir: "tests/system-tests/rdscore/internal/rdscoreconfig", Type: "CoreConfig"},
+	{Suite: "system-tests/spk", Dir: "tests/system-tests/spk/internal/spkconfig", Type: "SPKConfig"},
+	{Suite: "system-tests/vcore", Dir: "tests/system-tests/vcore/internal/vcoreconfig", Type: "VCoreConfig"},
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// schemaDialect is the JSON Schema version the generated schema conforms to.
+const schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// typePatterns are the regular expressions matching valid values for Go types that envconfig parses. Types not listed
+// here accept any string.
+var typePatterns = map[string]string{
+	"bool":          `^(1|0|t|f|T|F|true|false|TRUE|FALSE|True|False)$`,
+	"int":           `^[+-]?[0-9]+$`,
+	"int32":         `^[+-]?[0-9]+$`,
+	"int64":         `^[+-]?[0-9]+$`,
+	"uint":          `^\+?[0-9]+$`,
+	"uint32":        `^\+?[0-9]+$`,
+	"uint64":        `^\+?[0-9]+$`,
+	"float32":       `^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][+-]?[0-9]+)?$`,
+	"float64":       `^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][+-]?[0-9]+)?$`,
+	"time.Duration": `^[+-]?(0|([0-9]+(\.[0-9]*)?(ns|us|µs|ms|s|m|h))+)$`,
+}
+
+// Schema is the JSON Schema for the environment of a test run. It describes an object whose properties are the
+// environment variables read by the registered configs. Since every environment variable is a string, the Go type of
+// each variable is checked using a pattern instead.
+type Schema struct {
+	Schema      string                     `json:"$schema"`
+	Title       string                     `json:"title"`
+	Description string                     `json:"description"`
+	Type        string                     `json:"type"`
+	Properties  map[string]*SchemaProperty `json:"properties"`
+	// AdditionalProperties allows environment variables that are not read by any config, such as those used by the
+	// test runner.
+	AdditionalProperties *SchemaProperty `json:"additionalProperties"`
+}
+
+// SchemaProperty is the schema for a single environment variable. Fields prefixed with x- are annotations that are
+// ignored by validators but useful for generating job definitions.
+type SchemaProperty struct {
+	Type        string   `json:"type"`
+	Description string   `json:"description,omitempty"`
+	Default     string   `json:"default,omitempty"`
+	Pattern     string   `json:"pattern,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+	GoType      string   `json:"x-go-type,omitempty"`
+	YAMLKey     string   `json:"x-yaml-key,omitempty"`
+	Suites      []string `json:"x-suites,omitempty"`
+}
+
+// NewSchema creates a Schema with a property for every environment variable in suiteConfigs. When the same variable
+// is read by multiple suites, the first nonempty description and default are used.
+func NewSchema(suiteConfigs []*SuiteConfig) *Schema {
+	schema := &Schema{
+		Schema:               schemaDialect,
+		Title:                "eco-gotests environment",
+		Description:          "Environment variables read by the eco-gotests suite configs.",
+		Type:                 "object",
+		Properties:           make(map[string]*SchemaProperty),
+		AdditionalProperties: &SchemaProperty{Type: "string"},
+	}
+
+	for _, suiteConfig := range suiteConfigs {
+		for _, envVar := range suiteConfig.Vars {
+			property, ok := schema.Properties[envVar.Name]
+			if !ok {
+				property = &SchemaProperty{
+					Type:    "string",
+					Pattern: typePatterns[strings.TrimPrefix(envVar.Type, "*")],
+					Enum:    oneOfValues(envVar.Validate),
+					GoType:  envVar.Type,
+					YAMLKey: envVar.YAMLKey,
+				}
+				schema.Properties[envVar.Name] = property
+			}
+
+			if property.Description == "" {
+				property.Description = envVar.Description
+			}
+
+			if property.Default == "" {
+				property.Default = envVar.Default
+			}
+
+			if !slices.Contains(property.Suites, suiteConfig.Suite) {
+				property.Suites = append(property.Suites, suiteConfig.Suite)
+			}
+		}
+	}
+
+	return schema
+}
+
+// Write writes the schema to writer as indented JSON. Properties are sorted by name since they are encoded from a map.
+func (schema *Schema) Write(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+
+	return encoder.Encode(schema)
+}
+
+// ValidateEnv checks the environment variables in env against the schema. Variables starting with prefix that are not
+// in the schema are reported as unknown since they are most likely typos. The returned problems are sorted by
+// variable name and empty if env is valid.
+func (schema *Schema) ValidateEnv(env map[string]string, prefix string) []string {
+	klog.V(100).Infof("Validating %d environment variables against schema", len(env))
+
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	var problems []string
+
+	for _, name := range names {
+		property, ok := schema.Properties[name]
+		if !ok {
+			if strings.HasPrefix(name, prefix) {
+				problems = append(problems, fmt.Sprintf("%s is not read by any config", name))
+			}
+
+			continue
+		}
+
+		err := property.validateValue(env[name])
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s has invalid value %q: %v", name, env[name], err))
+		}
+	}
+
+	return problems
+}
+
+// validateValue checks that value can be parsed as the Go type of the property and is one of the enum values, if there
+// are any. Parsing uses the same functions as envconfig rather than the pattern so the errors are more useful.
+func (property *SchemaProperty) validateValue(value string) error {
+	var err error
+
+	switch strings.TrimPrefix(property.GoType, "*") {
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	case "int", "int32", "int64":
+		_, err = strconv.ParseInt(value, 0, 64)
+	case "uint", "uint32", "uint64":
+		_, err = strconv.ParseUint(value, 0, 64)
+	case "float32", "float64":
+		_, err = strconv.ParseFloat(value, 64)
+	case "time.Duration":
+		_, err = time.ParseDuration(value)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if value != "" && len(property.Enum) > 0 && !slices.Contains(property.Enum, value) {
+		return fmt.Errorf("must be one of %s", strings.Join(property.Enum, ", "))
+	}
+
+	return nil
+}
+
+// oneOfValues returns the values of the oneof rule in a validate tag or nil if there is no such rule.
+func oneOfValues(validate string) []string {
+	for rule := range strings.SplitSeq(validate, ",") {
+		if values, ok := strings.CutPrefix(strings.TrimSpace(rule), "oneof="); ok {
+			return strings.Fields(values)
+		}
+	}
+
+	return nil
+}
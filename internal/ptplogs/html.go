@@ -0,0 +1,137 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"os"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/ptplogs/stability"
+)
+
+// timelineWidth is the width in pixels of the timeline of each process.
+const timelineWidth = 1000.0
+
+var (
+	//go:embed timeline_template.html
+	timelineTemplateFile string
+
+	timelineTemplate = template.Must(template.New("timeline_template.html").Parse(timelineTemplateFile))
+)
+
+// timelineTemplateConfig contains the data necessary to template the timelines into an html page.
+type timelineTemplateConfig struct {
+	Generated time.Time
+	Width     float64
+	Timelines []timelineView
+}
+
+// timelineView is a single timeline with the positions of its intervals and violations computed, since templates
+// cannot easily do arithmetic.
+type timelineView struct {
+	*stability.Timeline
+	Duration  time.Duration
+	Processes []processView
+}
+
+// processView is a single process of a timelineView.
+type processView struct {
+	*stability.ProcessTimeline
+	Locked     time.Duration
+	Holdover   time.Duration
+	Freerun    time.Duration
+	Intervals  []intervalView
+	Violations []violationView
+}
+
+// intervalView is a TimelineInterval positioned on the timeline.
+type intervalView struct {
+	stability.TimelineInterval
+	X     float64
+	Width float64
+	Title string
+}
+
+// violationView is a TimelineViolation positioned on the timeline.
+type violationView struct {
+	stability.TimelineViolation
+	X float64
+}
+
+// writeTimelineHTML writes the timelines to an html page at path.
+func writeTimelineHTML(path string, timelines []*stability.Timeline) error {
+	config := timelineTemplateConfig{Generated: time.Now(), Width: timelineWidth}
+
+	for _, timeline := range timelines {
+		config.Timelines = append(config.Timelines, newTimelineView(timeline))
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create timeline %s: %w", path, err)
+	}
+
+	defer file.Close()
+
+	err = timelineTemplate.Execute(file, config)
+	if err != nil {
+		return fmt.Errorf("failed to template timeline %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// newTimelineView positions the intervals and violations of every process of timeline on a shared axis spanning from
+// the first to the last parsed line.
+func newTimelineView(timeline *stability.Timeline) timelineView {
+	start := timeline.Start.Uptime
+	span := timeline.End.Uptime - start
+
+	// position returns the x coordinate of the provided uptime. Timelines with a single point are drawn at the start.
+	position := func(uptime float64) float64 {
+		if span <= 0 {
+			return 0
+		}
+
+		return (uptime - start) / span * timelineWidth
+	}
+
+	view := timelineView{
+		Timeline: timeline,
+		Duration: time.Duration(span * float64(time.Second)),
+	}
+
+	for _, process := range timeline.Processes {
+		processView := processView{
+			ProcessTimeline: process,
+			Locked:          process.TimeIn(stability.SyncStateLocked),
+			Holdover:        process.TimeIn(stability.SyncStateHoldover),
+			Freerun:         process.TimeIn(stability.SyncStateFreerun),
+		}
+
+		for _, interval := range process.Intervals {
+			startX := position(interval.Start.Uptime)
+
+			processView.Intervals = append(processView.Intervals, intervalView{
+				TimelineInterval: interval,
+				X:                startX,
+				// Every interval is drawn at least one pixel wide so single lines are still visible.
+				Width: max(position(interval.End.Uptime)-startX, 1),
+				Title: fmt.Sprintf("%s for %s from uptime %.3f to %.3f",
+					interval.State, interval.Duration(), interval.Start.Uptime, interval.End.Uptime),
+			})
+		}
+
+		for _, violation := range process.Violations {
+			processView.Violations = append(processView.Violations, violationView{
+				TimelineViolation: violation,
+				X:                 position(violation.Uptime),
+			})
+		}
+
+		view.Processes = append(view.Processes, processView)
+	}
+
+	return view
+}
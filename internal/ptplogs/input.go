@@ -0,0 +1,172 @@
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/ptplogs/stability"
+	"k8s.io/klog/v2"
+)
+
+// daemonContainerName is the name of the container whose logs are analyzed when searching directories and tarballs.
+// It is the path component oc adm inspect uses for the container in must-gather output.
+const daemonContainerName = "linuxptp-daemon-container"
+
+// gzipMagic is the header every gzip stream starts with, used to detect compressed tarballs regardless of extension.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// analyzePaths analyzes every log found at paths, which may be log files, directories, or tarballs, and returns their
+// timelines in the order they were found.
+func analyzePaths(paths []string, thresholdAbsoluteNanoseconds int64) ([]*stability.Timeline, error) {
+	var timelines []*stability.Timeline
+
+	for _, path := range paths {
+		pathTimelines, err := analyzePath(path, thresholdAbsoluteNanoseconds)
+		if err != nil {
+			return nil, err
+		}
+
+		timelines = append(timelines, pathTimelines...)
+	}
+
+	return timelines, nil
+}
+
+// analyzePath analyzes the logs at a single path. Directories are walked for daemon logs, tarballs are read for daemon
+// logs, and any other file is analyzed as a log.
+func analyzePath(path string, thresholdAbsoluteNanoseconds int64) ([]*stability.Timeline, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	if info.IsDir() {
+		return analyzeDirectory(path, thresholdAbsoluteNanoseconds)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	defer file.Close()
+
+	reader, err := decompress(bufio.NewReader(file))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s: %w", path, err)
+	}
+
+	if isTarball(path) {
+		return analyzeTarball(path, reader, thresholdAbsoluteNanoseconds)
+	}
+
+	timeline, err := stability.AnalyzeTimeline(path, reader, thresholdAbsoluteNanoseconds)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*stability.Timeline{timeline}, nil
+}
+
+// analyzeDirectory analyzes every daemon log in the directory tree rooted at root, in lexical order.
+func analyzeDirectory(root string, thresholdAbsoluteNanoseconds int64) ([]*stability.Timeline, error) {
+	var timelines []*stability.Timeline
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() || !isDaemonLog(path) {
+			return nil
+		}
+
+		pathTimelines, err := analyzePath(path, thresholdAbsoluteNanoseconds)
+		if err != nil {
+			return err
+		}
+
+		timelines = append(timelines, pathTimelines...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory %s: %w", root, err)
+	}
+
+	return timelines, nil
+}
+
+// analyzeTarball analyzes every daemon log in the tarball read from reader, which is described by tarballPath.
+// Nested tarballs are not searched.
+func analyzeTarball(
+	tarballPath string, reader io.Reader, thresholdAbsoluteNanoseconds int64) ([]*stability.Timeline, error) {
+	var timelines []*stability.Timeline
+
+	tarReader := tar.NewReader(reader)
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return timelines, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tarball %s: %w", tarballPath, err)
+		}
+
+		if header.Typeflag != tar.TypeReg || !isDaemonLog(header.Name) {
+			continue
+		}
+
+		klog.V(90).Infof("Analyzing %s in tarball %s", header.Name, tarballPath)
+
+		entryReader, err := decompress(bufio.NewReader(tarReader))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress %s in tarball %s: %w", header.Name, tarballPath, err)
+		}
+
+		timeline, err := stability.AnalyzeTimeline(tarballPath+":"+header.Name, entryReader, thresholdAbsoluteNanoseconds)
+		if err != nil {
+			return nil, err
+		}
+
+		timelines = append(timelines, timeline)
+	}
+}
+
+// decompress returns a reader of the decompressed content of reader if it is gzip-compressed and reader otherwise.
+func decompress(reader *bufio.Reader) (io.Reader, error) {
+	header, err := reader.Peek(len(gzipMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if !bytes.Equal(header, gzipMagic) {
+		return reader, nil
+	}
+
+	return gzip.NewReader(reader)
+}
+
+// isTarball returns whether path has the extension of a tarball, either compressed or not.
+func isTarball(path string) bool {
+	return strings.HasSuffix(path, ".tar") || strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz")
+}
+
+// isDaemonLog returns whether path is a log of the linuxptp-daemon container, such as
+// namespaces/openshift-ptp/pods/<pod>/linuxptp-daemon-container/linuxptp-daemon-container/logs/current.log in
+// must-gather output.
+func isDaemonLog(path string) bool {
+	return strings.Contains(filepath.ToSlash(path), "/"+daemonContainerName+"/") &&
+		(strings.HasSuffix(path, ".log") || strings.HasSuffix(path, ".log.gz"))
+}
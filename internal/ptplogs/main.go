@@ -0,0 +1,176 @@
+/*
+Ptplogs is a tool to analyze saved linuxptp-daemon logs offline, so PTP stability failures can be triaged without
+reading the logs by eye. It produces the same offset statistics as the stability package does during a run, along with
+the servo state transitions, locked, holdover, and freerun intervals, and threshold violations of every ptp4l, phc2sys,
+ts2phc, dpll, gnss, and GM process in the logs.
+
+Inputs may be log files, directories, or tarballs, such as the ptp-must-gather.tar saved to the failure bundle by the
+must-gather collector. Gzip-compressed tarballs are also accepted. Log files given directly are always analyzed,
+whereas only the linuxptp-daemon-container logs are analyzed from directories and tarballs. Each log is analyzed
+separately, so the logs of different nodes or daemon restarts are not mixed.
+
+The analysis is written to the output directory as analysis.json and as timeline.html, which shows the intervals and
+violations of every process on a timeline. A summary is also printed to stdout.
+
+Upon success the exit code is 0, even if threshold violations are found. If any error occurs, it will be logged to
+stderr and the exit code will be 1.
+
+Usage:
+
+	ptplogs [flags] path...
+
+The flags are:
+
+	-h, -help
+		Print this help message
+
+	-o, -output string
+		Directory to write analysis.json and timeline.html to. Defaults to ptp-log-analysis
+
+	-t, -threshold int
+		Absolute offset threshold in nanoseconds for locked processes. Defaults to 100
+
+	-v int
+		Log level verbosity for klog. Use 100 for logging all messages or leave blank for none
+*/
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/ptplogs/stability"
+	"k8s.io/klog/v2"
+)
+
+const (
+	analysisFileName = "analysis.json"
+	timelineFileName = "timeline.html"
+)
+
+var (
+	help      bool
+	output    string
+	threshold int64
+)
+
+//nolint:gochecknoinits // This is a main package so init is fine.
+func init() {
+	const (
+		helpUsage      = "Print this help message"
+		outputUsage    = "Directory to write analysis.json and timeline.html to"
+		thresholdUsage = "Absolute offset threshold in nanoseconds for locked processes"
+
+		defaultHelp      = false
+		defaultOutput    = "ptp-log-analysis"
+		defaultThreshold = stability.DefaultOffsetThresholdAbsoluteNanoseconds
+
+		shorthand = " (shorthand)"
+	)
+
+	klog.InitFlags(nil)
+
+	_ = flag.Set("logtostderr", "true")
+
+	flag.BoolVar(&help, "help", defaultHelp, helpUsage)
+	flag.BoolVar(&help, "h", defaultHelp, helpUsage+shorthand)
+
+	flag.StringVar(&output, "output", defaultOutput, outputUsage)
+	flag.StringVar(&output, "o", defaultOutput, outputUsage+shorthand)
+
+	flag.Int64Var(&threshold, "threshold", defaultThreshold, thresholdUsage)
+	flag.Int64Var(&threshold, "t", defaultThreshold, thresholdUsage+shorthand)
+}
+
+func main() {
+	flag.Parse()
+
+	if help {
+		flag.Usage()
+
+		return
+	}
+
+	if flag.NArg() == 0 {
+		klog.Errorf("At least one log file, directory, or tarball must be provided")
+
+		os.Exit(1)
+	}
+
+	timelines, err := analyzePaths(flag.Args(), threshold)
+	if err != nil {
+		klog.Errorf("Failed to analyze logs: %v", err)
+
+		os.Exit(1)
+	}
+
+	if len(timelines) == 0 {
+		klog.Errorf("No linuxptp-daemon logs found in %v", flag.Args())
+
+		os.Exit(1)
+	}
+
+	err = writeOutput(output, timelines)
+	if err != nil {
+		klog.Errorf("Failed to write analysis: %v", err)
+
+		os.Exit(1)
+	}
+
+	printSummary(timelines)
+}
+
+// writeOutput writes the timelines as JSON and HTML to the output directory, creating it if it does not exist.
+func writeOutput(outputDir string, timelines []*stability.Timeline) error {
+	err := os.MkdirAll(outputDir, 0o755)
+	if err != nil {
+		return fmt.Errorf("failed to create output directory %s: %w", outputDir, err)
+	}
+
+	analysisJSON, err := json.MarshalIndent(timelines, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal analysis: %w", err)
+	}
+
+	analysisPath := filepath.Join(outputDir, analysisFileName)
+
+	err = os.WriteFile(analysisPath, analysisJSON, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write analysis %s: %w", analysisPath, err)
+	}
+
+	timelinePath := filepath.Join(outputDir, timelineFileName)
+
+	err = writeTimelineHTML(timelinePath, timelines)
+	if err != nil {
+		return err
+	}
+
+	klog.V(90).Infof("Wrote analysis to %s and timeline to %s", analysisPath, timelinePath)
+
+	return nil
+}
+
+// printSummary prints a table with one row per process of every timeline to stdout.
+func printSummary(timelines []*stability.Timeline) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(writer,
+		"SOURCE\tPROCESS\tCONFIG\tSAMPLES\tMAX ABS\tP99 ABS\tVIOLATIONS\tTRANSITIONS\tLOCKED\tHOLDOVER\tFREERUN")
+
+	for _, timeline := range timelines {
+		for _, process := range timeline.Processes {
+			_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%d\t%d\t%.1f\t%d\t%d\t%s\t%s\t%s\n",
+				timeline.Source, process.Process, process.Config, process.Stats.SampleCount, process.Stats.MaxAbs,
+				process.Stats.P99Abs, process.ViolationCount, len(process.Transitions),
+				process.TimeIn(stability.SyncStateLocked), process.TimeIn(stability.SyncStateHoldover),
+				process.TimeIn(stability.SyncStateFreerun))
+		}
+	}
+
+	_ = writer.Flush()
+}
@@ -7,50 +7,68 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/processes"
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/stats"
 	"k8s.io/klog/v2"
 )
 
+// logLevel is the klog level used by this package. It matches tsparams.LogLevel of the PTP suite, which cannot be
+// imported from here.
+const logLevel klog.Level = 80
+
 // DefaultOffsetThresholdAbsoluteNanoseconds is the default absolute offset threshold used by stability analysis. It is
 // intentionally positive, since it is meant for comparison with absolute offsets.
 const DefaultOffsetThresholdAbsoluteNanoseconds int64 = 100
 
+// The process names are not taken from the processes package since it depends on the cluster, which would prevent
+// analyzing saved logs offline.
+const (
+	ptp4lProcessName   = "ptp4l"
+	phc2sysProcessName = "phc2sys"
+)
+
 // OffsetStatistics captures descriptive statistics about absolute offsets over a set of samples. All offsets are in
 // nanoseconds.
 type OffsetStatistics struct {
 	// MaxAbs is the maximum absolute offset in nanoseconds.
-	MaxAbs int64
+	MaxAbs int64 `json:"maxAbs"`
 	// MinAbs is the minimum absolute offset in nanoseconds.
-	MinAbs int64
+	MinAbs int64 `json:"minAbs"`
 	// AvgAbs is the average absolute offset in nanoseconds.
-	AvgAbs float64
+	AvgAbs float64 `json:"avgAbs"`
+	// P99Abs is the 99th percentile of absolute offsets in nanoseconds, accurate to within 1%.
+	P99Abs float64 `json:"p99Abs"`
+	// P999Abs is the 99.9th percentile of absolute offsets in nanoseconds, accurate to within 1%.
+	P999Abs float64 `json:"p999Abs"`
 	// SampleCount is the number of samples used to compute the statistics.
-	SampleCount int
+	SampleCount int `json:"sampleCount"`
 
-	totalAbs float64
+	histogram *stats.LogHistogram
 }
 
 // observe adds a new offset sample to the statistics.
 func (s *OffsetStatistics) observe(offset int64) {
-	absoluteValue := abs(offset)
+	if s.histogram == nil {
+		// The accuracy is a valid constant, so this cannot fail.
+		s.histogram, _ = stats.NewLogHistogram(stats.DefaultRelativeAccuracy)
+	}
 
-	if s.SampleCount == 0 {
-		s.MinAbs = absoluteValue
-		s.MaxAbs = absoluteValue
-	} else {
-		if absoluteValue < s.MinAbs {
-			s.MinAbs = absoluteValue
-		}
+	s.histogram.Add(float64(abs(offset)))
 
-		if absoluteValue > s.MaxAbs {
-			s.MaxAbs = absoluteValue
-		}
+	aggregate := s.histogram.Aggregate()
+	s.MinAbs = int64(aggregate.Min())
+	s.MaxAbs = int64(aggregate.Max())
+	s.AvgAbs = aggregate.Mean()
+	s.SampleCount = aggregate.Count()
+}
+
+// finalize computes the percentiles of the observed offsets, which are too expensive to update on every sample.
+func (s *OffsetStatistics) finalize() {
+	if s.histogram == nil {
+		return
 	}
 
-	s.totalAbs += float64(absoluteValue)
-	s.SampleCount++
-	s.AvgAbs = s.totalAbs / float64(s.SampleCount)
+	s.P99Abs, _ = s.histogram.Percentile(99)
+	s.P999Abs, _ = s.histogram.Percentile(99.9)
 }
 
 // StateTransition describes a servo state change between adjacent parsed entries.
@@ -94,7 +112,7 @@ func (p *ProcessResult) processEntry(line string) {
 	p.candidateLines++
 
 	if result.Dropped {
-		klog.V(tsparams.LogLevel).Infof("%s: dropping line with unparseable offset %q", p.name, line)
+		klog.V(logLevel).Infof("%s: dropping line with unparseable offset %q", p.name, line)
 
 		p.droppedLines++
 
@@ -167,12 +185,12 @@ func AnalyzeFromFile(filePath string, thresholdAbsoluteNanoseconds int64) (Analy
 
 	result := AnalysisResult{
 		PTP4L: ProcessResult{
-			name:      string(processes.Ptp4l),
+			name:      ptp4lProcessName,
 			pattern:   ptp4lPattern,
 			threshold: thresholdAbsoluteNanoseconds,
 		},
 		PHC2SYS: ProcessResult{
-			name:      string(processes.Phc2sys),
+			name:      phc2sysProcessName,
 			pattern:   phc2sysPattern,
 			threshold: thresholdAbsoluteNanoseconds,
 		},
@@ -214,6 +232,8 @@ func (a *AnalysisResult) processLine(line string) {
 
 // finalize processes the accumulated data and determines the pass/fail decision.
 func (a *AnalysisResult) finalize() {
+	a.PTP4L.Stats.finalize()
+	a.PHC2SYS.Stats.finalize()
 	a.ParseWarnings = a.buildParseWarnings()
 	a.Details = buildFailureDetails(*a)
 	a.Passed = len(a.Details) == 0
@@ -309,9 +329,10 @@ func buildFailureDetails(result AnalysisResult) []string {
 }
 
 // formatStatsLine renders an OffsetStatistics value as a single key=value diagnostic line.
-func formatStatsLine(process string, stats OffsetStatistics) string {
-	return fmt.Sprintf("%s_offsets_max_abs=%d min_abs=%d avg_abs=%.3f samples=%d",
-		process, stats.MaxAbs, stats.MinAbs, stats.AvgAbs, stats.SampleCount)
+func formatStatsLine(process string, offsetStats OffsetStatistics) string {
+	return fmt.Sprintf("%s_offsets_max_abs=%d min_abs=%d avg_abs=%.3f p99_abs=%.1f p99_9_abs=%.1f samples=%d",
+		process, offsetStats.MaxAbs, offsetStats.MinAbs, offsetStats.AvgAbs, offsetStats.P99Abs, offsetStats.P999Abs,
+		offsetStats.SampleCount)
 }
 
 // abs returns the absolute value of an int64. It ignores the possibility of overflow since it is not applicable to the
@@ -0,0 +1,295 @@
+package stability
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// maxRecordedViolations is the maximum number of threshold violations recorded per process. Every violation is still
+// counted, but overnight runs with a misbehaving clock would otherwise produce a timeline as large as the logs.
+const maxRecordedViolations = 100
+
+// SyncState is the synchronization state of a process, derived from the servo state in its log lines.
+type SyncState string
+
+const (
+	// SyncStateLocked is the state of a process whose servo is locked (s2) or locked and stable (s3).
+	SyncStateLocked SyncState = "locked"
+	// SyncStateHoldover is the state of a DPLL, GNSS, or T-GM status line reporting s1, which the daemon uses for
+	// holdover.
+	SyncStateHoldover SyncState = "holdover"
+	// SyncStateFreerun is the state of a process whose servo is unlocked (s0) or, for ptp4l, phc2sys, and ts2phc,
+	// stepping the clock (s1).
+	SyncStateFreerun SyncState = "freerun"
+)
+
+var (
+	// timelineLinePattern matches the log lines of the processes run by the linuxptp daemon, optionally prefixed by
+	// a timestamp from saving the logs with timestamps, as oc adm inspect and must-gather do, or from the CRI log
+	// format. For example:
+	//  2026-01-02T03:04:05.678901234Z ptp4l[401304.873]: [ptp4l.1.config:6] master offset -3 s2 freq -94379 path delay 161
+	timelineLinePattern = regexp.MustCompile(`^(?:(?P<time>\d{4}-\d\d-\d\dT\S+)\s+(?:(?:stdout|stderr)\s+[FP]\s+)?)?` +
+		`(?P<process>ptp4l|phc2sys|ts2phc|dpll|gnss|GM)\[(?P<uptime>\d+(?:\.\d+)?)\]:\s*` +
+		`(?:\[(?P<config>[^\]:]+)(?::\d+)?\])?\s*(?P<message>.*)$`)
+	// timelineOffsetPattern matches the offset and servo state in the message of ptp4l, phc2sys, and ts2phc lines.
+	timelineOffsetPattern = regexp.MustCompile(`\boffset\s+(?P<offset>-?\d+)\s+(?P<state>s\d+)\b`)
+	// timelineStatePattern matches the servo state at the end of the message of dpll, gnss, and GM status lines.
+	timelineStatePattern = regexp.MustCompile(`\b(?P<state>s\d+)\s*$`)
+)
+
+// TimelinePoint is the time of a log line.
+type TimelinePoint struct {
+	// Uptime is the time in seconds since the node booted, as logged by the process. Unlike Time, it is always
+	// present, so intervals are measured using it.
+	Uptime float64 `json:"uptime"`
+	// Time is the wall clock time of the line if the logs were saved with timestamps and zero otherwise.
+	Time time.Time `json:"time,omitzero"`
+}
+
+// TimelineTransition is a change in the servo state of a process between adjacent log lines.
+type TimelineTransition struct {
+	TimelinePoint
+	From string `json:"from"`
+	To   string `json:"to"`
+	Line string `json:"line"`
+}
+
+// TimelineInterval is a period during which a process stayed in the same SyncState. It ends at the last line in that
+// state rather than the first line in the next state.
+type TimelineInterval struct {
+	State SyncState     `json:"state"`
+	Start TimelinePoint `json:"start"`
+	End   TimelinePoint `json:"end"`
+}
+
+// Duration returns the length of the interval, measured using the uptime of its start and end.
+func (interval TimelineInterval) Duration() time.Duration {
+	return time.Duration((interval.End.Uptime - interval.Start.Uptime) * float64(time.Second))
+}
+
+// TimelineViolation is a log line of a locked process whose absolute offset exceeded the threshold.
+type TimelineViolation struct {
+	TimelinePoint
+	Offset int64  `json:"offset"`
+	Line   string `json:"line"`
+}
+
+// ProcessTimeline is the timeline of a single process, identified by its name and the config it was started with.
+type ProcessTimeline struct {
+	Process string `json:"process"`
+	Config  string `json:"config,omitempty"`
+	// Stats are the statistics of the offsets of the process. They are empty for dpll, gnss, and GM, which only
+	// report states.
+	Stats       OffsetStatistics     `json:"stats"`
+	Transitions []TimelineTransition `json:"transitions,omitempty"`
+	Intervals   []TimelineInterval   `json:"intervals,omitempty"`
+	// ViolationCount is the number of locked lines whose absolute offset exceeded the threshold. Only the first 100
+	// are recorded in Violations.
+	ViolationCount int                 `json:"violationCount"`
+	Violations     []TimelineViolation `json:"violations,omitempty"`
+
+	prevState string
+}
+
+// TimeIn returns the total time the process spent in the provided state.
+func (process *ProcessTimeline) TimeIn(state SyncState) time.Duration {
+	var total time.Duration
+
+	for _, interval := range process.Intervals {
+		if interval.State == state {
+			total += interval.Duration()
+		}
+	}
+
+	return total
+}
+
+// observe updates the timeline of the process with a line at point, which has the provided servo state and, if
+// hasOffset is true, offset.
+func (process *ProcessTimeline) observe(
+	point TimelinePoint, line, state string, offset int64, hasOffset bool, threshold int64) {
+	syncState := classifyServoState(process.Process, state)
+
+	if hasOffset {
+		process.Stats.observe(offset)
+
+		if syncState == SyncStateLocked && abs(offset) > threshold {
+			process.ViolationCount++
+
+			if len(process.Violations) < maxRecordedViolations {
+				process.Violations = append(process.Violations,
+					TimelineViolation{TimelinePoint: point, Offset: offset, Line: line})
+			}
+		}
+	}
+
+	if process.prevState != "" && process.prevState != state {
+		process.Transitions = append(process.Transitions,
+			TimelineTransition{TimelinePoint: point, From: process.prevState, To: state, Line: line})
+	}
+
+	process.prevState = state
+
+	if lastIndex := len(process.Intervals) - 1; lastIndex >= 0 && process.Intervals[lastIndex].State == syncState {
+		process.Intervals[lastIndex].End = point
+
+		return
+	}
+
+	process.Intervals = append(process.Intervals, TimelineInterval{State: syncState, Start: point, End: point})
+}
+
+// Timeline is the result of analyzing a single daemon log over time. Unlike AnalysisResult, it keeps when offsets and
+// states changed, so it can be used to triage failures after the fact, and it covers every process of the daemon
+// rather than only ptp4l and phc2sys.
+type Timeline struct {
+	// Source is where the log was read from, such as its path.
+	Source string `json:"source"`
+	// Threshold is the absolute offset threshold in nanoseconds used to find violations.
+	Threshold int64 `json:"threshold"`
+	// Start and End are the times of the first and last parsed process lines.
+	Start TimelinePoint `json:"start"`
+	End   TimelinePoint `json:"end"`
+	// Processes are sorted by process name and then by config.
+	Processes []*ProcessTimeline `json:"processes"`
+
+	PTP4LStartCount  uint `json:"ptp4lStartCount"`
+	FaultyLineCount  int  `json:"faultyLineCount"`
+	TimeoutLineCount int  `json:"timeoutLineCount"`
+
+	processIndex map[string]*ProcessTimeline
+}
+
+// AnalyzeTimeline performs a single-pass streaming analysis of the daemon log read from reader, which is described by
+// source. Lines are expected to be in the format of the linuxptp-daemon container logs, optionally prefixed by a
+// timestamp. A non-positive threshold is replaced with DefaultOffsetThresholdAbsoluteNanoseconds.
+func AnalyzeTimeline(source string, reader io.Reader, thresholdAbsoluteNanoseconds int64) (*Timeline, error) {
+	if thresholdAbsoluteNanoseconds <= 0 {
+		thresholdAbsoluteNanoseconds = DefaultOffsetThresholdAbsoluteNanoseconds
+	}
+
+	timeline := &Timeline{
+		Source:       source,
+		Threshold:    thresholdAbsoluteNanoseconds,
+		processIndex: make(map[string]*ProcessTimeline),
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		timeline.processLine(scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading log %s: %w", source, err)
+	}
+
+	slices.SortFunc(timeline.Processes, func(a, b *ProcessTimeline) int {
+		return cmp.Or(cmp.Compare(a.Process, b.Process), cmp.Compare(a.Config, b.Config))
+	})
+
+	for _, process := range timeline.Processes {
+		process.Stats.finalize()
+	}
+
+	return timeline, nil
+}
+
+// processLine parses and accumulates a single log line.
+func (timeline *Timeline) processLine(line string) {
+	if containsFaulty(line) {
+		timeline.FaultyLineCount++
+	}
+
+	if containsTimeout(line) {
+		timeline.TimeoutLineCount++
+	}
+
+	if isPTP4LStart(line) {
+		timeline.PTP4LStartCount++
+	}
+
+	match := timelineLinePattern.FindStringSubmatch(line)
+	if match == nil {
+		return
+	}
+
+	processName := match[timelineLinePattern.SubexpIndex("process")]
+	message := match[timelineLinePattern.SubexpIndex("message")]
+
+	var (
+		state     string
+		offset    int64
+		hasOffset bool
+	)
+
+	if offsetMatch := timelineOffsetPattern.FindStringSubmatch(message); offsetMatch != nil {
+		parsedOffset, err := strconv.ParseInt(offsetMatch[timelineOffsetPattern.SubexpIndex("offset")], 10, 64)
+		if err != nil {
+			return
+		}
+
+		state = offsetMatch[timelineOffsetPattern.SubexpIndex("state")]
+		offset, hasOffset = parsedOffset, true
+	} else if stateMatch := timelineStatePattern.FindStringSubmatch(message); stateMatch != nil {
+		state = stateMatch[timelineStatePattern.SubexpIndex("state")]
+	} else {
+		return
+	}
+
+	// The uptime pattern only matches valid floats, so this cannot fail.
+	uptime, _ := strconv.ParseFloat(match[timelineLinePattern.SubexpIndex("uptime")], 64)
+	point := TimelinePoint{Uptime: uptime}
+
+	if wallTime, err := time.Parse(time.RFC3339Nano, match[timelineLinePattern.SubexpIndex("time")]); err == nil {
+		point.Time = wallTime
+	}
+
+	if len(timeline.processIndex) == 0 {
+		timeline.Start = point
+	}
+
+	timeline.End = point
+
+	timeline.process(processName, match[timelineLinePattern.SubexpIndex("config")]).
+		observe(point, line, state, offset, hasOffset, timeline.Threshold)
+}
+
+// process returns the timeline of the process with the provided name and config, creating it if it does not exist.
+func (timeline *Timeline) process(processName, config string) *ProcessTimeline {
+	key := processName + "/" + config
+
+	process, ok := timeline.processIndex[key]
+	if !ok {
+		process = &ProcessTimeline{Process: processName, Config: config}
+		timeline.processIndex[key] = process
+		timeline.Processes = append(timeline.Processes, process)
+	}
+
+	return process
+}
+
+// classifyServoState returns the SyncState of a servo state logged by the provided process. The daemon reports
+// holdover as s1 on the dpll, gnss, and GM status lines, whereas s1 means the servo is stepping the clock for the
+// other processes.
+func classifyServoState(processName, state string) SyncState {
+	switch state {
+	case "s2", "s3":
+		return SyncStateLocked
+	case "s1":
+		if processName == "dpll" || processName == "gnss" || processName == "GM" {
+			return SyncStateHoldover
+		}
+
+		return SyncStateFreerun
+	default:
+		return SyncStateFreerun
+	}
+}
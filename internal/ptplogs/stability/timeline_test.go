@@ -0,0 +1,84 @@
+package stability
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeTimeline(t *testing.T) {
+	file, err := os.Open("testdata/daemon.log")
+	assert.NoError(t, err)
+
+	defer file.Close()
+
+	timeline, err := AnalyzeTimeline("daemon.log", file, 0)
+	assert.NoError(t, err)
+
+	assert.Equal(t, DefaultOffsetThresholdAbsoluteNanoseconds, timeline.Threshold)
+	assert.Equal(t, 1000.0, timeline.Start.Uptime)
+	assert.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 100000000, time.UTC), timeline.Start.Time)
+	assert.Equal(t, 1007.0, timeline.End.Uptime)
+	assert.Equal(t, uint(1), timeline.PTP4LStartCount)
+	assert.Equal(t, 1, timeline.FaultyLineCount)
+
+	var processNames []string
+	for _, process := range timeline.Processes {
+		processNames = append(processNames, process.Process+"/"+process.Config)
+	}
+
+	assert.Equal(t, []string{
+		"dpll/ts2phc.0.config", "phc2sys/ptp4l.0.config", "ptp4l/ptp4l.0.config", "ts2phc/ts2phc.0.config",
+	}, processNames)
+
+	dpll, phc2sys, ptp4l := timeline.Processes[0], timeline.Processes[1], timeline.Processes[2]
+
+	assert.Equal(t, 5, ptp4l.Stats.SampleCount)
+	assert.Equal(t, int64(500), ptp4l.Stats.MaxAbs)
+	assert.Equal(t, 1, ptp4l.ViolationCount)
+	assert.Equal(t, int64(250), ptp4l.Violations[0].Offset)
+	assert.Len(t, ptp4l.Transitions, 2)
+	assert.Equal(t, []TimelineInterval{
+		{State: SyncStateFreerun, Start: TimelinePoint{Uptime: 1000}, End: TimelinePoint{Uptime: 1001}},
+		{State: SyncStateLocked, Start: TimelinePoint{Uptime: 1002}, End: TimelinePoint{Uptime: 1004}},
+	}, stripWallTimes(ptp4l.Intervals))
+	assert.Equal(t, 2*time.Second, ptp4l.TimeIn(SyncStateLocked))
+
+	assert.Equal(t, 2, phc2sys.Stats.SampleCount)
+	assert.Equal(t, 0, phc2sys.ViolationCount)
+
+	assert.Equal(t, 0, dpll.Stats.SampleCount)
+	assert.Equal(t, time.Second, dpll.TimeIn(SyncStateHoldover))
+	assert.Len(t, dpll.Transitions, 1)
+	assert.Equal(t, "s2", dpll.Transitions[0].From)
+	assert.Equal(t, "s1", dpll.Transitions[0].To)
+}
+
+func TestAnalyzeTimelineWithoutTimestamps(t *testing.T) {
+	timeline, err := AnalyzeTimeline("raw", strings.NewReader(
+		"ptp4l[401304.873]: [ptp4l.1.config:6] master offset -3 s2 freq -94379 path delay 161\n"+
+			"not a daemon line\n"), 10)
+	assert.NoError(t, err)
+
+	assert.True(t, timeline.Start.Time.IsZero())
+	assert.Equal(t, 401304.873, timeline.Start.Uptime)
+	assert.Len(t, timeline.Processes, 1)
+	assert.Equal(t, "ptp4l.1.config", timeline.Processes[0].Config)
+}
+
+// stripWallTimes returns intervals with the wall clock times of their start and end removed, so they can be compared
+// using only uptimes.
+func stripWallTimes(intervals []TimelineInterval) []TimelineInterval {
+	var stripped []TimelineInterval
+
+	for _, interval := range intervals {
+		interval.Start.Time = time.Time{}
+		interval.End.Time = time.Time{}
+		stripped = append(stripped, interval)
+	}
+
+	return stripped
+}
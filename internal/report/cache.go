@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/klauspost/compress/zstd"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/suitetree"
 	"k8s.io/klog/v2"
 )
 
@@ -38,7 +39,7 @@ type CacheKey struct {
 // Cache represents the format of the cache file. It will be saved as JSON according to the XDG base directory
 // specification.
 type Cache struct {
-	Trees     map[CacheKey]*SuiteTree
+	Trees     map[CacheKey]*suitetree.SuiteTree
 	directory string
 	ctx       context.Context
 }
@@ -49,7 +50,7 @@ func NewCacheContext(ctx context.Context) (*Cache, error) {
 	klog.V(100).Info("Instantiating new Cache and attempting to load")
 
 	cache := &Cache{
-		Trees: make(map[CacheKey]*SuiteTree),
+		Trees: make(map[CacheKey]*suitetree.SuiteTree),
 		ctx:   ctx,
 	}
 
@@ -169,7 +170,7 @@ func (cache *Cache) Save() error {
 // revision are concatenated and used as the key in the returned map. If the match was present in the cache, then its
 // value is the cached SuiteTree. If the match was not present in the cache, its value is nil. All matches will appear
 // in the returned map.
-func (cache *Cache) GetRemotePatterns(patterns []string) (map[CacheKey]*SuiteTree, error) {
+func (cache *Cache) GetRemotePatterns(patterns []string) (map[CacheKey]*suitetree.SuiteTree, error) {
 	klog.V(100).Infof("Checking if branches matching patterns %v are in cache", patterns)
 
 	if sourceCodeSum == "" {
@@ -183,7 +184,7 @@ func (cache *Cache) GetRemotePatterns(patterns []string) (map[CacheKey]*SuiteTre
 		return nil, err
 	}
 
-	cachedTrees := make(map[CacheKey]*SuiteTree)
+	cachedTrees := make(map[CacheKey]*suitetree.SuiteTree)
 
 	for branch, revision := range revisions {
 		key := CacheKey{Branch: branch, Revision: revision}
@@ -201,7 +202,7 @@ func (cache *Cache) GetRemotePatterns(patterns []string) (map[CacheKey]*SuiteTre
 
 // Get returns the suite tree for the given repo path from the cache. It returns a cache miss error if the repo has
 // uncommitted changes or if the cache does not contain the repo.
-func (cache *Cache) Get(repoPath string) (*SuiteTree, error) {
+func (cache *Cache) Get(repoPath string) (*suitetree.SuiteTree, error) {
 	klog.V(100).Infof("Getting cache for repo %s", repoPath)
 
 	if sourceCodeSum == "" {
@@ -226,7 +227,7 @@ func (cache *Cache) Get(repoPath string) (*SuiteTree, error) {
 // GetOrCreate returns the suite tree for the given repo path from the cache. It first calls Get and if there is a cache
 // miss, it calls the given create function and adds the result to the cache. Note that if the repo has local changes,
 // the create function will always be called, but the result will not be added to the cache.
-func (cache *Cache) GetOrCreate(repoPath string) (*SuiteTree, error) {
+func (cache *Cache) GetOrCreate(repoPath string) (*suitetree.SuiteTree, error) {
 	klog.V(100).Infof("Getting or creating cache for repo %s", repoPath)
 
 	tree, err := cache.Get(repoPath)
@@ -247,7 +248,7 @@ func (cache *Cache) GetOrCreate(repoPath string) (*SuiteTree, error) {
 		return nil, err
 	}
 
-	tree, err = NewFromFile(reportPath)
+	tree, err = suitetree.NewFromFile(reportPath)
 	if err != nil {
 		klog.V(100).Infof("Failed to create SuiteTree from report.json: %v", err)
 
@@ -276,7 +277,7 @@ func (cache *Cache) Update() error {
 		klog.V(100).Info(
 			"Unable to retrieve source code sum. All cache entries will be removed as their validity cannot be verified.")
 
-		cache.Trees = make(map[CacheKey]*SuiteTree)
+		cache.Trees = make(map[CacheKey]*suitetree.SuiteTree)
 
 		return nil
 	}
@@ -388,7 +389,7 @@ func (cache *Cache) deleteExpiredFiles() error {
 }
 
 // saveCacheFile saves the tree at the path provided by cacheFileName, truncating if the file already exists.
-func saveCacheFile(cacheFileName string, tree *SuiteTree) error {
+func saveCacheFile(cacheFileName string, tree *suitetree.SuiteTree) error {
 	klog.V(100).Infof("Saving cached tree to %s", cacheFileName)
 
 	file, err := os.Create(cacheFileName)
@@ -414,7 +415,7 @@ func saveCacheFile(cacheFileName string, tree *SuiteTree) error {
 }
 
 // loadCacheFile attempts to load a SuiteTree from cacheFileName.
-func loadCacheFile(cacheFileName string) (*SuiteTree, error) {
+func loadCacheFile(cacheFileName string) (*suitetree.SuiteTree, error) {
 	klog.V(100).Infof("Loading cached tree from %s", cacheFileName)
 
 	file, err := os.Open(cacheFileName)
@@ -429,7 +430,7 @@ func loadCacheFile(cacheFileName string) (*SuiteTree, error) {
 		return nil, err
 	}
 
-	tree := &SuiteTree{}
+	tree := &suitetree.SuiteTree{}
 
 	err = json.NewDecoder(decompressor).Decode(tree)
 	if err != nil {
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/suitetree"
+	"k8s.io/klog/v2"
+)
+
+// SpecChange is the kind of difference found for a single spec between two branches.
+type SpecChange string
+
+const (
+	// SpecAdded means the spec is present in the target branch but not the base branch.
+	SpecAdded SpecChange = "added"
+	// SpecRemoved means the spec is present in the base branch but not the target branch.
+	SpecRemoved SpecChange = "removed"
+	// SpecRelabelled means the spec is present in both branches but with a different set of labels.
+	SpecRelabelled SpecChange = "relabelled"
+	// SpecMoved means the spec is present in both branches but in different suites.
+	SpecMoved SpecChange = "moved"
+)
+
+// allSpecChanges lists every SpecChange in the order they should be displayed.
+var allSpecChanges = []SpecChange{SpecAdded, SpecRemoved, SpecRelabelled, SpecMoved}
+
+// SpecDiff describes a single difference for a spec between two branches. Specs are matched by their key from
+// GetSpecKey, so a spec that is both relabelled and moved will have two SpecDiffs.
+type SpecDiff struct {
+	Key    string
+	ID     string
+	Text   string
+	Change SpecChange
+	// BaseSuites and TargetSuites are the suite paths relative to the root of the tree for each branch. There may be
+	// more than one suite if the key is duplicated within a branch.
+	BaseSuites   []string
+	TargetSuites []string
+	BaseLabels   []string
+	TargetLabels []string
+}
+
+// BranchDiff contains all the differences between the specs of a base branch and a target branch.
+type BranchDiff struct {
+	Base   CacheKey
+	Target CacheKey
+	// Diffs are sorted by change, in the order of allSpecChanges, and then by key.
+	Diffs []SpecDiff
+}
+
+// diffEntry is the information about a single spec key in a single branch that is used for comparing branches.
+type diffEntry struct {
+	id     string
+	text   string
+	suites []string
+	labels []string
+}
+
+// DiffTreeMap compares the tree for the provided base branch against the trees for every other branch in treeMap. The
+// returned diffs are sorted by target branch name. It returns an error if the base branch is not in treeMap.
+func DiffTreeMap(treeMap map[CacheKey]*suitetree.SuiteTree, baseBranch string) ([]*BranchDiff, error) {
+	klog.V(100).Infof("Comparing trees for %d branches against base branch %s", len(treeMap), baseBranch)
+
+	var (
+		baseKey  CacheKey
+		baseTree *suitetree.SuiteTree
+	)
+
+	for key, tree := range treeMap {
+		if key.Branch == baseBranch {
+			baseKey = key
+			baseTree = tree
+
+			break
+		}
+	}
+
+	if baseTree == nil {
+		return nil, fmt.Errorf("base branch %s is not one of the branches matched", baseBranch)
+	}
+
+	var branchDiffs []*BranchDiff
+
+	for key, tree := range treeMap {
+		if key == baseKey {
+			continue
+		}
+
+		branchDiffs = append(branchDiffs, DiffTrees(baseKey, baseTree, key, tree))
+	}
+
+	slices.SortFunc(branchDiffs, func(diffA, diffB *BranchDiff) int {
+		return strings.Compare(diffA.Target.Branch, diffB.Target.Branch)
+	})
+
+	return branchDiffs, nil
+}
+
+// DiffTrees compares the specs in the base tree to the specs in the target tree. Specs are matched by their key from
+// GetSpecKey and suites are compared using their paths relative to the root of each tree, so both trees should be
+// trimmed the same way.
+func DiffTrees(baseKey CacheKey, base *suitetree.SuiteTree, targetKey CacheKey, target *suitetree.SuiteTree) *BranchDiff {
+	klog.V(100).Infof("Comparing tree for branch %s against base branch %s", targetKey.Branch, baseKey.Branch)
+
+	baseEntries := collectDiffEntries(base)
+	targetEntries := collectDiffEntries(target)
+	branchDiff := &BranchDiff{Base: baseKey, Target: targetKey}
+
+	for key, baseEntry := range baseEntries {
+		targetEntry, ok := targetEntries[key]
+		if !ok {
+			branchDiff.Diffs = append(branchDiff.Diffs, newSpecDiff(key, SpecRemoved, baseEntry, nil))
+
+			continue
+		}
+
+		if !slices.Equal(baseEntry.labels, targetEntry.labels) {
+			branchDiff.Diffs = append(branchDiff.Diffs, newSpecDiff(key, SpecRelabelled, baseEntry, targetEntry))
+		}
+
+		if !slices.Equal(baseEntry.suites, targetEntry.suites) {
+			branchDiff.Diffs = append(branchDiff.Diffs, newSpecDiff(key, SpecMoved, baseEntry, targetEntry))
+		}
+	}
+
+	for key, targetEntry := range targetEntries {
+		if _, ok := baseEntries[key]; !ok {
+			branchDiff.Diffs = append(branchDiff.Diffs, newSpecDiff(key, SpecAdded, nil, targetEntry))
+		}
+	}
+
+	slices.SortFunc(branchDiff.Diffs, func(diffA, diffB SpecDiff) int {
+		if n := cmp.Compare(slices.Index(allSpecChanges, diffA.Change), slices.Index(allSpecChanges, diffB.Change)); n != 0 {
+			return n
+		}
+
+		return strings.Compare(diffA.Key, diffB.Key)
+	})
+
+	return branchDiff
+}
+
+// Count returns the number of diffs with the provided change.
+func (branchDiff *BranchDiff) Count(change SpecChange) int {
+	count := 0
+
+	for _, diff := range branchDiff.Diffs {
+		if diff.Change == change {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Changes returns all the SpecChanges in display order. It is provided so templates can iterate over them.
+func (branchDiff *BranchDiff) Changes() []SpecChange {
+	return allSpecChanges
+}
+
+// String returns a string representation of the diff. It contains a summary line followed by one line per diff with
+// the change, key, and what changed.
+func (branchDiff *BranchDiff) String() string {
+	builder := &strings.Builder{}
+
+	fmt.Fprintf(builder, "Branch %s (%s) compared to %s (%s):",
+		branchDiff.Target.Branch, shortRevision(branchDiff.Target.Revision),
+		branchDiff.Base.Branch, shortRevision(branchDiff.Base.Revision))
+
+	for _, change := range allSpecChanges {
+		fmt.Fprintf(builder, " %d %s", branchDiff.Count(change), change)
+	}
+
+	builder.WriteByte('\n')
+
+	for _, diff := range branchDiff.Diffs {
+		fmt.Fprintf(builder, "%-10s %s", diff.Change, diff.Key)
+
+		switch diff.Change {
+		case SpecRelabelled:
+			fmt.Fprintf(builder, " [%s] -> [%s]", strings.Join(diff.BaseLabels, ", "), strings.Join(diff.TargetLabels, ", "))
+		case SpecMoved:
+			fmt.Fprintf(builder, " %s -> %s", strings.Join(diff.BaseSuites, ", "), strings.Join(diff.TargetSuites, ", "))
+		case SpecAdded:
+			fmt.Fprintf(builder, " in %s", strings.Join(diff.TargetSuites, ", "))
+		case SpecRemoved:
+			fmt.Fprintf(builder, " from %s", strings.Join(diff.BaseSuites, ", "))
+		}
+
+		builder.WriteByte('\n')
+	}
+
+	return builder.String()
+}
+
+// collectDiffEntries walks the tree and groups the specs by key. Suites and labels are sorted and deduplicated so that
+// entries from different branches can be compared directly.
+func collectDiffEntries(tree *suitetree.SuiteTree) map[string]*diffEntry {
+	entries := make(map[string]*diffEntry)
+
+	tree.WalkSpecs(func(suite *suitetree.SuiteTree, spec *types.SpecReport) {
+		key := suitetree.GetSpecKey(spec)
+
+		entry, ok := entries[key]
+		if !ok {
+			entry = &diffEntry{id: suitetree.GetSpecID(spec), text: spec.FullText()}
+			entries[key] = entry
+		}
+
+		suitePath, err := filepath.Rel(tree.Path, suite.Path)
+		if err != nil {
+			suitePath = suite.Path
+		}
+
+		entry.suites = append(entry.suites, suitePath)
+		entry.labels = append(entry.labels, spec.Labels()...)
+	})
+
+	for _, entry := range entries {
+		slices.Sort(entry.suites)
+		entry.suites = slices.Compact(entry.suites)
+
+		slices.Sort(entry.labels)
+		entry.labels = slices.Compact(entry.labels)
+	}
+
+	return entries
+}
+
+// newSpecDiff creates a SpecDiff from the base and target entries, either of which may be nil.
+func newSpecDiff(key string, change SpecChange, base, target *diffEntry) SpecDiff {
+	diff := SpecDiff{Key: key, Change: change}
+
+	if base != nil {
+		diff.ID = base.id
+		diff.Text = base.text
+		diff.BaseSuites = base.suites
+		diff.BaseLabels = base.labels
+	}
+
+	if target != nil {
+		diff.ID = target.id
+		diff.Text = target.text
+		diff.TargetSuites = target.suites
+		diff.TargetLabels = target.labels
+	}
+
+	return diff
+}
+
+// shortRevision returns the first 7 characters of revision or the entire revision if it is shorter.
+func shortRevision(revision string) string {
+	if len(revision) < 7 {
+		return revision
+	}
+
+	return revision[:7]
+}
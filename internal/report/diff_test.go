@@ -0,0 +1,182 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/suitetree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTreePath = "/repo/tests"
+
+// newSuiteReport returns a report for the suite at suitePath, relative to testTreePath, containing specs.
+func newSuiteReport(suitePath string, specs ...types.SpecReport) types.Report {
+	report := types.Report{SuitePath: testTreePath + "/" + suitePath, SuiteDescription: suitePath, SpecReports: specs}
+	report.PreRunStats.TotalSpecs = len(specs)
+
+	return report
+}
+
+// newTestTree returns the node for testTreePath of a SuiteTree created from reports.
+func newTestTree(t *testing.T, reports ...types.Report) *suitetree.SuiteTree {
+	t.Helper()
+
+	tree := suitetree.NewFromReports(reports).Find(testTreePath)
+	require.NotNil(t, tree)
+
+	return tree
+}
+
+func TestDiffTrees(t *testing.T) {
+	testCases := []struct {
+		name     string
+		base     []types.Report
+		target   []types.Report
+		expected []SpecDiff
+	}{
+		{
+			name:   "identical",
+			base:   []types.Report{newSuiteReport("a", newSpecReport("one", "1", "x"), newSpecReport("two", ""))},
+			target: []types.Report{newSuiteReport("a", newSpecReport("one", "1", "x"), newSpecReport("two", ""))},
+		},
+		{
+			name:   "added and removed",
+			base:   []types.Report{newSuiteReport("a", newSpecReport("one", "1"), newSpecReport("two", ""))},
+			target: []types.Report{newSuiteReport("a", newSpecReport("one", "1"), newSpecReport("three", "3"))},
+			expected: []SpecDiff{
+				{
+					Key: "3", ID: "3", Text: "three", Change: SpecAdded,
+					TargetSuites: []string{"a"}, TargetLabels: []string{"3", "test_id:3"},
+				},
+				{Key: "two", Text: "two", Change: SpecRemoved, BaseSuites: []string{"a"}},
+			},
+		},
+		{
+			name:   "matched by ID",
+			base:   []types.Report{newSuiteReport("a", newSpecReport("old text", "1"))},
+			target: []types.Report{newSuiteReport("a", newSpecReport("new text", "1"))},
+		},
+		{
+			name:   "matched by text",
+			base:   []types.Report{newSuiteReport("a", newSpecReport("old text", ""))},
+			target: []types.Report{newSuiteReport("a", newSpecReport("new text", ""))},
+			expected: []SpecDiff{
+				{Key: "new text", Text: "new text", Change: SpecAdded, TargetSuites: []string{"a"}},
+				{Key: "old text", Text: "old text", Change: SpecRemoved, BaseSuites: []string{"a"}},
+			},
+		},
+		{
+			name:   "relabelled",
+			base:   []types.Report{newSuiteReport("a", newSpecReport("one", "1", "x"))},
+			target: []types.Report{newSuiteReport("a", newSpecReport("one", "1", "y", "x"))},
+			expected: []SpecDiff{{
+				Key: "1", ID: "1", Text: "one", Change: SpecRelabelled,
+				BaseSuites: []string{"a"}, TargetSuites: []string{"a"},
+				BaseLabels: []string{"1", "test_id:1", "x"}, TargetLabels: []string{"1", "test_id:1", "x", "y"},
+			}},
+		},
+		{
+			name:   "moved",
+			base:   []types.Report{newSuiteReport("a", newSpecReport("one", "")), newSuiteReport("b")},
+			target: []types.Report{newSuiteReport("a"), newSuiteReport("b/c", newSpecReport("one", ""))},
+			expected: []SpecDiff{{
+				Key: "one", Text: "one", Change: SpecMoved, BaseSuites: []string{"a"}, TargetSuites: []string{"b/c"},
+			}},
+		},
+		{
+			name:   "relabelled and moved",
+			base:   []types.Report{newSuiteReport("a", newSpecReport("one", "1"))},
+			target: []types.Report{newSuiteReport("b", newSpecReport("one", "1", "x"))},
+			expected: []SpecDiff{
+				{
+					Key: "1", ID: "1", Text: "one", Change: SpecRelabelled,
+					BaseSuites: []string{"a"}, TargetSuites: []string{"b"},
+					BaseLabels: []string{"1", "test_id:1"}, TargetLabels: []string{"1", "test_id:1", "x"},
+				},
+				{
+					Key: "1", ID: "1", Text: "one", Change: SpecMoved,
+					BaseSuites: []string{"a"}, TargetSuites: []string{"b"},
+					BaseLabels: []string{"1", "test_id:1"}, TargetLabels: []string{"1", "test_id:1", "x"},
+				},
+			},
+		},
+		{
+			name: "duplicate keys",
+			base: []types.Report{
+				newSuiteReport("a", newSpecReport("one", "1"), newSpecReport("one again", "1", "x")),
+			},
+			target: []types.Report{
+				newSuiteReport("a", newSpecReport("one", "1", "x")), newSuiteReport("b", newSpecReport("one", "1")),
+			},
+			expected: []SpecDiff{{
+				Key: "1", ID: "1", Text: "one", Change: SpecMoved,
+				BaseSuites: []string{"a"}, TargetSuites: []string{"a", "b"},
+				BaseLabels: []string{"1", "test_id:1", "x"}, TargetLabels: []string{"1", "test_id:1", "x"},
+			}},
+		},
+	}
+
+	baseKey := CacheKey{Branch: "main", Revision: "base"}
+	targetKey := CacheKey{Branch: "feature", Revision: "target"}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			branchDiff := DiffTrees(
+				baseKey, newTestTree(t, testCase.base...), targetKey, newTestTree(t, testCase.target...))
+
+			assert.Equal(t, baseKey, branchDiff.Base)
+			assert.Equal(t, targetKey, branchDiff.Target)
+			assert.Equal(t, testCase.expected, branchDiff.Diffs)
+		})
+	}
+}
+
+func TestDiffTreeMap(t *testing.T) {
+	baseTree := newTestTree(t, newSuiteReport("a", newSpecReport("one", "1")))
+	treeMap := map[CacheKey]*suitetree.SuiteTree{
+		{Branch: "main", Revision: "1"}:      baseTree,
+		{Branch: "release-2", Revision: "2"}: newTestTree(t, newSuiteReport("a", newSpecReport("two", "2"))),
+		{Branch: "release-1", Revision: "3"}: baseTree,
+	}
+
+	branchDiffs, err := DiffTreeMap(treeMap, "main")
+	require.NoError(t, err)
+	require.Len(t, branchDiffs, 2)
+
+	assert.Equal(t, "release-1", branchDiffs[0].Target.Branch)
+	assert.Empty(t, branchDiffs[0].Diffs)
+	assert.Equal(t, "release-2", branchDiffs[1].Target.Branch)
+	assert.Equal(t, 1, branchDiffs[1].Count(SpecAdded))
+	assert.Equal(t, 1, branchDiffs[1].Count(SpecRemoved))
+	assert.Equal(t, 0, branchDiffs[1].Count(SpecMoved))
+
+	for _, branchDiff := range branchDiffs {
+		assert.Equal(t, CacheKey{Branch: "main", Revision: "1"}, branchDiff.Base)
+	}
+
+	_, err = DiffTreeMap(treeMap, "missing")
+	assert.ErrorContains(t, err, "base branch missing is not one of the branches matched")
+}
+
+func TestBranchDiffString(t *testing.T) {
+	branchDiff := &BranchDiff{
+		Base:   CacheKey{Branch: "main", Revision: "0123456789abcdef"},
+		Target: CacheKey{Branch: "feature", Revision: "abc"},
+		Diffs: []SpecDiff{
+			{Key: "1", Change: SpecAdded, TargetSuites: []string{"a", "b"}},
+			{Key: "two", Change: SpecRemoved, BaseSuites: []string{"a"}},
+			{Key: "3", Change: SpecRelabelled, BaseLabels: []string{"x"}, TargetLabels: []string{"x", "y"}},
+			{Key: "3", Change: SpecMoved, BaseSuites: []string{"a"}, TargetSuites: []string{"b"}},
+		},
+	}
+
+	expected := "Branch feature (abc) compared to main (0123456): 1 added 1 removed 1 relabelled 1 moved\n" +
+		"added      1 in a, b\n" +
+		"removed    two from a\n" +
+		"relabelled 3 [x] -> [x, y]\n" +
+		"moved      3 a -> b\n"
+
+	assert.Equal(t, expected, branchDiff.String())
+}
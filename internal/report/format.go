@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/suitetree"
+	"k8s.io/klog/v2"
+)
+
+// OutputFormat is the format used when writing a tree map to stdout.
+type OutputFormat string
+
+const (
+	// FormatText is the indented tree from SuiteTree.String preceded by the branch and revision.
+	FormatText OutputFormat = "text"
+	// FormatJSON is a list of BranchTree where each tree uses the same schema as the cache files.
+	FormatJSON OutputFormat = "json"
+	// FormatMarkdown is a section per branch with a nested list of suites and specs.
+	FormatMarkdown OutputFormat = "markdown"
+	// FormatCSV is a single table with one row per spec and a header row.
+	FormatCSV OutputFormat = "csv"
+)
+
+// allOutputFormats lists every valid OutputFormat.
+var allOutputFormats = []OutputFormat{FormatText, FormatJSON, FormatMarkdown, FormatCSV}
+
+// csvHeader is the first row written by the CSV format. Labels are separated by spaces.
+var csvHeader = []string{"branch", "revision", "suite", "id", "spec", "labels", "file", "line"}
+
+// BranchTree is the JSON representation of a single entry in the tree map. The Tree is encoded the same way as in the
+// cache files, so tools reading either only need to understand one schema.
+type BranchTree struct {
+	Branch   string
+	Revision string
+	Tree     *suitetree.SuiteTree
+}
+
+// ParseOutputFormat returns the OutputFormat matching format or an error if it is not a valid format.
+func ParseOutputFormat(format string) (OutputFormat, error) {
+	outputFormat := OutputFormat(format)
+	if !slices.Contains(allOutputFormats, outputFormat) {
+		return "", fmt.Errorf("invalid output format %s, must be one of %v", format, allOutputFormats)
+	}
+
+	return outputFormat, nil
+}
+
+// WriteTreeMap writes all the trees in treeMap to writer using the provided format. Branches are written in ascending
+// order by name so the output is stable.
+func WriteTreeMap(writer io.Writer, treeMap map[CacheKey]*suitetree.SuiteTree, format OutputFormat) error {
+	klog.V(100).Infof("Writing %d trees in %s format", len(treeMap), format)
+
+	branchTrees := sortedBranchTrees(treeMap)
+
+	switch format {
+	case FormatText:
+		return writeText(writer, branchTrees)
+	case FormatJSON:
+		return writeJSON(writer, branchTrees)
+	case FormatMarkdown:
+		return writeMarkdown(writer, branchTrees)
+	case FormatCSV:
+		return writeCSV(writer, branchTrees)
+	default:
+		return fmt.Errorf("invalid output format %s", format)
+	}
+}
+
+// sortedBranchTrees converts treeMap into a slice of BranchTree sorted by branch name.
+func sortedBranchTrees(treeMap map[CacheKey]*suitetree.SuiteTree) []BranchTree {
+	branchTrees := make([]BranchTree, 0, len(treeMap))
+	for key, tree := range treeMap {
+		branchTrees = append(branchTrees, BranchTree{Branch: key.Branch, Revision: key.Revision, Tree: tree})
+	}
+
+	slices.SortFunc(branchTrees, func(treeA, treeB BranchTree) int {
+		return strings.Compare(treeA.Branch, treeB.Branch)
+	})
+
+	return branchTrees
+}
+
+// writeText writes each tree preceded by a separator and a line with the branch and short revision.
+func writeText(writer io.Writer, branchTrees []BranchTree) error {
+	for _, branchTree := range branchTrees {
+		_, err := fmt.Fprintf(writer, "---\nBranch %s (%s)\n%s",
+			branchTree.Branch, shortRevision(branchTree.Revision), branchTree.Tree)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeJSON writes the branch trees as an indented JSON array.
+func writeJSON(writer io.Writer, branchTrees []BranchTree) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(branchTrees)
+}
+
+// writeMarkdown writes a heading per branch followed by a nested list where suites are bold with their spec count and
+// specs include their ID, labels, and location as inline code.
+func writeMarkdown(writer io.Writer, branchTrees []BranchTree) error {
+	builder := &strings.Builder{}
+
+	for _, branchTree := range branchTrees {
+		fmt.Fprintf(builder, "## Branch %s (%s)\n\n", branchTree.Branch, shortRevision(branchTree.Revision))
+		writeMarkdownLevel(builder, branchTree.Tree, 0)
+		builder.WriteByte('\n')
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+
+	return err
+}
+
+// writeMarkdownLevel is a helper function to recursively write the markdown list for a tree. The level parameter is
+// used to control the indentation and starts at 0.
+func writeMarkdownLevel(builder *strings.Builder, tree *suitetree.SuiteTree, level int) {
+	builder.WriteString(strings.Repeat("  ", level))
+
+	if tree.SpecReport == nil {
+		fmt.Fprintf(builder, "- **%s** (%d)\n", tree.Name, tree.Specs)
+
+		for _, child := range tree.Children {
+			writeMarkdownLevel(builder, child, level+1)
+		}
+
+		return
+	}
+
+	builder.WriteString("- ")
+
+	if id := suitetree.GetSpecID(tree.SpecReport); id != "" {
+		fmt.Fprintf(builder, "`%s` ", id)
+	}
+
+	builder.WriteString(tree.Name)
+
+	if labels := tree.SpecReport.Labels(); len(labels) > 0 {
+		fmt.Fprintf(builder, " `%s`", strings.Join(labels, ", "))
+	}
+
+	fmt.Fprintf(builder, " `%s`\n", specLocation(tree.SpecReport))
+}
+
+// writeCSV writes a header followed by one row per spec. File names are relative to the repo root.
+func writeCSV(writer io.Writer, branchTrees []BranchTree) error {
+	csvWriter := csv.NewWriter(writer)
+
+	err := csvWriter.Write(csvHeader)
+	if err != nil {
+		return err
+	}
+
+	for _, branchTree := range branchTrees {
+		root := branchTree.Tree
+		root.WalkSpecs(func(suite *suitetree.SuiteTree, spec *types.SpecReport) {
+			if err != nil {
+				return
+			}
+
+			suitePath, relErr := filepath.Rel(root.Path, suite.Path)
+			if relErr != nil {
+				suitePath = suite.Path
+			}
+
+			err = csvWriter.Write([]string{
+				branchTree.Branch,
+				branchTree.Revision,
+				suitePath,
+				suitetree.GetSpecID(spec),
+				spec.FullText(),
+				strings.Join(spec.Labels(), " "),
+				CleanPath(spec.LeafNodeLocation.FileName),
+				strconv.Itoa(spec.LeafNodeLocation.LineNumber),
+			})
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+
+	return csvWriter.Error()
+}
+
+// specLocation returns the file name, relative to the repo root, and line number of the spec separated by a colon.
+func specLocation(spec *types.SpecReport) string {
+	return fmt.Sprintf("%s:%d", CleanPath(spec.LeafNodeLocation.FileName), spec.LeafNodeLocation.LineNumber)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/suitetree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestTreeMap returns a tree map with two branches. The release branch comes first in the map but second in the
+// output since branches are sorted.
+func newTestTreeMap(t *testing.T) map[CacheKey]*suitetree.SuiteTree {
+	t.Helper()
+
+	return map[CacheKey]*suitetree.SuiteTree{
+		{Branch: "release", Revision: "fedcba9876543210"}: newTestTree(t, newSuiteReport("a", newSpecReport("one", "1"))),
+		{Branch: "main", Revision: "0123456789abcdef"}: newTestTree(t,
+			newSuiteReport("a", newSpecReport("one", "1", "x"), newSpecReport("two, quoted \"text\"", ""))),
+	}
+}
+
+func TestParseOutputFormat(t *testing.T) {
+	testCases := []struct {
+		format        string
+		expected      OutputFormat
+		expectedError bool
+	}{
+		{format: "text", expected: FormatText},
+		{format: "json", expected: FormatJSON},
+		{format: "markdown", expected: FormatMarkdown},
+		{format: "csv", expected: FormatCSV},
+		{format: "", expectedError: true},
+		{format: "JSON", expectedError: true},
+		{format: "yaml", expectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.format, func(t *testing.T) {
+			format, err := ParseOutputFormat(testCase.format)
+			if testCase.expectedError {
+				assert.ErrorContains(t, err, "invalid output format")
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, format)
+		})
+	}
+}
+
+func TestWriteTreeMap(t *testing.T) {
+	const location = "eco-gotests/tests/example/tests/example.go"
+
+	testCases := []struct {
+		format   OutputFormat
+		expected string
+	}{
+		{
+			format: FormatText,
+			expected: "---\nBranch main (0123456)\n" +
+				"tests 2\n.  a 2\n.  .  It one 1\n.  .  It two, quoted \"text\" 1\n" +
+				"---\nBranch release (fedcba9)\n" +
+				"tests 1\n.  a 1\n.  .  It one 1\n",
+		},
+		{
+			format: FormatMarkdown,
+			expected: "## Branch main (0123456)\n\n" +
+				"- **tests** (2)\n" +
+				"  - **a** (2)\n" +
+				"    - `1` It one `x, 1, test_id:1` `" + location + ":10`\n" +
+				"    - It two, quoted \"text\" `" + location + ":10`\n" +
+				"\n" +
+				"## Branch release (fedcba9)\n\n" +
+				"- **tests** (1)\n" +
+				"  - **a** (1)\n" +
+				"    - `1` It one `1, test_id:1` `" + location + ":10`\n" +
+				"\n",
+		},
+		{
+			format: FormatCSV,
+			expected: "branch,revision,suite,id,spec,labels,file,line\n" +
+				"main,0123456789abcdef,a,1,one,x 1 test_id:1," + location + ",10\n" +
+				"main,0123456789abcdef,a,,\"two, quoted \"\"text\"\"\",," + location + ",10\n" +
+				"release,fedcba9876543210,a,1,one,1 test_id:1," + location + ",10\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(string(testCase.format), func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+
+			require.NoError(t, WriteTreeMap(buffer, newTestTreeMap(t), testCase.format))
+			assert.Equal(t, testCase.expected, buffer.String())
+		})
+	}
+}
+
+func TestWriteTreeMapJSON(t *testing.T) {
+	treeMap := newTestTreeMap(t)
+	buffer := &bytes.Buffer{}
+
+	require.NoError(t, WriteTreeMap(buffer, treeMap, FormatJSON))
+	assert.True(t, strings.HasPrefix(buffer.String(), "[\n  {\n    \"Branch\": \"main\""), "output should be indented")
+
+	var branchTrees []BranchTree
+
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &branchTrees))
+	require.Len(t, branchTrees, 2)
+
+	for index, key := range []CacheKey{
+		{Branch: "main", Revision: "0123456789abcdef"}, {Branch: "release", Revision: "fedcba9876543210"},
+	} {
+		assert.Equal(t, key.Branch, branchTrees[index].Branch)
+		assert.Equal(t, key.Revision, branchTrees[index].Revision)
+		assert.Equal(t, treeMap[key].String(), branchTrees[index].Tree.String())
+		assert.Equal(t, "1", suitetree.GetSpecID(branchTrees[index].Tree.Children[0].Children[0].SpecReport))
+	}
+}
+
+func TestWriteTreeMapInvalidFormat(t *testing.T) {
+	err := WriteTreeMap(&bytes.Buffer{}, newTestTreeMap(t), OutputFormat("yaml"))
+	assert.ErrorContains(t, err, "invalid output format yaml")
+}
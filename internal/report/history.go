@@ -0,0 +1,513 @@
+package main
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/suitetree"
+	"k8s.io/klog/v2"
+)
+
+const (
+	historyCacheDir      = "eco-gotests-history"
+	historyFileExtension = ".json.zstd"
+)
+
+// History is a store of results from real Ginkgo runs, as opposed to the dry runs used for the SuiteTree. Each ingested
+// Ginkgo JSON report becomes a single RunRecord saved as its own file in the history directory. Unlike the Cache,
+// entries in the History never expire since they do not depend on this program's source code.
+type History struct {
+	Runs      []*RunRecord
+	directory string
+}
+
+// RunRecord is the condensed result of a single Ginkgo run. It may contain specs from multiple suites.
+type RunRecord struct {
+	// Name uniquely identifies the run. It is the SHA-256 sum of the Ginkgo report this record was created from so
+	// ingesting the same report twice does not result in duplicate runs.
+	Name string
+	// Source is the path of the Ginkgo report this record was created from.
+	Source string
+	// StartTime is the earliest start time of all suites in the run.
+	StartTime time.Time
+	// Specs contains the results of every It spec that was included in the run.
+	Specs []SpecResult
+}
+
+// SpecResult is the result of a single spec in a single run.
+type SpecResult struct {
+	// Key is the result of GetSpecKey on the spec report.
+	Key       string
+	ID        string
+	Text      string
+	SuitePath string
+	State     types.SpecState
+	StartTime time.Time
+	RunTime   time.Duration
+}
+
+// SpecHistory is the result of analyzing all the SpecResults across runs for a single spec.
+type SpecHistory struct {
+	Key       string
+	ID        string
+	Text      string
+	SuitePath string
+	Passed    int
+	Failed    int
+	Skipped   int
+	// Runs is the number of runs that included this spec, regardless of state.
+	Runs int
+	// Outcomes lists the results of this spec ordered by run start time ascending.
+	Outcomes []SpecOutcome
+	// Flakiness is a score in the range [0, 1]. It is the number of times the spec switched between passing and
+	// failing divided by the maximum number of switches possible given the number of passed and failed runs.
+	Flakiness float64
+	// MeanRunTime is the average run time of all passed and failed runs.
+	MeanRunTime time.Duration
+	// RunTimeTrend is the slope of the least-squares line through the run times of passed and failed runs, measured
+	// in change of run time per run. A positive trend means the spec has been getting slower.
+	RunTimeTrend time.Duration
+}
+
+// SpecOutcome is a single entry in the history of a spec.
+type SpecOutcome struct {
+	Run       string
+	StartTime time.Time
+	State     types.SpecState
+	RunTime   time.Duration
+}
+
+// NewHistory creates a new History instance and loads all runs from the history directory. If directory is empty, a
+// subdirectory of the OS-specific user cache directory is used. A nonexistent directory results in an empty History.
+func NewHistory(directory string) (*History, error) {
+	klog.V(100).Infof("Instantiating new History with directory %q and attempting to load", directory)
+
+	history := &History{directory: directory}
+
+	err := history.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// Load reads all the run records from the history directory, replacing any runs currently in the History. Runs are
+// sorted by start time ascending. Run records that cannot be loaded, such as those left behind by an interrupted save,
+// are logged and skipped. Ingesting the same report again overwrites them.
+func (history *History) Load() error {
+	historyPath, err := history.getDirectory()
+	if err != nil {
+		return err
+	}
+
+	klog.V(100).Infof("Loading history from %s", historyPath)
+
+	history.Runs = nil
+
+	historyDirEntries, err := os.ReadDir(historyPath)
+	if os.IsNotExist(err) {
+		klog.V(100).Infof("History directory %s does not exist. No runs will be loaded.", historyPath)
+
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, dirEntry := range historyDirEntries {
+		if !dirEntry.Type().IsRegular() || !strings.HasSuffix(dirEntry.Name(), historyFileExtension) {
+			continue
+		}
+
+		runFileName := filepath.Join(historyPath, dirEntry.Name())
+
+		run, err := loadRunFile(runFileName)
+		if err != nil {
+			klog.Warningf("Skipping run record %s since it could not be loaded: %v", runFileName, err)
+
+			continue
+		}
+
+		history.Runs = append(history.Runs, run)
+	}
+
+	history.sortRuns()
+
+	return nil
+}
+
+// Ingest reads the Ginkgo JSON report at reportPath, adds it to the History, and saves it to the history directory.
+// Reports that have already been ingested are skipped.
+func (history *History) Ingest(reportPath string) error {
+	klog.V(100).Infof("Ingesting Ginkgo JSON report at %s into history", reportPath)
+
+	run, err := newRunRecordFromFile(reportPath)
+	if err != nil {
+		return err
+	}
+
+	if slices.ContainsFunc(history.Runs, func(existing *RunRecord) bool { return existing.Name == run.Name }) {
+		klog.V(100).Infof("Report at %s has already been ingested as run %s", reportPath, run.Name)
+
+		return nil
+	}
+
+	historyPath, err := history.getDirectory()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(historyPath, 0755)
+	if err != nil {
+		return err
+	}
+
+	err = saveRunFile(filepath.Join(historyPath, run.Name+historyFileExtension), run)
+	if err != nil {
+		return err
+	}
+
+	history.Runs = append(history.Runs, run)
+	history.sortRuns()
+
+	return nil
+}
+
+// IngestPatterns calls Ingest for every file matching the provided glob patterns. It returns an error if a pattern
+// does not match any files.
+func (history *History) IngestPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return err
+		}
+
+		if len(matches) == 0 {
+			return fmt.Errorf("no Ginkgo reports found matching pattern %s", pattern)
+		}
+
+		for _, match := range matches {
+			err := history.Ingest(match)
+			if err != nil {
+				return fmt.Errorf("failed to ingest Ginkgo report %s: %w", match, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Analyze groups the results of all runs by spec key and computes the pass, fail, and skip counts, flakiness, and run
+// time trend for each spec. The returned map is keyed by spec key.
+func (history *History) Analyze() map[string]*SpecHistory {
+	klog.V(100).Infof("Analyzing history of %d runs", len(history.Runs))
+
+	specHistories := make(map[string]*SpecHistory)
+
+	for _, run := range history.Runs {
+		for _, result := range run.Specs {
+			specHistory, ok := specHistories[result.Key]
+			if !ok {
+				specHistory = &SpecHistory{Key: result.Key}
+				specHistories[result.Key] = specHistory
+			}
+
+			// Since runs are sorted, the latest run determines the displayed text and suite.
+			specHistory.ID = result.ID
+			specHistory.Text = result.Text
+			specHistory.SuitePath = result.SuitePath
+			specHistory.Outcomes = append(specHistory.Outcomes, SpecOutcome{
+				Run:       run.Name,
+				StartTime: result.StartTime,
+				State:     result.State,
+				RunTime:   result.RunTime,
+			})
+		}
+	}
+
+	for _, specHistory := range specHistories {
+		specHistory.computeStatistics()
+	}
+
+	return specHistories
+}
+
+// SortedByFlakiness returns the values of specHistories sorted by flakiness descending, then failure count descending,
+// then key ascending.
+func SortedByFlakiness(specHistories map[string]*SpecHistory) []*SpecHistory {
+	sorted := make([]*SpecHistory, 0, len(specHistories))
+	for _, specHistory := range specHistories {
+		sorted = append(sorted, specHistory)
+	}
+
+	slices.SortFunc(sorted, func(historyA, historyB *SpecHistory) int {
+		if n := cmp.Compare(historyB.Flakiness, historyA.Flakiness); n != 0 {
+			return n
+		}
+
+		if n := cmp.Compare(historyB.Failed, historyA.Failed); n != 0 {
+			return n
+		}
+
+		return strings.Compare(historyA.Key, historyB.Key)
+	})
+
+	return sorted
+}
+
+// PassRate returns the fraction of runs where the spec passed, ignoring skipped runs. It is 0 if the spec never ran.
+func (specHistory *SpecHistory) PassRate() float64 {
+	return ratio(specHistory.Passed, specHistory.Passed+specHistory.Failed)
+}
+
+// FailRate returns the fraction of runs where the spec failed, ignoring skipped runs. It is 0 if the spec never ran.
+func (specHistory *SpecHistory) FailRate() float64 {
+	return ratio(specHistory.Failed, specHistory.Passed+specHistory.Failed)
+}
+
+// SkipRate returns the fraction of all runs including the spec where it was skipped.
+func (specHistory *SpecHistory) SkipRate() float64 {
+	return ratio(specHistory.Skipped, specHistory.Runs)
+}
+
+// IsFlaky returns true if the spec has both passed and failed across the runs in the history.
+func (specHistory *SpecHistory) IsFlaky() bool {
+	return specHistory.Passed > 0 && specHistory.Failed > 0
+}
+
+// Result returns "passed", "failed", or "skipped" depending on the state of the outcome. All failure states, such as
+// panicked and timedout, are considered failed while all other non-passing states are considered skipped.
+func (outcome SpecOutcome) Result() string {
+	switch {
+	case outcome.State.Is(types.SpecStatePassed):
+		return "passed"
+	case outcome.State.Is(types.SpecStateFailureStates):
+		return "failed"
+	default:
+		return "skipped"
+	}
+}
+
+// computeStatistics fills in the counts, flakiness, and run time statistics based on the outcomes. Outcomes are
+// sorted by start time first.
+func (specHistory *SpecHistory) computeStatistics() {
+	slices.SortStableFunc(specHistory.Outcomes, func(outcomeA, outcomeB SpecOutcome) int {
+		return outcomeA.StartTime.Compare(outcomeB.StartTime)
+	})
+
+	var (
+		switches   int
+		lastResult string
+		runTimes   []time.Duration
+	)
+
+	specHistory.Runs = len(specHistory.Outcomes)
+
+	for _, outcome := range specHistory.Outcomes {
+		result := outcome.Result()
+
+		switch result {
+		case "passed":
+			specHistory.Passed++
+		case "failed":
+			specHistory.Failed++
+		default:
+			specHistory.Skipped++
+
+			continue
+		}
+
+		runTimes = append(runTimes, outcome.RunTime)
+
+		if lastResult != "" && result != lastResult {
+			switches++
+		}
+
+		lastResult = result
+	}
+
+	// The most switches possible is when passes and failures alternate, which is twice the smaller count, minus one
+	// if the counts are equal.
+	maxSwitches := 2 * min(specHistory.Passed, specHistory.Failed)
+	if specHistory.Passed == specHistory.Failed {
+		maxSwitches--
+	}
+
+	specHistory.Flakiness = ratio(switches, maxSwitches)
+	specHistory.MeanRunTime, specHistory.RunTimeTrend = runTimeStatistics(runTimes)
+}
+
+// sortRuns sorts the runs in the history by start time ascending and then by name to guarantee a stable order.
+func (history *History) sortRuns() {
+	slices.SortFunc(history.Runs, func(runA, runB *RunRecord) int {
+		if n := runA.StartTime.Compare(runB.StartTime); n != 0 {
+			return n
+		}
+
+		return strings.Compare(runA.Name, runB.Name)
+	})
+}
+
+// getDirectory returns the stored directory for this history if it exists, otherwise it uses a subdirectory of the
+// OS-specific user cache directory. This is separate from the Cache directory so cleaning the cache does not remove
+// the history.
+func (history *History) getDirectory() (string, error) {
+	if history.directory != "" {
+		return history.directory, nil
+	}
+
+	userCache, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(userCache, historyCacheDir), nil
+}
+
+// newRunRecordFromFile reads the Ginkgo JSON report at reportPath and condenses it into a RunRecord.
+func newRunRecordFromFile(reportPath string) (*RunRecord, error) {
+	reportBytes, err := os.ReadFile(reportPath)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := []types.Report{}
+
+	err = json.Unmarshal(reportBytes, &reports)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &RunRecord{
+		Name:   fmt.Sprintf("%x", sha256.Sum256(reportBytes)),
+		Source: reportPath,
+	}
+
+	for _, report := range reports {
+		if run.StartTime.IsZero() || report.StartTime.Before(run.StartTime) {
+			run.StartTime = report.StartTime
+		}
+
+		for _, spec := range report.SpecReports.WithLeafNodeType(types.NodeTypeIt) {
+			run.Specs = append(run.Specs, SpecResult{
+				Key:       suitetree.GetSpecKey(&spec),
+				ID:        suitetree.GetSpecID(&spec),
+				Text:      spec.FullText(),
+				SuitePath: report.SuitePath,
+				State:     spec.State,
+				StartTime: spec.StartTime,
+				RunTime:   spec.RunTime,
+			})
+		}
+	}
+
+	// Skipped specs have no start time so fall back to the start of the run to keep outcomes ordered.
+	for i := range run.Specs {
+		if run.Specs[i].StartTime.IsZero() {
+			run.Specs[i].StartTime = run.StartTime
+		}
+	}
+
+	return run, nil
+}
+
+// saveRunFile saves the run at runFileName, truncating if the file already exists.
+func saveRunFile(runFileName string, run *RunRecord) error {
+	klog.V(100).Infof("Saving run record to %s", runFileName)
+
+	file, err := os.Create(runFileName)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	compressor, err := zstd.NewWriter(file)
+	if err != nil {
+		return err
+	}
+
+	defer compressor.Close()
+
+	return json.NewEncoder(compressor).Encode(run)
+}
+
+// loadRunFile attempts to load a RunRecord from runFileName.
+func loadRunFile(runFileName string) (*RunRecord, error) {
+	klog.V(100).Infof("Loading run record from %s", runFileName)
+
+	file, err := os.Open(runFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	decompressor, err := zstd.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+
+	defer decompressor.Close()
+
+	run := &RunRecord{}
+
+	err = json.NewDecoder(decompressor).Decode(run)
+	if err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// runTimeStatistics returns the mean of runTimes and the slope of the least-squares line through them, using the
+// index as the x value. Both are 0 if runTimes is empty and the slope is 0 if there is only one run time.
+func runTimeStatistics(runTimes []time.Duration) (mean, slope time.Duration) {
+	if len(runTimes) == 0 {
+		return 0, 0
+	}
+
+	count := float64(len(runTimes))
+	meanX := (count - 1) / 2
+
+	var sumY float64
+	for _, runTime := range runTimes {
+		sumY += float64(runTime)
+	}
+
+	meanY := sumY / count
+
+	var covariance, variance float64
+
+	for i, runTime := range runTimes {
+		deltaX := float64(i) - meanX
+		covariance += deltaX * (float64(runTime) - meanY)
+		variance += deltaX * deltaX
+	}
+
+	if variance == 0 {
+		return time.Duration(meanY), 0
+	}
+
+	return time.Duration(meanY), time.Duration(covariance / variance)
+}
+
+// ratio returns numerator divided by denominator as a float or 0 if denominator is not positive.
+func ratio(numerator, denominator int) float64 {
+	if denominator <= 0 {
+		return 0
+	}
+
+	return float64(numerator) / float64(denominator)
+}
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSpecFileName is the file name used for every spec in the tests. It is in a clone of the repo so CleanPath will
+// make it relative to the repo root.
+const testSpecFileName = "/home/user/eco-gotests/tests/example/tests/example.go"
+
+// testStartTime is the start time of the first run in the tests. Later runs start an hour apart.
+var testStartTime = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// newSpecReport returns an It spec report with the provided text and labels. If id is not empty, the labels added by
+// reportxml.ID are included as well.
+func newSpecReport(text, id string, labels ...string) types.SpecReport {
+	if id != "" {
+		labels = append(labels, id, "test_id:"+id)
+	}
+
+	return types.SpecReport{
+		LeafNodeType:     types.NodeTypeIt,
+		LeafNodeText:     text,
+		LeafNodeLabels:   labels,
+		LeafNodeLocation: types.CodeLocation{FileName: testSpecFileName, LineNumber: 10},
+	}
+}
+
+// writeGinkgoReport writes the reports as a Ginkgo JSON report to a file in dir and returns its path.
+func writeGinkgoReport(t *testing.T, dir, name string, reports ...types.Report) string {
+	t.Helper()
+
+	reportBytes, err := json.Marshal(reports)
+	require.NoError(t, err)
+
+	reportPath := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(reportPath, reportBytes, 0o600))
+
+	return reportPath
+}
+
+// newRunReport returns a report for a run of the example suite starting index hours after testStartTime where each
+// spec has the provided state.
+func newRunReport(index int, states map[string]types.SpecState) types.Report {
+	startTime := testStartTime.Add(time.Duration(index) * time.Hour)
+	report := types.Report{SuitePath: "/repo/tests/example", StartTime: startTime}
+
+	for text, state := range states {
+		spec := newSpecReport(text, "")
+		spec.State = state
+
+		if state != types.SpecStateSkipped {
+			spec.StartTime = startTime.Add(time.Minute)
+			spec.RunTime = time.Duration(index+1) * time.Second
+		}
+
+		report.SpecReports = append(report.SpecReports, spec)
+	}
+
+	return report
+}
+
+func TestHistoryIngest(t *testing.T) {
+	reportDir := t.TempDir()
+	historyDir := filepath.Join(t.TempDir(), "history")
+
+	firstReport := writeGinkgoReport(t, reportDir, "first.json",
+		newRunReport(1, map[string]types.SpecState{"passes": types.SpecStatePassed}))
+	secondReport := writeGinkgoReport(t, reportDir, "second.json",
+		newRunReport(0, map[string]types.SpecState{"skips": types.SpecStateSkipped}))
+
+	history, err := NewHistory(historyDir)
+	require.NoError(t, err)
+	assert.Empty(t, history.Runs)
+
+	require.NoError(t, history.IngestPatterns([]string{filepath.Join(reportDir, "*.json")}))
+	require.Len(t, history.Runs, 2)
+
+	// Runs are sorted by start time, so the second report comes first.
+	assert.Equal(t, secondReport, history.Runs[0].Source)
+	assert.Equal(t, firstReport, history.Runs[1].Source)
+	assert.Equal(t, testStartTime.Add(time.Hour), history.Runs[1].StartTime)
+	assert.Equal(t, []SpecResult{{
+		Key:       "passes",
+		Text:      "passes",
+		SuitePath: "/repo/tests/example",
+		State:     types.SpecStatePassed,
+		StartTime: testStartTime.Add(time.Hour + time.Minute),
+		RunTime:   2 * time.Second,
+	}}, history.Runs[1].Specs)
+
+	// Skipped specs fall back to the start time of the run.
+	require.Len(t, history.Runs[0].Specs, 1)
+	assert.Equal(t, testStartTime, history.Runs[0].Specs[0].StartTime)
+
+	require.NoError(t, history.Ingest(firstReport))
+	assert.Len(t, history.Runs, 2, "ingesting the same report twice should not duplicate the run")
+
+	reloaded, err := NewHistory(historyDir)
+	require.NoError(t, err)
+	assert.Equal(t, history.Runs, reloaded.Runs)
+
+	err = history.IngestPatterns([]string{filepath.Join(reportDir, "*.xml")})
+	assert.ErrorContains(t, err, "no Ginkgo reports found")
+}
+
+func TestHistoryLoad(t *testing.T) {
+	testCases := []struct {
+		name         string
+		files        map[string]string
+		expectedRuns []string
+	}{
+		{name: "missing directory", expectedRuns: nil},
+		{
+			name:         "valid runs",
+			files:        map[string]string{"b" + historyFileExtension: "", "a" + historyFileExtension: ""},
+			expectedRuns: []string{"a", "b"},
+		},
+		{
+			name: "corrupt runs",
+			files: map[string]string{
+				"valid" + historyFileExtension:        "",
+				"truncated" + historyFileExtension:    "\x28\xb5\x2f",
+				"empty" + historyFileExtension:        "\x00",
+				"not-a-run.json":                      "ignored",
+				"uncompressed" + historyFileExtension: `{"Name":"uncompressed"}`,
+			},
+			expectedRuns: []string{"valid"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			historyDir := filepath.Join(t.TempDir(), "history")
+
+			if testCase.files != nil {
+				require.NoError(t, os.MkdirAll(historyDir, 0o755))
+			}
+
+			// Empty contents mean a valid run named after the file is saved instead.
+			for fileName, contents := range testCase.files {
+				filePath := filepath.Join(historyDir, fileName)
+
+				if contents == "" {
+					name := fileName[:len(fileName)-len(historyFileExtension)]
+					require.NoError(t, saveRunFile(filePath, &RunRecord{Name: name, StartTime: testStartTime}))
+
+					continue
+				}
+
+				require.NoError(t, os.WriteFile(filePath, []byte(contents), 0o600))
+			}
+
+			history, err := NewHistory(historyDir)
+			require.NoError(t, err)
+
+			var runNames []string
+			for _, run := range history.Runs {
+				runNames = append(runNames, run.Name)
+			}
+
+			assert.Equal(t, testCase.expectedRuns, runNames)
+		})
+	}
+}
+
+func TestHistoryAnalyze(t *testing.T) {
+	reportDir := t.TempDir()
+	history, err := NewHistory(filepath.Join(t.TempDir(), "history"))
+	require.NoError(t, err)
+
+	runStates := []map[string]types.SpecState{
+		{"stable": types.SpecStatePassed, "flaky": types.SpecStatePassed},
+		{"stable": types.SpecStatePassed, "flaky": types.SpecStateFailed, "new": types.SpecStatePanicked},
+		{"stable": types.SpecStatePassed, "flaky": types.SpecStatePassed, "new": types.SpecStateSkipped},
+	}
+
+	for index, states := range runStates {
+		reportPath := writeGinkgoReport(t, reportDir, string(rune('a'+index))+".json", newRunReport(index, states))
+		require.NoError(t, history.Ingest(reportPath))
+	}
+
+	specHistories := history.Analyze()
+	require.Len(t, specHistories, 3)
+
+	flaky := specHistories["flaky"]
+	assert.Equal(t, 3, flaky.Runs)
+	assert.Equal(t, 2, flaky.Passed)
+	assert.Equal(t, 1, flaky.Failed)
+	assert.InDelta(t, 1.0, flaky.Flakiness, 1e-9)
+	assert.Equal(t, 2*time.Second, flaky.MeanRunTime)
+	assert.Equal(t, time.Second, flaky.RunTimeTrend)
+	assert.True(t, flaky.IsFlaky())
+
+	newSpec := specHistories["new"]
+	assert.Equal(t, 2, newSpec.Runs)
+	assert.Equal(t, 1, newSpec.Failed)
+	assert.Equal(t, 1, newSpec.Skipped)
+	assert.InDelta(t, 0.5, newSpec.SkipRate(), 1e-9)
+	assert.InDelta(t, 1.0, newSpec.FailRate(), 1e-9)
+	assert.False(t, newSpec.IsFlaky())
+
+	sorted := SortedByFlakiness(specHistories)
+	require.Len(t, sorted, 3)
+	assert.Equal(t, "flaky", sorted[0].Key)
+	assert.Equal(t, "new", sorted[1].Key, "specs with equal flakiness should be sorted by failures")
+	assert.Equal(t, "stable", sorted[2].Key)
+}
+
+func TestComputeStatistics(t *testing.T) {
+	testCases := []struct {
+		name               string
+		results            string
+		expectedPassed     int
+		expectedFailed     int
+		expectedSkipped    int
+		expectedFlakiness  float64
+		expectedMean       time.Duration
+		expectedTrend      time.Duration
+		reverseStartTimes  bool
+		expectedFirstState types.SpecState
+	}{
+		{name: "no runs", results: ""},
+		{
+			name: "always passes", results: "PPP", expectedPassed: 3, expectedMean: 2 * time.Second,
+			expectedTrend: time.Second,
+		},
+		{
+			name: "always fails", results: "FF", expectedFailed: 2, expectedMean: 1500 * time.Millisecond,
+			expectedTrend: time.Second,
+		},
+		{name: "only skipped", results: "SS", expectedSkipped: 2},
+		{
+			name: "alternating", results: "PFPF", expectedPassed: 2, expectedFailed: 2, expectedFlakiness: 1,
+			expectedMean: 2500 * time.Millisecond, expectedTrend: time.Second,
+		},
+		{
+			name: "single switch", results: "PPFF", expectedPassed: 2, expectedFailed: 2, expectedFlakiness: 1.0 / 3,
+			expectedMean: 2500 * time.Millisecond, expectedTrend: time.Second,
+		},
+		{
+			name: "regression", results: "PPPF", expectedPassed: 3, expectedFailed: 1, expectedFlakiness: 0.5,
+			expectedMean: 2500 * time.Millisecond, expectedTrend: time.Second,
+		},
+		{
+			name: "skips ignored", results: "PSSF", expectedPassed: 1, expectedFailed: 1, expectedSkipped: 2,
+			expectedFlakiness: 1, expectedMean: 2500 * time.Millisecond, expectedTrend: 3 * time.Second,
+		},
+		{
+			name: "sorted by start time", results: "FPP", expectedPassed: 2, expectedFailed: 1, expectedFlakiness: 0.5,
+			expectedMean: 2 * time.Second, expectedTrend: -time.Second, reverseStartTimes: true,
+			expectedFirstState: types.SpecStatePassed,
+		},
+	}
+
+	states := map[rune]types.SpecState{'P': types.SpecStatePassed, 'F': types.SpecStateFailed, 'S': types.SpecStateSkipped}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			specHistory := &SpecHistory{Key: "spec"}
+
+			for index, result := range []rune(testCase.results) {
+				startIndex := index
+				if testCase.reverseStartTimes {
+					startIndex = len(testCase.results) - index
+				}
+
+				specHistory.Outcomes = append(specHistory.Outcomes, SpecOutcome{
+					Run:       string(result),
+					StartTime: testStartTime.Add(time.Duration(startIndex) * time.Hour),
+					State:     states[result],
+					RunTime:   time.Duration(index+1) * time.Second,
+				})
+			}
+
+			specHistory.computeStatistics()
+
+			assert.Equal(t, len(testCase.results), specHistory.Runs)
+			assert.Equal(t, testCase.expectedPassed, specHistory.Passed)
+			assert.Equal(t, testCase.expectedFailed, specHistory.Failed)
+			assert.Equal(t, testCase.expectedSkipped, specHistory.Skipped)
+			assert.InDelta(t, testCase.expectedFlakiness, specHistory.Flakiness, 1e-9)
+			assert.Equal(t, testCase.expectedMean, specHistory.MeanRunTime)
+			assert.Equal(t, testCase.expectedTrend, specHistory.RunTimeTrend)
+
+			if testCase.expectedFirstState != 0 {
+				assert.Equal(t, testCase.expectedFirstState, specHistory.Outcomes[0].State)
+			}
+		})
+	}
+}
+
+func TestSpecOutcomeResult(t *testing.T) {
+	testCases := []struct {
+		state    types.SpecState
+		expected string
+	}{
+		{state: types.SpecStatePassed, expected: "passed"},
+		{state: types.SpecStateFailed, expected: "failed"},
+		{state: types.SpecStatePanicked, expected: "failed"},
+		{state: types.SpecStateTimedout, expected: "failed"},
+		{state: types.SpecStateSkipped, expected: "skipped"},
+		{state: types.SpecStatePending, expected: "skipped"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.state.String(), func(t *testing.T) {
+			assert.Equal(t, testCase.expected, SpecOutcome{State: testCase.state}.Result())
+		})
+	}
+}
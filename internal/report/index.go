@@ -0,0 +1,217 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/suitetree"
+	"k8s.io/klog/v2"
+)
+
+// SpecIndex is an index of every label and reportxml.ID used by the specs in a single SuiteTree. The labels added by
+// reportxml.ID are only included in the IDs and not the Labels.
+type SpecIndex struct {
+	// Specs contains every spec in the tree in the order they were visited.
+	Specs []IndexedSpec
+	// Labels are sorted by label name.
+	Labels []*LabelEntry
+	// IDs are sorted by ID, with numeric IDs being sorted numerically.
+	IDs []*IDEntry
+}
+
+// IndexedSpec is the information about a single spec that is included in the SpecIndex.
+type IndexedSpec struct {
+	ID   string
+	Text string
+	// Suite is the path of the suite relative to the root of the tree.
+	Suite string
+	// Labels contains all labels for the spec, including those from containers and reportxml.ID. It is sorted.
+	Labels []string
+	// Location is the file name, relative to the repo root, and line number of the spec separated by a colon.
+	Location string
+}
+
+// LabelEntry is a single label in the SpecIndex along with where it is used.
+type LabelEntry struct {
+	Label string
+	// Specs is the number of specs that have this label.
+	Specs int
+	// Suites lists the sorted, unique suites containing at least one spec with this label.
+	Suites []string
+}
+
+// IDEntry is a single reportxml.ID in the SpecIndex along with the specs that use it.
+type IDEntry struct {
+	ID    string
+	Specs []IndexedSpec
+	// Suites lists the sorted, unique suites containing at least one spec with this ID.
+	Suites []string
+}
+
+// FilterResult is the result of applying a Ginkgo label filter to the specs in a SpecIndex.
+type FilterResult struct {
+	Filter string
+	// Matched contains the specs selected by the filter.
+	Matched []IndexedSpec
+	Total   int
+}
+
+// NewSpecIndex walks the tree and indexes every spec by its labels and reportxml.ID. Suite paths are relative to the
+// root of the tree.
+func NewSpecIndex(tree *suitetree.SuiteTree) *SpecIndex {
+	klog.V(100).Infof("Creating SpecIndex from tree with path %s", tree.Path)
+
+	index := &SpecIndex{}
+	labelEntries := make(map[string]*LabelEntry)
+	idEntries := make(map[string]*IDEntry)
+
+	tree.WalkSpecs(func(suite *suitetree.SuiteTree, spec *types.SpecReport) {
+		suitePath, err := filepath.Rel(tree.Path, suite.Path)
+		if err != nil {
+			suitePath = suite.Path
+		}
+
+		labels := slices.Clone(spec.Labels())
+		slices.Sort(labels)
+
+		indexedSpec := IndexedSpec{
+			ID:       suitetree.GetSpecID(spec),
+			Text:     spec.FullText(),
+			Suite:    suitePath,
+			Labels:   slices.Compact(labels),
+			Location: specLocation(spec),
+		}
+		index.Specs = append(index.Specs, indexedSpec)
+
+		for _, label := range indexedSpec.Labels {
+			if indexedSpec.ID != "" && (label == indexedSpec.ID || label == suitetree.SpecIDLabelPrefix+indexedSpec.ID) {
+				continue
+			}
+
+			entry, ok := labelEntries[label]
+			if !ok {
+				entry = &LabelEntry{Label: label}
+				labelEntries[label] = entry
+			}
+
+			entry.Specs++
+			entry.Suites = append(entry.Suites, suitePath)
+		}
+
+		if indexedSpec.ID == "" {
+			return
+		}
+
+		entry, ok := idEntries[indexedSpec.ID]
+		if !ok {
+			entry = &IDEntry{ID: indexedSpec.ID}
+			idEntries[indexedSpec.ID] = entry
+		}
+
+		entry.Specs = append(entry.Specs, indexedSpec)
+		entry.Suites = append(entry.Suites, suitePath)
+	})
+
+	for _, entry := range labelEntries {
+		slices.Sort(entry.Suites)
+		entry.Suites = slices.Compact(entry.Suites)
+		index.Labels = append(index.Labels, entry)
+	}
+
+	for _, entry := range idEntries {
+		slices.Sort(entry.Suites)
+		entry.Suites = slices.Compact(entry.Suites)
+		index.IDs = append(index.IDs, entry)
+	}
+
+	slices.SortFunc(index.Labels, func(entryA, entryB *LabelEntry) int {
+		return strings.Compare(entryA.Label, entryB.Label)
+	})
+
+	slices.SortFunc(index.IDs, func(entryA, entryB *IDEntry) int {
+		return compareIDs(entryA.ID, entryB.ID)
+	})
+
+	return index
+}
+
+// DuplicateIDs returns the IDs that are used by specs in more than one suite. Multiple specs in the same suite may
+// share an ID, such as entries in a DescribeTable, so these are not considered duplicates.
+func (index *SpecIndex) DuplicateIDs() []*IDEntry {
+	var duplicates []*IDEntry
+
+	for _, entry := range index.IDs {
+		if len(entry.Suites) > 1 {
+			duplicates = append(duplicates, entry)
+		}
+	}
+
+	return duplicates
+}
+
+// SpecsWithoutID returns all the specs in the index that do not have a reportxml.ID.
+func (index *SpecIndex) SpecsWithoutID() []IndexedSpec {
+	var specs []IndexedSpec
+
+	for _, spec := range index.Specs {
+		if spec.ID == "" {
+			specs = append(specs, spec)
+		}
+	}
+
+	return specs
+}
+
+// Filter parses the provided Ginkgo label filter, using the same syntax as ECO_TEST_LABELS and --label-filter, and
+// returns the specs it would select. It returns an error if the filter cannot be parsed.
+func (index *SpecIndex) Filter(filter string) (*FilterResult, error) {
+	labelFilter, err := types.ParseLabelFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &FilterResult{Filter: filter, Total: len(index.Specs)}
+
+	for _, spec := range index.Specs {
+		if labelFilter(spec.Labels) {
+			result.Matched = append(result.Matched, spec)
+		}
+	}
+
+	return result, nil
+}
+
+// String returns a summary of the index with one line for the number of labels and IDs and one line for problems
+// found with the IDs.
+func (index *SpecIndex) String() string {
+	return fmt.Sprintf("%d specs, %d labels, %d IDs\n%d IDs duplicated across suites, %d specs without an ID\n",
+		len(index.Specs), len(index.Labels), len(index.IDs), len(index.DuplicateIDs()), len(index.SpecsWithoutID()))
+}
+
+// String returns a single line with the filter and the number of specs it selects.
+func (result *FilterResult) String() string {
+	return fmt.Sprintf("Label filter %q selects %d of %d specs\n", result.Filter, len(result.Matched), result.Total)
+}
+
+// compareIDs compares reportxml.IDs numerically if they are both numbers, otherwise lexicographically. Numbers are
+// always sorted before other IDs.
+func compareIDs(idA, idB string) int {
+	numberA, errA := strconv.ParseUint(idA, 10, 64)
+	numberB, errB := strconv.ParseUint(idB, 10, 64)
+
+	switch {
+	case errA == nil && errB == nil:
+		return cmp.Compare(numberA, numberB)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	default:
+		return strings.Compare(idA, idB)
+	}
+}
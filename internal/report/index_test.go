@@ -0,0 +1,122 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestIndex returns a SpecIndex for a tree with two suites. ID 2 is used in both suites and spec three has no ID.
+func newTestIndex(t *testing.T) *SpecIndex {
+	t.Helper()
+
+	return NewSpecIndex(newTestTree(t,
+		newSuiteReport("a",
+			newSpecReport("one", "10", "x"), newSpecReport("two", "2", "y", "x"), newSpecReport("three", "", "y")),
+		newSuiteReport("b/c", newSpecReport("four", "2", "x"), newSpecReport("five", "abc")),
+	))
+}
+
+func TestNewSpecIndex(t *testing.T) {
+	index := newTestIndex(t)
+
+	location := "eco-gotests/tests/example/tests/example.go:10"
+	one := IndexedSpec{ID: "10", Text: "one", Suite: "a", Labels: []string{"10", "test_id:10", "x"}, Location: location}
+	two := IndexedSpec{ID: "2", Text: "two", Suite: "a", Labels: []string{"2", "test_id:2", "x", "y"}, Location: location}
+	three := IndexedSpec{Text: "three", Suite: "a", Labels: []string{"y"}, Location: location}
+	four := IndexedSpec{ID: "2", Text: "four", Suite: "b/c", Labels: []string{"2", "test_id:2", "x"}, Location: location}
+	five := IndexedSpec{ID: "abc", Text: "five", Suite: "b/c", Labels: []string{"abc", "test_id:abc"}, Location: location}
+
+	assert.Equal(t, []IndexedSpec{one, two, three, four, five}, index.Specs)
+	assert.Equal(t, []*LabelEntry{
+		{Label: "x", Specs: 3, Suites: []string{"a", "b/c"}},
+		{Label: "y", Specs: 2, Suites: []string{"a"}},
+	}, index.Labels, "labels added by reportxml.ID should not be indexed as labels")
+	assert.Equal(t, []*IDEntry{
+		{ID: "2", Specs: []IndexedSpec{two, four}, Suites: []string{"a", "b/c"}},
+		{ID: "10", Specs: []IndexedSpec{one}, Suites: []string{"a"}},
+		{ID: "abc", Specs: []IndexedSpec{five}, Suites: []string{"b/c"}},
+	}, index.IDs)
+
+	assert.Equal(t, []*IDEntry{index.IDs[0]}, index.DuplicateIDs())
+	assert.Equal(t, []IndexedSpec{three}, index.SpecsWithoutID())
+	assert.Equal(t, "5 specs, 2 labels, 3 IDs\n1 IDs duplicated across suites, 1 specs without an ID\n", index.String())
+}
+
+func TestSpecIndexDuplicateIDsSameSuite(t *testing.T) {
+	index := NewSpecIndex(newTestTree(t,
+		newSuiteReport("a", newSpecReport("entry one", "1"), newSpecReport("entry two", "1")),
+		newSuiteReport("b", newSpecReport("other", "2")),
+	))
+
+	require.Len(t, index.IDs, 2)
+	assert.Len(t, index.IDs[0].Specs, 2)
+	assert.Empty(t, index.DuplicateIDs(), "specs sharing an ID in the same suite should not be duplicates")
+}
+
+func TestSpecIndexFilter(t *testing.T) {
+	testCases := []struct {
+		filter        string
+		expected      []string
+		expectedError bool
+	}{
+		{filter: "", expected: []string{"one", "two", "three", "four", "five"}},
+		{filter: "x", expected: []string{"one", "two", "four"}},
+		{filter: "x && !y", expected: []string{"one", "four"}},
+		{filter: "y || 10", expected: []string{"one", "two", "three"}},
+		{filter: "z", expected: nil},
+		{filter: "x &&", expectedError: true},
+	}
+
+	index := newTestIndex(t)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.filter, func(t *testing.T) {
+			result, err := index.Filter(testCase.filter)
+			if testCase.expectedError {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			var matched []string
+			for _, spec := range result.Matched {
+				matched = append(matched, spec.Text)
+			}
+
+			assert.Equal(t, testCase.filter, result.Filter)
+			assert.Equal(t, testCase.expected, matched)
+			assert.Equal(t, 5, result.Total)
+		})
+	}
+}
+
+func TestFilterResultString(t *testing.T) {
+	result := &FilterResult{Filter: "x", Matched: []IndexedSpec{{Text: "one"}}, Total: 5}
+
+	assert.Equal(t, "Label filter \"x\" selects 1 of 5 specs\n", result.String())
+}
+
+func TestCompareIDs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		idA      string
+		idB      string
+		expected int
+	}{
+		{name: "numeric", idA: "9", idB: "10", expected: -1},
+		{name: "equal numbers", idA: "10", idB: "10", expected: 0},
+		{name: "number before text", idA: "99", idB: "1a", expected: -1},
+		{name: "text after number", idA: "abc", idB: "1", expected: 1},
+		{name: "text", idA: "abc", idB: "abd", expected: -1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, compareIDs(testCase.idA, testCase.idB))
+		})
+	}
+}
@@ -3,6 +3,21 @@ Report is a tool to generate a report of the test suites in a Ginkgo test suite.
 and the number of specs in each suite. If an output directory is provided, a static site for visualizing the test suites
 will be generated.
 
+The trees are printed as text by default but may also be printed as JSON, Markdown, or CSV for use by other tools.
+The JSON format encodes each tree with the same schema as the cache files, which are zstd-compressed JSON. Only the
+text format includes the index, diff, and history summaries so that other formats can be parsed directly.
+
+Every label and reportxml.ID used on each branch is indexed, listing which suites use a label, IDs duplicated across
+suites, and specs without an ID. A label filter can be provided to check how many specs it would select before
+starting a run.
+
+When comparing branches, specs are matched using their reportxml.ID, or their full text if they have no ID, and
+reported as added, removed, relabelled, or moved between suites relative to the base branch.
+
+Results from real runs can be ingested into a local run history store by providing Ginkgo JSON reports, for example
+those generated using --json-report. When the history is not empty, the flakiest specs are printed and the static site
+includes the pass, fail, and skip rates, run time trend, and flakiness score of each spec across all ingested runs.
+
 Upon successful generation of the report the exit code is 0. If any error occurs it will be logged to stderr and the
 exit code will be 1.
 
@@ -24,9 +39,24 @@ The flags are:
 	-c, -clean
 		Delete the test suite cache and exit without running
 
+	-D, -diff string
+		Branch to compare all other matched branches against. Leave blank to skip comparing branches
+
+	-f, -format string
+		Format to print the trees to stdout in. One of text, json, markdown, or csv. Defaults to text
+
+	-d, -history-dir string
+		Directory of the run history store. Uses a subdirectory of the user cache directory if left blank
+
+	-l, -label-filter string
+		Ginkgo label filter, such as ECO_TEST_LABELS, to count the specs selected on each branch
+
 	-o, -output string
 		Directory to output static site to. Will not be generated if left blank
 
+	-r, -runs string
+		Space-separated list of globs matching Ginkgo JSON reports from real runs to ingest into the run history
+
 	-v int
 		Log level verbosity for klog. Use 100 for logging all messages or leave blank for none
 */
@@ -44,16 +74,22 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/suitetree"
 	"k8s.io/klog/v2"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 var (
-	help      bool
-	actionURL string
-	branch    string
-	clean     bool
-	output    string
+	help        bool
+	actionURL   string
+	branch      string
+	clean       bool
+	diffBase    string
+	format      string
+	historyDir  string
+	labelFilter string
+	output      string
+	runs        string
 )
 
 //nolint:gochecknoinits // This is a main package so init is fine.
@@ -63,13 +99,24 @@ func init() {
 		actionURLUsage = "URL to the action generating this report. Only necessary with -o. Uses \"/\" if left blank"
 		branchUsage    = "Space-separated list of globs to match branches. Leave blank to use the local directory"
 		cleanUsage     = "Delete the test suite cache and exit without running"
+		diffUsage      = "Branch to compare all other matched branches against. Leave blank to skip comparing branches"
+		formatUsage    = "Format to print the trees to stdout in. One of text, json, markdown, or csv. Defaults to text"
+		historyUsage   = "Directory of the run history store. Uses a subdirectory of the user cache directory if left blank"
+		labelUsage     = "Ginkgo label filter, such as ECO_TEST_LABELS, to count the specs selected on each branch"
 		outputUsage    = "Directory to output static site to. Will not be generated if left blank"
-
-		defaultHelp      = false
-		defaultActionURL = "/"
-		defaultBranch    = ""
-		defaultClean     = false
-		defaultOutput    = ""
+		runsUsage      = "Space-separated list of globs matching Ginkgo JSON reports from real runs to ingest into the " +
+			"run history"
+
+		defaultHelp       = false
+		defaultActionURL  = "/"
+		defaultBranch     = ""
+		defaultClean      = false
+		defaultDiffBase   = ""
+		defaultFormat     = string(FormatText)
+		defaultHistoryDir = ""
+		defaultLabel      = ""
+		defaultOutput     = ""
+		defaultRuns       = ""
 
 		shorthand = " (shorthand)"
 	)
@@ -92,8 +139,23 @@ func init() {
 	flag.BoolVar(&clean, "clean", defaultClean, cleanUsage)
 	flag.BoolVar(&clean, "c", defaultClean, cleanUsage+shorthand)
 
+	flag.StringVar(&diffBase, "diff", defaultDiffBase, diffUsage)
+	flag.StringVar(&diffBase, "D", defaultDiffBase, diffUsage+shorthand)
+
+	flag.StringVar(&format, "format", defaultFormat, formatUsage)
+	flag.StringVar(&format, "f", defaultFormat, formatUsage+shorthand)
+
+	flag.StringVar(&historyDir, "history-dir", defaultHistoryDir, historyUsage)
+	flag.StringVar(&historyDir, "d", defaultHistoryDir, historyUsage+shorthand)
+
+	flag.StringVar(&labelFilter, "label-filter", defaultLabel, labelUsage)
+	flag.StringVar(&labelFilter, "l", defaultLabel, labelUsage+shorthand)
+
 	flag.StringVar(&output, "output", defaultOutput, outputUsage)
 	flag.StringVar(&output, "o", defaultOutput, outputUsage+shorthand)
+
+	flag.StringVar(&runs, "runs", defaultRuns, runsUsage)
+	flag.StringVar(&runs, "r", defaultRuns, runsUsage+shorthand)
 }
 
 func main() {
@@ -116,6 +178,13 @@ func main() {
 		return
 	}
 
+	outputFormat, err := ParseOutputFormat(format)
+	if err != nil {
+		klog.Errorf("Failed to parse output format: %v", err)
+
+		os.Exit(1)
+	}
+
 	treeMap, err := getTrees(branch)
 	if err != nil {
 		klog.Errorf("Failed to get suite trees when branch=\"%s\": %v", branch, err)
@@ -123,10 +192,57 @@ func main() {
 		os.Exit(1)
 	}
 
-	printTreeMap(treeMap)
+	history, err := getHistory(historyDir, runs)
+	if err != nil {
+		klog.Errorf("Failed to get run history when runs=\"%s\": %v", runs, err)
+
+		os.Exit(1)
+	}
+
+	specHistories := history.Analyze()
+
+	var branchDiffs []*BranchDiff
+
+	if diffBase != "" {
+		branchDiffs, err = DiffTreeMap(treeMap, diffBase)
+		if err != nil {
+			klog.Errorf("Failed to compare suite trees against base branch %s: %v", diffBase, err)
+
+			os.Exit(1)
+		}
+	}
+
+	specIndexes, filterResults, err := indexTreeMap(treeMap, labelFilter)
+	if err != nil {
+		klog.Errorf("Failed to index suite trees with label filter %q: %v", labelFilter, err)
+
+		os.Exit(1)
+	}
+
+	err = WriteTreeMap(os.Stdout, treeMap, outputFormat)
+	if err != nil {
+		klog.Errorf("Failed to print suite trees in %s format: %v", outputFormat, err)
+
+		os.Exit(1)
+	}
+
+	if outputFormat == FormatText {
+		printSpecIndexes(specIndexes, filterResults)
+		printBranchDiffs(branchDiffs)
+		printFlakySpecs(specHistories)
+	}
 
 	if output != "" {
-		err := templateTreeMap(treeMap, output)
+		data := reportData{
+			treeMap:       treeMap,
+			specHistories: specHistories,
+			runCount:      len(history.Runs),
+			branchDiffs:   branchDiffs,
+			specIndexes:   specIndexes,
+			filterResults: filterResults,
+		}
+
+		err := templateReport(data, output)
 		if err != nil {
 			klog.Errorf("Failed to template tree map and save to %s: %v", output, err)
 
@@ -135,7 +251,17 @@ func main() {
 	}
 }
 
-func getTrees(branch string) (map[CacheKey]*SuiteTree, error) {
+// reportData contains everything that may be included in the generated static site.
+type reportData struct {
+	treeMap       map[CacheKey]*suitetree.SuiteTree
+	specHistories map[string]*SpecHistory
+	runCount      int
+	branchDiffs   []*BranchDiff
+	specIndexes   map[CacheKey]*SpecIndex
+	filterResults map[CacheKey]*FilterResult
+}
+
+func getTrees(branch string) (map[CacheKey]*suitetree.SuiteTree, error) {
 	ctx, cancel := signal.NotifyContext(context.TODO(), os.Interrupt, os.Kill)
 	defer cancel()
 
@@ -144,7 +270,7 @@ func getTrees(branch string) (map[CacheKey]*SuiteTree, error) {
 		return nil, err
 	}
 
-	var treeMap map[CacheKey]*SuiteTree
+	var treeMap map[CacheKey]*suitetree.SuiteTree
 
 	if branch != "" {
 		patterns := strings.Fields(branch)
@@ -170,23 +296,103 @@ func getTrees(branch string) (map[CacheKey]*SuiteTree, error) {
 	return treeMap, nil
 }
 
-func printTreeMap(treeMap map[CacheKey]*SuiteTree) {
+func getHistory(historyDir, runs string) (*History, error) {
+	history, err := NewHistory(historyDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if runs != "" {
+		err = history.IngestPatterns(strings.Fields(runs))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return history, nil
+}
+
+func indexTreeMap(
+	treeMap map[CacheKey]*suitetree.SuiteTree, labelFilter string) (map[CacheKey]*SpecIndex, map[CacheKey]*FilterResult, error) {
+	specIndexes := make(map[CacheKey]*SpecIndex)
+	filterResults := make(map[CacheKey]*FilterResult)
+
 	for key, tree := range treeMap {
+		specIndexes[key] = NewSpecIndex(tree)
+
+		if labelFilter == "" {
+			continue
+		}
+
+		result, err := specIndexes[key].Filter(labelFilter)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		filterResults[key] = result
+	}
+
+	return specIndexes, filterResults, nil
+}
+
+func printSpecIndexes(specIndexes map[CacheKey]*SpecIndex, filterResults map[CacheKey]*FilterResult) {
+	for key, index := range specIndexes {
+		fmt.Println("---")
+		fmt.Printf("Branch %s (%s) index\n", key.Branch, shortRevision(key.Revision))
+		fmt.Print(index)
+
+		if result, ok := filterResults[key]; ok {
+			fmt.Print(result)
+		}
+	}
+}
+
+func printBranchDiffs(branchDiffs []*BranchDiff) {
+	for _, branchDiff := range branchDiffs {
 		fmt.Println("---")
-		fmt.Printf("Branch %s (%s)\n", key.Branch, key.Revision[:7])
-		fmt.Print(tree)
+		fmt.Print(branchDiff)
+	}
+}
+
+func printFlakySpecs(specHistories map[string]*SpecHistory) {
+	var printedHeader bool
+
+	for _, specHistory := range SortedByFlakiness(specHistories) {
+		if !specHistory.IsFlaky() {
+			break
+		}
+
+		if !printedHeader {
+			fmt.Println("---")
+			fmt.Println("Flaky specs")
+
+			printedHeader = true
+		}
+
+		fmt.Printf("%s %d/%d passed %s\n", percent(specHistory.Flakiness), specHistory.Passed,
+			specHistory.Passed+specHistory.Failed, specHistory.Key)
 	}
 }
 
-func templateTreeMap(treeMap map[CacheKey]*SuiteTree, output string) error {
+func templateReport(data reportData, output string) error {
 	err := os.MkdirAll(output, 0755)
 	if err != nil {
 		return err
 	}
 
+	historyFile, err := templateHistory(data, output)
+	if err != nil {
+		return err
+	}
+
+	diffFile, err := templateBranchDiffs(data, output)
+	if err != nil {
+		return err
+	}
+
 	var branchReports []BranchReportConfig
 
-	for key, tree := range treeMap {
+	for key, tree := range data.treeMap {
 		config := TreeTemplateConfig{
 			Tree:       tree,
 			Generated:  time.Now(),
@@ -194,6 +400,9 @@ func templateTreeMap(treeMap map[CacheKey]*SuiteTree, output string) error {
 			ActionURL:  template.URL(actionURL),
 			RepoURL:    RemoteURL,
 			TimeFormat: time.RFC3339,
+
+			SpecHistories: data.specHistories,
+			HistoryFile:   historyFile,
 		}
 		outputFileName := fmt.Sprintf("report_%s.html", key.Branch)
 		outputFilePath := filepath.Join(output, outputFileName)
@@ -203,17 +412,36 @@ func templateTreeMap(treeMap map[CacheKey]*SuiteTree, output string) error {
 			return err
 		}
 
+		indexFileName := fmt.Sprintf("index_%s.html", key.Branch)
+		indexConfig := IndexTemplateConfig{
+			Index:      data.specIndexes[key],
+			Filter:     data.filterResults[key],
+			Generated:  time.Now(),
+			Branch:     key.Branch,
+			ActionURL:  template.URL(actionURL),
+			RepoURL:    RemoteURL,
+			TimeFormat: time.RFC3339,
+		}
+
+		err = TemplateIndex(indexConfig, filepath.Join(output, indexFileName))
+		if err != nil {
+			return err
+		}
+
 		branchReport := BranchReportConfig{
 			Name:          key.Branch,
 			ReportFile:    outputFileName,
 			Revision:      key.Revision,
 			ShortRevision: key.Revision[:7],
+			IndexFile:     indexFileName,
 		}
 		branchReports = append(branchReports, branchReport)
 	}
 
 	config := ReportTemplateConfig{
 		BranchReports: branchReports,
+		HistoryFile:   historyFile,
+		DiffFile:      diffFile,
 		Generated:     time.Now(),
 		ActionURL:     template.URL(actionURL),
 		RepoURL:       RemoteURL,
@@ -229,7 +457,56 @@ func templateTreeMap(treeMap map[CacheKey]*SuiteTree, output string) error {
 	return nil
 }
 
-func getLocalTreeMap(cache *Cache, repoPath string) (map[CacheKey]*SuiteTree, error) {
+// templateHistory generates the history page if there are any runs in the history and returns its file name. If there
+// are no runs, the returned file name is empty.
+func templateHistory(data reportData, output string) (string, error) {
+	if data.runCount == 0 {
+		return "", nil
+	}
+
+	config := HistoryTemplateConfig{
+		SpecHistories: SortedByFlakiness(data.specHistories),
+		Runs:          data.runCount,
+		Generated:     time.Now(),
+		ActionURL:     template.URL(actionURL),
+		RepoURL:       RemoteURL,
+		TimeFormat:    time.RFC3339,
+	}
+	outputFileName := "history.html"
+
+	err := TemplateHistory(config, filepath.Join(output, outputFileName))
+	if err != nil {
+		return "", err
+	}
+
+	return outputFileName, nil
+}
+
+// templateBranchDiffs generates the diff page if any branches were compared and returns its file name. If no branches
+// were compared, the returned file name is empty.
+func templateBranchDiffs(data reportData, output string) (string, error) {
+	if len(data.branchDiffs) == 0 {
+		return "", nil
+	}
+
+	config := DiffTemplateConfig{
+		BranchDiffs: data.branchDiffs,
+		Generated:   time.Now(),
+		ActionURL:   template.URL(actionURL),
+		RepoURL:     RemoteURL,
+		TimeFormat:  time.RFC3339,
+	}
+	outputFileName := "diff.html"
+
+	err := TemplateDiff(config, filepath.Join(output, outputFileName))
+	if err != nil {
+		return "", err
+	}
+
+	return outputFileName, nil
+}
+
+func getLocalTreeMap(cache *Cache, repoPath string) (map[CacheKey]*suitetree.SuiteTree, error) {
 	tree, err := cache.GetOrCreate(repoPath)
 	if err != nil {
 		klog.Errorf("Failed to get or create SuiteTree from cache: %v", err)
@@ -239,7 +516,7 @@ func getLocalTreeMap(cache *Cache, repoPath string) (map[CacheKey]*SuiteTree, er
 
 	key, err := cache.GetKeyFromPath(repoPath)
 	if IsMiss(err) {
-		treeMap := map[CacheKey]*SuiteTree{{Branch: "local", Revision: "local"}: tree}
+		treeMap := map[CacheKey]*suitetree.SuiteTree{{Branch: "local", Revision: "local"}: tree}
 
 		return treeMap, nil
 	}
@@ -248,12 +525,12 @@ func getLocalTreeMap(cache *Cache, repoPath string) (map[CacheKey]*SuiteTree, er
 		return nil, err
 	}
 
-	treeMap := map[CacheKey]*SuiteTree{key: tree}
+	treeMap := map[CacheKey]*suitetree.SuiteTree{key: tree}
 
 	return treeMap, nil
 }
 
-func getFromCacheOrClone(ctx context.Context, cache *Cache, patterns []string) (map[CacheKey]*SuiteTree, error) {
+func getFromCacheOrClone(ctx context.Context, cache *Cache, patterns []string) (map[CacheKey]*suitetree.SuiteTree, error) {
 	treeMap, err := cache.GetRemotePatterns(patterns)
 	if err != nil {
 		return nil, err
@@ -4,6 +4,8 @@ import (
 	"crypto/sha256"
 	"embed"
 	"fmt"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/suitetree"
 )
 
 //go:embed *.go
@@ -14,25 +16,27 @@ var programSourceCode embed.FS
 // expire cache too eagerly but guarantees compatibility.
 var sourceCodeSum string = getSourceSum()
 
-// getSourceSum returns the SHA256 sum of all the .go files in this directory. If any errors are encountered, an empty
-// string is returned.
+// getSourceSum returns the SHA256 sum of all the .go files in this directory and the suitetree package, since it
+// defines the encoding of the cache. If any errors are encountered, an empty string is returned.
 func getSourceSum() string {
 	summer := sha256.New()
 
-	dirEntries, err := programSourceCode.ReadDir(".")
-	if err != nil {
-		return ""
-	}
-
-	for _, dirEntry := range dirEntries {
-		contents, err := programSourceCode.ReadFile(dirEntry.Name())
+	for _, sourceCode := range []embed.FS{programSourceCode, suitetree.SourceCode} {
+		dirEntries, err := sourceCode.ReadDir(".")
 		if err != nil {
 			return ""
 		}
 
-		_, err = summer.Write(contents)
-		if err != nil {
-			return ""
+		for _, dirEntry := range dirEntries {
+			contents, err := sourceCode.ReadFile(dirEntry.Name())
+			if err != nil {
+				return ""
+			}
+
+			_, err = summer.Write(contents)
+			if err != nil {
+				return ""
+			}
 		}
 	}
 
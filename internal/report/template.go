@@ -1,13 +1,18 @@
 package main
 
 import (
+	"crypto/sha256"
 	_ "embed"
+	"fmt"
 	"html/template"
 	"os"
 	"path/filepath"
 	"slices"
 	"strings"
 	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/suitetree"
 )
 
 var (
@@ -16,38 +21,78 @@ var (
 
 	//go:embed report_template.html
 	reportTemplateFile string
+
+	//go:embed history_template.html
+	historyTemplateFile string
+
+	//go:embed diff_template.html
+	diffTemplateFile string
+
+	//go:embed index_template.html
+	indexTemplateFile string
 )
 
 var (
 	funcMap = template.FuncMap{
-		"cleanPath": cleanPath,
+		"cleanPath": CleanPath,
+		"percent":   percent,
+		"anchor":    anchor,
+		// specHistory and historyFile are overridden for each call to TemplateTree since nested templates cannot access
+		// the top level config.
+		"specHistory": func(*types.SpecReport) *SpecHistory { return nil },
+		"historyFile": func() string { return "" },
 	}
-	treeTemplate   = template.Must(template.New("tree_template.html").Funcs(funcMap).Parse(treeTemplateFile))
-	reportTemplate = template.Must(template.New("report_template.html").Parse(reportTemplateFile))
+	treeTemplate    = template.Must(template.New("tree_template.html").Funcs(funcMap).Parse(treeTemplateFile))
+	reportTemplate  = template.Must(template.New("report_template.html").Parse(reportTemplateFile))
+	historyTemplate = template.Must(template.New("history_template.html").Funcs(funcMap).Parse(historyTemplateFile))
+	diffTemplate    = template.Must(template.New("diff_template.html").Funcs(funcMap).Parse(diffTemplateFile))
+	indexTemplate   = template.Must(template.New("index_template.html").Funcs(funcMap).Parse(indexTemplateFile))
 )
 
 // TreeTemplateConfig contains the data necessary to template a single SuiteTree into an html report.
 type TreeTemplateConfig struct {
-	Tree       *SuiteTree
+	Tree       *suitetree.SuiteTree
 	Generated  time.Time
 	Branch     string
 	ActionURL  template.URL
 	RepoURL    template.URL
 	TimeFormat string
+	// SpecHistories is keyed by spec key and is used to show the run history of each spec. It may be nil.
+	SpecHistories map[string]*SpecHistory
+	// HistoryFile is the name of the file generated by TemplateHistory. Leave empty if there is no history page.
+	HistoryFile string
 }
 
 // TemplateTree uses config to generate a SuiteTree report and save it at outputFileName.
 func TemplateTree(config TreeTemplateConfig, outputFileName string) error {
-	return executeTemplateAndSave(treeTemplate, config, outputFileName)
+	tmpl, err := treeTemplate.Clone()
+	if err != nil {
+		return err
+	}
+
+	tmpl = tmpl.Funcs(template.FuncMap{
+		"specHistory": func(spec *types.SpecReport) *SpecHistory {
+			return config.SpecHistories[suitetree.GetSpecKey(spec)]
+		},
+		"historyFile": func() string {
+			return config.HistoryFile
+		},
+	})
+
+	return executeTemplateAndSave(tmpl, config, outputFileName)
 }
 
 // ReportTemplateConfig contains the data necessary to generate a report linking to multiple templated SuiteTrees.
 type ReportTemplateConfig struct {
 	BranchReports []BranchReportConfig
-	Generated     time.Time
-	ActionURL     template.URL
-	RepoURL       template.URL
-	TimeFormat    string
+	// HistoryFile is the name of the file generated by TemplateHistory. Leave empty if there is no history page.
+	HistoryFile string
+	// DiffFile is the name of the file generated by TemplateDiff. Leave empty if there is no diff page.
+	DiffFile   string
+	Generated  time.Time
+	ActionURL  template.URL
+	RepoURL    template.URL
+	TimeFormat string
 }
 
 // BranchReportConfig contains the data necessary to include a single templated SuiteTree for a certain branch.
@@ -56,6 +101,8 @@ type BranchReportConfig struct {
 	ReportFile    string
 	Revision      string
 	ShortRevision string
+	// IndexFile is the name of the file generated by TemplateIndex for this branch. Leave empty if there is none.
+	IndexFile string
 }
 
 // TemplateReport uses config to generate a report linking to multiple SuiteTree reports and save it at outputFileName.
@@ -68,6 +115,53 @@ func TemplateReport(config ReportTemplateConfig, outputFileName string) error {
 	return executeTemplateAndSave(reportTemplate, config, outputFileName)
 }
 
+// HistoryTemplateConfig contains the data necessary to generate a page with the run history of every spec.
+type HistoryTemplateConfig struct {
+	// SpecHistories should be sorted in the order they are to be displayed, usually by SortedByFlakiness.
+	SpecHistories []*SpecHistory
+	Runs          int
+	Generated     time.Time
+	ActionURL     template.URL
+	RepoURL       template.URL
+	TimeFormat    string
+}
+
+// TemplateHistory uses config to generate a report of the run history of every spec and save it at outputFileName.
+func TemplateHistory(config HistoryTemplateConfig, outputFileName string) error {
+	return executeTemplateAndSave(historyTemplate, config, outputFileName)
+}
+
+// DiffTemplateConfig contains the data necessary to generate a page comparing the specs of branches to a base branch.
+type DiffTemplateConfig struct {
+	BranchDiffs []*BranchDiff
+	Generated   time.Time
+	ActionURL   template.URL
+	RepoURL     template.URL
+	TimeFormat  string
+}
+
+// TemplateDiff uses config to generate a report of the differences between branches and save it at outputFileName.
+func TemplateDiff(config DiffTemplateConfig, outputFileName string) error {
+	return executeTemplateAndSave(diffTemplate, config, outputFileName)
+}
+
+// IndexTemplateConfig contains the data necessary to generate a searchable page of the labels and IDs used on a branch.
+type IndexTemplateConfig struct {
+	Index *SpecIndex
+	// Filter is the result of applying the label filter provided to the program. It may be nil.
+	Filter     *FilterResult
+	Generated  time.Time
+	Branch     string
+	ActionURL  template.URL
+	RepoURL    template.URL
+	TimeFormat string
+}
+
+// TemplateIndex uses config to generate a report of the labels and IDs on a branch and save it at outputFileName.
+func TemplateIndex(config IndexTemplateConfig, outputFileName string) error {
+	return executeTemplateAndSave(indexTemplate, config, outputFileName)
+}
+
 // executeTemplateAndSave creates a file at outputFileName before executing tmpl with data provided by config. If
 // outputFileName already exists, then it is truncated.
 func executeTemplateAndSave(tmpl *template.Template, config any, outputFileName string) error {
@@ -86,9 +180,9 @@ func executeTemplateAndSave(tmpl *template.Template, config any, outputFileName
 	return nil
 }
 
-// cleanPath cleans the provided path of anything preceding the eco-gotests directory. This is useful to template paths
+// CleanPath cleans the provided path of anything preceding the eco-gotests directory. This is useful to template paths
 // to be relative to the repo root rather than / on the machine that generated the report.
-func cleanPath(path string) string {
+func CleanPath(path string) string {
 	pathElements := strings.Split(path, string(os.PathSeparator))
 	for i, element := range pathElements {
 		if element == "eco-gotests" {
@@ -98,3 +192,14 @@ func cleanPath(path string) string {
 
 	return path
 }
+
+// percent formats a ratio in the range [0, 1] as a percentage with one decimal place.
+func percent(value float64) string {
+	return fmt.Sprintf("%.1f%%", value*100)
+}
+
+// anchor converts a spec key into a string that is safe to use as an HTML id and URL fragment. Keys are either
+// reportxml.IDs or arbitrary spec text, so the latter is hashed.
+func anchor(key string) string {
+	return fmt.Sprintf("spec-%x", sha256.Sum256([]byte(key)))[:len("spec-")+16]
+}
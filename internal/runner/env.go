@@ -0,0 +1,71 @@
+package main
+
+import (
+	"os"
+	"strings"
+)
+
+// The environment variables below are the defaults for the flags of the same name. They are kept from the test runner
+// script so existing CI jobs do not need to change. All of them are documented in the top level README.
+
+// envFeatures returns the features in ECO_TEST_FEATURES.
+func envFeatures() []string {
+	return splitList(os.Getenv("ECO_TEST_FEATURES"))
+}
+
+// envExcludeFeatures returns the features in ECO_TEST_EXCLUDE_FEATURES.
+func envExcludeFeatures() []string {
+	return splitList(os.Getenv("ECO_TEST_EXCLUDE_FEATURES"))
+}
+
+// envLabelFilter returns the label filter in ECO_TEST_LABELS.
+func envLabelFilter() string {
+	return os.Getenv("ECO_TEST_LABELS")
+}
+
+// envIDs returns the reportxml.IDs in ECO_TEST_IDS.
+func envIDs() []string {
+	return splitList(os.Getenv("ECO_TEST_IDS"))
+}
+
+// envExcludeIDs returns the reportxml.IDs in ECO_TEST_EXCLUDE_IDS.
+func envExcludeIDs() []string {
+	return splitList(os.Getenv("ECO_TEST_EXCLUDE_IDS"))
+}
+
+// envOutputDir returns the directory in ECO_TEST_REPORTS_DIR.
+func envOutputDir() string {
+	return os.Getenv("ECO_TEST_REPORTS_DIR")
+}
+
+// envRunID returns the run ID in ECO_RUN_ID.
+func envRunID() string {
+	return os.Getenv("ECO_RUN_ID")
+}
+
+// envVerbose returns whether ECO_TEST_VERBOSE is true.
+func envVerbose() bool {
+	return os.Getenv("ECO_TEST_VERBOSE") == "true"
+}
+
+// envTrace returns whether ECO_TEST_TRACE is true.
+func envTrace() bool {
+	return os.Getenv("ECO_TEST_TRACE") == "true"
+}
+
+// envVerboseScript returns whether ECO_VERBOSE_SCRIPT is true.
+func envVerboseScript() bool {
+	return os.Getenv("ECO_VERBOSE_SCRIPT") == "true"
+}
+
+// splitList splits a list separated by spaces or commas, dropping empty elements.
+func splitList(list string) []string {
+	return strings.FieldsFunc(list, func(char rune) bool {
+		return char == ',' || char == ' ' || char == '\t' || char == '\n'
+	})
+}
+
+// joinList is the inverse of splitList, used to show list defaults in the help message.
+func joinList(list []string) string {
+	return strings.Join(list, " ")
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/suitetree"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// jsonReportName and junitReportName are the names of the merged reports written to the output directory.
+	jsonReportName  = "report.json"
+	junitReportName = "junit.xml"
+	// runIDFormat is the time layout of the run ID generated when none is given, matching the one the suite configs
+	// generate.
+	runIDFormat = "20060102T150405Z"
+)
+
+// RunOptions are the settings used when invoking Ginkgo on the planned suites.
+type RunOptions struct {
+	// Verbose runs Ginkgo with -vv.
+	Verbose bool
+	// Trace runs Ginkgo with --trace.
+	Trace bool
+	// RunID is passed to every suite as ECO_RUN_ID so the files they share in the reports directory are scoped to
+	// this run. A run ID based on the current time is used if it is empty.
+	RunID string
+	// OutputDir is the directory where the merged JSON and JUnit reports are written. Reports are not written if it is
+	// empty.
+	OutputDir string
+	// ExtraArgs are passed to Ginkgo after all other flags and before the suites.
+	ExtraArgs []string
+}
+
+// DryRun runs every suite in dirs, which must be under testsDir, in dry-run mode and returns the node of the resulting
+// SuiteTree for testsDir. The tree only contains the suites in dirs. The output of Ginkgo is only logged if it fails.
+func DryRun(ctx context.Context, testsDir string, dirs []string) (*suitetree.SuiteTree, error) {
+	absTestsDir, err := filepath.Abs(testsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	klog.V(100).Infof("Running dry run of %v in %s", dirs, absTestsDir)
+
+	tempDir, err := os.MkdirTemp("", "eco-gotests-runner")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+
+	reportPath := filepath.Join(tempDir, jsonReportName)
+
+	args := []string{"--json-report=" + reportPath, "--dry-run", "-v", "-r"}
+
+	for _, dir := range dirs {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, absDir)
+	}
+
+	cmd := exec.CommandContext(ctx, "ginkgo", args...)
+	cmd.Env = append(os.Environ(), "ECO_DRY_RUN=true")
+
+	var output bytes.Buffer
+
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err = cmd.Run()
+	if err != nil {
+		klog.Errorf("Dry run failed with output:\n%s", output.String())
+
+		return nil, fmt.Errorf("failed to dry run %v: %w", dirs, err)
+	}
+
+	tree, err := suitetree.NewFromFile(reportPath)
+	if err != nil {
+		return nil, err
+	}
+
+	testsTree := tree.Find(absTestsDir)
+	if testsTree == nil {
+		return nil, fmt.Errorf("no suites found in %s", absTestsDir)
+	}
+
+	return testsTree, nil
+}
+
+// Run invokes Ginkgo on the suites of the plan with the label filter of the plan, streaming its output. Suites are
+// passed individually rather than recursively so exactly the planned suites run. Suite paths are made relative to the
+// current directory to keep the printed command short.
+func Run(ctx context.Context, plan *Plan, options RunOptions) error {
+	args := []string{"-timeout=24h", "--keep-going", "--require-suite"}
+
+	if options.Verbose {
+		args = append(args, "-vv")
+	}
+
+	if options.Trace {
+		args = append(args, "--trace")
+	}
+
+	if plan.LabelFilter != "" {
+		args = append(args, "--label-filter="+plan.LabelFilter)
+	}
+
+	if options.OutputDir != "" {
+		args = append(args,
+			"--output-dir="+options.OutputDir, "--json-report="+jsonReportName, "--junit-report="+junitReportName)
+	}
+
+	args = append(args, options.ExtraArgs...)
+
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	for _, suite := range plan.Suites {
+		suitePath := relativePath(workingDir, suite.Path)
+		if !strings.HasPrefix(suitePath, ".") {
+			suitePath = "./" + suitePath
+		}
+
+		args = append(args, suitePath)
+	}
+
+	runID := options.RunID
+	if runID == "" {
+		runID = time.Now().UTC().Format(runIDFormat)
+	}
+
+	cmd := exec.CommandContext(ctx, "ginkgo", args...)
+	cmd.Env = append(os.Environ(), "ECO_RUN_ID="+runID)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	fmt.Println(cmd.String())
+
+	return cmd.Run()
+}
@@ -0,0 +1,223 @@
+/*
+Runner is a tool to select and run the test suites in the tests directory. It replaces the feature lookup of the test
+runner script, which now only calls this tool, by resolving features against the SuiteTree built from a dry run of the
+suites under the matching directories, the same tree used by the report tool.
+
+Features are matched against the names of the directories under the tests directory, or their paths relative to it,
+and select every suite under a matching directory. Internal directories are never matched. Features that do not match
+any directory are an error rather than being skipped, and similarly named directories are suggested. Suites can be
+excluded the same way, and specs can be further selected using a Ginkgo label filter and lists of reportxml.IDs to
+include or exclude.
+
+Before running anything, the planned suites and specs are printed. Ginkgo is then invoked once on exactly the planned
+suites, with a label filter combining the label filter and IDs. If an output directory is provided, Ginkgo writes the
+merged JSON and JUnit reports of the run to it.
+
+Every flag defaults to the environment variable used by the test runner script, so existing jobs keep working. Any
+arguments after -- are passed to Ginkgo.
+
+Upon success the exit code is 0. If the selection is invalid or any other error occurs, it will be logged to stderr and
+the exit code will be 1. If Ginkgo fails, its exit code is used.
+
+Usage:
+
+	runner [flags] [-- ginkgo flags]
+
+The flags are:
+
+	-h, -help
+		Print this help message
+
+	-f, -features string
+		Space-separated list of features to run, or all for every suite. Defaults to ECO_TEST_FEATURES
+
+	-F, -exclude-features string
+		Space-separated list of features to exclude. Defaults to ECO_TEST_EXCLUDE_FEATURES
+
+	-i, -ids string
+		Space-separated list of reportxml.IDs to run. Defaults to ECO_TEST_IDS, or all IDs if left blank
+
+	-I, -exclude-ids string
+		Space-separated list of reportxml.IDs to exclude. Defaults to ECO_TEST_EXCLUDE_IDS
+
+	-l, -label-filter string
+		Ginkgo label filter to select specs with. Defaults to ECO_TEST_LABELS
+
+	-o, -output-dir string
+		Directory for Ginkgo to write the JSON and JUnit reports to. Defaults to ECO_TEST_REPORTS_DIR
+
+	-p, -plan
+		Print the planned specs and exit without running them
+
+	-t, -tests-dir string
+		Directory containing the test suites. Defaults to ./tests
+
+	-v int
+		Log level verbosity for klog. Use 100 for logging all messages or leave blank for none
+
+The specs of each planned suite are only printed with -plan or when ECO_VERBOSE_SCRIPT is true. Ginkgo is run with
+-vv if ECO_TEST_VERBOSE is true and with --trace if ECO_TEST_TRACE is true. Every suite is run with the same
+ECO_RUN_ID, which is kept if already set and otherwise generated from the current time.
+*/
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+
+	"k8s.io/klog/v2"
+)
+
+var (
+	help            bool
+	features        string
+	excludeFeatures string
+	ids             string
+	excludeIDs      string
+	labelFilter     string
+	outputDir       string
+	planOnly        bool
+	testsDir        string
+)
+
+//nolint:gochecknoinits,funlen // This is a main package so init is fine.
+func init() {
+	const (
+		helpUsage            = "Print this help message"
+		featuresUsage        = "Space-separated list of features to run, or all for every suite"
+		excludeFeaturesUsage = "Space-separated list of features to exclude"
+		idsUsage             = "Space-separated list of reportxml.IDs to run, or all IDs if left blank"
+		excludeIDsUsage      = "Space-separated list of reportxml.IDs to exclude"
+		labelFilterUsage     = "Ginkgo label filter to select specs with"
+		outputDirUsage       = "Directory for Ginkgo to write the JSON and JUnit reports to"
+		planOnlyUsage        = "Print the planned specs and exit without running them"
+		testsDirUsage        = "Directory containing the test suites"
+
+		defaultHelp     = false
+		defaultPlanOnly = false
+		defaultTestsDir = "./tests"
+
+		shorthand = " (shorthand)"
+	)
+
+	var (
+		defaultFeatures        = joinList(envFeatures())
+		defaultExcludeFeatures = joinList(envExcludeFeatures())
+		defaultIDs             = joinList(envIDs())
+		defaultExcludeIDs      = joinList(envExcludeIDs())
+		defaultLabelFilter     = envLabelFilter()
+		defaultOutputDir       = envOutputDir()
+	)
+
+	klog.InitFlags(nil)
+
+	_ = flag.Set("logtostderr", "true")
+
+	flag.BoolVar(&help, "help", defaultHelp, helpUsage)
+	flag.BoolVar(&help, "h", defaultHelp, helpUsage+shorthand)
+
+	flag.StringVar(&features, "features", defaultFeatures, featuresUsage)
+	flag.StringVar(&features, "f", defaultFeatures, featuresUsage+shorthand)
+
+	flag.StringVar(&excludeFeatures, "exclude-features", defaultExcludeFeatures, excludeFeaturesUsage)
+	flag.StringVar(&excludeFeatures, "F", defaultExcludeFeatures, excludeFeaturesUsage+shorthand)
+
+	flag.StringVar(&ids, "ids", defaultIDs, idsUsage)
+	flag.StringVar(&ids, "i", defaultIDs, idsUsage+shorthand)
+
+	flag.StringVar(&excludeIDs, "exclude-ids", defaultExcludeIDs, excludeIDsUsage)
+	flag.StringVar(&excludeIDs, "I", defaultExcludeIDs, excludeIDsUsage+shorthand)
+
+	flag.StringVar(&labelFilter, "label-filter", defaultLabelFilter, labelFilterUsage)
+	flag.StringVar(&labelFilter, "l", defaultLabelFilter, labelFilterUsage+shorthand)
+
+	flag.StringVar(&outputDir, "output-dir", defaultOutputDir, outputDirUsage)
+	flag.StringVar(&outputDir, "o", defaultOutputDir, outputDirUsage+shorthand)
+
+	flag.BoolVar(&planOnly, "plan", defaultPlanOnly, planOnlyUsage)
+	flag.BoolVar(&planOnly, "p", defaultPlanOnly, planOnlyUsage+shorthand)
+
+	flag.StringVar(&testsDir, "tests-dir", defaultTestsDir, testsDirUsage)
+	flag.StringVar(&testsDir, "t", defaultTestsDir, testsDirUsage+shorthand)
+}
+
+func main() {
+	flag.Parse()
+
+	if help {
+		flag.Usage()
+
+		return
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	selection := Selection{
+		Features:        splitList(features),
+		ExcludeFeatures: splitList(excludeFeatures),
+		LabelFilter:     labelFilter,
+		IDs:             splitList(ids),
+		ExcludeIDs:      splitList(excludeIDs),
+	}
+
+	// The label filter is checked first since the dry run takes a while and an invalid filter is a common mistake.
+	_, err := selection.CombinedLabelFilter()
+	if err != nil {
+		klog.Errorf("Invalid selection: %v", err)
+
+		os.Exit(1)
+	}
+
+	// Only the directories matching the features are dry run since building every suite takes much longer.
+	featureDirs, err := selection.FeatureDirs(testsDir)
+	if err != nil {
+		klog.Errorf("Invalid selection: %v", err)
+
+		os.Exit(1)
+	}
+
+	testsTree, err := DryRun(ctx, testsDir, featureDirs)
+	if err != nil {
+		klog.Errorf("Failed to build suite tree: %v", err)
+
+		os.Exit(1)
+	}
+
+	plan, err := NewPlan(testsTree, selection)
+	if err != nil {
+		klog.Errorf("Invalid selection: %v", err)
+
+		os.Exit(1)
+	}
+
+	fmt.Print(plan.Format(testsTree.Path, planOnly || envVerboseScript()))
+
+	if planOnly {
+		return
+	}
+
+	err = Run(ctx, plan, RunOptions{
+		Verbose:   envVerbose(),
+		Trace:     envTrace(),
+		RunID:     envRunID(),
+		OutputDir: outputDir,
+		ExtraArgs: flag.Args(),
+	})
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.ExitCode())
+	}
+
+	if err != nil {
+		klog.Errorf("Failed to run ginkgo: %v", err)
+
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,453 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/suitetree"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// allFeatures is the feature that selects every suite.
+	allFeatures = "all"
+	// maxSuggestionDistance is the largest edit distance between an unknown feature and a suite directory for the
+	// directory to be suggested.
+	maxSuggestionDistance = 2
+)
+
+// Selection is the set of features, labels, and reportxml.IDs that determines which specs are run.
+type Selection struct {
+	// Features are suite directory names, or paths relative to the tests directory, to include. The feature all
+	// includes every suite.
+	Features []string
+	// ExcludeFeatures are resolved the same way as Features and remove suites from them.
+	ExcludeFeatures []string
+	// LabelFilter is a Ginkgo label filter, using the same syntax as --label-filter.
+	LabelFilter string
+	// IDs are the reportxml.IDs to include. If empty, all IDs are included.
+	IDs []string
+	// ExcludeIDs are the reportxml.IDs to exclude.
+	ExcludeIDs []string
+}
+
+// Plan is the result of resolving a Selection against the SuiteTree of the tests directory.
+type Plan struct {
+	// Suites contains only the suites with at least one planned spec, sorted by path.
+	Suites []*suitetree.SuiteTree
+	// Specs maps the path of each suite to the specs planned in it.
+	Specs map[string][]PlannedSpec
+	// LabelFilter combines the label filter and IDs of the Selection. It is passed to Ginkgo when running the suites so
+	// that exactly the planned specs are run.
+	LabelFilter string
+	// Total is the number of specs in the suites selected by the features before filtering.
+	Total int
+}
+
+// PlannedSpec is a single spec that will be run.
+type PlannedSpec struct {
+	ID     string
+	Text   string
+	Labels []string
+}
+
+// CombinedLabelFilter returns the label filter of the selection with the IDs added to it. The reportxml.ID labels are
+// used so that IDs do not match other labels. It returns an error if the resulting filter is invalid, which usually
+// means the label filter of the selection is invalid.
+func (selection Selection) CombinedLabelFilter() (string, error) {
+	var clauses []string
+
+	if strings.TrimSpace(selection.LabelFilter) != "" {
+		clauses = append(clauses, "("+selection.LabelFilter+")")
+	}
+
+	if len(selection.IDs) > 0 {
+		clauses = append(clauses, "("+idLabelFilter(selection.IDs)+")")
+	}
+
+	if len(selection.ExcludeIDs) > 0 {
+		clauses = append(clauses, "!("+idLabelFilter(selection.ExcludeIDs)+")")
+	}
+
+	combined := strings.Join(clauses, " && ")
+
+	_, err := types.ParseLabelFilter(combined)
+	if err != nil {
+		return "", fmt.Errorf("invalid label filter %q: %w", combined, err)
+	}
+
+	return combined, nil
+}
+
+// FeatureDirs returns the directories under testsDir matching the features of the selection, so only they need to be
+// dry run. Directories under another returned directory are omitted and testsDir itself is returned for the feature
+// all. Directories are matched on the filesystem the same way NewPlan matches them in the SuiteTree, so it returns an
+// error if any feature or excluded feature does not match a directory, along with suggestions.
+func (selection Selection) FeatureDirs(testsDir string) ([]string, error) {
+	if len(selection.Features) == 0 {
+		return nil, fmt.Errorf("no features provided, use %s to select every suite", allFeatures)
+	}
+
+	var (
+		relPaths []string
+		names    []string
+	)
+
+	err := filepath.WalkDir(testsDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !entry.IsDir() || path == testsDir {
+			return nil
+		}
+
+		if entry.Name() == "internal" || strings.HasPrefix(entry.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		relPaths = append(relPaths, relativePath(testsDir, path))
+		names = append(names, entry.Name())
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directories in %s: %w", testsDir, err)
+	}
+
+	var (
+		dirs    []string
+		unknown []string
+	)
+
+	for _, feature := range slices.Concat(selection.Features, selection.ExcludeFeatures) {
+		if feature == allFeatures {
+			dirs = append(dirs, ".")
+
+			continue
+		}
+
+		matched := false
+
+		for index, relPath := range relPaths {
+			if matchesFeature(names[index], relPath, feature) {
+				matched = true
+
+				if slices.Contains(selection.Features, feature) {
+					dirs = append(dirs, relPath)
+				}
+			}
+		}
+
+		if !matched {
+			unknown = append(unknown, unknownFeature(names, feature))
+		}
+	}
+
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("no directories found for features: %s", strings.Join(unknown, "; "))
+	}
+
+	slices.Sort(dirs)
+
+	var topDirs []string
+
+	for _, dir := range slices.Compact(dirs) {
+		if !slices.ContainsFunc(topDirs, func(topDir string) bool { return isUnder(topDir, dir) }) {
+			topDirs = append(topDirs, dir)
+		}
+	}
+
+	featureDirs := make([]string, 0, len(topDirs))
+	for _, dir := range topDirs {
+		featureDirs = append(featureDirs, filepath.Join(testsDir, dir))
+	}
+
+	return featureDirs, nil
+}
+
+// NewPlan resolves the selection against testsTree, which should be the node for the tests directory. It returns an
+// error if any feature does not match a suite, the label filter is invalid, any included ID is not used by a spec in
+// the selected suites, or no specs are selected. All unknown features are reported together, along with suggestions.
+func NewPlan(testsTree *suitetree.SuiteTree, selection Selection) (*Plan, error) {
+	klog.V(100).Infof("Creating plan for selection %+v", selection)
+
+	labelFilter, err := selection.CombinedLabelFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := types.ParseLabelFilter(labelFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	suites, err := resolveFeatures(testsTree, selection.Features, selection.ExcludeFeatures)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{Specs: make(map[string][]PlannedSpec), LabelFilter: labelFilter}
+	foundIDs := make(map[string]bool)
+
+	for _, suite := range suites {
+		suite.WalkSpecs(func(_ *suitetree.SuiteTree, spec *types.SpecReport) {
+			plan.Total++
+
+			id := suitetree.GetSpecID(spec)
+			foundIDs[id] = true
+
+			if !filter(spec.Labels()) {
+				return
+			}
+
+			plan.Specs[suite.Path] = append(plan.Specs[suite.Path], PlannedSpec{
+				ID:     id,
+				Text:   spec.FullText(),
+				Labels: spec.Labels(),
+			})
+		})
+
+		if len(plan.Specs[suite.Path]) > 0 {
+			plan.Suites = append(plan.Suites, suite)
+		}
+	}
+
+	var missingIDs []string
+
+	for _, id := range selection.IDs {
+		if !foundIDs[id] {
+			missingIDs = append(missingIDs, id)
+		}
+	}
+
+	if len(missingIDs) > 0 {
+		return nil, fmt.Errorf("no specs in the selected suites have IDs %s", strings.Join(missingIDs, ", "))
+	}
+
+	if len(plan.Suites) == 0 {
+		return nil, fmt.Errorf("no specs selected out of %d in the suites matching the features", plan.Total)
+	}
+
+	return plan, nil
+}
+
+// Count returns the number of planned specs.
+func (plan *Plan) Count() int {
+	count := 0
+
+	for _, specs := range plan.Specs {
+		count += len(specs)
+	}
+
+	return count
+}
+
+// Format returns a summary line followed by the path of each suite relative to root. If listSpecs is true, the planned
+// specs of each suite are listed under it.
+func (plan *Plan) Format(root string, listSpecs bool) string {
+	builder := &strings.Builder{}
+
+	fmt.Fprintf(builder, "Planned %d of %d specs in %d suites", plan.Count(), plan.Total, len(plan.Suites))
+
+	if plan.LabelFilter != "" {
+		fmt.Fprintf(builder, " with label filter %q", plan.LabelFilter)
+	}
+
+	builder.WriteByte('\n')
+
+	for _, suite := range plan.Suites {
+		fmt.Fprintf(builder, "%s (%d specs)\n", relativePath(root, suite.Path), len(plan.Specs[suite.Path]))
+
+		if !listSpecs {
+			continue
+		}
+
+		for _, spec := range plan.Specs[suite.Path] {
+			builder.WriteString("  ")
+
+			if spec.ID != "" {
+				fmt.Fprintf(builder, "[%s] ", spec.ID)
+			}
+
+			builder.WriteString(spec.Text)
+			builder.WriteByte('\n')
+		}
+	}
+
+	return builder.String()
+}
+
+// resolveFeatures returns the suites matching any of the features and none of the excluded features, sorted by path.
+func resolveFeatures(
+	testsTree *suitetree.SuiteTree, features, excludeFeatures []string) ([]*suitetree.SuiteTree, error) {
+	if len(features) == 0 {
+		return nil, fmt.Errorf("no features provided, use %s to select every suite", allFeatures)
+	}
+
+	included, err := matchFeatures(testsTree, features)
+	if err != nil {
+		return nil, err
+	}
+
+	// Excluded features are checked against the tests directory by FeatureDirs. They are not checked here since the
+	// tree may only contain the directories that were dry run, which need not include the excluded ones.
+	excluded, _ := matchFeatures(testsTree, excludeFeatures)
+
+	included = slices.DeleteFunc(included, func(suite *suitetree.SuiteTree) bool {
+		return slices.Contains(excluded, suite)
+	})
+
+	if len(included) == 0 {
+		return nil, fmt.Errorf("every suite matching features %v is excluded by %v", features, excludeFeatures)
+	}
+
+	return included, nil
+}
+
+// matchFeatures returns the sorted, unique suites under any node matching one of the features. A node matches a
+// feature if its name or its path relative to testsTree equals the feature. Nodes under internal directories never
+// match, just like the test runner script.
+func matchFeatures(testsTree *suitetree.SuiteTree, features []string) ([]*suitetree.SuiteTree, error) {
+	var (
+		suites  []*suitetree.SuiteTree
+		unknown []string
+	)
+
+	for _, feature := range features {
+		if feature == allFeatures {
+			suites = append(suites, testsTree.Suites()...)
+
+			continue
+		}
+
+		matched := false
+
+		walkDirs(testsTree, func(node *suitetree.SuiteTree) {
+			if matchesFeature(node.Name, relativePath(testsTree.Path, node.Path), feature) {
+				suites = append(suites, node.Suites()...)
+				matched = true
+			}
+		})
+
+		if !matched {
+			unknown = append(unknown, unknownFeature(dirNames(testsTree), feature))
+		}
+	}
+
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("no suites found for features: %s", strings.Join(unknown, "; "))
+	}
+
+	slices.SortFunc(suites, func(suiteA, suiteB *suitetree.SuiteTree) int {
+		return strings.Compare(suiteA.Path, suiteB.Path)
+	})
+
+	return slices.Compact(suites), nil
+}
+
+// matchesFeature returns true if a directory with the name and path relative to the tests directory matches feature.
+func matchesFeature(name, relPath, feature string) bool {
+	return name == feature || relPath == strings.Trim(feature, "/")
+}
+
+// dirNames returns the names of every directory under testsTree, as visited by walkDirs.
+func dirNames(testsTree *suitetree.SuiteTree) []string {
+	var names []string
+
+	walkDirs(testsTree, func(node *suitetree.SuiteTree) {
+		names = append(names, node.Name)
+	})
+
+	return names
+}
+
+// unknownFeature describes a feature that did not match any directory, including the directory names that are similar.
+func unknownFeature(names []string, feature string) string {
+	var suggestions []string
+
+	for _, name := range names {
+		if editDistance(name, feature) <= maxSuggestionDistance && !slices.Contains(suggestions, name) {
+			suggestions = append(suggestions, name)
+		}
+	}
+
+	if len(suggestions) == 0 {
+		return feature
+	}
+
+	slices.Sort(suggestions)
+
+	return fmt.Sprintf("%s (did you mean %s?)", feature, strings.Join(suggestions, " or "))
+}
+
+// walkDirs calls visit for every node under tree that is not a spec, skipping internal directories. The tree itself is
+// not visited.
+func walkDirs(tree *suitetree.SuiteTree, visit func(node *suitetree.SuiteTree)) {
+	for _, child := range tree.Children {
+		if child.SpecReport != nil || child.Name == "internal" {
+			continue
+		}
+
+		visit(child)
+		walkDirs(child, visit)
+	}
+}
+
+// idLabelFilter returns a label filter matching any of the reportxml.IDs.
+func idLabelFilter(ids []string) string {
+	labels := make([]string, 0, len(ids))
+	for _, id := range ids {
+		labels = append(labels, suitetree.SpecIDLabelPrefix+id)
+	}
+
+	return strings.Join(labels, " || ")
+}
+
+// isUnder returns true if the relative path target is base or is in a subdirectory of it. A base of . contains every
+// relative path.
+func isUnder(base, target string) bool {
+	return base == "." || target == base || strings.HasPrefix(target, base+string(filepath.Separator))
+}
+
+// relativePath returns target relative to base, or target itself if it cannot be made relative.
+func relativePath(base, target string) string {
+	relPath, err := filepath.Rel(base, target)
+	if err != nil {
+		return target
+	}
+
+	return relPath
+}
+
+// editDistance returns the Levenshtein distance between stringA and stringB.
+func editDistance(stringA, stringB string) int {
+	runesA, runesB := []rune(stringA), []rune(stringB)
+	previous := make([]int, len(runesB)+1)
+	current := make([]int, len(runesB)+1)
+
+	for index := range previous {
+		previous[index] = index
+	}
+
+	for indexA := range runesA {
+		current[0] = indexA + 1
+
+		for indexB := range runesB {
+			cost := 1
+			if runesA[indexA] == runesB[indexB] {
+				cost = 0
+			}
+
+			current[indexB+1] = min(previous[indexB+1]+1, current[indexB]+1, previous[indexB]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(runesB)]
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/suitetree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testsPath = "/repo/tests"
+
+// newTestsTree returns the node for testsPath of a SuiteTree with a few suites. Each spec has the ID given, along with
+// the ran label for specs under cnf/ran.
+func newTestsTree(t *testing.T) *suitetree.SuiteTree {
+	t.Helper()
+
+	reports := []types.Report{
+		newReport("cnf/ran/ptp", "11", "12"),
+		newReport("cnf/ran/gitopsztp", "21"),
+		newReport("cnf/core/network", "31"),
+		newReport("hw-accel/kmm", "41", ""),
+	}
+
+	testsTree := suitetree.NewFromReports(reports).Find(testsPath)
+	require.NotNil(t, testsTree)
+
+	return testsTree
+}
+
+// newReport returns a report for the suite at suitePath, relative to testsPath, with one spec per ID. Empty IDs
+// result in a spec without an ID.
+func newReport(suitePath string, ids ...string) types.Report {
+	report := types.Report{SuitePath: filepath.Join(testsPath, suitePath), SuiteDescription: suitePath}
+
+	for _, id := range ids {
+		var labels []string
+
+		if id != "" {
+			labels = append(labels, id, suitetree.SpecIDLabelPrefix+id)
+		}
+
+		if filepath.Dir(suitePath) == "cnf/ran" {
+			labels = append(labels, "ran")
+		}
+
+		report.SpecReports = append(report.SpecReports, types.SpecReport{
+			LeafNodeType:   types.NodeTypeIt,
+			LeafNodeText:   "spec " + id,
+			LeafNodeLabels: labels,
+		})
+	}
+
+	report.PreRunStats.TotalSpecs = len(ids)
+
+	return report
+}
+
+func TestCombinedLabelFilter(t *testing.T) {
+	testCases := []struct {
+		name          string
+		selection     Selection
+		expected      string
+		expectedError string
+	}{
+		{name: "empty", selection: Selection{}, expected: ""},
+		{name: "label filter", selection: Selection{LabelFilter: "ran || core"}, expected: "(ran || core)"},
+		{
+			name:      "ids",
+			selection: Selection{IDs: []string{"11", "12"}, ExcludeIDs: []string{"21"}},
+			expected:  "(test_id:11 || test_id:12) && !(test_id:21)",
+		},
+		{
+			name:      "label filter and ids",
+			selection: Selection{LabelFilter: "ran", IDs: []string{"11"}},
+			expected:  "(ran) && (test_id:11)",
+		},
+		{name: "invalid", selection: Selection{LabelFilter: "ran &&"}, expectedError: `invalid label filter "(ran &&)"`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			combined, err := testCase.selection.CombinedLabelFilter()
+			if testCase.expectedError != "" {
+				assert.ErrorContains(t, err, testCase.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, combined)
+		})
+	}
+}
+
+func TestNewPlan(t *testing.T) {
+	testsTree := newTestsTree(t)
+
+	testCases := []struct {
+		name           string
+		selection      Selection
+		expectedSuites []string
+		expectedCount  int
+		expectedTotal  int
+		expectedError  string
+	}{
+		{
+			name:           "feature name",
+			selection:      Selection{Features: []string{"ran"}},
+			expectedSuites: []string{"cnf/ran/gitopsztp", "cnf/ran/ptp"},
+			expectedCount:  3,
+			expectedTotal:  3,
+		},
+		{
+			name:           "feature path",
+			selection:      Selection{Features: []string{"cnf/ran/"}, ExcludeFeatures: []string{"gitopsztp"}},
+			expectedSuites: []string{"cnf/ran/ptp"},
+			expectedCount:  2,
+			expectedTotal:  2,
+		},
+		{
+			name:           "all with label filter",
+			selection:      Selection{Features: []string{"all"}, LabelFilter: "!ran"},
+			expectedSuites: []string{"cnf/core/network", "hw-accel/kmm"},
+			expectedCount:  3,
+			expectedTotal:  6,
+		},
+		{
+			name:           "ids",
+			selection:      Selection{Features: []string{"cnf", "ptp"}, IDs: []string{"12", "31"}},
+			expectedSuites: []string{"cnf/core/network", "cnf/ran/ptp"},
+			expectedCount:  2,
+			expectedTotal:  4,
+		},
+		{
+			name:           "unknown excluded feature",
+			selection:      Selection{Features: []string{"kmm"}, ExcludeFeatures: []string{"nfd"}},
+			expectedSuites: []string{"hw-accel/kmm"},
+			expectedCount:  2,
+			expectedTotal:  2,
+		},
+		{
+			name:          "no features",
+			selection:     Selection{},
+			expectedError: "no features provided, use all to select every suite",
+		},
+		{
+			name:          "unknown features",
+			selection:     Selection{Features: []string{"ptpp", "core", "internal", "foo"}},
+			expectedError: "no suites found for features: ptpp (did you mean ptp?); internal; foo",
+		},
+		{
+			name:          "missing ids",
+			selection:     Selection{Features: []string{"ran"}, IDs: []string{"11", "31", "99"}},
+			expectedError: "no specs in the selected suites have IDs 31, 99",
+		},
+		{
+			name:          "all excluded",
+			selection:     Selection{Features: []string{"kmm"}, ExcludeFeatures: []string{"hw-accel"}},
+			expectedError: "every suite matching features [kmm] is excluded by [hw-accel]",
+		},
+		{
+			name:          "no specs selected",
+			selection:     Selection{Features: []string{"kmm"}, LabelFilter: "ran"},
+			expectedError: "no specs selected out of 2 in the suites matching the features",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			plan, err := NewPlan(testsTree, testCase.selection)
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			var suites []string
+			for _, suite := range plan.Suites {
+				suites = append(suites, relativePath(testsPath, suite.Path))
+			}
+
+			assert.Equal(t, testCase.expectedSuites, suites)
+			assert.Equal(t, testCase.expectedCount, plan.Count())
+			assert.Equal(t, testCase.expectedTotal, plan.Total)
+		})
+	}
+}
+
+func TestFeatureDirs(t *testing.T) {
+	testsDir := t.TempDir()
+
+	for _, dir := range []string{"cnf/ran/ptp", "cnf/ran/internal/ptp", "cnf/ran-du", "cnf/core/ptp", "hw-accel/kmm"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(testsDir, dir), 0o755))
+	}
+
+	testCases := []struct {
+		name          string
+		selection     Selection
+		expected      []string
+		expectedError string
+	}{
+		{name: "name", selection: Selection{Features: []string{"ptp"}}, expected: []string{"cnf/core/ptp", "cnf/ran/ptp"}},
+		{
+			name:      "nested",
+			selection: Selection{Features: []string{"ptp", "ran-du", "cnf/ran"}, ExcludeFeatures: []string{"kmm"}},
+			expected:  []string{"cnf/core/ptp", "cnf/ran", "cnf/ran-du"},
+		},
+		{name: "all", selection: Selection{Features: []string{"kmm", "all"}}, expected: []string{"."}},
+		{name: "no features", selection: Selection{}, expectedError: "no features provided"},
+		{
+			name:          "unknown",
+			selection:     Selection{Features: []string{"rann"}, ExcludeFeatures: []string{"internal"}},
+			expectedError: "no directories found for features: rann (did you mean ran?); internal",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dirs, err := testCase.selection.FeatureDirs(testsDir)
+			if testCase.expectedError != "" {
+				assert.ErrorContains(t, err, testCase.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			var expected []string
+			for _, dir := range testCase.expected {
+				expected = append(expected, filepath.Join(testsDir, dir))
+			}
+
+			assert.Equal(t, expected, dirs)
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	testCases := []struct {
+		stringA  string
+		stringB  string
+		expected int
+	}{
+		{stringA: "", stringB: "", expected: 0},
+		{stringA: "ptp", stringB: "", expected: 3},
+		{stringA: "ptp", stringB: "ptp", expected: 0},
+		{stringA: "ptp", stringB: "ptpp", expected: 1},
+		{stringA: "kmm", stringB: "kmn", expected: 1},
+		{stringA: "gitopsztp", stringB: "ztp", expected: 6},
+		{stringA: "kitten", stringB: "sitting", expected: 3},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, editDistance(testCase.stringA, testCase.stringB),
+			"distance between %q and %q", testCase.stringA, testCase.stringB)
+		assert.Equal(t, testCase.expected, editDistance(testCase.stringB, testCase.stringA))
+	}
+}
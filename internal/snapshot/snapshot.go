@@ -0,0 +1,302 @@
+// Package snapshot captures selected objects from a live cluster into a YAML file and loads them back into the
+// eco-goinfra fake client, so helpers that read cluster state can be unit tested offline against real-world data. It is
+// shared by the capture tool, which writes snapshots, and unit tests, which load them from their testdata directories.
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	sriovv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	nmstatev1beta1 "github.com/nmstate/kubernetes-nmstate/api/v1beta1"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	olmv1alpha1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/olm/operators/v1alpha1"
+	ptpv1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/ptp/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	kjson "k8s.io/apimachinery/pkg/runtime/serializer/json"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/klog/v2"
+	policiesv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
+)
+
+// Resource is a kind of object that can be captured in a snapshot.
+type Resource struct {
+	// Name is the lowercase plural name of the resource, such as ptpconfigs, used to select it in the capture tool.
+	Name string
+	GVK  schema.GroupVersionKind
+	// AddToScheme adds the types of the resource to a scheme so it can be decoded into typed objects and used with
+	// the fake client.
+	AddToScheme clients.SchemeAttacher
+}
+
+// Resources are the kinds of objects that can be captured, in the order they are written to snapshots. New resources
+// should be added here along with the scheme for their types.
+var Resources = []Resource{
+	{Name: "nodes", GVK: corev1.SchemeGroupVersion.WithKind("Node"), AddToScheme: corev1.AddToScheme},
+	{Name: "clusterversions", GVK: configv1.GroupVersion.WithKind("ClusterVersion"), AddToScheme: configv1.Install},
+	{
+		Name:        "clusterserviceversions",
+		GVK:         olmv1alpha1.SchemeGroupVersion.WithKind("ClusterServiceVersion"),
+		AddToScheme: olmv1alpha1.AddToScheme,
+	},
+	{Name: "ptpconfigs", GVK: ptpv1.GroupVersion.WithKind("PtpConfig"), AddToScheme: ptpv1.AddToScheme},
+	{
+		Name:        "sriovnetworknodestates",
+		GVK:         sriovv1.GroupVersion.WithKind("SriovNetworkNodeState"),
+		AddToScheme: sriovv1.AddToScheme,
+	},
+	{
+		Name:        "sriovnetworknodepolicies",
+		GVK:         sriovv1.GroupVersion.WithKind("SriovNetworkNodePolicy"),
+		AddToScheme: sriovv1.AddToScheme,
+	},
+	{
+		Name:        "nodenetworkstates",
+		GVK:         nmstatev1beta1.GroupVersion.WithKind("NodeNetworkState"),
+		AddToScheme: nmstatev1beta1.AddToScheme,
+	},
+	{Name: "policies", GVK: policiesv1.GroupVersion.WithKind("Policy"), AddToScheme: policiesv1.AddToScheme},
+}
+
+// ResourceNames returns the names of every resource in Resources.
+func ResourceNames() []string {
+	var names []string
+	for _, resource := range Resources {
+		names = append(names, resource.Name)
+	}
+
+	return names
+}
+
+// LookupResources returns the resources with each of names, in the order of Resources. An empty list of names returns
+// every resource.
+func LookupResources(names ...string) ([]Resource, error) {
+	if len(names) == 0 {
+		return slices.Clone(Resources), nil
+	}
+
+	for _, name := range names {
+		if !slices.ContainsFunc(Resources, func(resource Resource) bool { return resource.Name == name }) {
+			return nil, fmt.Errorf("unknown resource %q, must be one of %s", name, strings.Join(ResourceNames(), ", "))
+		}
+	}
+
+	return slices.DeleteFunc(slices.Clone(Resources), func(resource Resource) bool {
+		return !slices.Contains(names, resource.Name)
+	}), nil
+}
+
+// Capture lists every object of each of resources on the cluster, across all namespaces, sorted by resource and then by
+// namespace and name. Resources whose CRD is not installed on the cluster are skipped. Fields that only add noise to
+// snapshots, such as managed fields and resource versions, are removed.
+func Capture(
+	ctx context.Context, apiClient *clients.Settings, resources []Resource) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+
+	for _, resource := range resources {
+		objectList := &unstructured.UnstructuredList{}
+		objectList.SetGroupVersionKind(resource.GVK.GroupVersion().WithKind(resource.GVK.Kind + "List"))
+
+		err := apiClient.List(ctx, objectList)
+		if apimeta.IsNoMatchError(err) {
+			klog.V(90).Infof("Skipping resource %s since it is not installed on the cluster", resource.Name)
+
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", resource.Name, err)
+		}
+
+		klog.V(90).Infof("Captured %d objects of resource %s", len(objectList.Items), resource.Name)
+
+		slices.SortFunc(objectList.Items, func(a, b unstructured.Unstructured) int {
+			return cmp.Or(cmp.Compare(a.GetNamespace(), b.GetNamespace()), cmp.Compare(a.GetName(), b.GetName()))
+		})
+
+		for index := range objectList.Items {
+			object := &objectList.Items[index]
+			object.SetGroupVersionKind(resource.GVK)
+			sanitize(object)
+
+			objects = append(objects, object)
+		}
+	}
+
+	return objects, nil
+}
+
+// Write writes objects to writer as a multi-document YAML snapshot.
+func Write(writer io.Writer, objects []*unstructured.Unstructured) error {
+	yamlSerializer := kjson.NewSerializerWithOptions(
+		kjson.DefaultMetaFactory, nil, nil, kjson.SerializerOptions{Yaml: true})
+
+	for index, object := range objects {
+		if index > 0 {
+			if _, err := io.WriteString(writer, "---\n"); err != nil {
+				return fmt.Errorf("failed to write document separator: %w", err)
+			}
+		}
+
+		err := yamlSerializer.Encode(object, writer)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s %s: %w", object.GetKind(), objectKey(object), err)
+		}
+	}
+
+	return nil
+}
+
+// WriteFile writes objects to a snapshot file at path, replacing it if it exists.
+func WriteFile(path string, objects []*unstructured.Unstructured) error {
+	buffer := &bytes.Buffer{}
+
+	err := Write(buffer, objects)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(path, buffer.Bytes(), 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Read decodes the multi-document YAML snapshot in reader into typed objects. Every object must be of a kind in
+// Resources.
+func Read(reader io.Reader) ([]runtime.Object, error) {
+	scheme, err := newScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	yamlReader := utilyaml.NewYAMLReader(bufio.NewReader(reader))
+
+	var objects []runtime.Object
+
+	for {
+		document, err := yamlReader.Read()
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot document: %w", err)
+		}
+
+		if len(bytes.TrimSpace(document)) == 0 {
+			continue
+		}
+
+		object, _, err := decoder.Decode(document, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot document %d: %w", len(objects)+1, err)
+		}
+
+		objects = append(objects, object)
+	}
+}
+
+// Load reads the snapshot files at paths and returns their objects.
+func Load(paths ...string) ([]runtime.Object, error) {
+	var objects []runtime.Object
+
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open snapshot %s: %w", path, err)
+		}
+
+		fileObjects, err := Read(file)
+		_ = file.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to load snapshot %s: %w", path, err)
+		}
+
+		objects = append(objects, fileObjects...)
+	}
+
+	return objects, nil
+}
+
+// NewTestClient returns an eco-goinfra fake client, as created by clients.GetTestClients, containing the objects of the
+// snapshot files at paths. The schemes of every resource in Resources are attached so their builders work against it.
+func NewTestClient(paths ...string) (*clients.Settings, error) {
+	objects, err := Load(paths...)
+	if err != nil {
+		return nil, err
+	}
+
+	var schemeAttachers []clients.SchemeAttacher
+	for _, resource := range Resources {
+		schemeAttachers = append(schemeAttachers, resource.AddToScheme)
+	}
+
+	apiClient := clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects:  objects,
+		SchemeAttachers: schemeAttachers,
+	})
+	if apiClient == nil {
+		return nil, fmt.Errorf("failed to create test client for snapshots %v", paths)
+	}
+
+	return apiClient, nil
+}
+
+// newScheme returns a scheme with the types of every resource in Resources.
+func newScheme() (*runtime.Scheme, error) {
+	scheme := runtime.NewScheme()
+
+	for _, resource := range Resources {
+		err := resource.AddToScheme(scheme)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add scheme for %s: %w", resource.Name, err)
+		}
+	}
+
+	return scheme, nil
+}
+
+// sanitize removes the fields of object that change on every capture or are irrelevant to helpers, so snapshots stay
+// small and diff cleanly when recaptured.
+func sanitize(object *unstructured.Unstructured) {
+	object.SetManagedFields(nil)
+	object.SetResourceVersion("")
+
+	annotations := object.GetAnnotations()
+	delete(annotations, "kubectl.kubernetes.io/last-applied-configuration")
+
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+
+	object.SetAnnotations(annotations)
+}
+
+// objectKey returns the namespace and name of object in the form namespace/name, or just the name for cluster-scoped
+// objects.
+func objectKey(object *unstructured.Unstructured) string {
+	if object.GetNamespace() == "" {
+		return object.GetName()
+	}
+
+	return object.GetNamespace() + "/" + object.GetName()
+}
@@ -0,0 +1,112 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/ptp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestLookupResources(t *testing.T) {
+	resources, err := LookupResources()
+	assert.NoError(t, err)
+	assert.Len(t, resources, len(Resources))
+
+	resources, err = LookupResources("ptpconfigs", "nodes")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"nodes", "ptpconfigs"}, []string{resources[0].Name, resources[1].Name})
+
+	_, err = LookupResources("nodes", "ptpconfig")
+	assert.ErrorContains(t, err, `unknown resource "ptpconfig"`)
+}
+
+func TestWriteAndRead(t *testing.T) {
+	node := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Node",
+		"metadata": map[string]any{
+			"name":            "worker-0",
+			"resourceVersion": "12345",
+			"labels":          map[string]any{"ptp/slave": ""},
+			"annotations": map[string]any{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+			"managedFields": []any{map[string]any{"manager": "kubelet"}},
+		},
+	}}
+	sanitize(node)
+
+	buffer := &bytes.Buffer{}
+	err := Write(buffer, []*unstructured.Unstructured{node, ptpConfig("slave")})
+	require.NoError(t, err)
+
+	assert.NotContains(t, buffer.String(), "managedFields")
+	assert.NotContains(t, buffer.String(), "resourceVersion")
+	assert.NotContains(t, buffer.String(), "last-applied-configuration")
+
+	objects, err := Read(buffer)
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+
+	typedNode, ok := objects[0].(*corev1.Node)
+	require.True(t, ok, "expected *corev1.Node, got %T", objects[0])
+	assert.Equal(t, "worker-0", typedNode.Name)
+	assert.Equal(t, map[string]string{"ptp/slave": ""}, typedNode.Labels)
+}
+
+func TestReadUnknownKind(t *testing.T) {
+	_, err := Read(bytes.NewBufferString("apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: a\n"))
+	assert.ErrorContains(t, err, "failed to decode snapshot document 1")
+}
+
+func TestNewTestClientAndCapture(t *testing.T) {
+	node := &unstructured.Unstructured{}
+	node.SetAPIVersion("v1")
+	node.SetKind("Node")
+	node.SetName("worker-0")
+
+	path := filepath.Join(t.TempDir(), "snapshot.yaml")
+	err := WriteFile(path, []*unstructured.Unstructured{ptpConfig("slave"), node})
+	require.NoError(t, err)
+
+	client, err := NewTestClient(path)
+	require.NoError(t, err)
+
+	nodeList, err := nodes.List(client, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, nodeList, 1)
+	assert.Equal(t, "worker-0", nodeList[0].Definition.Name)
+
+	ptpConfigs, err := ptp.ListPtpConfigs(client)
+	require.NoError(t, err)
+	require.Len(t, ptpConfigs, 1)
+	assert.Equal(t, "slave", ptpConfigs[0].Definition.Name)
+
+	resources, err := LookupResources("nodes", "ptpconfigs")
+	require.NoError(t, err)
+
+	captured, err := Capture(context.TODO(), client, resources)
+	require.NoError(t, err)
+	require.Len(t, captured, 2)
+	assert.Equal(t, []string{"Node", "PtpConfig"}, []string{captured[0].GetKind(), captured[1].GetKind()})
+	assert.Empty(t, captured[1].GetResourceVersion())
+}
+
+func ptpConfig(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "ptp.openshift.io/v1",
+		"kind":       "PtpConfig",
+		"metadata":   map[string]any{"name": name, "namespace": "openshift-ptp"},
+		"spec": map[string]any{
+			"profile": []any{map[string]any{"name": name, "interface": "ens1f0", "ptp4lOpts": "-2 -s"}},
+		},
+	}}
+}
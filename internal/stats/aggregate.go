@@ -0,0 +1,106 @@
+package stats
+
+import "math"
+
+// Aggregate tracks the count, minimum, maximum, mean, and variance of values as they are added, without storing them.
+// The zero value is an empty aggregate ready for use. Aggregates computed separately, such as per node, can be
+// combined using Merge.
+type Aggregate struct {
+	count int
+	mean  float64
+	// m2 is the sum of squared differences from the mean, as in Welford's algorithm.
+	m2  float64
+	min float64
+	max float64
+}
+
+// Add adds values to the aggregate.
+func (aggregate *Aggregate) Add(values ...float64) {
+	for _, value := range values {
+		if aggregate.count == 0 {
+			aggregate.min = value
+			aggregate.max = value
+		} else {
+			aggregate.min = min(aggregate.min, value)
+			aggregate.max = max(aggregate.max, value)
+		}
+
+		aggregate.count++
+
+		delta := value - aggregate.mean
+		aggregate.mean += delta / float64(aggregate.count)
+		aggregate.m2 += delta * (value - aggregate.mean)
+	}
+}
+
+// Merge adds every value of other to the aggregate, as though they had been added directly.
+func (aggregate *Aggregate) Merge(other Aggregate) {
+	switch {
+	case other.count == 0:
+		return
+	case aggregate.count == 0:
+		*aggregate = other
+
+		return
+	}
+
+	count := aggregate.count + other.count
+	delta := other.mean - aggregate.mean
+
+	aggregate.m2 += other.m2 + delta*delta*float64(aggregate.count)*float64(other.count)/float64(count)
+	aggregate.mean += delta * float64(other.count) / float64(count)
+	aggregate.min = min(aggregate.min, other.min)
+	aggregate.max = max(aggregate.max, other.max)
+	aggregate.count = count
+}
+
+// Count returns the number of values added.
+func (aggregate Aggregate) Count() int {
+	return aggregate.count
+}
+
+// Min returns the smallest value added, or NaN if there are none.
+func (aggregate Aggregate) Min() float64 {
+	if aggregate.count == 0 {
+		return math.NaN()
+	}
+
+	return aggregate.min
+}
+
+// Max returns the largest value added, or NaN if there are none.
+func (aggregate Aggregate) Max() float64 {
+	if aggregate.count == 0 {
+		return math.NaN()
+	}
+
+	return aggregate.max
+}
+
+// Mean returns the arithmetic mean of the values added, or NaN if there are none.
+func (aggregate Aggregate) Mean() float64 {
+	if aggregate.count == 0 {
+		return math.NaN()
+	}
+
+	return aggregate.mean
+}
+
+// Sum returns the sum of the values added.
+func (aggregate Aggregate) Sum() float64 {
+	return aggregate.mean * float64(aggregate.count)
+}
+
+// Variance returns the population variance of the values added, or NaN if there are none.
+func (aggregate Aggregate) Variance() float64 {
+	if aggregate.count == 0 {
+		return math.NaN()
+	}
+
+	return aggregate.m2 / float64(aggregate.count)
+}
+
+// StdDev returns the population standard deviation of the values added, or NaN if there are none.
+func (aggregate Aggregate) StdDev() float64 {
+	return math.Sqrt(aggregate.Variance())
+}
@@ -0,0 +1,278 @@
+package stats
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+)
+
+// Bucket is a bucket of a Histogram containing the values greater than the upper bound of the previous bucket and less
+// than or equal to UpperBound. The last bucket has an UpperBound of +Inf.
+type Bucket struct {
+	UpperBound float64
+	Count      uint64
+}
+
+// Histogram counts values in buckets with fixed bounds, in addition to tracking their Aggregate. Percentiles are
+// estimated by interpolating within buckets, so their accuracy depends on the bucket bounds.
+type Histogram struct {
+	upperBounds []float64
+	// counts has one more element than upperBounds for values above the last bound.
+	counts    []uint64
+	aggregate Aggregate
+}
+
+// NewHistogram creates a new Histogram with buckets ending at each of the upperBounds, which must be in strictly
+// increasing order, plus a bucket for values above the last bound.
+func NewHistogram(upperBounds ...float64) (*Histogram, error) {
+	if len(upperBounds) == 0 {
+		return nil, fmt.Errorf("histogram must have at least 1 bucket bound")
+	}
+
+	for index := 1; index < len(upperBounds); index++ {
+		if upperBounds[index] <= upperBounds[index-1] {
+			return nil, fmt.Errorf("histogram bucket bounds must be strictly increasing, got %v", upperBounds)
+		}
+	}
+
+	return &Histogram{
+		upperBounds: slices.Clone(upperBounds),
+		counts:      make([]uint64, len(upperBounds)+1),
+	}, nil
+}
+
+// LinearBuckets returns count bucket bounds starting at start and each width apart.
+func LinearBuckets(start, width float64, count int) []float64 {
+	bounds := make([]float64, count)
+	for index := range bounds {
+		bounds[index] = start + width*float64(index)
+	}
+
+	return bounds
+}
+
+// ExponentialBuckets returns count bucket bounds starting at start and each factor times the previous.
+func ExponentialBuckets(start, factor float64, count int) []float64 {
+	bounds := make([]float64, count)
+	for index := range bounds {
+		bounds[index] = start * math.Pow(factor, float64(index))
+	}
+
+	return bounds
+}
+
+// Add adds values to the histogram.
+func (histogram *Histogram) Add(values ...float64) {
+	for _, value := range values {
+		index, _ := slices.BinarySearch(histogram.upperBounds, value)
+		histogram.counts[index]++
+		histogram.aggregate.Add(value)
+	}
+}
+
+// Merge adds every value of other to the histogram. Both histograms must have the same bucket bounds.
+func (histogram *Histogram) Merge(other *Histogram) error {
+	if !slices.Equal(histogram.upperBounds, other.upperBounds) {
+		return fmt.Errorf("cannot merge histograms with different bucket bounds")
+	}
+
+	for index, count := range other.counts {
+		histogram.counts[index] += count
+	}
+
+	histogram.aggregate.Merge(other.aggregate)
+
+	return nil
+}
+
+// Buckets returns the buckets of the histogram in increasing order.
+func (histogram *Histogram) Buckets() []Bucket {
+	buckets := make([]Bucket, len(histogram.counts))
+	for index, count := range histogram.counts {
+		buckets[index] = Bucket{UpperBound: math.Inf(1), Count: count}
+
+		if index < len(histogram.upperBounds) {
+			buckets[index].UpperBound = histogram.upperBounds[index]
+		}
+	}
+
+	return buckets
+}
+
+// Aggregate returns the Aggregate of every value added to the histogram.
+func (histogram *Histogram) Aggregate() Aggregate {
+	return histogram.aggregate
+}
+
+// Percentile estimates the pth percentile of the values added, where p is between 0 and 100. Values are assumed to be
+// evenly spread within each bucket, and buckets at the ends are bounded by the minimum and maximum values.
+func (histogram *Histogram) Percentile(p float64) (float64, error) {
+	if err := validatePercentile(p, histogram.aggregate.Count()); err != nil {
+		return math.NaN(), err
+	}
+
+	rank := p / 100 * float64(histogram.aggregate.Count())
+	cumulative := uint64(0)
+
+	for index, count := range histogram.counts {
+		if count == 0 || float64(cumulative+count) < rank {
+			cumulative += count
+
+			continue
+		}
+
+		lower := histogram.aggregate.Min()
+		if index > 0 {
+			lower = max(lower, histogram.upperBounds[index-1])
+		}
+
+		upper := histogram.aggregate.Max()
+		if index < len(histogram.upperBounds) {
+			upper = min(upper, histogram.upperBounds[index])
+		}
+
+		return lower + (upper-lower)*(rank-float64(cumulative))/float64(count), nil
+	}
+
+	return histogram.aggregate.Max(), nil
+}
+
+// DefaultRelativeAccuracy is the relative accuracy of percentiles estimated by a LogHistogram created with
+// NewLogHistogram(DefaultRelativeAccuracy), which is 1%.
+const DefaultRelativeAccuracy = 0.01
+
+// LogHistogram counts values in buckets whose width grows with their magnitude, in the style of HDR histograms, in
+// addition to tracking their Aggregate. Unlike Histogram, it needs no bounds up front and estimates every percentile to
+// within a fixed relative accuracy, making it suited to long tails such as p99.9 latencies and offsets.
+type LogHistogram struct {
+	relativeAccuracy float64
+	// logGamma is the log of the ratio between the bounds of consecutive buckets.
+	logGamma float64
+	// positive and negative map bucket indexes to the number of values in them. Negative values are bucketed by
+	// their absolute value.
+	positive  map[int]uint64
+	negative  map[int]uint64
+	zero      uint64
+	aggregate Aggregate
+}
+
+// NewLogHistogram creates a new LogHistogram whose estimated percentiles are within relativeAccuracy of a value added,
+// which must be between 0 and 1 exclusive.
+func NewLogHistogram(relativeAccuracy float64) (*LogHistogram, error) {
+	if relativeAccuracy <= 0 || relativeAccuracy >= 1 {
+		return nil, fmt.Errorf("relative accuracy must be between 0 and 1, got %v", relativeAccuracy)
+	}
+
+	return &LogHistogram{
+		relativeAccuracy: relativeAccuracy,
+		logGamma:         math.Log((1 + relativeAccuracy) / (1 - relativeAccuracy)),
+		positive:         make(map[int]uint64),
+		negative:         make(map[int]uint64),
+	}, nil
+}
+
+// Add adds values to the histogram.
+func (histogram *LogHistogram) Add(values ...float64) {
+	for _, value := range values {
+		switch {
+		case value > 0:
+			histogram.positive[histogram.index(value)]++
+		case value < 0:
+			histogram.negative[histogram.index(-value)]++
+		default:
+			histogram.zero++
+		}
+
+		histogram.aggregate.Add(value)
+	}
+}
+
+// Merge adds every value of other to the histogram. Both histograms must have the same relative accuracy.
+func (histogram *LogHistogram) Merge(other *LogHistogram) error {
+	if histogram.relativeAccuracy != other.relativeAccuracy {
+		return fmt.Errorf("cannot merge log histograms with relative accuracies %v and %v",
+			histogram.relativeAccuracy, other.relativeAccuracy)
+	}
+
+	for index, count := range other.positive {
+		histogram.positive[index] += count
+	}
+
+	for index, count := range other.negative {
+		histogram.negative[index] += count
+	}
+
+	histogram.zero += other.zero
+	histogram.aggregate.Merge(other.aggregate)
+
+	return nil
+}
+
+// Aggregate returns the Aggregate of every value added to the histogram.
+func (histogram *LogHistogram) Aggregate() Aggregate {
+	return histogram.aggregate
+}
+
+// Percentile estimates the pth percentile of the values added, where p is between 0 and 100. The estimate is the
+// nearest-rank value to within the relative accuracy of the histogram.
+func (histogram *LogHistogram) Percentile(p float64) (float64, error) {
+	if err := validatePercentile(p, histogram.aggregate.Count()); err != nil {
+		return math.NaN(), err
+	}
+
+	rank := uint64(math.Ceil(p / 100 * float64(histogram.aggregate.Count())))
+	rank = max(rank, 1)
+	cumulative := uint64(0)
+
+	// Negative values are visited from the largest magnitude, which is the smallest value.
+	for _, index := range slices.Backward(slices.Sorted(maps.Keys(histogram.negative))) {
+		cumulative += histogram.negative[index]
+		if cumulative >= rank {
+			return histogram.clamp(-histogram.value(index)), nil
+		}
+	}
+
+	cumulative += histogram.zero
+	if cumulative >= rank {
+		return 0, nil
+	}
+
+	for _, index := range slices.Sorted(maps.Keys(histogram.positive)) {
+		cumulative += histogram.positive[index]
+		if cumulative >= rank {
+			return histogram.clamp(histogram.value(index)), nil
+		}
+	}
+
+	return histogram.aggregate.Max(), nil
+}
+
+// index returns the index of the bucket containing the positive value.
+func (histogram *LogHistogram) index(value float64) int {
+	return int(math.Ceil(math.Log(value) / histogram.logGamma))
+}
+
+// value returns the value representing the bucket with index, which is within the relative accuracy of every value in
+// the bucket.
+func (histogram *LogHistogram) value(index int) float64 {
+	return math.Exp(float64(index)*histogram.logGamma) * (1 - histogram.relativeAccuracy)
+}
+
+// clamp limits value to the range of values added, since bucket values may lie slightly outside it.
+func (histogram *LogHistogram) clamp(value float64) float64 {
+	return min(max(value, histogram.aggregate.Min()), histogram.aggregate.Max())
+}
+
+// validatePercentile returns an error if p is not a valid percentile or there are no values to compute it from.
+func validatePercentile(p float64, count int) error {
+	if count < 1 {
+		return fmt.Errorf("histogram must have at least 1 value")
+	}
+
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return fmt.Errorf("percentile must be between 0 and 100, got %v", p)
+	}
+
+	return nil
+}
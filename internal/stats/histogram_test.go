@@ -0,0 +1,139 @@
+package stats
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateMerge(t *testing.T) {
+	input := []float64{3, -1, 4, 1, 5, 9, 2, 6}
+
+	var whole, first, second Aggregate
+
+	whole.Add(input...)
+	first.Add(input[:3]...)
+	second.Add(input[3:]...)
+	first.Merge(second)
+
+	expectedStdDev, _ := StdDev(input)
+
+	for _, aggregate := range []Aggregate{whole, first} {
+		assert.Equal(t, len(input), aggregate.Count())
+		assert.InDelta(t, -1, aggregate.Min(), epsilon)
+		assert.InDelta(t, 9, aggregate.Max(), epsilon)
+		assert.InDelta(t, 3.625, aggregate.Mean(), epsilon)
+		assert.InDelta(t, 29, aggregate.Sum(), epsilon)
+		assert.InDelta(t, expectedStdDev, aggregate.StdDev(), epsilon)
+	}
+
+	var empty Aggregate
+
+	assert.True(t, math.IsNaN(empty.Mean()))
+	empty.Merge(whole)
+	assert.Equal(t, whole, empty)
+}
+
+func TestHistogram(t *testing.T) {
+	_, err := NewHistogram(10, 5)
+	assert.EqualError(t, err, "histogram bucket bounds must be strictly increasing, got [10 5]")
+
+	histogram, err := NewHistogram(LinearBuckets(10, 10, 10)...)
+	assert.NoError(t, err)
+
+	for value := 1; value <= 100; value++ {
+		histogram.Add(float64(value))
+	}
+
+	histogram.Add(250)
+
+	buckets := histogram.Buckets()
+	assert.Len(t, buckets, 11)
+	assert.Equal(t, Bucket{UpperBound: 10, Count: 10}, buckets[0])
+	assert.Equal(t, Bucket{UpperBound: math.Inf(1), Count: 1}, buckets[10])
+
+	p50, err := histogram.Percentile(50)
+	assert.NoError(t, err)
+	assert.InDelta(t, 50.5, p50, 1)
+
+	p100, err := histogram.Percentile(100)
+	assert.NoError(t, err)
+	assert.InDelta(t, 250, p100, epsilon)
+
+	other, _ := NewHistogram(LinearBuckets(10, 10, 10)...)
+	other.Add(5)
+	assert.NoError(t, histogram.Merge(other))
+	assert.Equal(t, uint64(11), histogram.Buckets()[0].Count)
+
+	mismatched, _ := NewHistogram(ExponentialBuckets(1, 2, 4)...)
+	assert.Error(t, histogram.Merge(mismatched))
+}
+
+func TestLogHistogram(t *testing.T) {
+	_, err := NewLogHistogram(0)
+	assert.Error(t, err)
+
+	random := rand.New(rand.NewPCG(1, 2))
+	values := make([]float64, 10000)
+
+	first, _ := NewLogHistogram(DefaultRelativeAccuracy)
+	second, _ := NewLogHistogram(DefaultRelativeAccuracy)
+
+	for index := range values {
+		// Exponentially distributed offsets with a mix of signs and an occasional zero give a long tail.
+		values[index] = math.Round(random.ExpFloat64() * 50)
+		if index%3 == 0 {
+			values[index] = -values[index]
+		}
+
+		if index%2 == 0 {
+			first.Add(values[index])
+		} else {
+			second.Add(values[index])
+		}
+	}
+
+	assert.NoError(t, first.Merge(second))
+	assert.Equal(t, len(values), first.Aggregate().Count())
+
+	for _, percentile := range []float64{0, 1, 50, 95, 99, 99.9, 100} {
+		exact := nearestRank(values, percentile)
+		estimate, err := first.Percentile(percentile)
+
+		assert.NoError(t, err)
+		assert.InDelta(t, exact, estimate, math.Abs(exact)*DefaultRelativeAccuracy+epsilon, "p%v", percentile)
+	}
+
+	coarse, _ := NewLogHistogram(0.05)
+	assert.Error(t, first.Merge(coarse))
+
+	empty, _ := NewLogHistogram(DefaultRelativeAccuracy)
+	_, err = empty.Percentile(50)
+	assert.EqualError(t, err, "histogram must have at least 1 value")
+}
+
+func TestCheckThresholds(t *testing.T) {
+	values := Values{10, 20, 30, 40, 1000}
+
+	assert.NoError(t, CheckThresholds(values, Threshold{Percentile: 50, Max: 30}))
+
+	err := CheckThresholds(values,
+		Threshold{Percentile: 50, Max: 25},
+		Threshold{Percentile: 99.9, Max: 100},
+		Threshold{Percentile: 200, Max: 1})
+	assert.ErrorContains(t, err, "p50 of 30 exceeds threshold of 25")
+	assert.ErrorContains(t, err, "p99.9 of ")
+	assert.ErrorContains(t, err, "failed to compute p200: percentile must be between 0 and 100, got 200")
+	assert.Equal(t, "p99.9<=100", Threshold{Percentile: 99.9, Max: 100}.String())
+}
+
+// nearestRank returns the smallest value that at least p percent of values are less than or equal to.
+func nearestRank(values []float64, p float64) float64 {
+	sorted := slices.Sorted(slices.Values(values))
+	rank := max(int(math.Ceil(p/100*float64(len(sorted)))), 1)
+
+	return sorted[rank-1]
+}
@@ -0,0 +1,126 @@
+// Package stats computes descriptive statistics of measurements such as PTP offsets, latencies, and power usage. The
+// functions operating on arrays are exact, while Aggregate, Histogram, and LogHistogram aggregate values as they are
+// observed, using bounded memory, and can be merged with partial aggregates from other sources.
+package stats
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Mean computes the arithmetic mean of the input array.
+func Mean(input []float64) (float64, error) {
+	if len(input) < 1 {
+		return math.NaN(), fmt.Errorf("input array must have at least 1 element")
+	}
+
+	sum := 0.0
+	for _, x := range input {
+		sum += x
+	}
+
+	return sum / float64(len(input)), nil
+}
+
+// StdDev computes the population standard deviation of the input array.
+func StdDev(input []float64) (float64, error) {
+	if len(input) < 1 {
+		return math.NaN(), fmt.Errorf("input array must have at least 1 element")
+	}
+
+	mean, _ := Mean(input)
+
+	sum := 0.0
+	for _, x := range input {
+		sum += (x - mean) * (x - mean)
+	}
+
+	return math.Sqrt(sum / float64(len(input))), nil
+}
+
+// Median computes the median value of the input array.
+func Median(input []float64) (float64, error) {
+	if len(input) < 1 {
+		return math.NaN(), fmt.Errorf("input array must have at least 1 element")
+	}
+
+	numElements := len(input)
+
+	// sort a copy of the input array
+	inputCopy := make([]float64, numElements)
+	copy(inputCopy, input)
+
+	slices.Sort(inputCopy)
+
+	if numElements%2 == 1 {
+		return inputCopy[numElements/2], nil
+	}
+
+	return (inputCopy[numElements/2] + inputCopy[numElements/2-1]) / 2, nil
+}
+
+// Percentile computes the pth percentile of the input array, where p is between 0 and 100, interpolating linearly
+// between the closest ranks. For example, a p of 99.9 returns the value 99.9% of the input is less than or equal to.
+func Percentile(input []float64, p float64) (float64, error) {
+	if len(input) < 1 {
+		return math.NaN(), fmt.Errorf("input array must have at least 1 element")
+	}
+
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return math.NaN(), fmt.Errorf("percentile must be between 0 and 100, got %v", p)
+	}
+
+	inputCopy := slices.Clone(input)
+	slices.Sort(inputCopy)
+
+	return sortedPercentile(inputCopy, p), nil
+}
+
+// Summary contains descriptive statistics of a set of values. StdDev is the population standard deviation.
+type Summary struct {
+	Count  int
+	Min    float64
+	Max    float64
+	Mean   float64
+	StdDev float64
+	P50    float64
+	P95    float64
+	P99    float64
+	P999   float64
+}
+
+// Summarize computes the Summary of the input array.
+func Summarize(input []float64) (Summary, error) {
+	if len(input) < 1 {
+		return Summary{}, fmt.Errorf("input array must have at least 1 element")
+	}
+
+	var aggregate Aggregate
+
+	aggregate.Add(input...)
+
+	sorted := slices.Clone(input)
+	slices.Sort(sorted)
+
+	return Summary{
+		Count:  aggregate.Count(),
+		Min:    aggregate.Min(),
+		Max:    aggregate.Max(),
+		Mean:   aggregate.Mean(),
+		StdDev: aggregate.StdDev(),
+		P50:    sortedPercentile(sorted, 50),
+		P95:    sortedPercentile(sorted, 95),
+		P99:    sortedPercentile(sorted, 99),
+		P999:   sortedPercentile(sorted, 99.9),
+	}, nil
+}
+
+// sortedPercentile returns the pth percentile of a sorted, non-empty array.
+func sortedPercentile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
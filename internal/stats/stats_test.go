@@ -93,3 +93,74 @@ func TestMedian(t *testing.T) {
 		}
 	}
 }
+
+func TestPercentile(t *testing.T) {
+	testCases := []struct {
+		input          []float64
+		percentile     float64
+		expectedOutput float64
+		expectedError  error
+	}{
+		{
+			input:          []float64{5, 1, 4, 2, 3},
+			percentile:     50,
+			expectedOutput: 3,
+			expectedError:  nil,
+		},
+		{
+			input:          []float64{1, 2, 3, 4},
+			percentile:     50,
+			expectedOutput: 2.5,
+			expectedError:  nil,
+		},
+		{
+			input:          []float64{1, 2, 3, 4, 5},
+			percentile:     100,
+			expectedOutput: 5,
+			expectedError:  nil,
+		},
+		{
+			input:          []float64{1, 2, 3, 4, 5},
+			percentile:     101,
+			expectedOutput: math.NaN(),
+			expectedError:  fmt.Errorf("percentile must be between 0 and 100, got 101"),
+		},
+		{
+			input:          []float64{},
+			percentile:     50,
+			expectedOutput: math.NaN(),
+			expectedError:  fmt.Errorf("input array must have at least 1 element"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		output, err := Percentile(testCase.input, testCase.percentile)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.InDelta(t, testCase.expectedOutput, output, epsilon)
+		}
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	input := make([]float64, 1000)
+	for index := range input {
+		input[index] = float64(index + 1)
+	}
+
+	summary, err := Summarize(input)
+	assert.NoError(t, err)
+	assert.Equal(t, 1000, summary.Count)
+	assert.InDelta(t, 1, summary.Min, epsilon)
+	assert.InDelta(t, 1000, summary.Max, epsilon)
+	assert.InDelta(t, 500.5, summary.Mean, epsilon)
+	assert.InDelta(t, 500.5, summary.P50, epsilon)
+	assert.InDelta(t, 999.001, summary.P999, epsilon)
+
+	stdDev, _ := StdDev(input)
+	assert.InDelta(t, stdDev, summary.StdDev, epsilon)
+
+	_, err = Summarize(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,61 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// PercentileSource is a source of percentiles, such as Values, Histogram, or LogHistogram.
+type PercentileSource interface {
+	Percentile(p float64) (float64, error)
+}
+
+// Values is an array of values that computes exact percentiles, for use as a PercentileSource.
+type Values []float64
+
+// Percentile computes the pth percentile of the values using the Percentile function.
+func (values Values) Percentile(p float64) (float64, error) {
+	return Percentile(values, p)
+}
+
+// Threshold is an upper limit on a percentile of values, such as the p99.9 of absolute PTP offsets being at most
+// 100 ns.
+type Threshold struct {
+	// Percentile is the percentile to check, between 0 and 100.
+	Percentile float64
+	// Max is the largest value the percentile may have.
+	Max float64
+}
+
+// String returns the threshold in the form p99.9<=100.
+func (threshold Threshold) String() string {
+	return fmt.Sprintf("%s<=%v", formatPercentile(threshold.Percentile), threshold.Max)
+}
+
+// CheckThresholds computes each percentile of the thresholds from source and returns an error describing every
+// threshold that was exceeded, or nil if none were.
+func CheckThresholds(source PercentileSource, thresholds ...Threshold) error {
+	var errs []error
+
+	for _, threshold := range thresholds {
+		value, err := source.Percentile(threshold.Percentile)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to compute %s: %w", formatPercentile(threshold.Percentile), err))
+
+			continue
+		}
+
+		if value > threshold.Max {
+			errs = append(errs, fmt.Errorf("%s of %v exceeds threshold of %v",
+				formatPercentile(threshold.Percentile), value, threshold.Max))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// formatPercentile returns the name of percentile p, such as p99.9.
+func formatPercentile(p float64) string {
+	return "p" + strconv.FormatFloat(p, 'f', -1, 64)
+}
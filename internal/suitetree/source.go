@@ -0,0 +1,9 @@
+package suitetree
+
+import "embed"
+
+// SourceCode contains the source of this package. Programs that cache encoded SuiteTrees, such as the report tool,
+// should include it in the sum used to validate their cache since a change here may change the encoding.
+//
+//go:embed *.go
+var SourceCode embed.FS
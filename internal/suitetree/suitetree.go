@@ -1,4 +1,6 @@
-package main
+// Package suitetree builds trees of Ginkgo suites and specs from Ginkgo JSON reports, such as those generated by a dry
+// run. It is shared by the report tool and the test runner so both resolve suites the same way.
+package suitetree
 
 import (
 	"cmp"
@@ -14,6 +16,11 @@ import (
 	"k8s.io/klog/v2"
 )
 
+const (
+	// SpecIDLabelPrefix is the prefix of the label added to specs by reportxml.ID. The rest of the label is the ID.
+	SpecIDLabelPrefix = "test_id:"
+)
+
 // SuiteTree represents a tree of test suites. Suites are indentified by their path in in file system.
 type SuiteTree struct {
 	// Path is the absolute path to the test suite.
@@ -174,6 +181,77 @@ func (tree *SuiteTree) TrimRoot() *SuiteTree {
 	return tree
 }
 
+// WalkSpecs calls visit for every leaf node in the tree that has a SpecReport. The suite passed to visit is the parent
+// of the leaf node, which is the suite the spec belongs to. Nodes are visited in the order of their children.
+func (tree *SuiteTree) WalkSpecs(visit func(suite *SuiteTree, spec *types.SpecReport)) {
+	for _, child := range tree.Children {
+		if child.SpecReport != nil {
+			visit(tree, child.SpecReport)
+
+			continue
+		}
+
+		child.WalkSpecs(visit)
+	}
+}
+
+// Find returns the node in the tree with the provided path or nil if there is no such node. The path must start with
+// the path of the tree.
+func (tree *SuiteTree) Find(nodePath string) *SuiteTree {
+	klog.V(100).Infof("Finding node with path %s in tree with path %s", nodePath, tree.Path)
+
+	nodePath = path.Clean(nodePath)
+
+	relPath, found := strings.CutPrefix(nodePath, tree.Path)
+	if !found || (relPath != "" && tree.Path != "/" && !strings.HasPrefix(relPath, "/")) {
+		return nil
+	}
+
+	if relPath == "" {
+		return tree
+	}
+
+	elems := strings.Split(strings.TrimPrefix(relPath, "/"), "/")
+
+	// Insert splits the whole suite path, so a tree rooted at / has a child with an empty name for the leading slash.
+	if tree.Path == "/" {
+		elems = strings.Split(nodePath, "/")
+	}
+
+	currNode := tree
+
+	for _, elem := range elems {
+		currNode = currNode.findChild(elem)
+		if currNode == nil {
+			return nil
+		}
+	}
+
+	return currNode
+}
+
+// Suites returns every suite in the tree. A suite is a node that is not a spec and whose children, if it has any, are
+// specs. Suites are returned in the order of their parents' children.
+func (tree *SuiteTree) Suites() []*SuiteTree {
+	if tree.SpecReport != nil {
+		return nil
+	}
+
+	if len(tree.Children) == 0 || slices.ContainsFunc(tree.Children, func(child *SuiteTree) bool {
+		return child.SpecReport != nil
+	}) {
+		return []*SuiteTree{tree}
+	}
+
+	var suites []*SuiteTree
+
+	for _, child := range tree.Children {
+		suites = append(suites, child.Suites()...)
+	}
+
+	return suites
+}
+
 // String returns a string representation of the tree. It contains one line per node and is indented with a dot and two
 // spaces per level.
 func (tree *SuiteTree) String() string {
@@ -200,6 +278,36 @@ func (tree *SuiteTree) stringLevel(builder *strings.Builder, level uint) {
 	}
 }
 
+// GetSpecID returns the reportxml.ID of the provided spec or an empty string if it does not have one. The ID is taken
+// from the test_id label that reportxml.ID adds alongside the bare ID label.
+func GetSpecID(spec *types.SpecReport) string {
+	if spec == nil {
+		return ""
+	}
+
+	for _, label := range spec.Labels() {
+		if id, found := strings.CutPrefix(label, SpecIDLabelPrefix); found {
+			return id
+		}
+	}
+
+	return ""
+}
+
+// GetSpecKey returns a key that identifies the provided spec across runs and branches. It is the reportxml.ID if the
+// spec has one, otherwise it is the full text of the spec.
+func GetSpecKey(spec *types.SpecReport) string {
+	if id := GetSpecID(spec); id != "" {
+		return id
+	}
+
+	if spec == nil {
+		return ""
+	}
+
+	return spec.FullText()
+}
+
 // findChild returns the child with the given name or nil if no child with that name exists. It only searches direct
 // children of the tree.
 func (tree *SuiteTree) findChild(name string) *SuiteTree {
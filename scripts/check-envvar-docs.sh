@@ -17,7 +17,8 @@ declare -A GLOBAL_VARS
 while IFS= read -r v; do
     [[ -n "$v" ]] && GLOBAL_VARS["$v"]=1
 done < <({
-    grep -ohP 'ECO_[A-Z0-9_]+' "$REPO_ROOT/scripts/test-runner.sh" 2>/dev/null || true
+    grep -ohP 'os\.Getenv\("ECO_[A-Z0-9_]+"\)' "$REPO_ROOT/internal/runner/env.go" 2>/dev/null \
+        | sed 's/os\.Getenv("//;s/")//' || true
     grep -ohP 'envconfig:"ECO_[A-Z0-9_]+"' "$TESTS_DIR/internal/config/config.go" 2>/dev/null \
         | sed 's/envconfig:"//;s/"//' || true
 } | sort -u)
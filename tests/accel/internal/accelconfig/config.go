@@ -1,22 +1,19 @@
 package accelconfig
 
 import (
+	_ "embed"
 	"log"
-	"os"
-	"path/filepath"
-	"runtime"
 
-	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
-	"gopkg.in/yaml.v2"
 	"k8s.io/klog/v2"
 )
 
-const (
-	// PathToDefaultAccelParamsFile path to config file with default accel tests parameters.
-	PathToDefaultAccelParamsFile = "./default.yaml"
-)
+// defaultAccelParams is the config file with default accel tests parameters. It is embedded so the compiled suite does
+// not depend on the location of the source tree.
+//
+//go:embed default.yaml
+var defaultAccelParams []byte
 
 // AccelConfig contains environment information related to ocp upgrade tests.
 type AccelConfig struct {
@@ -26,7 +23,7 @@ type AccelConfig struct {
 	SpokeKubeConfig      string `envconfig:"ECO_ACCEL_SPOKE_KUBECONFIG"`
 	HubClusterName       string `envconfig:"ECO_ACCEL_HUB_CLUSTER_NAME"`
 	HubMinorVersion      string `envconfig:"ECO_ACCEL_HUB_MINOR_VERSION"`
-	IBUWorkloadImage     string `yaml:"ibu_workload_image" envconfig:"ECO_ACCEL_WORKLOAD_IMAGE"`
+	IBUWorkloadImage     string `yaml:"ibu_workload_image" envconfig:"ECO_ACCEL_WORKLOAD_IMAGE" validate:"required"`
 	SpokeAPIClient       *clients.Settings
 	*config.GeneralConfig
 }
@@ -39,18 +36,8 @@ func NewAccelConfig() *AccelConfig {
 
 	accelConfig.GeneralConfig = config.NewConfig()
 
-	_, filename, _, _ := runtime.Caller(0)
-	baseDir := filepath.Dir(filename)
-	configFile := filepath.Join(baseDir, PathToDefaultAccelParamsFile)
-
-	err := readFile(&accelConfig, configFile)
-	if err != nil {
-		klog.V(90).Infof("Error reading config file %s", configFile)
-
-		return nil
-	}
-
-	err = envconfig.Process("eco_accel_", &accelConfig)
+	err := config.NewLoader().WithDefaultYAML(defaultAccelParams).WithEnvconfigPrefix("eco_accel_").
+		WithEnvPrefix("ECO_ACCEL_").Load(&accelConfig)
 	if err != nil {
 		log.Printf("failed to instantiate AccelConfig: %v", err)
 
@@ -70,20 +57,3 @@ func NewAccelConfig() *AccelConfig {
 
 	return &accelConfig
 }
-
-func readFile(accelConfig *AccelConfig, configFile string) error {
-	openedConfigFile, err := os.Open(configFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedConfigFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedConfigFile)
-
-	err = decoder.Decode(&accelConfig)
-
-	return err
-}
@@ -5,7 +5,6 @@ import (
 	"os"
 	"strings"
 
-	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/assisted"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/configmap"
@@ -16,6 +15,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/assisted/ztp/internal/find"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/assisted/ztp/internal/ztpparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
 	"k8s.io/klog/v2"
 )
@@ -87,7 +87,8 @@ func (ztpconfig *ZTPConfig) newHubConfig() error {
 
 	ztpconfig.HubConfig = new(HubConfig)
 
-	err := envconfig.Process("eco_assisted_ztp_hub_", ztpconfig.HubConfig)
+	err := config.NewLoader().WithEnvconfigPrefix("eco_assisted_ztp_hub_").
+		WithEnvPrefix("ECO_ASSISTED_ZTP_HUB_").Load(ztpconfig.HubConfig)
 	if err != nil {
 		klog.V(ztpparams.ZTPLogLevel).Infof("failed to instantiate HubConfig: %v", err)
 	}
@@ -158,7 +159,8 @@ func (ztpconfig *ZTPConfig) newHubConfig() error {
 func (ztpconfig *ZTPConfig) newSpokeConfig() error {
 	klog.V(ztpparams.ZTPLogLevel).Info("Creating new SpokeConfig struct")
 
-	err := envconfig.Process("eco_assisted_ztp_spoke_", ztpconfig.SpokeConfig)
+	err := config.NewLoader().WithEnvconfigPrefix("eco_assisted_ztp_spoke_").
+		WithEnvPrefix("ECO_ASSISTED_ZTP_SPOKE_").Load(ztpconfig.SpokeConfig)
 	if err != nil {
 		klog.V(ztpparams.ZTPLogLevel).Infof("failed to instantiate SpokeConfig: %v", err)
 
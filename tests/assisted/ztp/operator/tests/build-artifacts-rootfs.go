@@ -1,6 +1,7 @@
 package operator_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -78,11 +79,14 @@ var _ = Describe(
 				err = os.Mkdir(rootfsDownloadDir, 0755)
 				Expect(err).ToNot(HaveOccurred(), "error creating downloads directory")
 
-				err = url.DownloadToDir(rootfsSpokeResources.InfraEnv.Object.Status.ISODownloadURL, rootfsDownloadDir, true)
+				downloadClient := url.NewClient(url.WithInsecureSkipVerify(true))
+
+				_, err = downloadClient.Download(context.TODO(),
+					rootfsSpokeResources.InfraEnv.Object.Status.ISODownloadURL, rootfsDownloadDir)
 				Expect(err).ToNot(HaveOccurred(), "error downloading ISO")
 
-				err = url.DownloadToDir(rootfsSpokeResources.InfraEnv.Object.Status.BootArtifacts.RootfsURL,
-					rootfsDownloadDir, true)
+				_, err = downloadClient.Download(context.TODO(),
+					rootfsSpokeResources.InfraEnv.Object.Status.BootArtifacts.RootfsURL, rootfsDownloadDir)
 				Expect(err).ToNot(HaveOccurred(), "error downloading rootfs")
 
 				dirEntry, err := os.ReadDir(rootfsDownloadDir)
@@ -2,13 +2,11 @@ package coreconfig
 
 import (
 	"log"
-	"os"
 	"path/filepath"
 	"runtime"
 
-	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/internal/cnfconfig"
-	"gopkg.in/yaml.v2"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 )
 
 const (
@@ -33,48 +31,12 @@ func NewCoreConfig() *CoreConfig {
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultCnfCoreParamsFile)
 
-	err := readFile(&coreConf, confFile)
+	err := config.NewLoader().WithDefaultFile(confFile).Load(&coreConf)
 	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
-
-		return nil
-	}
-
-	err = readEnv(&coreConf)
-	if err != nil {
-		log.Print("Error to read environment variables")
+		log.Printf("Error to load config file %s: %v", confFile, err)
 
 		return nil
 	}
 
 	return &coreConf
 }
-
-func readFile(coreConfig *CoreConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-
-	err = decoder.Decode(&coreConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(coreConfig *CoreConfig) error {
-	err := envconfig.Process("", coreConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
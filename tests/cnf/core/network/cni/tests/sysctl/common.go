@@ -488,7 +488,7 @@ func setHostIPForwarding(nodeName, interfaceName string, enabled bool) {
 }
 
 func getHostIPForwardingQuiet(nodeName, interfaceName string) (bool, error) {
-	output, err := cmd.RunCommandOnHostNetworkPod(nodeName, tsparams.TestNamespaceName, fmt.Sprintf(
+	output, err := cmd.RunCommandOnNode(nodeName, fmt.Sprintf(
 		"cat /proc/sys/net/ipv4/conf/%s/forwarding", interfaceName))
 	if err != nil {
 		return false, err
@@ -503,7 +503,7 @@ func setHostIPForwardingQuiet(nodeName, interfaceName string, enabled bool) erro
 		value = "1"
 	}
 
-	_, err := cmd.RunCommandOnHostNetworkPod(nodeName, tsparams.TestNamespaceName, fmt.Sprintf(
+	_, err := cmd.RunCommandOnNode(nodeName, fmt.Sprintf(
 		"echo %s > /proc/sys/net/ipv4/conf/%s/forwarding", value, interfaceName))
 
 	return err
@@ -2,6 +2,7 @@ package day1day2env
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -69,17 +70,31 @@ func DoesClusterSupportDay1Day2Tests(requiredCPNodeNumber, requiredWorkerNodeNum
 	return nil
 }
 
-// GetBondModeViaCmd returns Bond mode for given Bond interface on a specific node.
+// GetBondModeViaCmd returns Bond mode for given Bond interface on a specific node, such as balance-rr.
 func GetBondModeViaCmd(bondInterfaceName, nodeName string) (string, error) {
 	klog.V(90).Infof("Getting Bond mode for bond interface %s on a node %s", bondInterfaceName, nodeName)
 
-	bondMode, err := cmd.RunCommandOnHostNetworkPod(nodeName, tsparams.TestNamespaceName,
-		fmt.Sprintf("cat /sys/class/net/%s/bonding/mode", bondInterfaceName))
+	output, err := cmd.RunCommandOnNode(nodeName, bondModeCommand(bondInterfaceName))
 	if err != nil {
 		return "", err
 	}
 
-	return bondMode, nil
+	return parseBondMode(output)
+}
+
+// bondModeCommand returns the command printing the mode of the given Bond interface, such as "balance-rr 0".
+func bondModeCommand(bondInterfaceName string) string {
+	return fmt.Sprintf("cat /sys/class/net/%s/bonding/mode", bondInterfaceName)
+}
+
+// parseBondMode returns the name of the Bond mode from the output of bondModeCommand, without the mode number.
+func parseBondMode(output string) (string, error) {
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("failed to parse Bond mode from empty output")
+	}
+
+	return fields[0], nil
 }
 
 // GetBondInterfaceMiimon returns miimon value for given bond interface and node.
@@ -0,0 +1,45 @@
+//go:build unit_test
+
+package day1day2env
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBondMode(t *testing.T) {
+	fixtures, err := cluster.ReadExecFixtures("testdata/bond-mode.yaml")
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name              string
+		nodeName          string
+		bondInterfaceName string
+		expectedMode      string
+		expectedError     bool
+	}{
+		{name: "active-backup", nodeName: "worker-0", bondInterfaceName: "bond0", expectedMode: "active-backup"},
+		{name: "802.3ad", nodeName: "worker-0", bondInterfaceName: "bond1", expectedMode: "802.3ad"},
+		{name: "balance-rr", nodeName: "worker-1", bondInterfaceName: "bond0", expectedMode: "balance-rr"},
+		{name: "empty output", nodeName: "worker-1", bondInterfaceName: "bond1", expectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			output, err := fixtures.Stdout(testCase.nodeName, bondModeCommand(testCase.bondInterfaceName))
+			assert.NoError(t, err)
+
+			bondMode, err := parseBondMode(output)
+			if testCase.expectedError {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedMode, bondMode)
+		})
+	}
+}
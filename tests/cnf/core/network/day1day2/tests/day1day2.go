@@ -115,7 +115,7 @@ var _ = Describe("Day1Day2", Ordered, Label(tsparams.LabelSuite), ContinueOnFail
 	It("VF: change QOS configuration", reportxml.ID("63926"), func() {
 		By("Collecting information about test interfaces")
 
-		pfUnderTest, err := cmd.GetSrIovPf(bondSlaves[0], tsparams.TestNamespaceName, workerNodeList[0].Definition.Name)
+		pfUnderTest, err := cmd.GetSrIovPf(bondSlaves[0], workerNodeList[0].Definition.Name)
 		Expect(err).ToNot(HaveOccurred(), fmt.Sprintf("Failed to get SR-IOV PF for VF %s", bondSlaves[0]))
 
 		By(fmt.Sprintf("Saving MaxTxRate value on the first VF of interface %s before the test", pfUnderTest))
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"strconv"
@@ -11,6 +12,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/ipaddr"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netparam"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
 	"k8s.io/klog/v2"
 )
 
@@ -46,37 +48,36 @@ func ICMPConnectivityCheck(clientPod *pod.Builder, destIPAddresses []string, ifN
 	return nil
 }
 
-// RunCommandOnHostNetworkPod creates hostNetwork pod  and executes given command on it.
-// The Pod will be removed at the end.
-func RunCommandOnHostNetworkPod(nodeName, namespace, command string) (string, error) {
-	klog.V(90).Infof("Running command %s on the host network pod on node %s",
-		command, nodeName)
+// RunCommandOnNode runs the given command on the host of the node using the node executor set by
+// ECO_NODE_EXEC_TRANSPORT, so that its output can be recorded and replayed from fixtures. It returns the stdout of the
+// command.
+func RunCommandOnNode(nodeName, command string) (string, error) {
+	klog.V(90).Infof("Running command %s on node %s", command, nodeName)
 
-	testPod, err := pod.NewBuilder(APIClient, "hostnetworkpod", namespace, NetConfig.CnfNetTestContainer).
-		DefineOnNode(nodeName).WithPrivilegedFlag().WithHostNetwork().CreateAndWaitUntilRunning(netparam.DefaultTimeout)
+	executor, err := cluster.NewNodeExecutor(APIClient)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("failed to create node executor: %w", err)
 	}
 
-	output, err := testPod.ExecCommand([]string{"bash", "-c", command})
-	if err != nil {
-		return "", err
-	}
+	defer func() {
+		if err := executor.Close(); err != nil {
+			klog.V(90).Infof("Failed to close node executor: %v", err)
+		}
+	}()
 
-	_, err = testPod.DeleteAndWait(netparam.DefaultTimeout)
+	result, err := executor.Exec(context.TODO(), nodeName, command, cluster.WithExecTimeout(netparam.DefaultTimeout))
 	if err != nil {
-		return output.String(), err
+		return "", err
 	}
 
-	return output.String(), nil
+	return result.Stdout, nil
 }
 
 // GetSrIovPf returns SR-IOV PF name for given SR-IOV VF.
-func GetSrIovPf(vfInterfaceName, namespace, nodeName string) (string, error) {
+func GetSrIovPf(vfInterfaceName, nodeName string) (string, error) {
 	klog.V(90).Infof("Getting PF interface name for VF %s on node %s", vfInterfaceName, nodeName)
 
-	pfName, err := RunCommandOnHostNetworkPod(nodeName, namespace,
-		fmt.Sprintf("ls /sys/class/net/%s/device/physfn/net/", vfInterfaceName))
+	pfName, err := RunCommandOnNode(nodeName, fmt.Sprintf("ls /sys/class/net/%s/device/physfn/net/", vfInterfaceName))
 	if err != nil {
 		return "", err
 	}
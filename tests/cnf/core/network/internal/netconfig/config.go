@@ -4,15 +4,13 @@ import (
 	"fmt"
 	"log"
 	"net"
-	"os"
 	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
 
-	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/internal/coreconfig"
-	"gopkg.in/yaml.v2"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 )
 
 const (
@@ -23,13 +21,13 @@ const (
 // NetworkConfig type keeps network configuration.
 type NetworkConfig struct {
 	*coreconfig.CoreConfig
-	CnfNetTestContainer            string `yaml:"cnf_net_test_container" envconfig:"ECO_CNF_CORE_NET_TEST_CONTAINER"`
+	CnfNetTestContainer            string `yaml:"cnf_net_test_container" envconfig:"ECO_CNF_CORE_NET_TEST_CONTAINER" validate:"required"` //nolint:lll
 	DpdkTestContainer              string `yaml:"dpdk_test_container" envconfig:"ECO_CNF_CORE_NET_DPDK_TEST_CONTAINER"`
-	MlbOperatorNamespace           string `yaml:"metal_lb_operator_namespace" envconfig:"ECO_CNF_CORE_NET_MLB_OPERATOR_NAMESPACE"` //nolint:lll
+	MlbOperatorNamespace           string `yaml:"metal_lb_operator_namespace" envconfig:"ECO_CNF_CORE_NET_MLB_OPERATOR_NAMESPACE" validate:"required"` //nolint:lll
 	Frrk8sNamespace                string `yaml:"frr-k8s_namespace" envconfig:"ECO_CNF_CORE_NET_FRR_K8S_NAMESPACE"`
 	PFStatusRelayOperatorNamespace string `yaml:"pf_status_relay_operator_namespace" envconfig:"ECO_CNF_CORE_NET_PF_STATUS_RELAY_OPERATOR_NAMESPACE"` //nolint:lll
-	CnfMcpLabel                    string `yaml:"cnf_mcp_label" envconfig:"ECO_CNF_CORE_NET_CNF_MCP_LABEL"`
-	MultusNamesapce                string `yaml:"multus_namespace" envconfig:"ECO_CNF_CORE_NET_MULTUS_NAMESPACE"`
+	CnfMcpLabel                    string `yaml:"cnf_mcp_label" envconfig:"ECO_CNF_CORE_NET_CNF_MCP_LABEL" validate:"required"`                       //nolint:lll
+	MultusNamesapce                string `yaml:"multus_namespace" envconfig:"ECO_CNF_CORE_NET_MULTUS_NAMESPACE" validate:"required"`                 //nolint:lll
 	SwitchUser                     string `envconfig:"ECO_CNF_CORE_NET_SWITCH_USER"`
 	SwitchPass                     string `envconfig:"ECO_CNF_CORE_NET_SWITCH_PASS"`
 	SwitchIP                       string `envconfig:"ECO_CNF_CORE_NET_SWITCH_IP"`
@@ -38,7 +36,7 @@ type NetworkConfig struct {
 	SwitchLagNames                 string `envconfig:"ECO_CNF_CORE_NET_SWITCH_LAGS"`
 	ClusterVlan                    string `envconfig:"ECO_CNF_CORE_NET_CLUSTER_VLAN"`
 	//nolint:lll
-	PrometheusOperatorNamespace string `yaml:"prometheus_operator_namespace" envconfig:"ECO_CNF_CORE_NET_PROMETHEUS_OPERATOR_NAMESPACE"`
+	PrometheusOperatorNamespace string `yaml:"prometheus_operator_namespace" envconfig:"ECO_CNF_CORE_NET_PROMETHEUS_OPERATOR_NAMESPACE" validate:"required"`
 	MlbAddressPoolIP            string `envconfig:"ECO_CNF_CORE_NET_MLB_ADDR_LIST"`
 	SriovInterfaces             string `envconfig:"ECO_CNF_CORE_NET_SRIOV_INTERFACE_LIST"`
 	FrrImage                    string `yaml:"frr_image" envconfig:"ECO_CNF_CORE_NET_FRR_IMAGE"`
@@ -63,16 +61,9 @@ func NewNetConfig() *NetworkConfig {
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultCnfCoreNetParamsFile)
 
-	err := readFile(&netConf, confFile)
+	err := config.NewLoader().WithDefaultFile(confFile).WithEnvPrefix("ECO_CNF_CORE_").Load(&netConf)
 	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
-
-		return nil
-	}
-
-	err = readEnv(&netConf)
-	if err != nil {
-		log.Print("Error to read environment variables")
+		log.Printf("Error to load config file %s: %v", confFile, err)
 
 		return nil
 	}
@@ -267,32 +258,3 @@ func (netConfig *NetworkConfig) GetClusterVlan() (string, error) {
 
 	return netConfig.ClusterVlan, nil
 }
-
-func readFile(netConfig *NetworkConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-
-	err = decoder.Decode(&netConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(netConfig *NetworkConfig) error {
-	err := envconfig.Process("", netConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
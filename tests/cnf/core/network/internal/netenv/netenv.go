@@ -16,6 +16,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/internal/netparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/retry"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -341,7 +342,7 @@ func ConfigureSriovMlnxFirmwareOnWorkers(
 		// Reboot is issued separately: the exec session is expected to drop when the node reboots.
 		_, rebootErr := runCommandOnConfigDaemon(apiClient, sriovOperatorNamespace, workerNode.Object.Name,
 			[]string{"bash", "-c", "chroot /host reboot"})
-		if rebootErr != nil && !isRebootExecDisconnectError(rebootErr) {
+		if rebootErr != nil && !retry.Matches(rebootErr, retry.ClassifyRebootDisconnect) {
 			return fmt.Errorf("failed to reboot node %s after Mellanox firmware configuration: %s",
 				workerNode.Object.Name, rebootErr.Error())
 		}
@@ -384,24 +385,6 @@ func ConfigureSriovMlnxFirmwareOnWorkersAndWaitMCP(
 	return nil
 }
 
-// isRebootExecDisconnectError reports whether err is the expected exec failure when reboot closes the session.
-func isRebootExecDisconnectError(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	msg := strings.ToLower(err.Error())
-
-	return strings.Contains(msg, "connection reset") ||
-		strings.Contains(msg, "connection refused") ||
-		strings.Contains(msg, "eof") ||
-		strings.Contains(msg, "broken pipe") ||
-		strings.Contains(msg, "unable to upgrade connection") ||
-		strings.Contains(msg, "command terminated") ||
-		errors.Is(err, context.Canceled) ||
-		errors.Is(err, context.DeadlineExceeded)
-}
-
 // isVfCreated checks that the expected number of VFs exists on the given SR-IOV interface.
 func isVfCreated(sriovNodeState *sriov.NetworkNodeStateBuilder, vfNumber int, sriovInterfaceName string) error {
 	sriovNumVfs, err := sriovNodeState.GetNumVFs(sriovInterfaceName)
@@ -308,7 +308,7 @@ func CheckThatWorkersDeployedWithBondVfs(
 
 	for _, bondSlave := range bondSlaves {
 		// If a baseInterface has SR-IOV PF, it means that the baseInterface is VF.
-		_, err = cmd.GetSrIovPf(bondSlave, namespace, workerNodes[0].Definition.Name)
+		_, err = cmd.GetSrIovPf(bondSlave, workerNodes[0].Definition.Name)
 		if err != nil && strings.Contains(err.Error(), "No such file or directory") {
 			klog.V(90).Infof("Failed to find PF for the baseInterface VFs")
 
@@ -1,57 +1,42 @@
 package prometheus
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/promquery"
 	"k8s.io/klog/v2"
 )
 
-type queryOutput struct {
-	Data data
-}
-type data struct {
-	Result []result
-}
-type result struct {
-	Metric metric
-}
-
-type metric struct {
-	Pod string
-}
-
 // PodMetricsPresentInDB returns true if the given metrics are present for the given pod in a prometheus database.
 func PodMetricsPresentInDB(prometheusPod *pod.Builder, podName string, uniqueMetricKeys []string) (bool, error) {
+	podClient, err := promquery.NewPodClient(prometheusPod, "", "http://localhost:9090")
+	if err != nil {
+		return false, err
+	}
+
+	prometheusAPI := prometheusv1.NewAPI(podClient)
+
 	for _, metricsKey := range uniqueMetricKeys {
 		metricFound := false
-		command := []string{
-			"curl",
-			fmt.Sprintf("%squery?query=%s", "http://localhost:9090/api/v1/", metricsKey),
-		}
 
-		stdout, err := prometheusPod.ExecCommand(command)
+		vector, err := promquery.QueryVector(context.TODO(), prometheusAPI, promquery.Expr(metricsKey), time.Time{})
 		if err != nil {
-			klog.V(90).Infof("Fail to collect metric %s. Stdout %s", metricsKey, stdout.String())
+			klog.V(90).Infof("Fail to collect metric %s: %v", metricsKey, err)
 
 			return false, err
 		}
 
-		var queryOutput queryOutput
-
-		err = json.Unmarshal(stdout.Bytes(), &queryOutput)
-		if err != nil {
-			return false, err
-		}
-
-		if len(queryOutput.Data.Result) < 1 {
+		if len(vector) < 1 {
 			return false, fmt.Errorf("failed to detect metric %s", metricsKey)
 		}
 
-		for _, result := range queryOutput.Data.Result {
-			if strings.Contains(result.Metric.Pod, podName) {
+		for _, sample := range vector {
+			if sample != nil && strings.Contains(string(sample.Metric["pod"]), podName) {
 				metricFound = true
 			}
 		}
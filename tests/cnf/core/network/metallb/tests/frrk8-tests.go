@@ -963,12 +963,10 @@ func removeSecondaryIPsFromWorkerNodes(workerNodeList []*nodes.Builder, ipv4Addr
 			interfaceName := srIovInterfacesUnderTest[0]
 
 			ipv4Cmd := fmt.Sprintf("ip addr del %s/24 dev %s 2>/dev/null || true", ipv4Addr, interfaceName)
-			_, _ = netcmd.RunCommandOnHostNetworkPod(workerNode.Definition.Name,
-				NetConfig.MlbOperatorNamespace, ipv4Cmd)
+			_, _ = netcmd.RunCommandOnNode(workerNode.Definition.Name, ipv4Cmd)
 
 			ipv6Cmd := fmt.Sprintf("ip addr del %s/64 dev %s 2>/dev/null || true", ipv6Addr, interfaceName)
-			_, _ = netcmd.RunCommandOnHostNetworkPod(workerNode.Definition.Name,
-				NetConfig.MlbOperatorNamespace, ipv6Cmd)
+			_, _ = netcmd.RunCommandOnNode(workerNode.Definition.Name, ipv6Cmd)
 		}
 	}
 }
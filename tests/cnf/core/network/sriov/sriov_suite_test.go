@@ -12,14 +12,19 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/sriov/internal/tsparams"
 	_ "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/network/sriov/tests"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/hwinventory"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/params"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/reporter"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/sriovoperator"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
 )
 
 var (
 	_, currentFile, _, _ = runtime.Caller(0)
 	testNS               = namespace.NewBuilder(APIClient, tsparams.TestNamespaceName)
+	clusterHardware      []hwinventory.Node
 )
 
 func TestLB(t *testing.T) {
@@ -49,6 +54,33 @@ var _ = BeforeSuite(func() {
 
 	err = cluster.PullTestImageOnNodes(APIClient, NetConfig.WorkerLabel, NetConfig.CnfNetTestContainer, 300)
 	Expect(err).ToNot(HaveOccurred(), "Failed to pull test image on nodes")
+
+	By("Collecting the hardware inventory of the cluster nodes")
+
+	// The inventory is only used for reporting, so a partial inventory should not fail the suite.
+	clusterHardware, err = hwinventory.CollectNodes(APIClient, metav1.ListOptions{})
+	if err != nil {
+		klog.V(90).Infof("Failed to fully collect hardware inventory of cluster nodes: %v", err)
+	}
+
+	By("Recording the SR-IOV interfaces under test in the hardware coverage")
+
+	// Every spec of the suite runs on the configured interfaces, so they are recorded as used by the whole suite.
+	sriovInterfaces, err := NetConfig.GetSriovInterfaces(1)
+	if err != nil {
+		klog.V(90).Infof("Failed to get SR-IOV interfaces under test for the hardware coverage: %v", err)
+
+		return
+	}
+
+	interfacesUnderTest, err := sriovoperator.CollectInterfacesUnderTest(
+		APIClient, NetConfig.SriovOperatorNamespace, sriovInterfaces,
+		metav1.ListOptions{LabelSelector: labels.Set(NetConfig.WorkerLabelMap).String()})
+	if err != nil {
+		klog.V(90).Infof("Failed to collect SR-IOV interfaces under test for the hardware coverage: %v", err)
+	}
+
+	hwinventory.RecordNodeUsages(interfacesUnderTest...)
 })
 var _ = AfterSuite(func() {
 	By("Deleting test namespace")
@@ -64,4 +96,14 @@ var _ = JustAfterEach(func() {
 
 var _ = ReportAfterSuite("", func(report Report) {
 	reportxml.Create(report, NetConfig.GetReportPath(), NetConfig.TCPrefix)
+
+	By("Generating hardware coverage report")
+
+	coverage := hwinventory.NewCoverage(NetConfig.RunID, report.SuiteDescription, clusterHardware)
+
+	err := hwinventory.MergeIntoFile(
+		hwinventory.CoveragePath(NetConfig.ReportsDirAbsPath, NetConfig.RunID), coverage)
+	Expect(err).ToNot(HaveOccurred(), "Failed to save hardware coverage")
+
+	AddReportEntry("hardware coverage", coverage.Report())
 })
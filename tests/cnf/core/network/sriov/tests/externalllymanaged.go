@@ -476,7 +476,7 @@ var _ = Describe("ExternallyManaged", Ordered, Label(tsparams.LabelExternallyMan
 
 				By("Creating SR-IOV policy with flag ExternallyManage true")
 
-				pfInterface, err := cmd.GetSrIovPf(vfsUnderTest[0], tsparams.TestNamespaceName, workerNodeList[0].Object.Name)
+				pfInterface, err := cmd.GetSrIovPf(vfsUnderTest[0], workerNodeList[0].Object.Name)
 				Expect(err).ToNot(HaveOccurred(), fmt.Sprintf("Failed to get PF for VF interface %s", vfsUnderTest[0]))
 
 				sriovPolicy := sriov.NewPolicyBuilder(
@@ -1201,7 +1201,7 @@ func setVFPromiscMode(nodeName, srIovInterfacesUnderTest, sriovVendor, onOff str
 			srIovInterfacesUnderTest, onOff)
 	}
 
-	output, err := cmd.RunCommandOnHostNetworkPod(nodeName, NetConfig.SriovOperatorNamespace, promiscVFCommand)
+	output, err := cmd.RunCommandOnNode(nodeName, promiscVFCommand)
 	Expect(err).ToNot(HaveOccurred(), fmt.Sprintf("Failed to run command on node %s", output))
 }
 
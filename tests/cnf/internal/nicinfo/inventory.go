@@ -0,0 +1,187 @@
+package nicinfo
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/hwinventory"
+	"k8s.io/klog/v2"
+)
+
+// InventoryFilePrefix is the prefix of the inventory files written alongside the junit reports, which are named after
+// it and the run ID.
+const InventoryFilePrefix = "nicinfo_inventory"
+
+// Interface is the inventory record of a tested network interface.
+type Interface struct {
+	Node             string `json:"node"`
+	Name             string `json:"name"`
+	Driver           string `json:"driver"`
+	Version          string `json:"version"`
+	FirmwareVersion  string `json:"firmware_version"`
+	PTPHardwareClock string `json:"ptp_hardware_clock"`
+	BusInfo          string `json:"bus_info,omitempty"`
+	PCIID            string `json:"pci_id,omitempty"`
+	LinkSpeed        string `json:"link_speed,omitempty"`
+	// Suites are the names of the test suites that tested the interface, sorted.
+	Suites []string `json:"suites"`
+}
+
+// Inventory is the inventory of every network interface tested on a cluster, possibly across several suites.
+// Interfaces are sorted by node and then by name. It is a view of the NICs in a hwinventory.Coverage, which holds the
+// inventory shared with the rest of the hardware and is what suites merge across a run.
+type Inventory struct {
+	// UpdatedAt is the last time nodes or usages were added to the coverage the inventory is from.
+	UpdatedAt  time.Time   `json:"updated_at"`
+	Interfaces []Interface `json:"interfaces"`
+}
+
+// CollectNodes gets the information of every interface marked as tested and returns them as hwinventory nodes with
+// only their NIC devices set, for adding to the hardware coverage of a suite.
+func CollectNodes(client *clients.Settings) ([]hwinventory.Node, error) {
+	var nodes []hwinventory.Node
+
+	for _, nodeNICInfo := range getStoredNodeNICInfos() {
+		klog.V(logLevel).Infof("Collecting inventory for node %s", nodeNICInfo.name)
+
+		var interfaceNames []string
+
+		nodeNICInfo.interfaces.Range(func(interfaceNameUntyped, _ any) bool {
+			interfaceName, ok := interfaceNameUntyped.(string)
+			if !ok {
+				return true
+			}
+
+			interfaceNames = append(interfaceNames, interfaceName)
+
+			return true
+		})
+
+		node := hwinventory.Node{Name: nodeNICInfo.name}
+
+		for _, interfaceName := range slices.Sorted(slices.Values(interfaceNames)) {
+			nicInfo, err := getInterfaceInfo(client, nodeNICInfo.name, interfaceName)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"failed to get info for interface %s on node %s: %w", interfaceName, nodeNICInfo.name, err)
+			}
+
+			node.Devices = append(node.Devices, nicInfo.Device())
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	slices.SortFunc(nodes, func(a, b hwinventory.Node) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return nodes, nil
+}
+
+// NewInventory returns the inventory of every NIC in coverage, with the suites that tested each according to the
+// usages of the coverage. For the inventory of a whole run, pass the coverage read from the file of the run.
+func NewInventory(coverage *hwinventory.Coverage) *Inventory {
+	inventory := &Inventory{UpdatedAt: coverage.UpdatedAt}
+
+	for _, node := range coverage.Nodes {
+		for _, device := range node.Devices {
+			if device.Kind != hwinventory.DeviceKindNIC {
+				continue
+			}
+
+			nicInterface := interfaceFromDevice(node.Name, device)
+
+			for _, usage := range coverage.Usages {
+				if usage.Node == node.Name && usage.Kind == device.Kind && usage.Device == device.Name {
+					nicInterface.Suites = append(nicInterface.Suites, usage.Suite)
+				}
+			}
+
+			nicInterface.Suites = slices.Compact(slices.Sorted(slices.Values(nicInterface.Suites)))
+			inventory.Interfaces = append(inventory.Interfaces, nicInterface)
+		}
+	}
+
+	slices.SortFunc(inventory.Interfaces, func(a, b Interface) int {
+		return cmp.Or(cmp.Compare(a.Node, b.Node), cmp.Compare(a.Name, b.Name))
+	})
+
+	return inventory
+}
+
+// Report returns the inventory as indented JSON, suitable for a report entry.
+func (inventory *Inventory) Report() (string, error) {
+	report, err := json.MarshalIndent(inventory, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal inventory: %w", err)
+	}
+
+	return string(report), nil
+}
+
+// Device returns the interface as a hwinventory device.
+func (nicInterface Interface) Device() hwinventory.Device {
+	return hwinventory.Device{
+		Kind:             hwinventory.DeviceKindNIC,
+		Name:             nicInterface.Name,
+		Driver:           nicInterface.Driver,
+		DriverVersion:    nicInterface.Version,
+		FirmwareVersion:  nicInterface.FirmwareVersion,
+		PCIAddress:       nicInterface.BusInfo,
+		PCIID:            nicInterface.PCIID,
+		LinkSpeed:        nicInterface.LinkSpeed,
+		PTPHardwareClock: nicInterface.PTPHardwareClock,
+	}
+}
+
+// interfaceFromDevice returns the inventory record of the NIC device on nodeName, without any suites.
+func interfaceFromDevice(nodeName string, device hwinventory.Device) Interface {
+	return Interface{
+		Node:             nodeName,
+		Name:             device.Name,
+		Driver:           device.Driver,
+		Version:          device.DriverVersion,
+		FirmwareVersion:  device.FirmwareVersion,
+		PTPHardwareClock: device.PTPHardwareClock,
+		BusInfo:          device.PCIAddress,
+		PCIID:            device.PCIID,
+		LinkSpeed:        device.LinkSpeed,
+	}
+}
+
+// InventoryPath returns the path of the inventory file of the run identified by runID in reportsDir, which should be
+// the directory of the junit reports.
+func InventoryPath(reportsDir, runID string) string {
+	return filepath.Join(reportsDir, fmt.Sprintf("%s_%s.json", InventoryFilePrefix, runID))
+}
+
+// WriteFile writes inventory to the inventory file at path, replacing it atomically. Since suites merge their NICs
+// into the hardware coverage of the run rather than this file, it should be written from the coverage read back from
+// the file of the run, so it covers every suite of the run so far.
+func WriteFile(path string, inventory *Inventory) error {
+	return hwinventory.WriteJSONFile(path, inventory)
+}
+
+// ReadInventory reads an inventory written by WriteFile.
+func ReadInventory(path string) (*Inventory, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inventory %s: %w", path, err)
+	}
+
+	inventory := &Inventory{}
+
+	err = json.Unmarshal(content, inventory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal inventory %s: %w", path, err)
+	}
+
+	return inventory, nil
+}
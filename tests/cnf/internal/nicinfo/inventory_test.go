@@ -0,0 +1,71 @@
+package nicinfo
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/hwinventory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInventory(t *testing.T) {
+	updatedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	nic := Interface{Node: "node-b", Name: "ens1f0", Driver: "ice", Version: "1.14", FirmwareVersion: "4.50",
+		PTPHardwareClock: "1", BusInfo: "0000:51:00.0", PCIID: "8086:1593", LinkSpeed: "25000Mb/s"}
+
+	inventory := NewInventory(&hwinventory.Coverage{
+		UpdatedAt: updatedAt,
+		Nodes: []hwinventory.Node{
+			{Name: "node-a", Devices: []hwinventory.Device{
+				{Kind: hwinventory.DeviceKindGPU, Name: "nvidia.com/gpu", Count: 1},
+				{Kind: hwinventory.DeviceKindNIC, Name: "ens2f0", Driver: "i40e"},
+			}},
+			{Name: "node-b", Devices: []hwinventory.Device{nic.Device()}},
+		},
+		Usages: []hwinventory.Usage{
+			{Suite: "SR-IOV Suite", Node: "node-b", Kind: hwinventory.DeviceKindNIC, Device: "ens1f0"},
+			{Suite: "RAN PTP Suite", Spec: "a", Node: "node-b", Kind: hwinventory.DeviceKindNIC, Device: "ens1f0"},
+			{Suite: "RAN PTP Suite", Spec: "b", Node: "node-b", Kind: hwinventory.DeviceKindNIC, Device: "ens1f0"},
+			{Suite: "GPU Suite", Node: "node-a", Kind: hwinventory.DeviceKindGPU, Device: "nvidia.com/gpu"},
+		},
+	})
+
+	nic.Suites = []string{"RAN PTP Suite", "SR-IOV Suite"}
+
+	assert.Equal(t, &Inventory{
+		UpdatedAt: updatedAt,
+		Interfaces: []Interface{
+			{Node: "node-a", Name: "ens2f0", Driver: "i40e"},
+			nic,
+		},
+	}, inventory)
+}
+
+func TestWriteFile(t *testing.T) {
+	path := InventoryPath(t.TempDir(), "run-1")
+	assert.Equal(t, "nicinfo_inventory_run-1.json", filepath.Base(path))
+
+	inventory := &Inventory{
+		Interfaces: []Interface{{Node: "node-a", Name: "ens1f0", Suites: []string{"a", "b"}}},
+	}
+
+	err := WriteFile(path, inventory)
+	assert.NoError(t, err)
+
+	read, err := ReadInventory(path)
+	assert.NoError(t, err)
+	assert.Equal(t, inventory.Interfaces, read.Interfaces)
+
+	matches, err := filepath.Glob(path + ".*")
+	assert.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestFindOptionalSubmatch(t *testing.T) {
+	ethtoolOutput := "Settings for ens1f0:\n\tSupported ports: [ FIBRE ]\n\tSpeed: 25000Mb/s\n\tDuplex: Full\n"
+	assert.Equal(t, "25000Mb/s", findOptionalSubmatch(linkSpeedRegex, ethtoolOutput))
+	assert.Equal(t, "", findOptionalSubmatch(busInfoRegex, ethtoolOutput))
+	assert.Equal(t, "0000:51:00.0", findOptionalSubmatch(busInfoRegex, "driver: ice\nbus-info: 0000:51:00.0\n"))
+	assert.Equal(t, []string{"8086", "1593"}, pciIDRegex.FindStringSubmatch("0x8086\t0x1593\n")[1:])
+}
@@ -1,7 +1,6 @@
 package nicinfo
 
 import (
-	"encoding/json"
 	"fmt"
 	"iter"
 	"regexp"
@@ -11,6 +10,7 @@ import (
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/hwinventory"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/klog/v2"
@@ -31,17 +31,19 @@ func newNodeNICInfo(nodeName string) *NodeNICInfo {
 	}
 }
 
-// MarkTested marks interfaces as tested for this node. Tested interfaces will show up in the final report.
+// MarkTested marks interfaces as tested for this node. Tested interfaces will show up in the final report and are
+// recorded as used by the current spec in the hardware coverage.
 func (n *NodeNICInfo) MarkTested(interfaceNames ...string) {
 	for _, interfaceName := range interfaceNames {
 		klog.V(logLevel).Infof("Marking interface %s as tested for node %s", interfaceName, n.name)
 
 		n.interfaces.Store(interfaceName, struct{}{})
+		hwinventory.RecordUsage(n.name, hwinventory.DeviceKindNIC, interfaceName)
 	}
 }
 
 // MarkSeqTested marks a sequence of interface names as tested for this node. Tested interfaces will show up in the
-// final report.
+// final report and are recorded as used by the current spec in the hardware coverage.
 //
 // This function is equivalent to:
 //
@@ -51,6 +53,7 @@ func (n *NodeNICInfo) MarkSeqTested(interfaceNames iter.Seq[string]) {
 		klog.V(logLevel).Infof("Marking interface %s as tested for node %s", interfaceName, n.name)
 
 		n.interfaces.Store(interfaceName, struct{}{})
+		hwinventory.RecordUsage(n.name, hwinventory.DeviceKindNIC, interfaceName)
 	}
 }
 
@@ -73,69 +76,6 @@ func Node(nodeName string) *NodeNICInfo {
 	return nodeNICInfo
 }
 
-// nicInfo contains information about a network interface.
-type nicInfo struct {
-	Name             string `json:"name"`
-	Driver           string `json:"driver"`
-	Version          string `json:"version"`
-	FirmwareVersion  string `json:"firmware_version"`
-	PTPHardwareClock string `json:"ptp_hardware_clock"`
-}
-
-// nodeInfo contains information about a node and its network interfaces. Only tested interfaces will be included in the
-// interface list.
-type nodeInfo struct {
-	NodeName   string    `json:"node_name"`
-	Interfaces []nicInfo `json:"interfaces"`
-}
-
-// GenerateReport generates a JSON report of the network interface information for all nodes in the cluster.
-func GenerateReport(client *clients.Settings) (string, error) {
-	nodeNICInfos := getStoredNodeNICInfos()
-
-	var nodeInfos []nodeInfo
-
-	for _, nodeNICInfo := range nodeNICInfos {
-		klog.V(logLevel).Infof("Generating report for node %s", nodeNICInfo.name)
-
-		nodeInfo := nodeInfo{
-			NodeName:   nodeNICInfo.name,
-			Interfaces: []nicInfo{},
-		}
-
-		var interfaceNames []string
-
-		nodeNICInfo.interfaces.Range(func(interfaceNameUntyped, _ any) bool {
-			interfaceName, ok := interfaceNameUntyped.(string)
-			if !ok {
-				return true
-			}
-
-			interfaceNames = append(interfaceNames, interfaceName)
-
-			return true
-		})
-
-		for _, interfaceName := range interfaceNames {
-			nicInfo, err := getInterfaceInfo(client, nodeNICInfo.name, interfaceName)
-			if err != nil {
-				return "", fmt.Errorf("failed to get info for interface %s on node %s: %w", interfaceName, nodeNICInfo.name, err)
-			}
-
-			nodeInfo.Interfaces = append(nodeInfo.Interfaces, nicInfo)
-		}
-
-		nodeInfos = append(nodeInfos, nodeInfo)
-	}
-
-	report, err := json.Marshal(nodeInfos)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal node infos: %w", err)
-	}
-
-	return string(report), nil
-}
-
 func getStoredNodeNICInfos() []*NodeNICInfo {
 	var nodeNICInfos []*NodeNICInfo
 
@@ -159,6 +99,9 @@ var (
 	versionRegex          = regexp.MustCompile(`(?m)^version: (.+)$`)
 	firmwareVersionRegex  = regexp.MustCompile(`(?m)^firmware-version: (.+)$`)
 	ptpHardwareClockRegex = regexp.MustCompile(`(?m)^(?:PTP Hardware Clock|Hardware timestamp provider index): (\d+)$`)
+	busInfoRegex          = regexp.MustCompile(`(?m)^bus-info: (.+)$`)
+	linkSpeedRegex        = regexp.MustCompile(`(?m)^\s*Speed: (.+)$`)
+	pciIDRegex            = regexp.MustCompile(`(?m)^0x([0-9a-fA-F]{4})\s+0x([0-9a-fA-F]{4})[ \t]*$`)
 )
 
 const (
@@ -200,8 +143,9 @@ func execEthtoolWithRetryOnEmpty(
 
 // getInterfaceInfo gets the information for a given interface on a given node by running ethtool commands on the node
 // and parsing the output. Commands are retried up to 3 times with a 20 second delay between retries, and also retried
-// if the output is empty.
-func getInterfaceInfo(client *clients.Settings, nodeName string, interfaceName string) (nicInfo, error) {
+// if the output is empty. The bus info, PCI ID, and link speed are left empty if they cannot be found, since not every
+// interface has them.
+func getInterfaceInfo(client *clients.Settings, nodeName string, interfaceName string) (Interface, error) {
 	nodeSelector := metav1.ListOptions{
 		FieldSelector: fields.SelectorFromSet(fields.Set{"metadata.name": nodeName}).String(),
 	}
@@ -210,27 +154,27 @@ func getInterfaceInfo(client *clients.Settings, nodeName string, interfaceName s
 
 	driverInfoOutput, err := execEthtoolWithRetryOnEmpty(client, nodeName, driverInfoCommand, nodeSelector)
 	if err != nil {
-		return nicInfo{}, fmt.Errorf(
+		return Interface{}, fmt.Errorf(
 			"failed to get driver info for interface %s on node %s: %w", interfaceName, nodeName, err)
 	}
 
 	driver := driverRegex.FindStringSubmatch(driverInfoOutput)
 	if len(driver) == 0 {
-		return nicInfo{}, fmt.Errorf(
+		return Interface{}, fmt.Errorf(
 			"failed to find driver in ethtool output for interface %s on node %s: output was %q",
 			interfaceName, nodeName, driverInfoOutput)
 	}
 
 	version := versionRegex.FindStringSubmatch(driverInfoOutput)
 	if len(version) == 0 {
-		return nicInfo{}, fmt.Errorf(
+		return Interface{}, fmt.Errorf(
 			"failed to find version in ethtool output for interface %s on node %s: output was %q",
 			interfaceName, nodeName, driverInfoOutput)
 	}
 
 	firmwareVersion := firmwareVersionRegex.FindStringSubmatch(driverInfoOutput)
 	if len(firmwareVersion) == 0 {
-		return nicInfo{}, fmt.Errorf(
+		return Interface{}, fmt.Errorf(
 			"failed to find firmware-version in ethtool output for interface %s on node %s: output was %q",
 			interfaceName, nodeName, driverInfoOutput)
 	}
@@ -239,22 +183,72 @@ func getInterfaceInfo(client *clients.Settings, nodeName string, interfaceName s
 
 	ptpHardwareClockOutput, err := execEthtoolWithRetryOnEmpty(client, nodeName, ptpHardwareClockCommand, nodeSelector)
 	if err != nil {
-		return nicInfo{}, fmt.Errorf(
+		return Interface{}, fmt.Errorf(
 			"failed to get PTP hardware clock for interface %s on node %s: %w", interfaceName, nodeName, err)
 	}
 
 	ptpHardwareClock := ptpHardwareClockRegex.FindStringSubmatch(ptpHardwareClockOutput)
 	if len(ptpHardwareClock) == 0 {
-		return nicInfo{}, fmt.Errorf(
+		return Interface{}, fmt.Errorf(
 			"failed to find PTP hardware clock index in ethtool output for interface %s on node %s: output was %q",
 			interfaceName, nodeName, ptpHardwareClockOutput)
 	}
 
-	return nicInfo{
+	return Interface{
+		Node:             nodeName,
 		Name:             interfaceName,
 		Driver:           driver[1],
 		Version:          version[1],
 		FirmwareVersion:  firmwareVersion[1],
 		PTPHardwareClock: ptpHardwareClock[1],
+		BusInfo:          findOptionalSubmatch(busInfoRegex, driverInfoOutput),
+		PCIID:            getPCIID(client, nodeName, interfaceName, nodeSelector),
+		LinkSpeed:        getLinkSpeed(client, nodeName, interfaceName, nodeSelector),
 	}, nil
 }
+
+// getPCIID gets the vendor and device ID of the PCI device backing an interface, such as 8086:1593, or an empty string
+// if it cannot be found, such as for virtual interfaces.
+func getPCIID(client *clients.Settings, nodeName, interfaceName string, nodeSelector metav1.ListOptions) string {
+	pciIDCommand := fmt.Sprintf(
+		"paste /sys/class/net/%[1]s/device/vendor /sys/class/net/%[1]s/device/device", interfaceName)
+
+	outputs, err := cluster.ExecCmdWithStdoutWithRetries(
+		client, ethtoolRetries, ethtoolRetryInterval, pciIDCommand, nodeSelector)
+	if err != nil {
+		klog.V(logLevel).Infof("Failed to get PCI ID for interface %s on node %s: %v", interfaceName, nodeName, err)
+
+		return ""
+	}
+
+	pciID := pciIDRegex.FindStringSubmatch(outputs[nodeName])
+	if len(pciID) == 0 {
+		return ""
+	}
+
+	return strings.ToLower(pciID[1] + ":" + pciID[2])
+}
+
+// getLinkSpeed gets the link speed of an interface as reported by ethtool, such as 25000Mb/s, or an empty string if it
+// cannot be found.
+func getLinkSpeed(client *clients.Settings, nodeName, interfaceName string, nodeSelector metav1.ListOptions) string {
+	outputs, err := cluster.ExecCmdWithStdoutWithRetries(
+		client, ethtoolRetries, ethtoolRetryInterval, fmt.Sprintf("ethtool %s", interfaceName), nodeSelector)
+	if err != nil {
+		klog.V(logLevel).Infof("Failed to get link speed for interface %s on node %s: %v", interfaceName, nodeName, err)
+
+		return ""
+	}
+
+	return findOptionalSubmatch(linkSpeedRegex, outputs[nodeName])
+}
+
+// findOptionalSubmatch returns the first submatch of regex in output, or an empty string if it does not match.
+func findOptionalSubmatch(regex *regexp.Regexp, output string) string {
+	match := regex.FindStringSubmatch(output)
+	if len(match) < 2 {
+		return ""
+	}
+
+	return strings.TrimSpace(match[1])
+}
@@ -1,8 +1,6 @@
 package deployment
 
 import (
-	"fmt"
-	"path"
 	"runtime"
 	"testing"
 
@@ -26,37 +24,28 @@ func TestDeployment(t *testing.T) {
 }
 
 var _ = JustAfterEach(func() {
-	var (
-		currentDir, currentFilename = path.Split(currentFile)
-		hubReportPath               = fmt.Sprintf("%shub_%s", currentDir, currentFilename)
-		spoke2ReportPath            = fmt.Sprintf("%sspoke2_%s", currentDir, currentFilename)
-		report                      = CurrentSpecReport()
-	)
+	clusters := map[string]reporter.ClusterDump{}
 
 	if Spoke1APIClient != nil && Spoke1APIClient.KubeconfigPath != "" {
-		reporter.ReportIfFailed(
-			report,
-			currentFile,
-			map[string]string{},
-			tsparams.ReporterSpokeCRsToDump)
+		clusters["spoke1"] = reporter.ClusterDump{CRs: tsparams.ReporterSpokeCRsToDump}
 	}
 
 	if HubAPIClient != nil && HubAPIClient.KubeconfigPath != "" {
-		reporter.ReportIfFailedOnCluster(
-			HubAPIClient.KubeconfigPath,
-			report,
-			hubReportPath,
-			map[string]string{},
-			tsparams.ReporterHubCRsToDump)
+		clusters["hub"] = reporter.ClusterDump{
+			Kubeconfig: HubAPIClient.KubeconfigPath,
+			CRs:        tsparams.ReporterHubCRsToDump,
+		}
 	}
 
 	if Spoke2APIClient != nil && Spoke2APIClient.KubeconfigPath != "" {
-		reporter.ReportIfFailedOnCluster(
-			Spoke2APIClient.KubeconfigPath,
-			report,
-			spoke2ReportPath,
-			map[string]string{},
-			tsparams.ReporterSpokeCRsToDump)
+		clusters["spoke2"] = reporter.ClusterDump{
+			Kubeconfig: Spoke2APIClient.KubeconfigPath,
+			CRs:        tsparams.ReporterSpokeCRsToDump,
+		}
+	}
+
+	if len(clusters) > 0 {
+		reporter.ReportIfFailedOnClusters(CurrentSpecReport(), currentFile, clusters)
 	}
 })
 
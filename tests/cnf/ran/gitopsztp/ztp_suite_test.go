@@ -1,8 +1,6 @@
 package ztp
 
 import (
-	"fmt"
-	"path"
 	"runtime"
 	"strings"
 	"testing"
@@ -66,23 +64,19 @@ var _ = AfterSuite(func() {
 })
 
 var _ = JustAfterEach(func() {
-	var (
-		currentDir, currentFilename = path.Split(currentFile)
-		hubReportPath               = fmt.Sprintf("%shub_%s", currentDir, currentFilename)
-		report                      = CurrentSpecReport()
-	)
-
-	reporter.ReportIfFailed(
-		report, currentFile, tsparams.ReporterSpokeNamespacesToDump, tsparams.ReporterSpokeCRsToDump)
+	clusters := map[string]reporter.ClusterDump{
+		"spoke1": {Namespaces: tsparams.ReporterSpokeNamespacesToDump, CRs: tsparams.ReporterSpokeCRsToDump},
+	}
 
 	if HubAPIClient != nil {
-		reporter.ReportIfFailedOnCluster(
-			RANConfig.HubKubeconfig,
-			report,
-			hubReportPath,
-			tsparams.ReporterHubNamespacesToDump,
-			tsparams.ReporterHubCRsToDump)
+		clusters["hub"] = reporter.ClusterDump{
+			Kubeconfig: RANConfig.HubKubeconfig,
+			Namespaces: tsparams.ReporterHubNamespacesToDump,
+			CRs:        tsparams.ReporterHubCRsToDump,
+		}
 	}
+
+	reporter.ReportIfFailedOnClusters(CurrentSpecReport(), currentFile, clusters)
 })
 
 var _ = ReportAfterSuite("", func(report Report) {
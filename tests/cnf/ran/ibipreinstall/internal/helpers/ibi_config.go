@@ -3,11 +3,10 @@ package helpers
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
+	neturl "net/url"
 	"os"
 	"os/exec"
 	"path"
@@ -19,6 +18,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ibipreinstall/internal/tsparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/url"
 )
 
 const httpFetchTimeout = 30 * time.Second
@@ -87,7 +87,7 @@ func ResolveIBIConfigTemplateURL(explicitURL, clusterInstanceURL string) (string
 		return explicitURL, nil
 	}
 
-	parsed, err := url.Parse(clusterInstanceURL)
+	parsed, err := neturl.Parse(clusterInstanceURL)
 	if err != nil {
 		return "", fmt.Errorf("parse ClusterInstance URL %q: %w", clusterInstanceURL, err)
 	}
@@ -216,46 +216,37 @@ func VerifyNmstatectlAvailable() error {
 	return nil
 }
 
-// FetchYAMLFromURL fetches raw YAML content from a URL. When skipTLS is true,
+// FetchYAMLFromURL fetches raw YAML content from a URL, retrying transient failures. When skipTLS is true,
 // TLS certificate verification is skipped.
 func FetchYAMLFromURL(rawURL string, skipTLS bool) ([]byte, error) {
 	klog.V(tsparams.LogLevel).Infof("Fetching YAML from %s (skipTLS=%v)", rawURL, skipTLS)
 
-	client := httpClient(skipTLS)
+	client := url.NewClient(url.WithInsecureSkipVerify(skipTLS), url.WithRequestTimeout(httpFetchTimeout))
 
-	resp, err := client.Get(rawURL)
+	resp, err := client.Get(context.TODO(), rawURL)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP fetch %s: %w", rawURL, err)
 	}
 
-	defer resp.Body.Close()
-
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("HTTP fetch %s: status %d", rawURL, resp.StatusCode)
 	}
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("reading response from %s: %w", rawURL, err)
-	}
-
-	return data, nil
+	return resp.Body, nil
 }
 
-// VerifyHTTPAccessible sends a HEAD request to the given URL and returns an error
+// VerifyHTTPAccessible sends a HEAD request to the given URL, retrying transient failures, and returns an error
 // if the server does not respond with HTTP 200. Use this to confirm that an artifact
 // (e.g., the IBI ISO) is reachable before handing the URL to an external consumer
 // like a BareMetalHost.
 func VerifyHTTPAccessible(rawURL string) error {
-	client := &http.Client{Timeout: httpFetchTimeout}
+	client := url.NewClient(url.WithRequestTimeout(httpFetchTimeout))
 
-	resp, err := client.Head(rawURL)
+	resp, err := client.Do(context.TODO(), http.MethodHead, rawURL, nil)
 	if err != nil {
 		return fmt.Errorf("HEAD %s: %w", rawURL, err)
 	}
 
-	resp.Body.Close()
-
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("HEAD %s: expected 200, got %d", rawURL, resp.StatusCode)
 	}
@@ -265,23 +256,6 @@ func VerifyHTTPAccessible(rawURL string) error {
 	return nil
 }
 
-// httpClient returns an HTTP client, optionally configured to skip TLS verification.
-func httpClient(skipTLS bool) *http.Client {
-	client := &http.Client{Timeout: httpFetchTimeout}
-
-	if skipTLS {
-		client.Transport = &http.Transport{
-			//nolint:gosec // user-requested via ECO_CNF_RAN_SKIP_TLS_VERIFY
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-				MinVersion:         tls.VersionTLS12,
-			},
-		}
-	}
-
-	return client
-}
-
 // RedactedIBIConfigFilename is the name of the redacted IBI config copy that
 // survives openshift-install's "purge asset" step.  The diagnostics collector
 // looks for this file instead of the original (which is deleted).
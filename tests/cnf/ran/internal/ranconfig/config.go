@@ -2,20 +2,18 @@ package ranconfig
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
 
-	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/internal/cnfconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/version"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
-	"gopkg.in/yaml.v2"
 	"k8s.io/klog/v2"
 )
 
@@ -35,15 +33,15 @@ type RANConfig struct {
 	MetricSamplingInterval string        `yaml:"metricSamplingInterval" envconfig:"ECO_CNF_RAN_METRIC_SAMPLING_INTERVAL"`
 	NoWorkloadDuration     string        `yaml:"noWorkloadDuration" envconfig:"ECO_CNF_RAN_NO_WORKLOAD_DURATION"`
 	WorkloadDuration       string        `yaml:"workloadDuration" envconfig:"ECO_CNF_RAN_WORKLOAD_DURATION"`
-	PtpStabilityDuration   time.Duration `yaml:"ptpStabilityDuration" envconfig:"ECO_CNF_RAN_PTP_STABILITY_DURATION"`
+	PtpStabilityDuration   time.Duration `yaml:"ptpStabilityDuration" envconfig:"ECO_CNF_RAN_PTP_STABILITY_DURATION" validate:"min=0s"` //nolint:lll
 	// PtpStabilityThreshold is the absolute offset threshold for PTP stability analysis. It is measured in
 	// nanoseconds.
-	PtpStabilityThreshold int64    `yaml:"ptpStabilityThreshold" envconfig:"ECO_CNF_RAN_PTP_STABILITY_THRESHOLD"`
+	PtpStabilityThreshold int64    `yaml:"ptpStabilityThreshold" envconfig:"ECO_CNF_RAN_PTP_STABILITY_THRESHOLD" validate:"min=0"` //nolint:lll
 	StressngTestImage     string   `yaml:"stressngTestImage" envconfig:"ECO_CNF_RAN_STRESSNG_TEST_IMAGE"`
 	CnfTestImage          string   `yaml:"cnfTestImage" envconfig:"ECO_CNF_RAN_TEST_IMAGE"`
 	OcpUpgradeUpstreamURL string   `yaml:"ocpUpgradeUpstreamUrl" envconfig:"ECO_CNF_RAN_OCP_UPGRADE_UPSTREAM_URL"`
-	AcmOperatorNamespace  string   `yaml:"acmOperatorNamespace" envconfig:"ECO_CNF_RAN_ACM_OPERATOR_NAMESPACE"`
-	PtpOperatorNamespace  string   `yaml:"ptpOperatorNamespace" envconfig:"ECO_CNF_RAN_PTP_OPERATOR_NAMESPACE"`
+	AcmOperatorNamespace  string   `yaml:"acmOperatorNamespace" envconfig:"ECO_CNF_RAN_ACM_OPERATOR_NAMESPACE" validate:"required"` //nolint:lll
+	PtpOperatorNamespace  string   `yaml:"ptpOperatorNamespace" envconfig:"ECO_CNF_RAN_PTP_OPERATOR_NAMESPACE" validate:"required"` //nolint:lll
 	TalmPreCachePolicies  []string `yaml:"talmPreCachePolicies" envconfig:"ECO_CNF_RAN_TALM_PRECACHE_POLICIES"`
 	ZtpSiteGenerateImage  string   `yaml:"ztpSiteGenerateImage" envconfig:"ECO_CNF_RAN_ZTP_SITE_GENERATE_IMAGE"`
 
@@ -68,6 +66,14 @@ type RANConfig struct {
 	// so that digests may be specified if needed.
 	PtpEventConsumerV2Tag string `yaml:"ptpEventConsumerV2Tag" envconfig:"ECO_CNF_RAN_PTP_EVENT_CONSUMER_V2_TAG"`
 
+	// PtpEventReceiverURL is the URL the event publisher sends events to when tests receive them directly instead of
+	// through the consumer. It must route to PtpEventReceiverListenAddress on the test runner from the cluster nodes.
+	// If it is empty, the consumer is used.
+	PtpEventReceiverURL string `envconfig:"ECO_CNF_RAN_PTP_EVENT_RECEIVER_URL"`
+	// PtpEventReceiverListenAddress is the address the test runner serves the event receiver on.
+	//nolint:lll
+	PtpEventReceiverListenAddress string `yaml:"ptpEventReceiverListenAddress" envconfig:"ECO_CNF_RAN_PTP_EVENT_RECEIVER_LISTEN_ADDRESS"`
+
 	// PtpMustGatherImage is the image to use for PTP must-gather. If the value is set, this will be used for the
 	// must-gather. Otherwise, it will fallback to the CSV annotation, followed by the image from registry.redhat.io
 	// corresponding to the current Spoke 1 OCP version.
@@ -191,7 +197,9 @@ func NewRANConfig() *RANConfig {
 	baseDir := filepath.Dir(filename)
 	configFile := filepath.Join(baseDir, PathToDefaultCnfRanParamsFile)
 
-	err := readConfig(&ranConfig, configFile)
+	// Only the main config checks for unknown variables, since its type includes the fields of the hub, spoke, and IBI
+	// configs, which are each read separately.
+	err := config.NewLoader().WithDefaultFile(configFile).WithEnvPrefix("ECO_CNF_RAN_").Load(&ranConfig)
 	if err != nil {
 		klog.V(ranparam.LogLevel).Infof("Error reading main RAN Config: %v", err)
 
@@ -371,36 +379,8 @@ func (ranconfig *RANConfig) newSpoke2Config(configFile string) {
 	klog.V(ranparam.LogLevel).Infof("Found OCP version on spoke 2: %s", ranconfig.Spoke2Config.Spoke2OCPVersion)
 }
 
-func readConfig[C any](config *C, configFile string) error {
-	err := readFile(config, configFile)
-	if err != nil {
-		return err
-	}
-
-	return readEnv(config)
-}
-
-func readFile[C any](config *C, configFile string) error {
-	openedConfigFile, err := os.Open(configFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedConfigFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedConfigFile)
-
-	err = decoder.Decode(config)
-
-	return err
-}
-
-func readEnv[C any](config *C) error {
-	err := envconfig.Process("", config)
-
-	return err
+func readConfig[C any](ranConfig *C, configFile string) error {
+	return config.NewLoader().WithDefaultFile(configFile).Load(ranConfig)
 }
 
 // ISOArtifactURL builds the full HTTP URL for the IBI ISO file.
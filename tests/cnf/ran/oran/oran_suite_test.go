@@ -1,8 +1,6 @@
 package oran
 
 import (
-	"fmt"
-	"path"
 	"runtime"
 	"testing"
 
@@ -35,23 +33,22 @@ var _ = BeforeSuite(func() {
 })
 
 var _ = JustAfterEach(func() {
-	var (
-		currentDir, currentFilename = path.Split(currentFile)
-		hubReportPath               = fmt.Sprintf("%shub_%s", currentDir, currentFilename)
-		report                      = CurrentSpecReport()
-	)
+	clusters := map[string]reporter.ClusterDump{
+		"hub": {
+			Kubeconfig: RANConfig.HubKubeconfig,
+			Namespaces: tsparams.ReporterHubNamespacesToDump,
+			CRs:        tsparams.ReporterHubCRsToDump,
+		},
+	}
 
 	if Spoke1APIClient != nil {
-		reporter.ReportIfFailed(
-			report, currentFile, tsparams.ReporterSpokeNamespacesToDump, tsparams.ReporterSpokeCRsToDump)
+		clusters["spoke1"] = reporter.ClusterDump{
+			Namespaces: tsparams.ReporterSpokeNamespacesToDump,
+			CRs:        tsparams.ReporterSpokeCRsToDump,
+		}
 	}
 
-	reporter.ReportIfFailedOnCluster(
-		RANConfig.HubKubeconfig,
-		report,
-		hubReportPath,
-		tsparams.ReporterHubNamespacesToDump,
-		tsparams.ReporterHubCRsToDump)
+	reporter.ReportIfFailedOnClusters(CurrentSpecReport(), currentFile, clusters)
 })
 
 var _ = ReportAfterSuite("", func(report Report) {
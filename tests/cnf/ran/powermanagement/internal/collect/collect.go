@@ -3,14 +3,13 @@ package collect
 import (
 	"fmt"
 	"math"
-	"slices"
 	"strconv"
 	"time"
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nto"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/stats"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/stats"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/powermanagement/internal/tsparams"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -224,44 +223,27 @@ func computePowerUsageStatistics(
 	tag string) (map[string]string, error) {
 	klog.V(tsparams.LogLevel).Infof("Power usage measurements for %s: %v", scenario, instantPowerData)
 
+	summary, err := stats.Summarize(instantPowerData)
+	if err != nil {
+		return nil, err
+	}
+
 	compMap := make(map[string]string)
 
 	compMap[fmt.Sprintf("%s_%s_%s", tsparams.RanPowerMetricTotalSamples, scenario, tag)] =
-		fmt.Sprintf("%d", len(instantPowerData))
+		fmt.Sprintf("%d", summary.Count)
 	compMap[fmt.Sprintf("%s_%s_%s", tsparams.RanPowerMetricSamplingIntervalSeconds, scenario, tag)] =
 		fmt.Sprintf("%.0f", samplingInterval.Seconds())
-
-	minInstantaneousPower := slices.Min(instantPowerData)
 	compMap[fmt.Sprintf("%s_%s_%s", tsparams.RanPowerMetricMinInstantPower, scenario, tag)] =
-		fmt.Sprintf("%.7f", minInstantaneousPower)
-
-	maxInstantaneousPower := slices.Max(instantPowerData)
+		fmt.Sprintf("%.7f", summary.Min)
 	compMap[fmt.Sprintf("%s_%s_%s", tsparams.RanPowerMetricMaxInstantPower, scenario, tag)] =
-		fmt.Sprintf("%.7f", maxInstantaneousPower)
-
-	meanInstantaneousPower, err := stats.Mean(instantPowerData)
-	if err != nil {
-		return compMap, err
-	}
-
+		fmt.Sprintf("%.7f", summary.Max)
 	compMap[fmt.Sprintf("%s_%s_%s", tsparams.RanPowerMetricMeanInstantPower, scenario, tag)] =
-		fmt.Sprintf("%.7f", meanInstantaneousPower)
-
-	stdDevInstantaneousPower, err := stats.StdDev(instantPowerData)
-	if err != nil {
-		return compMap, err
-	}
-
+		fmt.Sprintf("%.7f", summary.Mean)
 	compMap[fmt.Sprintf("%s_%s_%s", tsparams.RanPowerMetricStdDevInstantPower, scenario, tag)] =
-		fmt.Sprintf("%.7f", stdDevInstantaneousPower)
-
-	medianInstantaneousPower, err := stats.Median(instantPowerData)
-	if err != nil {
-		return compMap, err
-	}
-
+		fmt.Sprintf("%.7f", summary.StdDev)
 	compMap[fmt.Sprintf("%s_%s_%s", tsparams.RanPowerMetricMedianInstantPower, scenario, tag)] =
-		fmt.Sprintf("%.7f", medianInstantaneousPower)
+		fmt.Sprintf("%.7f", summary.P50)
 
 	return compMap, nil
 }
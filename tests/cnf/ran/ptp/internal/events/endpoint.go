@@ -0,0 +1,85 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/ptpdaemon"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
+	"k8s.io/klog/v2"
+)
+
+// publisherPort is the port the cloud-event-proxy container of the linuxptp daemon pod serves the v2 REST API on. It
+// is the target port of the service used by PublisherURL.
+const publisherPort = 9043
+
+// Serve serves the receiver over HTTP on listenAddress, such as :9095, until the returned stop function is called.
+// The address it is listening on is returned so a port of 0 can be used. The endpoint URL of the receiver must route
+// to this address for the publisher to send events to it.
+func (receiver *Receiver) Serve(listenAddress string) (net.Addr, func(ctx context.Context) error, error) {
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to listen for events on %s: %w", listenAddress, err)
+	}
+
+	server := &http.Server{Handler: receiver, ReadHeaderTimeout: 30 * time.Second}
+
+	go func() {
+		err := server.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			klog.V(tsparams.LogLevel).Infof("Event receiver on %s stopped: %v", listener.Addr(), err)
+		}
+	}()
+
+	klog.V(tsparams.LogLevel).Infof("Serving event receiver on %s", listener.Addr())
+
+	return listener.Addr(), server.Shutdown, nil
+}
+
+// StartReceiver starts a Receiver for the event publisher on nodeName and serves it on listenAddress. The publisher is
+// told to send events to endpointURL, which must route to listenAddress from the cluster nodes, for example when the
+// tests run in a pod or on a host the nodes can reach. Since the test runner usually cannot resolve cluster DNS, the
+// publisher is reached through a port-forward to the linuxptp daemon pod on the node rather than PublisherURL.
+//
+// The returned stop function deletes the subscriptions of the Receiver, then stops the server and the port-forward.
+// It should always be called, even if subscribing fails, since the publisher keeps sending events to endpointURL
+// until the subscriptions are deleted.
+func StartReceiver(
+	client *clients.Settings,
+	nodeName, listenAddress, endpointURL string,
+	options ...ReceiverOption) (*Receiver, func(ctx context.Context) error, error) {
+	daemonPod, err := ptpdaemon.GetPtpDaemonPodOnNode(client, nodeName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get event publisher pod on node %s: %w", nodeName, err)
+	}
+
+	publisherAddress, stopPortForward, err := daemonPod.PortForward(0, publisherPort)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to port-forward to event publisher on node %s: %w", nodeName, err)
+	}
+
+	receiver := NewReceiver("http://"+publisherAddress+"/api/ocloudNotifications/v2", endpointURL, options...)
+
+	_, stopServer, err := receiver.Serve(listenAddress)
+	if err != nil {
+		stopPortForward()
+
+		return nil, nil, err
+	}
+
+	stop := func(ctx context.Context) error {
+		// Subscriptions are deleted before the port-forward is stopped since it is how the publisher is reached.
+		unsubscribeErr := receiver.UnsubscribeAll(ctx)
+
+		stopPortForward()
+
+		return errors.Join(unsubscribeErr, stopServer(ctx))
+	}
+
+	return receiver, stop, nil
+}
@@ -0,0 +1,82 @@
+//go:build unit_test
+
+package events
+
+import (
+	"testing"
+
+	"github.com/redhat-cne/sdk-go/pkg/event"
+	eventptp "github.com/redhat-cne/sdk-go/pkg/event/ptp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventFilters(t *testing.T) {
+	lockedEvent := newTestEvent(eventptp.PtpStateChange,
+		event.DataValue{
+			Resource:  "/cluster/node/spoke1/ens1fx/master",
+			DataType:  event.NOTIFICATION,
+			ValueType: event.ENUMERATION,
+			Value:     string(eventptp.LOCKED),
+		},
+		event.DataValue{
+			Resource:  "/cluster/node/spoke1/ens1fx/master",
+			DataType:  event.METRIC,
+			ValueType: event.DECIMAL,
+			Value:     -1.6,
+		})
+
+	testCases := []struct {
+		name     string
+		filter   EventFilter
+		expected bool
+	}{
+		{name: "type", filter: IsType(eventptp.PtpStateChange), expected: true},
+		{name: "other type", filter: IsType(eventptp.PtpClockClassChange), expected: false},
+		{name: "sync state", filter: HasValue(WithSyncState(eventptp.LOCKED)), expected: true},
+		{name: "other sync state", filter: HasValue(WithSyncState(eventptp.HOLDOVER)), expected: false},
+		{name: "rounded metric", filter: HasValue(WithMetric(-2)), expected: true},
+		{name: "node", filter: HasValue(OnNode("spoke1")), expected: true},
+		{name: "interface", filter: HasValue(OnInterface("ens1f0")), expected: true},
+		{name: "other interface", filter: HasValue(OnInterface("ens2f0")), expected: false},
+		{name: "resource", filter: HasValue(ContainingResource("/master")), expected: true},
+		{
+			name:     "values matched separately",
+			filter:   HasValue(WithSyncState(eventptp.LOCKED), WithMetric(-2)),
+			expected: false,
+		},
+		{name: "empty all", filter: All(), expected: true},
+		{name: "empty any", filter: Any(), expected: false},
+		{
+			name:     "all",
+			filter:   All(IsType(eventptp.PtpStateChange), HasValue(WithSyncState(eventptp.LOCKED), OnNode("spoke1"))),
+			expected: true,
+		},
+		{
+			name:     "any",
+			filter:   Any(IsType(eventptp.PtpClockClassChange), HasValue(WithSyncState(eventptp.LOCKED))),
+			expected: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.filter.Filter(lockedEvent))
+		})
+	}
+}
+
+func TestHasValueWithoutData(t *testing.T) {
+	assert.False(t, HasValue().Filter(event.Event{Type: string(eventptp.PtpStateChange)}))
+}
+
+// newTestEvent returns an event of the provided type with the provided values, as the publisher would send it.
+func newTestEvent(eventType eventptp.EventType, values ...event.DataValue) event.Event {
+	testEvent := event.Event{}
+	testEvent.SetType(string(eventType))
+	testEvent.SetSource(values[0].Resource)
+	testEvent.SetID("test")
+	testEvent.SetDataContentType(event.ApplicationJSON)
+	testEvent.SetData(event.Data{Version: event.APISchemaVersion, Values: values})
+
+	return testEvent
+}
@@ -0,0 +1,345 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/redhat-cne/sdk-go/pkg/event"
+	"github.com/redhat-cne/sdk-go/pkg/pubsub"
+	"github.com/redhat-cne/sdk-go/pkg/types"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
+	"k8s.io/klog/v2"
+)
+
+// publisherURLFormat is the format of the v2 REST API URL of the event publisher on a node. It takes the node name.
+const publisherURLFormat = "http://ptp-event-publisher-service-%s.openshift-ptp.svc.cluster.local:9043" +
+	"/api/ocloudNotifications/v2"
+
+// PublisherURL returns the URL of the v2 REST API of the event publisher on the provided node. It is only reachable
+// from inside the cluster.
+func PublisherURL(nodeName string) string {
+	return fmt.Sprintf(publisherURLFormat, nodeName)
+}
+
+// ReceivedEvent is an event received by a Receiver along with the time it was received. Since the event time is set by
+// the publisher, ReceivedAt is used for time windows so they are not affected by clock skew between the node and the
+// test runner.
+type ReceivedEvent struct {
+	event.Event
+	ReceivedAt time.Time
+}
+
+// Receiver receives PTP events from the event publisher over the v2 REST API, replacing the cloud-event-consumer
+// sidecar. It subscribes to resources on the publisher, which then sends events as cloud events to the endpoint URL.
+// The Receiver is an http.Handler for that endpoint, so it must be served somewhere the publisher can reach, such as by
+// StartReceiver.
+//
+// Received events are stored in memory and can be queried or waited for using the same EventFilter as WaitForEvent.
+// All methods are safe to call concurrently.
+type Receiver struct {
+	publisherURL string
+	endpointURL  string
+	httpClient   *http.Client
+
+	mutex         sync.Mutex
+	events        []ReceivedEvent
+	subscriptions map[string]pubsub.PubSub
+	// received is closed and replaced whenever an event is received, waking up any goroutines waiting for events.
+	received chan struct{}
+}
+
+// Assert at compile time that Receiver implements http.Handler.
+var _ http.Handler = (*Receiver)(nil)
+
+// ReceiverOption is a function that modifies a Receiver when it is created. The options are applied in the order they
+// are provided.
+type ReceiverOption func(*Receiver)
+
+// WithHTTPClient is an option for NewReceiver that specifies the HTTP client used to reach the publisher. If not
+// specified, a client with a 30 second timeout is used.
+func WithHTTPClient(httpClient *http.Client) ReceiverOption {
+	return func(receiver *Receiver) {
+		receiver.httpClient = httpClient
+	}
+}
+
+// NewReceiver returns a Receiver that subscribes to the publisher at publisherURL, such as one returned by
+// PublisherURL, and asks it to send events to endpointURL.
+func NewReceiver(publisherURL, endpointURL string, options ...ReceiverOption) *Receiver {
+	receiver := &Receiver{
+		publisherURL:  strings.TrimSuffix(publisherURL, "/"),
+		endpointURL:   endpointURL,
+		httpClient:    &http.Client{Timeout: 30 * time.Second},
+		subscriptions: make(map[string]pubsub.PubSub),
+		received:      make(chan struct{}),
+	}
+
+	for _, option := range options {
+		option(receiver)
+	}
+
+	return receiver
+}
+
+// ServeHTTP implements the http.Handler interface. It accepts cloud events in either binary or structured mode and
+// stores them. GET requests are answered with OK so the endpoint can be health checked.
+func (receiver *Receiver) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead:
+		writer.WriteHeader(http.StatusOK)
+
+		return
+	case http.MethodPost:
+	default:
+		writer.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	cloudEvent, err := cehttp.NewEventFromHTTPRequest(request)
+	if err != nil {
+		klog.V(tsparams.LogLevel).Infof("Failed to read cloud event from request: %v", err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	var receivedEvent event.Event
+
+	err = receivedEvent.GetCloudNativeEvents(cloudEvent)
+	if err != nil {
+		klog.V(tsparams.LogLevel).Infof("Failed to convert cloud event %s to PTP event: %v", cloudEvent.ID(), err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	receiver.store(receivedEvent)
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// Subscribe subscribes to the provided resource address on the publisher, such as
+// /cluster/node/<node-name>/sync/ptp-status/lock-state, and returns the created subscription. The publisher sends
+// events for the resource to the endpoint URL until the subscription is deleted.
+func (receiver *Receiver) Subscribe(ctx context.Context, resource string) (pubsub.PubSub, error) {
+	requestBody, err := json.Marshal(pubsub.PubSub{
+		EndPointURI: types.ParseURI(receiver.endpointURL),
+		Resource:    resource,
+	})
+	if err != nil {
+		return pubsub.PubSub{}, fmt.Errorf("failed to marshal subscription to %s: %w", resource, err)
+	}
+
+	responseBody, err := receiver.doRequest(
+		ctx, http.MethodPost, "/subscriptions", bytes.NewReader(requestBody), http.StatusCreated)
+	if err != nil {
+		return pubsub.PubSub{}, fmt.Errorf("failed to subscribe to %s: %w", resource, err)
+	}
+
+	var subscription pubsub.PubSub
+
+	err = json.Unmarshal(responseBody, &subscription)
+	if err != nil {
+		return pubsub.PubSub{}, fmt.Errorf("failed to unmarshal subscription to %s: %w", resource, err)
+	}
+
+	if subscription.ID == "" {
+		return pubsub.PubSub{}, fmt.Errorf("subscription to %s was created without an ID", resource)
+	}
+
+	klog.V(tsparams.LogLevel).Infof("Subscribed to %s with subscription %s", resource, subscription.ID)
+
+	receiver.mutex.Lock()
+	receiver.subscriptions[subscription.ID] = subscription
+	receiver.mutex.Unlock()
+
+	return subscription, nil
+}
+
+// Unsubscribe deletes the subscription with the provided ID from the publisher. Events that were already received for
+// the subscription are kept.
+func (receiver *Receiver) Unsubscribe(ctx context.Context, subscriptionID string) error {
+	_, err := receiver.doRequest(ctx, http.MethodDelete, "/subscriptions/"+subscriptionID, nil, http.StatusNoContent)
+	if err != nil {
+		return fmt.Errorf("failed to delete subscription %s: %w", subscriptionID, err)
+	}
+
+	receiver.mutex.Lock()
+	delete(receiver.subscriptions, subscriptionID)
+	receiver.mutex.Unlock()
+
+	return nil
+}
+
+// UnsubscribeAll deletes every subscription created by this Receiver. It accumulates errors and returns them all at
+// once, so failing to delete one subscription does not prevent the others from being deleted.
+func (receiver *Receiver) UnsubscribeAll(ctx context.Context) error {
+	var unsubscribeErrors []error
+
+	for _, subscription := range receiver.Subscriptions() {
+		err := receiver.Unsubscribe(ctx, subscription.ID)
+		if err != nil {
+			unsubscribeErrors = append(unsubscribeErrors, err)
+		}
+	}
+
+	return errors.Join(unsubscribeErrors...)
+}
+
+// Subscriptions returns the subscriptions created by this Receiver that have not been deleted, sorted by ID.
+func (receiver *Receiver) Subscriptions() []pubsub.PubSub {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	var subscriptions []pubsub.PubSub
+	for _, subscription := range receiver.subscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	slices.SortFunc(subscriptions, func(a, b pubsub.PubSub) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return subscriptions
+}
+
+// CurrentState asks the publisher for the current state of the provided resource address. The returned event is not
+// stored since it was not received through a subscription.
+func (receiver *Receiver) CurrentState(ctx context.Context, resource string) (event.Event, error) {
+	responseBody, err := receiver.doRequest(ctx, http.MethodGet, resource+"/CurrentState", nil, http.StatusOK)
+	if err != nil {
+		return event.Event{}, fmt.Errorf("failed to get current state of %s: %w", resource, err)
+	}
+
+	var currentState event.Event
+
+	err = json.Unmarshal(responseBody, &currentState)
+	if err != nil {
+		return event.Event{}, fmt.Errorf("failed to unmarshal current state of %s: %w", resource, err)
+	}
+
+	return currentState, nil
+}
+
+// Events returns the events received at or after startTime that match the provided filter, in the order they were
+// received. A nil filter matches every event.
+func (receiver *Receiver) Events(startTime time.Time, filter EventFilter) []ReceivedEvent {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	return receiver.matchingEvents(startTime, filter)
+}
+
+// WaitForEvent waits up to the specified timeout for an event matching the provided filter to be received at or after
+// startTime. Like the package-level WaitForEvent, startTime may be in the past and does not count towards the timeout,
+// so events received before WaitForEvent is called are also checked. It returns the first matching event or an error if
+// none is received within the timeout or before ctx is done.
+func (receiver *Receiver) WaitForEvent(
+	ctx context.Context, startTime time.Time, timeout time.Duration, filter EventFilter) (ReceivedEvent, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		receiver.mutex.Lock()
+		matchingEvents := receiver.matchingEvents(startTime, filter)
+		received := receiver.received
+		receiver.mutex.Unlock()
+
+		if len(matchingEvents) > 0 {
+			return matchingEvents[0], nil
+		}
+
+		select {
+		case <-received:
+		case <-timer.C:
+			return ReceivedEvent{}, fmt.Errorf("no event matching filter %#v received within %s", filter, timeout)
+		case <-ctx.Done():
+			return ReceivedEvent{}, fmt.Errorf("stopped waiting for event matching filter %#v: %w", filter, ctx.Err())
+		}
+	}
+}
+
+// Reset discards every received event. Subscriptions are not affected.
+func (receiver *Receiver) Reset() {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	receiver.events = nil
+}
+
+// store adds receivedEvent to the received events and wakes up any goroutines waiting for events.
+func (receiver *Receiver) store(receivedEvent event.Event) {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	klog.V(tsparams.LogLevel).Infof("Received event: %#v", receivedEvent)
+
+	receiver.events = append(receiver.events, ReceivedEvent{Event: receivedEvent, ReceivedAt: time.Now()})
+
+	close(receiver.received)
+	receiver.received = make(chan struct{})
+}
+
+// matchingEvents returns the received events at or after startTime that match filter. The mutex must be held by the
+// caller.
+func (receiver *Receiver) matchingEvents(startTime time.Time, filter EventFilter) []ReceivedEvent {
+	var matchingEvents []ReceivedEvent
+
+	for _, receivedEvent := range receiver.events {
+		if receivedEvent.ReceivedAt.Before(startTime) {
+			continue
+		}
+
+		if filter != nil && !filter.Filter(receivedEvent.Event) {
+			continue
+		}
+
+		matchingEvents = append(matchingEvents, receivedEvent)
+	}
+
+	return matchingEvents
+}
+
+// doRequest sends a request to the provided path on the publisher and returns the response body. It returns an error
+// if the response status code is not expectedStatus.
+func (receiver *Receiver) doRequest(
+	ctx context.Context, method, path string, body io.Reader, expectedStatus int) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, method, receiver.publisherURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s request for %s: %w", method, path, err)
+	}
+
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := receiver.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send %s request for %s: %w", method, path, err)
+	}
+
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response to %s request for %s: %w", method, path, err)
+	}
+
+	if response.StatusCode != expectedStatus {
+		return nil, fmt.Errorf("unexpected status %d for %s request for %s: %s",
+			response.StatusCode, method, path, strings.TrimSpace(string(responseBody)))
+	}
+
+	return responseBody, nil
+}
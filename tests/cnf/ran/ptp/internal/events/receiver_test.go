@@ -0,0 +1,275 @@
+//go:build unit_test
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/redhat-cne/sdk-go/pkg/event"
+	eventptp "github.com/redhat-cne/sdk-go/pkg/event/ptp"
+	"github.com/redhat-cne/sdk-go/pkg/pubsub"
+	"github.com/redhat-cne/sdk-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const testLockStateResource = "/cluster/node/spoke1" + string(eventptp.PtpLockState)
+
+func TestReceiverSubscribeAndWait(t *testing.T) {
+	publisher, receiver := newTestPublisherAndReceiver(t)
+
+	subscription, err := receiver.Subscribe(context.TODO(), testLockStateResource)
+	assert.NoError(t, err)
+	assert.Equal(t, "sub-1", subscription.ID)
+	assert.Equal(t, testLockStateResource, subscription.Resource)
+	assert.Len(t, receiver.Subscriptions(), 1)
+
+	startTime := time.Now()
+	lockedFilter := All(IsType(eventptp.PtpStateChange), HasValue(WithSyncState(eventptp.LOCKED), OnNode("spoke1")))
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		publisher.publish(t, newTestLockStateEvent(eventptp.FREERUN))
+		publisher.publish(t, newTestLockStateEvent(eventptp.LOCKED))
+	}()
+
+	receivedEvent, err := receiver.WaitForEvent(context.TODO(), startTime, 5*time.Second, lockedFilter)
+	assert.NoError(t, err)
+	assert.Equal(t, string(eventptp.PtpStateChange), receivedEvent.Type)
+	assert.False(t, receivedEvent.ReceivedAt.Before(startTime))
+
+	assert.Len(t, receiver.Events(startTime, nil), 2)
+	assert.Len(t, receiver.Events(startTime, HasValue(WithSyncState(eventptp.FREERUN))), 1)
+	assert.Empty(t, receiver.Events(time.Now().Add(time.Minute), nil))
+
+	holdoverFilter := HasValue(WithSyncState(eventptp.HOLDOVER))
+	_, err = receiver.WaitForEvent(context.TODO(), startTime, 100*time.Millisecond, holdoverFilter)
+	assert.ErrorContains(t, err, "no event matching filter")
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	_, err = receiver.WaitForEvent(ctx, startTime, time.Minute, holdoverFilter)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	receiver.Reset()
+	assert.Empty(t, receiver.Events(startTime, nil))
+}
+
+func TestReceiverUnsubscribe(t *testing.T) {
+	publisher, receiver := newTestPublisherAndReceiver(t)
+
+	_, err := receiver.Subscribe(context.TODO(), testLockStateResource)
+	assert.NoError(t, err)
+
+	_, err = receiver.Subscribe(context.TODO(), "/cluster/node/spoke1"+string(eventptp.PtpClockClass))
+	assert.NoError(t, err)
+
+	err = receiver.UnsubscribeAll(context.TODO())
+	assert.NoError(t, err)
+	assert.Empty(t, receiver.Subscriptions())
+	assert.Empty(t, publisher.subscriptionIDs())
+
+	publisher.publish(t, newTestLockStateEvent(eventptp.LOCKED))
+	assert.Empty(t, receiver.Events(time.Time{}, nil))
+
+	err = receiver.Unsubscribe(context.TODO(), "sub-1")
+	assert.ErrorContains(t, err, "unexpected status 404")
+}
+
+func TestReceiverSubscribeRejected(t *testing.T) {
+	_, receiver := newTestPublisherAndReceiver(t)
+
+	_, err := receiver.Subscribe(context.TODO(), "/cluster/node/spoke1/sync/unknown")
+	assert.ErrorContains(t, err, "unexpected status 400")
+	assert.Empty(t, receiver.Subscriptions())
+}
+
+func TestReceiverCurrentState(t *testing.T) {
+	_, receiver := newTestPublisherAndReceiver(t)
+
+	currentState, err := receiver.CurrentState(context.TODO(), testLockStateResource)
+	assert.NoError(t, err)
+	assert.True(t, HasValue(WithSyncState(eventptp.LOCKED)).Filter(currentState))
+	assert.Empty(t, receiver.Events(time.Time{}, nil))
+}
+
+func TestReceiverServeHTTP(t *testing.T) {
+	receiver := NewReceiver("http://publisher.invalid", "http://receiver.invalid")
+
+	recorder := httptest.NewRecorder()
+	receiver.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/event", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	receiver.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/event", strings.NewReader("not an event")))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Empty(t, receiver.Events(time.Time{}, nil))
+}
+
+func TestReceiverServe(t *testing.T) {
+	receiver := NewReceiver("http://publisher.invalid", "http://receiver.invalid")
+
+	address, stop, err := receiver.Serve("127.0.0.1:0")
+	assert.NoError(t, err)
+
+	response, err := http.Get("http://" + address.String())
+	assert.NoError(t, err)
+
+	_ = response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	lockedEvent := newTestLockStateEvent(eventptp.LOCKED)
+	cloudEvent, err := lockedEvent.NewCloudEventV2()
+	assert.NoError(t, err)
+
+	request, err := cehttp.NewHTTPRequestFromEvent(context.TODO(), "http://"+address.String(), *cloudEvent)
+	assert.NoError(t, err)
+
+	response, err = http.DefaultClient.Do(request)
+	assert.NoError(t, err)
+
+	_ = response.Body.Close()
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+	assert.Len(t, receiver.Events(time.Time{}, HasValue(WithSyncState(eventptp.LOCKED))), 1)
+
+	assert.NoError(t, stop(context.TODO()))
+
+	_, err = http.Get("http://" + address.String())
+	assert.Error(t, err)
+}
+
+// testPublisher is a stand-in for the v2 REST API of the event publisher. It only accepts subscriptions to the PTP
+// lock state and clock class resources and sends published events to the endpoints of matching subscriptions.
+type testPublisher struct {
+	server *httptest.Server
+
+	mutex         sync.Mutex
+	nextID        int
+	subscriptions map[string]pubsub.PubSub
+}
+
+// newTestPublisherAndReceiver starts a testPublisher and a Receiver subscribing to it, both of which are stopped when
+// the test ends.
+func newTestPublisherAndReceiver(t *testing.T) (*testPublisher, *Receiver) {
+	t.Helper()
+
+	publisher := &testPublisher{subscriptions: make(map[string]pubsub.PubSub)}
+	publisher.server = httptest.NewServer(publisher)
+	t.Cleanup(publisher.server.Close)
+
+	endpoint := httptest.NewUnstartedServer(nil)
+	receiver := NewReceiver(
+		publisher.server.URL+"/api/ocloudNotifications/v2", "http://"+endpoint.Listener.Addr().String())
+	endpoint.Config.Handler = receiver
+	endpoint.Start()
+	t.Cleanup(endpoint.Close)
+
+	return publisher, receiver
+}
+
+func (publisher *testPublisher) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	path := strings.TrimPrefix(request.URL.Path, "/api/ocloudNotifications/v2")
+
+	switch {
+	case request.Method == http.MethodPost && path == "/subscriptions":
+		var subscription pubsub.PubSub
+
+		err := json.NewDecoder(request.Body).Decode(&subscription)
+		if err != nil || !strings.HasSuffix(subscription.Resource, string(eventptp.PtpLockState)) &&
+			!strings.HasSuffix(subscription.Resource, string(eventptp.PtpClockClass)) {
+			http.Error(writer, "invalid subscription", http.StatusBadRequest)
+
+			return
+		}
+
+		publisher.mutex.Lock()
+		publisher.nextID++
+		subscription.ID = fmt.Sprintf("sub-%d", publisher.nextID)
+		subscription.URILocation = types.ParseURI(publisher.server.URL + path + "/" + subscription.ID)
+		publisher.subscriptions[subscription.ID] = subscription
+		publisher.mutex.Unlock()
+
+		writer.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(writer).Encode(subscription)
+	case request.Method == http.MethodDelete && strings.HasPrefix(path, "/subscriptions/"):
+		publisher.mutex.Lock()
+		defer publisher.mutex.Unlock()
+
+		subscriptionID := strings.TrimPrefix(path, "/subscriptions/")
+		if _, ok := publisher.subscriptions[subscriptionID]; !ok {
+			http.Error(writer, "subscription not found", http.StatusNotFound)
+
+			return
+		}
+
+		delete(publisher.subscriptions, subscriptionID)
+		writer.WriteHeader(http.StatusNoContent)
+	case request.Method == http.MethodGet && path == testLockStateResource+"/CurrentState":
+		_ = json.NewEncoder(writer).Encode(newTestLockStateEvent(eventptp.LOCKED))
+	default:
+		http.NotFound(writer, request)
+	}
+}
+
+// publish sends testEvent as a cloud event to every subscription whose resource is the source of the event.
+func (publisher *testPublisher) publish(t *testing.T, testEvent event.Event) {
+	t.Helper()
+
+	cloudEvent, err := testEvent.NewCloudEventV2()
+	assert.NoError(t, err)
+
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+
+	for _, subscription := range publisher.subscriptions {
+		if subscription.Resource != testEvent.Source {
+			continue
+		}
+
+		request, err := cehttp.NewHTTPRequestFromEvent(context.TODO(), subscription.GetEndpointURI(), *cloudEvent)
+		assert.NoError(t, err)
+
+		response, err := http.DefaultClient.Do(request)
+		assert.NoError(t, err)
+
+		_ = response.Body.Close()
+		assert.Equal(t, http.StatusNoContent, response.StatusCode)
+	}
+}
+
+// subscriptionIDs returns the IDs of the subscriptions that have not been deleted.
+func (publisher *testPublisher) subscriptionIDs() []string {
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+
+	var subscriptionIDs []string
+	for subscriptionID := range publisher.subscriptions {
+		subscriptionIDs = append(subscriptionIDs, subscriptionID)
+	}
+
+	return subscriptionIDs
+}
+
+// newTestLockStateEvent returns a lock state event for spoke1 with the provided sync state, as the publisher would
+// send it.
+func newTestLockStateEvent(syncState eventptp.SyncState) event.Event {
+	testEvent := newTestEvent(eventptp.PtpStateChange, event.DataValue{
+		Resource:  "/cluster/node/spoke1/ens1fx/master",
+		DataType:  event.NOTIFICATION,
+		ValueType: event.ENUMERATION,
+		Value:     string(syncState),
+	})
+	testEvent.SetSource(testLockStateResource)
+	testEvent.SetTime(time.Now())
+
+	return testEvent
+}
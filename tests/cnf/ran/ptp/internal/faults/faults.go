@@ -0,0 +1,288 @@
+// Package faults provides a declarative layer for injecting PTP faults, such as GNSS loss or SMA disconnects, and
+// guaranteeing they are restored. Faults are described by a Spec and injected through an Injector, which records every
+// injection and restoration in a Journal and restores any fault still active when the spec ends.
+package faults
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/iface"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/processes"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
+	"k8s.io/klog/v2"
+)
+
+// ReportEntryName is the name of the report entry NewSpecInjector attaches the journal to.
+const ReportEntryName = "ptp fault journal"
+
+// Type enumerates the supported types of faults.
+type Type string
+
+const (
+	// TypeGNSSLoss simulates a loss of GNSS sync on the node using ubxtool. It requires ProtocolVersion.
+	TypeGNSSLoss Type = "gnss-loss"
+	// TypeSMADisconnect disconnects the SMA Pin of Interface. It requires Interface, Pin, and PinConfig, which is the
+	// sysfs value the pin is restored to, such as the one from profiles.GetSmaPinFromProfile.
+	TypeSMADisconnect Type = "sma-disconnect"
+	// TypeInterfaceDown sets Interface down. It requires Interface.
+	TypeInterfaceDown Type = "interface-down"
+	// TypePHCAdjust adjusts the PTP hardware clock of Interface by Offset and is restored by resetting the clock. It
+	// requires Interface.
+	TypePHCAdjust Type = "phc-adjust"
+	// TypeProcessKill kills Process and is restored once the daemon restarts it. It requires Process.
+	TypeProcessKill Type = "process-kill"
+)
+
+// Spec describes a fault to inject. Only the fields required by the Type need to be set.
+type Spec struct {
+	Type Type
+	Node string
+
+	Interface       iface.Name
+	Pin             string
+	PinConfig       string
+	ProtocolVersion string
+	Process         processes.PtpProcess
+	Offset          time.Duration
+
+	// Duration is how long Apply holds the fault before restoring it. It is ignored by Inject and During.
+	Duration time.Duration
+	// Repeat is how many times Apply injects the fault. Values less than 1 inject it once.
+	Repeat int
+	// Interval is how long Apply waits after restoring the fault before injecting it again.
+	Interval time.Duration
+}
+
+// String returns a short description of the fault for logs and the journal.
+func (spec Spec) String() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "%s on %s", spec.Type, spec.Node)
+
+	if spec.Interface != "" {
+		fmt.Fprintf(&builder, " interface %s", spec.Interface)
+	}
+
+	if spec.Pin != "" {
+		fmt.Fprintf(&builder, " pin %s", spec.Pin)
+	}
+
+	if spec.Process != "" {
+		fmt.Fprintf(&builder, " process %s", spec.Process)
+	}
+
+	if spec.Offset != 0 {
+		fmt.Fprintf(&builder, " offset %s", spec.Offset)
+	}
+
+	return builder.String()
+}
+
+// Fault is a fault injected by an Injector.
+type Fault struct {
+	Spec Spec
+
+	injector *Injector
+	// restoring is whether the handler is currently restoring the fault, so concurrent calls to Restore do not run it
+	// twice.
+	restoring bool
+	restored  bool
+}
+
+// Restore restores the fault. Restoring a fault that has already been restored is a no-op, as is restoring one that is
+// being restored concurrently, which leaves the result to the call already restoring it. If restoring fails, the fault
+// remains active so the Injector tries again in RestoreAll.
+func (fault *Fault) Restore() error {
+	return fault.injector.restore(fault)
+}
+
+// Injector injects faults and keeps track of the ones still active so they can be restored. It is safe for concurrent
+// use.
+type Injector struct {
+	client   *clients.Settings
+	journal  *Journal
+	handlers map[Type]handler
+	sleep    func(time.Duration)
+
+	mutex  sync.Mutex
+	active []*Fault
+}
+
+// NewInjector returns an Injector for the cluster of client. Faults it injects are only restored when requested, so
+// tests should generally use NewSpecInjector instead.
+func NewInjector(client *clients.Settings) *Injector {
+	return &Injector{
+		client:   client,
+		journal:  &Journal{now: time.Now},
+		handlers: defaultHandlers,
+		sleep:    time.Sleep,
+	}
+}
+
+// NewSpecInjector returns an Injector whose active faults are restored by a cleanup registered with DeferCleanup, which
+// also attaches the journal to the spec report. It must be called from a setup or subject node, such as BeforeEach or
+// It. Since the cleanup runs even if the spec fails, the lab is restored after assertions in the middle of a fault
+// fail.
+func NewSpecInjector(client *clients.Settings) *Injector {
+	injector := NewInjector(client)
+
+	ginkgo.DeferCleanup(func() error {
+		err := injector.RestoreAll()
+
+		ginkgo.AddReportEntry(ReportEntryName, injector.journal.Entries(), ginkgo.ReportEntryVisibilityFailureOrVerbose)
+
+		return err
+	})
+
+	return injector
+}
+
+// Journal returns the journal of the faults injected and restored by this Injector.
+func (injector *Injector) Journal() *Journal {
+	return injector.journal
+}
+
+// Inject injects the fault described by spec and returns it so it can be restored later. Duration, Repeat, and
+// Interval are ignored. If injecting fails, the fault may be partially injected, so it is still tracked and restored by
+// RestoreAll.
+func (injector *Injector) Inject(spec Spec) (*Fault, error) {
+	err := spec.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid fault: %w", err)
+	}
+
+	faultHandler, ok := injector.handlers[spec.Type]
+	if !ok {
+		return nil, fmt.Errorf("no handler for fault type %s", spec.Type)
+	}
+
+	fault := &Fault{Spec: spec, injector: injector}
+
+	injector.mutex.Lock()
+	injector.active = append(injector.active, fault)
+	injector.mutex.Unlock()
+
+	klog.V(tsparams.LogLevel).Infof("Injecting fault %s", spec)
+
+	err = faultHandler.inject(injector.client, spec)
+	if err != nil {
+		injector.journal.record(ActionInjectFailed, spec, err)
+
+		return nil, fmt.Errorf("failed to inject fault %s: %w", spec, err)
+	}
+
+	injector.journal.record(ActionInjected, spec, nil)
+
+	return fault, nil
+}
+
+// Apply injects the fault described by spec, holds it for spec.Duration, and restores it, repeating spec.Repeat times
+// with spec.Interval between restoring and injecting again. If any step fails, Apply returns immediately and the fault
+// is left to RestoreAll.
+func (injector *Injector) Apply(spec Spec) error {
+	repeat := max(spec.Repeat, 1)
+
+	for iteration := range repeat {
+		fault, err := injector.Inject(spec)
+		if err != nil {
+			return err
+		}
+
+		injector.sleep(spec.Duration)
+
+		err = fault.Restore()
+		if err != nil {
+			return err
+		}
+
+		if iteration < repeat-1 {
+			injector.sleep(spec.Interval)
+		}
+	}
+
+	return nil
+}
+
+// During injects the fault described by spec, calls action, and then restores the fault, composing faults when action
+// injects others. For example, GNSS loss can be simulated during an SMA disconnect:
+//
+//	err := injector.During(smaSpec, func() error {
+//		return injector.Apply(gnssSpec)
+//	})
+//
+// The fault is restored even if action returns an error, and both errors are returned. If action panics, as failed
+// Gomega assertions do, the fault is left to RestoreAll.
+func (injector *Injector) During(spec Spec, action func() error) error {
+	fault, err := injector.Inject(spec)
+	if err != nil {
+		return err
+	}
+
+	actionErr := action()
+	restoreErr := fault.Restore()
+
+	return errors.Join(actionErr, restoreErr)
+}
+
+// RestoreAll restores every active fault in the reverse order they were injected, so composed faults are unwound the
+// same way they were built. All faults are attempted even if some fail, and the errors are joined.
+func (injector *Injector) RestoreAll() error {
+	injector.mutex.Lock()
+	active := slices.Clone(injector.active)
+	injector.mutex.Unlock()
+
+	var errs []error
+
+	for _, fault := range slices.Backward(active) {
+		errs = append(errs, fault.Restore())
+	}
+
+	return errors.Join(errs...)
+}
+
+// restore restores the fault and stops tracking it if successful.
+func (injector *Injector) restore(fault *Fault) error {
+	injector.mutex.Lock()
+
+	if fault.restored || fault.restoring {
+		injector.mutex.Unlock()
+
+		return nil
+	}
+
+	fault.restoring = true
+	injector.mutex.Unlock()
+
+	klog.V(tsparams.LogLevel).Infof("Restoring fault %s", fault.Spec)
+
+	err := injector.handlers[fault.Spec.Type].restore(injector.client, fault.Spec)
+	if err != nil {
+		injector.journal.record(ActionRestoreFailed, fault.Spec, err)
+
+		injector.mutex.Lock()
+		fault.restoring = false
+		injector.mutex.Unlock()
+
+		return fmt.Errorf("failed to restore fault %s: %w", fault.Spec, err)
+	}
+
+	injector.journal.record(ActionRestored, fault.Spec, nil)
+
+	injector.mutex.Lock()
+	defer injector.mutex.Unlock()
+
+	fault.restoring = false
+	fault.restored = true
+	injector.active = slices.DeleteFunc(injector.active, func(active *Fault) bool {
+		return active == fault
+	})
+
+	return nil
+}
@@ -0,0 +1,248 @@
+//go:build unit_test
+
+package faults
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/processes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testGNSSSpec = Spec{Type: TypeGNSSLoss, Node: "node-0", ProtocolVersion: "29.20", Duration: time.Minute}
+	testSMASpec  = Spec{Type: TypeSMADisconnect, Node: "node-0", Interface: "ens1f0", Pin: "SMA1", PinConfig: "1 1"}
+)
+
+// testInjector is an Injector whose handlers record the calls made to them instead of modifying a cluster.
+type testInjector struct {
+	*Injector
+	calls  []string
+	sleeps []time.Duration
+	// failures maps calls to the error they return.
+	failures map[string]error
+}
+
+func newTestInjector() *testInjector {
+	testInjector := &testInjector{Injector: NewInjector(nil), failures: map[string]error{}}
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	testInjector.journal.now = func() time.Time {
+		now = now.Add(time.Second)
+
+		return now
+	}
+
+	testInjector.sleep = func(duration time.Duration) {
+		testInjector.sleeps = append(testInjector.sleeps, duration)
+	}
+
+	testInjector.handlers = map[Type]handler{}
+	for faultType := range defaultHandlers {
+		testInjector.handlers[faultType] = handler{
+			inject:  testInjector.handle("inject"),
+			restore: testInjector.handle("restore"),
+		}
+	}
+
+	return testInjector
+}
+
+func (testInjector *testInjector) handle(action string) func(*clients.Settings, Spec) error {
+	return func(_ *clients.Settings, spec Spec) error {
+		call := action + " " + string(spec.Type)
+		testInjector.calls = append(testInjector.calls, call)
+
+		return testInjector.failures[call]
+	}
+}
+
+func TestSpecValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		spec        Spec
+		expectedErr string
+	}{
+		{name: "valid gnss", spec: testGNSSSpec},
+		{name: "valid sma", spec: testSMASpec},
+		{name: "valid process", spec: Spec{Type: TypeProcessKill, Node: "node-0", Process: processes.Ptp4l}},
+		{
+			name:        "missing node",
+			spec:        Spec{Type: TypeInterfaceDown, Interface: "ens1f0"},
+			expectedErr: "fault interface-down has no node",
+		},
+		{
+			name:        "missing pin",
+			spec:        Spec{Type: TypeSMADisconnect, Node: "node-0", Interface: "ens1f0"},
+			expectedErr: "fault sma-disconnect requires both an interface and a pin",
+		},
+		{
+			name:        "missing pin config",
+			spec:        Spec{Type: TypeSMADisconnect, Node: "node-0", Interface: "ens1f0", Pin: "SMA1"},
+			expectedErr: "fault sma-disconnect has no pin config to restore",
+		},
+		{
+			name:        "missing interface",
+			spec:        Spec{Type: TypePHCAdjust, Node: "node-0"},
+			expectedErr: "fault phc-adjust has no interface",
+		},
+		{
+			name:        "unknown type",
+			spec:        Spec{Type: "reboot", Node: "node-0"},
+			expectedErr: `unknown fault type "reboot"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.spec.validate()
+			if testCase.expectedErr == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func TestInjectorApply(t *testing.T) {
+	injector := newTestInjector()
+
+	spec := testGNSSSpec
+	spec.Repeat = 2
+	spec.Interval = 30 * time.Second
+
+	err := injector.Apply(spec)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"inject gnss-loss", "restore gnss-loss", "inject gnss-loss", "restore gnss-loss",
+	}, injector.calls)
+	assert.Equal(t, []time.Duration{time.Minute, 30 * time.Second, time.Minute}, injector.sleeps)
+	assert.Empty(t, injector.active)
+
+	entries := injector.Journal().Entries()
+	require.Len(t, entries, 4)
+	assert.Equal(t, JournalEntry{
+		Time:   time.Date(2026, 1, 2, 3, 4, 6, 0, time.UTC),
+		Action: ActionInjected,
+		Fault:  "gnss-loss on node-0",
+	}, entries[0])
+	assert.Equal(t, ActionRestored, entries[3].Action)
+}
+
+func TestInjectorDuring(t *testing.T) {
+	injector := newTestInjector()
+
+	err := injector.During(testSMASpec, func() error {
+		return injector.Apply(testGNSSSpec)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"inject sma-disconnect", "inject gnss-loss", "restore gnss-loss", "restore sma-disconnect",
+	}, injector.calls)
+	assert.Empty(t, injector.active)
+
+	injector = newTestInjector()
+	actionErr := errors.New("holdover not entered")
+
+	err = injector.During(testSMASpec, func() error {
+		return actionErr
+	})
+	assert.ErrorIs(t, err, actionErr)
+	assert.Equal(t, []string{"inject sma-disconnect", "restore sma-disconnect"}, injector.calls)
+}
+
+func TestInjectorRestoreAll(t *testing.T) {
+	injector := newTestInjector()
+	injector.failures["restore sma-disconnect"] = errors.New("pin not found")
+	injector.failures["inject interface-down"] = errors.New("link busy")
+
+	smaFault, err := injector.Inject(testSMASpec)
+	require.NoError(t, err)
+
+	_, err = injector.Inject(testGNSSSpec)
+	require.NoError(t, err)
+
+	// A fault that fails to be injected may be partially injected, so it is still restored.
+	_, err = injector.Inject(Spec{Type: TypeInterfaceDown, Node: "node-0", Interface: "ens1f1"})
+	require.ErrorContains(t, err, "link busy")
+
+	err = injector.RestoreAll()
+	require.ErrorContains(t, err, "failed to restore fault sma-disconnect on node-0 interface ens1f0 pin SMA1")
+
+	assert.Equal(t, []string{
+		"inject sma-disconnect", "inject gnss-loss", "inject interface-down",
+		"restore interface-down", "restore gnss-loss", "restore sma-disconnect",
+	}, injector.calls)
+	assert.Equal(t, []*Fault{smaFault}, injector.active)
+
+	// The failed restoration is retried on the next call, and restored faults are not restored again.
+	delete(injector.failures, "restore sma-disconnect")
+
+	err = injector.RestoreAll()
+	require.NoError(t, err)
+	assert.Empty(t, injector.active)
+	assert.Equal(t, "restore sma-disconnect", injector.calls[len(injector.calls)-1])
+	assert.Len(t, injector.calls, 7)
+
+	actions := []Action{}
+	for _, entry := range injector.Journal().Entries() {
+		actions = append(actions, entry.Action)
+	}
+
+	assert.Equal(t, []Action{
+		ActionInjected, ActionInjected, ActionInjectFailed,
+		ActionRestored, ActionRestored, ActionRestoreFailed, ActionRestored,
+	}, actions)
+
+	table := injector.Journal().Entries().String()
+	assert.Regexp(t,
+		`(?m)^2026-01-02T03:04:08Z +inject failed +interface-down on node-0 interface ens1f1 +link busy$`, table)
+}
+
+func TestFaultRestoreConcurrent(t *testing.T) {
+	injector := NewInjector(nil)
+
+	var restores atomic.Int32
+
+	restoreStarted := make(chan struct{})
+	finishRestore := make(chan struct{})
+
+	injector.handlers = map[Type]handler{TypeGNSSLoss: {
+		inject: func(*clients.Settings, Spec) error { return nil },
+		restore: func(*clients.Settings, Spec) error {
+			restores.Add(1)
+			close(restoreStarted)
+			<-finishRestore
+
+			return nil
+		},
+	}}
+
+	fault, err := injector.Inject(testGNSSSpec)
+	require.NoError(t, err)
+
+	restoreErr := make(chan error)
+
+	go func() {
+		restoreErr <- fault.Restore()
+	}()
+
+	<-restoreStarted
+
+	assert.NoError(t, injector.RestoreAll())
+	close(finishRestore)
+	assert.NoError(t, <-restoreErr)
+	assert.Equal(t, int32(1), restores.Load())
+	assert.NoError(t, fault.Restore())
+	assert.Equal(t, int32(1), restores.Load())
+}
@@ -0,0 +1,101 @@
+package faults
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/gnss"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/iface"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/processes"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/sma"
+)
+
+// processRestartTimeout is how long restoring a killed process waits for the daemon to restart it.
+const processRestartTimeout = 2 * time.Minute
+
+// handler injects and restores a single type of fault.
+type handler struct {
+	inject  func(client *clients.Settings, spec Spec) error
+	restore func(client *clients.Settings, spec Spec) error
+}
+
+// defaultHandlers are the handlers used by injectors, which call the existing helpers for each type of fault.
+var defaultHandlers = map[Type]handler{
+	TypeGNSSLoss: {
+		inject: func(client *clients.Settings, spec Spec) error {
+			return gnss.SimulateSyncLoss(client, spec.Node, spec.ProtocolVersion)
+		},
+		restore: func(client *clients.Settings, spec Spec) error {
+			return gnss.SimulateSyncRecovery(client, spec.Node, spec.ProtocolVersion)
+		},
+	},
+	TypeSMADisconnect: {
+		inject: func(client *clients.Settings, spec Spec) error {
+			return sma.DisconnectSma(client, spec.Node, spec.Interface, spec.Pin)
+		},
+		restore: func(client *clients.Settings, spec Spec) error {
+			return sma.ReconnectSma(client, spec.Node, spec.Interface, spec.Pin, spec.PinConfig)
+		},
+	},
+	TypeInterfaceDown: {
+		inject: func(client *clients.Settings, spec Spec) error {
+			return iface.SetInterfaceStatus(client, spec.Node, spec.Interface, iface.InterfaceStateDown)
+		},
+		restore: func(client *clients.Settings, spec Spec) error {
+			return iface.SetInterfaceStatus(client, spec.Node, spec.Interface, iface.InterfaceStateUp)
+		},
+	},
+	TypePHCAdjust: {
+		inject: func(client *clients.Settings, spec Spec) error {
+			return iface.AdjustPTPHardwareClock(client, spec.Node, spec.Interface, spec.Offset.Seconds())
+		},
+		restore: func(client *clients.Settings, spec Spec) error {
+			return iface.ResetPTPHardwareClock(client, spec.Node, spec.Interface)
+		},
+	},
+	TypeProcessKill: {
+		inject: func(client *clients.Settings, spec Spec) error {
+			return processes.KillPtpProcess(client, spec.Node, spec.Process)
+		},
+		// Killed processes are restarted by the daemon, so restoring only waits for that to happen.
+		restore: func(client *clients.Settings, spec Spec) error {
+			return processes.WaitForProcessRunning(client, spec.Node, spec.Process, true, processRestartTimeout)
+		},
+	},
+}
+
+// validate returns an error if the spec is missing a field required by its type.
+func (spec Spec) validate() error {
+	if spec.Node == "" {
+		return fmt.Errorf("fault %s has no node", spec.Type)
+	}
+
+	switch spec.Type {
+	case TypeGNSSLoss:
+		if spec.ProtocolVersion == "" {
+			return fmt.Errorf("fault %s has no protocol version", spec.Type)
+		}
+	case TypeSMADisconnect:
+		if spec.Interface == "" || spec.Pin == "" {
+			return fmt.Errorf("fault %s requires both an interface and a pin", spec.Type)
+		}
+
+		// Restoring the fault writes PinConfig to the pin, so without one the pin would be left disconnected.
+		if spec.PinConfig == "" {
+			return fmt.Errorf("fault %s has no pin config to restore", spec.Type)
+		}
+	case TypeInterfaceDown, TypePHCAdjust:
+		if spec.Interface == "" {
+			return fmt.Errorf("fault %s has no interface", spec.Type)
+		}
+	case TypeProcessKill:
+		if spec.Process == "" {
+			return fmt.Errorf("fault %s has no process", spec.Type)
+		}
+	default:
+		return fmt.Errorf("unknown fault type %q", spec.Type)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package faults
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Action is what happened to a fault in a journal entry.
+type Action string
+
+const (
+	// ActionInjected means the fault was injected.
+	ActionInjected Action = "injected"
+	// ActionRestored means the fault was restored.
+	ActionRestored Action = "restored"
+	// ActionInjectFailed means injecting the fault returned an error. The fault may be partially injected, so it
+	// is still restored.
+	ActionInjectFailed Action = "inject failed"
+	// ActionRestoreFailed means restoring the fault returned an error. The lab may need to be restored manually.
+	ActionRestoreFailed Action = "restore failed"
+)
+
+// JournalEntry is a single action taken on a fault.
+type JournalEntry struct {
+	Time   time.Time `json:"time"`
+	Action Action    `json:"action"`
+	Fault  string    `json:"fault"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// JournalEntries are the entries of a journal in the order the actions were taken. They are provided as their own type
+// so they can be attached to the report as a table.
+type JournalEntries []JournalEntry
+
+// String returns the entries as a table with one row per entry.
+func (entries JournalEntries) String() string {
+	if len(entries) == 0 {
+		return "No faults were injected"
+	}
+
+	var builder strings.Builder
+
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(writer, "TIME\tACTION\tFAULT\tERROR")
+
+	for _, entry := range entries {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n",
+			entry.Time.UTC().Format(time.RFC3339Nano), entry.Action, entry.Fault, entry.Error)
+	}
+
+	_ = writer.Flush()
+
+	return builder.String()
+}
+
+// Journal records the faults injected and restored by an Injector with timestamps. It is safe for concurrent use.
+type Journal struct {
+	mutex   sync.Mutex
+	entries JournalEntries
+	now     func() time.Time
+}
+
+// Entries returns a copy of the entries recorded so far.
+func (journal *Journal) Entries() JournalEntries {
+	journal.mutex.Lock()
+	defer journal.mutex.Unlock()
+
+	return append(JournalEntries(nil), journal.entries...)
+}
+
+// record appends an entry for the action on the fault described by spec. A nil err records no error.
+func (journal *Journal) record(action Action, spec Spec, err error) {
+	journal.mutex.Lock()
+	defer journal.mutex.Unlock()
+
+	entry := JournalEntry{Time: journal.now(), Action: action, Fault: spec.String()}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	journal.entries = append(journal.entries, entry)
+}
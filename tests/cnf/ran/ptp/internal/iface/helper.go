@@ -46,15 +46,12 @@ func GetEgressInterfaceName(client *clients.Settings, nodeName string) (Name, er
 // GetPTPHardwareClock uses ethtool to retrieve the PTP hardware clock for a given network interface on a specified
 // node.
 func GetPTPHardwareClock(client *clients.Settings, nodeName string, ifName Name) (int, error) {
-	command := fmt.Sprintf(
-		"ethtool -T %s | grep -E 'PTP Hardware Clock|Hardware timestamp provider index' | awk '{print $NF}'", ifName)
-
-	output, err := ptpdaemon.ExecuteCommandInPtpDaemonPod(client, nodeName, command)
+	output, err := ptpdaemon.ExecuteCommandInPtpDaemonPod(client, nodeName, ptpHardwareClockCommand(ifName))
 	if err != nil {
 		return -1, fmt.Errorf("failed to get PTP hardware clock for interface %s on node %s: %w", ifName, nodeName, err)
 	}
 
-	hardwareClock, err := strconv.Atoi(strings.TrimSpace(output))
+	hardwareClock, err := parsePTPHardwareClock(output)
 	if err != nil {
 		return -1, fmt.Errorf("failed to convert PTP hardware clock for interface %s on node %s to int: %w",
 			ifName, nodeName, err)
@@ -63,6 +60,18 @@ func GetPTPHardwareClock(client *clients.Settings, nodeName string, ifName Name)
 	return hardwareClock, nil
 }
 
+// ptpHardwareClockCommand returns the command printing the PTP hardware clock index of ifName. Older versions of
+// ethtool label it PTP Hardware Clock, while newer ones label it Hardware timestamp provider index.
+func ptpHardwareClockCommand(ifName Name) string {
+	return fmt.Sprintf(
+		"ethtool -T %s | grep -E 'PTP Hardware Clock|Hardware timestamp provider index' | awk '{print $NF}'", ifName)
+}
+
+// parsePTPHardwareClock parses the output of ptpHardwareClockCommand into the PTP hardware clock index.
+func parsePTPHardwareClock(output string) (int, error) {
+	return strconv.Atoi(strings.TrimSpace(output))
+}
+
 // AdjustPTPHardwareClock adjusts the PTP hardware clock for a given network interface on a specified node. This affects
 // the CLOCK_REALTIME offset. The amount is in seconds.
 func AdjustPTPHardwareClock(client *clients.Settings, nodeName string, ifName Name, amount float64) error {
@@ -0,0 +1,44 @@
+//go:build unit_test
+
+package iface
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePTPHardwareClock(t *testing.T) {
+	fixtures, err := cluster.ReadExecFixtures("testdata/ethtool-ptp-hardware-clock.yaml")
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		nodeName      string
+		ifName        Name
+		expectedClock int
+		expectedError bool
+	}{
+		{name: "ptp hardware clock", nodeName: "spoke1-e810", ifName: "ens1f0", expectedClock: 2},
+		{name: "timestamp provider index", nodeName: "spoke1-e825", ifName: "eno8303", expectedClock: 1},
+		{name: "no hardware clock", nodeName: "spoke1-e810", ifName: "ens2f1", expectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			output, err := fixtures.Stdout(testCase.nodeName, ptpHardwareClockCommand(testCase.ifName))
+			assert.NoError(t, err)
+
+			hardwareClock, err := parsePTPHardwareClock(output)
+			if testCase.expectedError {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedClock, hardwareClock)
+		})
+	}
+}
@@ -6,10 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
-	"strings"
 	"time"
 
-	"github.com/onsi/ginkgo/v2/types"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/olm"
@@ -19,6 +17,7 @@ import (
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/version"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/reporter"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -47,61 +46,43 @@ const (
 	mustGatherPodTimeout = 10 * time.Minute
 	// mustGatherPodName is the name of the must-gather pod.
 	mustGatherPodName = "ptp-must-gather"
+
+	// CollectorName is the name the must-gather collector is registered with.
+	CollectorName = "ptp-must-gather"
+	// CollectorTimeout allows for the must-gather pod to complete and its resources to be cleaned up.
+	CollectorTimeout = mustGatherPodTimeout + 5*time.Minute
 )
 
 var majorMinorVersionRegex = regexp.MustCompile(`(\d+)\.(\d+)`)
 
-// MustGatherIfFailed runs the PTP must-gather if the test has failed, saving the output to the report directory.
-func MustGatherIfFailed(
-	report types.SpecReport,
-	testSuite string,
-	client *clients.Settings,
-) {
-	if !report.State.Is(types.SpecStateFailureStates) {
-		return
-	}
-
-	if client == nil {
-		klog.V(ranparam.LogLevel).Info("Client is nil, skipping PTP must-gather")
-
-		return
-	}
-
-	dumpDir := RANConfig.GetDumpFailedTestReportLocation(testSuite)
-	if dumpDir == "" {
-		klog.V(ranparam.LogLevel).Info("No dump directory configured, skipping PTP must-gather")
-
-		return
-	}
-
-	image, err := getMustGatherImage(client)
-	if err != nil {
-		klog.V(ranparam.LogLevel).Infof("Failed to get must-gather image: %v, skipping PTP must-gather", err)
-
-		return
-	}
-
-	klog.V(ranparam.LogLevel).Infof("Running PTP must-gather with image: %s", image)
-
-	// Create the test case specific directory for the must-gather output
-	tcReportFolderName := strings.ReplaceAll(report.FullText(), " ", "_")
-	mustGatherDir := filepath.Join(dumpDir, tcReportFolderName)
+// Collector returns a reporter.Collector that runs the PTP must-gather on the cluster of client, saving the output
+// to the directory of the collector in the failure bundle. It should be registered with a timeout of at least
+// CollectorTimeout. Once ctx is done, the collector stops waiting for the must-gather and cleans up its resources
+// before returning.
+func Collector(client *clients.Settings) reporter.Collector {
+	return reporter.CollectorFunc(func(ctx context.Context, spec reporter.FailedSpec) error {
+		if client == nil {
+			return fmt.Errorf("client is nil, skipping PTP must-gather")
+		}
 
-	if err := os.MkdirAll(mustGatherDir, 0755); err != nil {
-		klog.V(ranparam.LogLevel).Infof("Failed to create must-gather directory: %v", err)
+		image, err := getMustGatherImage(client)
+		if err != nil {
+			return fmt.Errorf("failed to get must-gather image: %w", err)
+		}
 
-		return
-	}
+		klog.V(ranparam.LogLevel).Infof("Running PTP must-gather with image: %s", image)
 
-	tarballPath := filepath.Join(mustGatherDir, "ptp-must-gather.tar")
+		tarballPath := filepath.Join(spec.Dir, "ptp-must-gather.tar")
 
-	if err := runMustGather(client, image, tarballPath); err != nil {
-		klog.V(ranparam.LogLevel).Infof("Failed to run PTP must-gather: %v", err)
+		err = runMustGather(ctx, client, image, tarballPath)
+		if err != nil {
+			return fmt.Errorf("failed to run PTP must-gather: %w", err)
+		}
 
-		return
-	}
+		klog.V(ranparam.LogLevel).Infof("PTP must-gather completed successfully, output saved to: %s", tarballPath)
 
-	klog.V(ranparam.LogLevel).Infof("PTP must-gather completed successfully, output saved to: %s", tarballPath)
+		return nil
+	})
 }
 
 // getMustGatherImage retrieves the must-gather image, trying in order:
@@ -164,8 +145,10 @@ func getPtpMustGatherImageForVersion(ocpVersion string) (string, error) {
 	return fmt.Sprintf("registry.redhat.io/openshift4/ptp-must-gather-rhel8:v%s.%s", major, minor), nil
 }
 
-// runMustGather creates the necessary resources, runs the must-gather pod, and downloads the output.
-func runMustGather(client *clients.Settings, image, tarballPath string) error {
+// runMustGather creates the necessary resources, runs the must-gather pod, and downloads the output. It returns early
+// once ctx is done, but the resources it created are always cleaned up, since they would otherwise be left on the
+// cluster.
+func runMustGather(ctx context.Context, client *clients.Settings, image, tarballPath string) error {
 	runID := time.Now().UnixNano()
 	nsName := fmt.Sprintf("%s%d", mustGatherNamespacePrefix, runID)
 	crbName := fmt.Sprintf("%s%d", mustGatherCRBPrefix, runID)
@@ -183,6 +166,10 @@ func runMustGather(client *clients.Settings, image, tarballPath string) error {
 		}
 	}()
 
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
 	_, err = serviceaccount.NewBuilder(client, mustGatherServiceAccountName, nsName).Create()
 	if err != nil {
 		return fmt.Errorf("failed to create service account: %w", err)
@@ -201,16 +188,24 @@ func runMustGather(client *clients.Settings, image, tarballPath string) error {
 		return fmt.Errorf("failed to create cluster role binding: %w", err)
 	}
 
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
 	podBuilder, err := createMustGatherPod(client, nsName, image)
 	if err != nil {
 		return fmt.Errorf("failed to create must-gather pod: %w", err)
 	}
 
-	err = waitForGatherComplete(podBuilder)
+	err = waitForGatherComplete(ctx, podBuilder)
 	if err != nil {
 		return fmt.Errorf("failed waiting for must-gather to complete: %w", err)
 	}
 
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
 	err = downloadMustGatherOutput(podBuilder, tarballPath)
 	if err != nil {
 		return fmt.Errorf("failed to download must-gather output: %w", err)
@@ -293,19 +288,16 @@ func createMustGatherPod(client *clients.Settings, nsName, image string) (*pod.B
 	return podBuilder, nil
 }
 
-// waitForGatherComplete waits for the gather container to complete.
-func waitForGatherComplete(podBuilder *pod.Builder) error {
+// waitForGatherComplete waits for the gather container to complete, for up to mustGatherPodTimeout or until ctx is
+// done.
+func waitForGatherComplete(ctx context.Context, podBuilder *pod.Builder) error {
 	klog.V(ranparam.LogLevel).Info("Waiting for must-gather to complete...")
 
-	err := podBuilder.WaitUntilRunning(mustGatherPodTimeout)
-	if err != nil {
-		return fmt.Errorf("pod failed to start running: %w", err)
-	}
-
 	// Poll until the gather container has terminated. We cannot use the pod condition since the copy container will
-	// stay running.
+	// stay running. Until the pod is running, the gather container has no terminated state so this also covers
+	// waiting for the pod to start.
 	return wait.PollUntilContextTimeout(
-		context.TODO(), 10*time.Second, mustGatherPodTimeout, true,
+		ctx, 10*time.Second, mustGatherPodTimeout, true,
 		func(ctx context.Context) (bool, error) {
 			if !podBuilder.Exists() {
 				return false, fmt.Errorf("pod no longer exists")
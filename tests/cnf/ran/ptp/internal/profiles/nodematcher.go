@@ -1,7 +1,6 @@
 package profiles
 
 import (
-	"cmp"
 	"fmt"
 	"slices"
 
@@ -10,6 +9,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/ptp"
 	ptpv1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/ptp/v1"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/ptprecommend"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
@@ -40,7 +40,7 @@ func GetNodeInfoMap(client *clients.Settings) (map[string]*NodeInfo, error) {
 	// profiles fall back to the PtpConfig plugin path.
 	hwConfigIndex := buildHardwareConfigIndex(client)
 
-	allRecommends := getAllRecommends(ptpConfigList)
+	allRecommends := ptprecommend.All(getConfigDefinitions(ptpConfigList))
 	ptpProfileInfos := make(map[ProfileReference]*ProfileInfo)
 	nodeInfoMap := make(map[string]*NodeInfo)
 
@@ -106,100 +106,39 @@ func buildHardwareConfigIndex(client *clients.Settings) map[string]*ptp.Hardware
 	return index
 }
 
-// recommendWithProfileReference is a helper struct that combines a PTP recommend with a reference to the profile it
-// applies to. Since all recommends are aggregated from all PtpConfigs, this struct is used to keep track of the
-// profile reference along with the recommend itself.
-type recommendWithProfileReference struct {
-	recommend ptpv1.PtpRecommend
-	reference ProfileReference
-}
-
-// getAllRecommends returns the recommend structures for all PtpConfigs provided and sorts by priority in ascending
-// order. Recommends without profiles, priorities, or matches are filtered out. Recommends that do not match any profile
-// in the config are also filtered out.
-func getAllRecommends(configs []*ptp.PtpConfigBuilder) []recommendWithProfileReference {
-	var recommends []recommendWithProfileReference
+// getConfigDefinitions returns the definitions of the provided PtpConfig builders so they can be used with the
+// ptprecommend package.
+func getConfigDefinitions(configs []*ptp.PtpConfigBuilder) []*ptpv1.PtpConfig {
+	definitions := make([]*ptpv1.PtpConfig, 0, len(configs))
 
 	for _, config := range configs {
-		for _, recommend := range config.Definition.Spec.Recommend {
-			if recommend.Profile == nil || recommend.Priority == nil || len(recommend.Match) == 0 {
-				continue
-			}
-
-			profileIndex := slices.IndexFunc(config.Definition.Spec.Profile, func(profile ptpv1.PtpProfile) bool {
-				return profile.Name != nil && *profile.Name == *recommend.Profile
-			})
-
-			// If the recommend does not match any profile, it is ignored.
-			if profileIndex == -1 {
-				continue
-			}
-
-			reference := ProfileReference{
-				ConfigReference: runtimeclient.ObjectKeyFromObject(config.Definition),
-				ProfileIndex:    profileIndex,
-				ProfileName:     *recommend.Profile,
-			}
-			recommends = append(recommends, recommendWithProfileReference{
-				recommend: recommend,
-				reference: reference,
-			})
-		}
+		definitions = append(definitions, config.Definition)
 	}
 
-	slices.SortFunc(recommends, func(a, b recommendWithProfileReference) int {
-		// We already filtered out nil pointers, so we can safely dereference.
-		return cmp.Compare(*a.recommend.Priority, *b.recommend.Priority)
-	})
-
-	return recommends
+	return definitions
 }
 
-// getRecommendsForNode matches the recommends to a node based on the match criteria. The provided recommends are
-// assumed to be sorted in ascending order by priority, with non-nil priorities and profile names. It returns a set of
-// profile references that are recommended for the node. The returned map is guaranteed to not be nil.
-//
-// The algorithm is the same as the one used in the PTP operator:
-//   - Loop through all recommends in order and ignore those that do not match the node.
-//   - The lowest priority that matches the node is used to determine which profiles are recommended.
-//   - If a recommend with a different priority is found, the loop is broken and no further recommends are considered.
+// getRecommendsForNode matches the recommends to a node based on the match criteria, as described in
+// [ptprecommend.ForNode]. The provided recommends are assumed to be sorted in ascending order by priority. It returns a
+// set of profile references that are recommended for the node. The returned map is guaranteed to not be nil.
 func getRecommendsForNode(
-	node *corev1.Node, allRecommends []recommendWithProfileReference) map[ProfileReference]struct{} {
+	node *corev1.Node, allRecommends []ptprecommend.Recommendation) map[ProfileReference]struct{} {
 	profiles := make(map[ProfileReference]struct{})
-	priority := int64(-1)
-
-	for _, recommend := range allRecommends {
-		if !nodeMatches(node, recommend.recommend) {
-			continue
-		}
-
-		if priority >= 0 && *recommend.recommend.Priority != priority {
-			break
-		}
 
-		priority = *recommend.recommend.Priority
-		profiles[recommend.reference] = struct{}{}
+	for _, recommendation := range ptprecommend.ForNode(node, allRecommends) {
+		profiles[getProfileReference(recommendation)] = struct{}{}
 	}
 
 	return profiles
 }
 
-// nodeMatches checks if the node matches the criteria in the recommend. It returns true if the node matches any of the
-// matches for the recommend.
-func nodeMatches(node *corev1.Node, recommend ptpv1.PtpRecommend) bool {
-	for _, match := range recommend.Match {
-		if match.NodeName != nil && *match.NodeName == node.Name {
-			return true
-		}
-
-		if match.NodeLabel != nil {
-			if _, ok := node.Labels[*match.NodeLabel]; ok {
-				return true
-			}
-		}
+// getProfileReference returns the reference to the profile of a recommendation.
+func getProfileReference(recommendation ptprecommend.Recommendation) ProfileReference {
+	return ProfileReference{
+		ConfigReference: runtimeclient.ObjectKeyFromObject(recommendation.Config),
+		ProfileIndex:    recommendation.ProfileIndex,
+		ProfileName:     recommendation.ProfileName,
 	}
-
-	return false
 }
 
 // getControlledNamesForNode scans all profiles recommended to the node and collects the values of controllingProfile
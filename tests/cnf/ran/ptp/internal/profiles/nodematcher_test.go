@@ -0,0 +1,34 @@
+//go:build unit_test
+
+package profiles
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/snapshot"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/iface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNodeInfoMapFromSnapshot(t *testing.T) {
+	client, err := snapshot.NewTestClient("testdata/oc-two-nodes.yaml")
+	require.NoError(t, err)
+
+	nodeInfoMap, err := GetNodeInfoMap(client)
+	require.NoError(t, err)
+
+	// Only worker-0 has the ptp/slave label the recommend matches.
+	require.Len(t, nodeInfoMap, 1)
+	require.Contains(t, nodeInfoMap, "worker-0")
+
+	nodeInfo := nodeInfoMap["worker-0"]
+	assert.Equal(t, ProfileCounts{ProfileTypeOC: 1}, nodeInfo.Counts)
+	require.Len(t, nodeInfo.Profiles, 1)
+
+	profileInfo := nodeInfo.Profiles[0]
+	assert.Equal(t, "slave", profileInfo.Reference.ProfileName)
+	assert.Equal(t, "openshift-ptp", profileInfo.Reference.ConfigReference.Namespace)
+	require.Contains(t, profileInfo.Interfaces, iface.Name("ens1f0"))
+	assert.Equal(t, ClockTypeClient, profileInfo.Interfaces["ens1f0"].ClockType)
+}
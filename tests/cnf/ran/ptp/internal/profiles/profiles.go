@@ -55,6 +55,34 @@ const (
 	ProfileTypeTTSC
 )
 
+// String returns the name of the profile type, matching the name of its constant without the ProfileType prefix.
+func (profileType PtpProfileType) String() string {
+	switch profileType {
+	case ProfileTypeOC:
+		return "OC"
+	case ProfileTypeTwoPortOC:
+		return "TwoPortOC"
+	case ProfileTypeBC:
+		return "BC"
+	case ProfileTypeHA:
+		return "HA"
+	case ProfileTypeGM:
+		return "GM"
+	case ProfileTypeMultiNICGM:
+		return "MultiNICGM"
+	case ProfileTypeNTPFallback:
+		return "NTPFallback"
+	case ProfileTypeTBCTransmitter:
+		return "TBCTransmitter"
+	case ProfileTypeTBCReceiver:
+		return "TBCReceiver"
+	case ProfileTypeTTSC:
+		return "TTSC"
+	default:
+		return fmt.Sprintf("PtpProfileType(%d)", int(profileType))
+	}
+}
+
 // PtpClockType enumerates the roles of each interface. It is different from the roles in metrics, which include extra
 // runtime values not represented in the profile. The zero value is a client and only serverOnly (or masterOnly) values
 // of 1 indicate a server.
@@ -67,6 +95,18 @@ const (
 	ClockTypeServer
 )
 
+// String returns the port role of the clock type, either follower or leader.
+func (clockType PtpClockType) String() string {
+	switch clockType {
+	case ClockTypeClient:
+		return "follower"
+	case ClockTypeServer:
+		return "leader"
+	default:
+		return fmt.Sprintf("PtpClockType(%d)", int(clockType))
+	}
+}
+
 // profileNameSeparator is the separator used by the PTP operator (4.22+) to construct qualified profile names
 // in the format PtpConfigName_ProfileName. Since Kubernetes resource names cannot contain underscores (RFC 1123),
 // splitting on the first underscore is always unambiguous.
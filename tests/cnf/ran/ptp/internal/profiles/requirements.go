@@ -0,0 +1,168 @@
+package profiles
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/ptp"
+)
+
+// TopologyRequirement is a condition a node in the topology must satisfy for a test to run on it. Tests declare the
+// requirements they have and skip when Topology.FindNode, or Topology.FindNodes for tests that run on every matching
+// node, returns an error, for example:
+//
+//	node, err := topology.FindNode(
+//		profiles.RequirePorts(profiles.ClockRoleTBC, profiles.ClockTypeClient, 2),
+//		profiles.RequireHoldoverSettings())
+//	if err != nil {
+//		Skip(err.Error())
+//	}
+type TopologyRequirement struct {
+	// Description is a short description of the requirement used in errors, such as "a profile with clock role T-BC".
+	Description string
+	// IsSatisfied returns whether the provided node satisfies the requirement.
+	IsSatisfied func(node *NodeTopology) bool
+}
+
+// RequireClockRole requires that the node has at least one profile with the provided clock role.
+func RequireClockRole(role ClockRole) TopologyRequirement {
+	return TopologyRequirement{
+		Description: fmt.Sprintf("a profile with clock role %s", role),
+		IsSatisfied: func(node *NodeTopology) bool {
+			return len(node.GetProfilesByRole(role)) > 0
+		},
+	}
+}
+
+// RequireProfileType requires that the node has at least one profile of any of the provided types, the same as
+// checking NodeInfo.Counts.
+func RequireProfileType(profileTypes ...PtpProfileType) TopologyRequirement {
+	descriptions := make([]string, 0, len(profileTypes))
+	for _, profileType := range profileTypes {
+		descriptions = append(descriptions, profileType.String())
+	}
+
+	return TopologyRequirement{
+		Description: fmt.Sprintf("a profile of type %s", strings.Join(descriptions, " or ")),
+		IsSatisfied: func(node *NodeTopology) bool {
+			return slices.ContainsFunc(node.Profiles, func(profile *TopologyProfile) bool {
+				return slices.Contains(profileTypes, profile.Type)
+			})
+		},
+	}
+}
+
+// RequirePorts requires that the profiles with the provided clock role on the node have at least count ports with
+// the provided port role in total. Since both profiles of a T-BC receiver and transmitter pair have the T-BC role,
+// their ports are counted together.
+func RequirePorts(role ClockRole, portRole PtpClockType, count int) TopologyRequirement {
+	return TopologyRequirement{
+		Description: fmt.Sprintf("at least %d %s ports in %s profiles", count, portRole, role),
+		IsSatisfied: func(node *NodeTopology) bool {
+			total := 0
+
+			for _, profile := range node.GetProfilesByRole(role) {
+				total += len(profile.GetPortsByRole(portRole))
+			}
+
+			return total >= count
+		},
+	}
+}
+
+// RequirePlugin requires that at least one profile on the node has the provided hardware plugin.
+func RequirePlugin(pluginType ptp.PluginType) TopologyRequirement {
+	return TopologyRequirement{
+		Description: fmt.Sprintf("a profile with the %s plugin", pluginType),
+		IsSatisfied: func(node *NodeTopology) bool {
+			return slices.ContainsFunc(node.Profiles, func(profile *TopologyProfile) bool {
+				return slices.Contains(profile.Plugins, pluginType)
+			})
+		},
+	}
+}
+
+// RequireHoldoverSettings requires that at least one profile on the node has holdover settings in an Intel plugin.
+func RequireHoldoverSettings() TopologyRequirement {
+	return TopologyRequirement{
+		Description: "a profile with holdover plugin settings",
+		IsSatisfied: func(node *NodeTopology) bool {
+			return slices.ContainsFunc(node.Profiles, func(profile *TopologyProfile) bool {
+				return profile.HoldoverSettings != nil
+			})
+		},
+	}
+}
+
+// GetNodesSatisfying returns the nodes in the topology that satisfy all of the provided requirements, sorted by name.
+func (topology *Topology) GetNodesSatisfying(requirements ...TopologyRequirement) []*NodeTopology {
+	var nodes []*NodeTopology
+
+	for _, node := range topology.Nodes {
+		if len(getUnsatisfiedRequirements(node, requirements)) == 0 {
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes
+}
+
+// FindNode returns the first node, sorted by name, that satisfies all of the provided requirements. If no node does,
+// the error describes which requirements each node does not satisfy so it can be used as the reason for skipping.
+func (topology *Topology) FindNode(requirements ...TopologyRequirement) (*NodeTopology, error) {
+	nodes, err := topology.FindNodes(requirements...)
+	if err != nil {
+		return nil, err
+	}
+
+	return nodes[0], nil
+}
+
+// FindNodes returns all the nodes, sorted by name, that satisfy all of the provided requirements, for tests that run
+// on every such node. If no node does, the error is the same as for FindNode. The returned slice is never empty when
+// there is no error.
+func (topology *Topology) FindNodes(requirements ...TopologyRequirement) ([]*NodeTopology, error) {
+	if len(topology.Nodes) == 0 {
+		return nil, fmt.Errorf("topology requires %s but no nodes have PTP profiles",
+			joinRequirements(requirements))
+	}
+
+	nodes := topology.GetNodesSatisfying(requirements...)
+	if len(nodes) > 0 {
+		return nodes, nil
+	}
+
+	var reasons []string
+
+	for _, node := range topology.Nodes {
+		unsatisfied := getUnsatisfiedRequirements(node, requirements)
+		reasons = append(reasons, fmt.Sprintf("%s (%s) lacks %s", node.Name, node.Role, joinRequirements(unsatisfied)))
+	}
+
+	return nil, fmt.Errorf("no node satisfies the topology requirements: %s", strings.Join(reasons, "; "))
+}
+
+// getUnsatisfiedRequirements returns the requirements the node does not satisfy.
+func getUnsatisfiedRequirements(node *NodeTopology, requirements []TopologyRequirement) []TopologyRequirement {
+	var unsatisfied []TopologyRequirement
+
+	for _, requirement := range requirements {
+		if !requirement.IsSatisfied(node) {
+			unsatisfied = append(unsatisfied, requirement)
+		}
+	}
+
+	return unsatisfied
+}
+
+// joinRequirements joins the descriptions of the requirements for use in errors.
+func joinRequirements(requirements []TopologyRequirement) string {
+	descriptions := make([]string, 0, len(requirements))
+
+	for _, requirement := range requirements {
+		descriptions = append(descriptions, requirement.Description)
+	}
+
+	return strings.Join(descriptions, " and ")
+}
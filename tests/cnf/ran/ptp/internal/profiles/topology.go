@@ -0,0 +1,386 @@
+package profiles
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/ptp"
+	ptpv1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/ptp/v1"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/iface"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClockRole is the role of a PTP clock in the network, derived from the types of the profiles recommended to a node.
+type ClockRole string
+
+const (
+	// ClockRoleGM is a grandmaster, including multi-NIC and NTP fallback configurations.
+	ClockRoleGM ClockRole = "T-GM"
+	// ClockRoleTBC is a boundary clock, either in a single profile or split between a T-BC receiver and transmitter.
+	ClockRoleTBC ClockRole = "T-BC"
+	// ClockRoleTTSC is a telecom time slave clock.
+	ClockRoleTTSC ClockRole = "T-TSC"
+	// ClockRoleDualPortOC is an ordinary clock with two follower ports, only one of which is active at a time.
+	ClockRoleDualPortOC ClockRole = "dual-port OC"
+	// ClockRoleOC is an ordinary clock with a single follower port.
+	ClockRoleOC ClockRole = "OC"
+	// ClockRoleNone is the role of HA profiles, which do not correspond to a clock, and of nodes with only HA
+	// profiles.
+	ClockRoleNone ClockRole = "none"
+)
+
+// clockRolePrecedence is the order in which roles are assigned to a node with profiles of multiple roles. A node is
+// given the role closest to the grandmaster, since that is the role other clocks depend on.
+var clockRolePrecedence = []ClockRole{
+	ClockRoleGM, ClockRoleTBC, ClockRoleTTSC, ClockRoleDualPortOC, ClockRoleOC, ClockRoleNone,
+}
+
+// GetClockRole returns the clock role of a profile of the provided type.
+func GetClockRole(profileType PtpProfileType) ClockRole {
+	switch profileType {
+	case ProfileTypeGM, ProfileTypeMultiNICGM, ProfileTypeNTPFallback:
+		return ClockRoleGM
+	case ProfileTypeBC, ProfileTypeTBCReceiver, ProfileTypeTBCTransmitter:
+		return ClockRoleTBC
+	case ProfileTypeTTSC:
+		return ClockRoleTTSC
+	case ProfileTypeTwoPortOC:
+		return ClockRoleDualPortOC
+	case ProfileTypeOC:
+		return ClockRoleOC
+	default:
+		return ClockRoleNone
+	}
+}
+
+// TopologyLinkKind is the reason two endpoints in the topology are linked.
+type TopologyLinkKind string
+
+const (
+	// TopologyLinkBoundaryClock links the follower port of a BC profile to its leader ports.
+	TopologyLinkBoundaryClock TopologyLinkKind = "boundary clock"
+	// TopologyLinkControllingProfile links the follower port of a T-BC receiver to the leader ports of the T-BC
+	// transmitter that names it in the controllingProfile setting.
+	TopologyLinkControllingProfile TopologyLinkKind = "controlling profile"
+	// TopologyLinkHAProfile links a profile to the HA profile that names it in the haProfiles setting.
+	TopologyLinkHAProfile TopologyLinkKind = "ha profile"
+	// TopologyLinkParentPort links a port to a port whose parent port identity it is, as found by
+	// NodeInfo.LinkInterfacesByPortIdentities. These links are only present when port identities have been set.
+	TopologyLinkParentPort TopologyLinkKind = "parent port"
+)
+
+// TopologyEndpoint is one end of a link, either a port of a profile or, if Interface is empty, the profile itself.
+type TopologyEndpoint struct {
+	Profile   string
+	Interface iface.Name
+}
+
+// String returns the endpoint as profile/interface, or only profile if there is no interface.
+func (endpoint TopologyEndpoint) String() string {
+	if endpoint.Interface == "" {
+		return endpoint.Profile
+	}
+
+	return endpoint.Profile + "/" + string(endpoint.Interface)
+}
+
+// TopologyLink is a directed relationship between two endpoints on the same node, where time flows from Upstream to
+// Downstream.
+type TopologyLink struct {
+	Upstream   TopologyEndpoint
+	Downstream TopologyEndpoint
+	Kind       TopologyLinkKind
+}
+
+// TopologyPort is a single interface of a profile along with its port role.
+type TopologyPort struct {
+	Name iface.Name
+	// Role is whether the port is a follower (client) or leader (server).
+	Role               PtpClockType
+	PortIdentity       string
+	ParentPortIdentity string
+}
+
+// TopologyProfile is a single profile recommended to a node.
+type TopologyProfile struct {
+	Name string
+	Type PtpProfileType
+	Role ClockRole
+	// Ports are sorted by name.
+	Ports []TopologyPort
+	// Plugins are the hardware plugins configured in the profile, sorted by name.
+	Plugins []ptp.PluginType
+	// HoldoverSettings are the holdover settings of the Intel plugin in the profile. It is nil if the profile has no
+	// Intel plugin with holdover settings.
+	HoldoverSettings *HoldoverPluginSettings
+	// HardwareConfig is the name of the HardwareConfig associated with the profile, if any.
+	HardwareConfig string
+	// Info is the ProfileInfo the profile was built from.
+	Info *ProfileInfo
+}
+
+// GetPortsByRole returns the ports of the profile with the provided role.
+func (profile *TopologyProfile) GetPortsByRole(role PtpClockType) []TopologyPort {
+	var ports []TopologyPort
+
+	for _, port := range profile.Ports {
+		if port.Role == role {
+			ports = append(ports, port)
+		}
+	}
+
+	return ports
+}
+
+// NodeTopology is the PTP topology of a single node.
+type NodeTopology struct {
+	Name string
+	// Role is the role of the node, which is the role closest to the grandmaster among its profiles.
+	Role ClockRole
+	// Profiles are sorted by name.
+	Profiles []*TopologyProfile
+	// Links are the upstream and downstream relationships between the ports and profiles of the node.
+	Links []TopologyLink
+	// Info is the NodeInfo the node was built from. Tests use it after finding a node with the required topology,
+	// so the profiles, interfaces, and counts are the same ones GetNodeInfoMap returns.
+	Info *NodeInfo
+}
+
+// GetProfilesByRole returns the profiles of the node with the provided clock role.
+func (node *NodeTopology) GetProfilesByRole(role ClockRole) []*TopologyProfile {
+	var profiles []*TopologyProfile
+
+	for _, profile := range node.Profiles {
+		if profile.Role == role {
+			profiles = append(profiles, profile)
+		}
+	}
+
+	return profiles
+}
+
+// GetProfileByName returns the profile of the node with the provided name, or nil if there is none.
+func (node *NodeTopology) GetProfileByName(name string) *TopologyProfile {
+	for _, profile := range node.Profiles {
+		if profile.Name == name {
+			return profile
+		}
+	}
+
+	return nil
+}
+
+// Topology is the PTP topology of the cluster, derived from the PtpConfig profiles recommended to each node.
+type Topology struct {
+	// Nodes contains only the nodes with profiles recommended to them, sorted by name.
+	Nodes []*NodeTopology
+}
+
+// GetTopology builds the PTP topology of the cluster from the PtpConfigs on it. Port identities are not set, so there
+// are no parent port links. To include them, use NewTopology after calling NodeInfo.SetPortIdentitiesAndLink.
+func GetTopology(client *clients.Settings) (*Topology, error) {
+	nodeInfoMap, err := GetNodeInfoMap(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node info map for topology: %w", err)
+	}
+
+	ptpConfigList, err := ptp.ListPtpConfigs(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PtpConfigs for topology: %w", err)
+	}
+
+	return NewTopology(nodeInfoMap, ptpConfigList), nil
+}
+
+// NewTopology builds the PTP topology from the provided NodeInfo map, such as the one returned by GetNodeInfoMap. The
+// ptpConfigs are used to look up the plugins and settings of each profile; profiles not found in them have no plugins
+// or settings-based links.
+func NewTopology(nodeInfoMap map[string]*NodeInfo, ptpConfigs []*ptp.PtpConfigBuilder) *Topology {
+	topology := &Topology{}
+
+	for _, nodeInfo := range nodeInfoMap {
+		topology.Nodes = append(topology.Nodes, newNodeTopology(nodeInfo, ptpConfigs))
+	}
+
+	slices.SortFunc(topology.Nodes, func(a, b *NodeTopology) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return topology
+}
+
+// GetNodeByName returns the topology of the node with the provided name, or nil if the node has no profiles.
+func (topology *Topology) GetNodeByName(name string) *NodeTopology {
+	for _, node := range topology.Nodes {
+		if node.Name == name {
+			return node
+		}
+	}
+
+	return nil
+}
+
+// newNodeTopology builds the topology of a single node.
+func newNodeTopology(nodeInfo *NodeInfo, ptpConfigs []*ptp.PtpConfigBuilder) *NodeTopology {
+	node := &NodeTopology{Name: nodeInfo.Name, Role: ClockRoleNone, Info: nodeInfo}
+	ptpProfiles := make(map[string]*ptpv1.PtpProfile)
+
+	for _, profileInfo := range nodeInfo.Profiles {
+		ptpProfile := findPtpProfile(profileInfo.Reference, ptpConfigs)
+		ptpProfiles[profileInfo.Reference.ProfileName] = ptpProfile
+
+		node.Profiles = append(node.Profiles, newTopologyProfile(profileInfo, ptpProfile))
+	}
+
+	slices.SortFunc(node.Profiles, func(a, b *TopologyProfile) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	for _, role := range clockRolePrecedence {
+		if len(node.GetProfilesByRole(role)) > 0 {
+			node.Role = role
+
+			break
+		}
+	}
+
+	for _, profile := range node.Profiles {
+		node.Links = append(node.Links, getProfileLinks(node, profile, ptpProfiles[profile.Name])...)
+	}
+
+	return node
+}
+
+// newTopologyProfile builds the topology of a single profile. The ptpProfile may be nil, in which case the plugins and
+// holdover settings are left empty.
+func newTopologyProfile(profileInfo *ProfileInfo, ptpProfile *ptpv1.PtpProfile) *TopologyProfile {
+	profile := &TopologyProfile{
+		Name: profileInfo.Reference.ProfileName,
+		Type: profileInfo.ProfileType,
+		Role: GetClockRole(profileInfo.ProfileType),
+		Info: profileInfo,
+	}
+
+	for _, interfaceInfo := range profileInfo.Interfaces {
+		profile.Ports = append(profile.Ports, TopologyPort{
+			Name:               interfaceInfo.Name,
+			Role:               interfaceInfo.ClockType,
+			PortIdentity:       interfaceInfo.PortIdentity,
+			ParentPortIdentity: interfaceInfo.ParentPortIdentity,
+		})
+	}
+
+	slices.SortFunc(profile.Ports, func(a, b TopologyPort) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	if profileInfo.HardwareConfig != nil && profileInfo.HardwareConfig.Definition != nil {
+		profile.HardwareConfig = profileInfo.HardwareConfig.Definition.Name
+	}
+
+	if ptpProfile == nil {
+		return profile
+	}
+
+	// An error only means the profile has no plugins, which leaves Plugins empty.
+	pluginTypes, _ := GetPluginTypesFromProfile(ptpProfile)
+	slices.Sort(pluginTypes)
+	profile.Plugins = pluginTypes
+
+	holdoverSettings, err := GetHoldoverPluginSettings(ptpProfile)
+	if err == nil {
+		profile.HoldoverSettings = holdoverSettings
+	}
+
+	return profile
+}
+
+// getProfileLinks returns the links whose downstream endpoint is in the provided profile. The ptpProfile may be nil, in
+// which case links from settings are not found.
+func getProfileLinks(node *NodeTopology, profile *TopologyProfile, ptpProfile *ptpv1.PtpProfile) []TopologyLink {
+	var links []TopologyLink
+
+	if profile.Type == ProfileTypeBC {
+		links = append(links, linkPorts(profile, profile, TopologyLinkBoundaryClock)...)
+	}
+
+	if ptpProfile != nil && ptpProfile.PtpSettings != nil {
+		if profile.Type == ProfileTypeTBCTransmitter {
+			receiver := node.GetProfileByName(ptpProfile.PtpSettings["controllingProfile"])
+			if receiver != nil {
+				links = append(links, linkPorts(receiver, profile, TopologyLinkControllingProfile)...)
+			}
+		}
+
+		if profile.Type == ProfileTypeHA {
+			for haProfileName := range strings.SplitSeq(ptpProfile.PtpSettings["haProfiles"], ",") {
+				haProfileName = strings.TrimSpace(haProfileName)
+				if node.GetProfileByName(haProfileName) == nil {
+					continue
+				}
+
+				links = append(links, TopologyLink{
+					Upstream:   TopologyEndpoint{Profile: haProfileName},
+					Downstream: TopologyEndpoint{Profile: profile.Name},
+					Kind:       TopologyLinkHAProfile,
+				})
+			}
+		}
+	}
+
+	for _, port := range profile.Ports {
+		interfaceInfo := profile.Info.Interfaces[port.Name]
+		if interfaceInfo == nil || interfaceInfo.Parent == nil || interfaceInfo.Parent.Profile == nil {
+			continue
+		}
+
+		links = append(links, TopologyLink{
+			Upstream: TopologyEndpoint{
+				Profile:   interfaceInfo.Parent.Profile.Reference.ProfileName,
+				Interface: interfaceInfo.Parent.Name,
+			},
+			Downstream: TopologyEndpoint{Profile: profile.Name, Interface: port.Name},
+			Kind:       TopologyLinkParentPort,
+		})
+	}
+
+	return links
+}
+
+// linkPorts returns links from every follower port of upstream to every leader port of downstream.
+func linkPorts(upstream, downstream *TopologyProfile, kind TopologyLinkKind) []TopologyLink {
+	var links []TopologyLink
+
+	for _, follower := range upstream.GetPortsByRole(ClockTypeClient) {
+		for _, leader := range downstream.GetPortsByRole(ClockTypeServer) {
+			links = append(links, TopologyLink{
+				Upstream:   TopologyEndpoint{Profile: upstream.Name, Interface: follower.Name},
+				Downstream: TopologyEndpoint{Profile: downstream.Name, Interface: leader.Name},
+				Kind:       kind,
+			})
+		}
+	}
+
+	return links
+}
+
+// findPtpProfile returns the profile referenced by reference in ptpConfigs, or nil if it cannot be found.
+func findPtpProfile(reference ProfileReference, ptpConfigs []*ptp.PtpConfigBuilder) *ptpv1.PtpProfile {
+	configIndex := slices.IndexFunc(ptpConfigs, func(config *ptp.PtpConfigBuilder) bool {
+		return config.Definition != nil &&
+			runtimeclient.ObjectKeyFromObject(config.Definition) == reference.ConfigReference
+	})
+	if configIndex == -1 {
+		return nil
+	}
+
+	configProfiles := ptpConfigs[configIndex].Definition.Spec.Profile
+	if reference.ProfileIndex < 0 || reference.ProfileIndex >= len(configProfiles) {
+		return nil
+	}
+
+	return &configProfiles[reference.ProfileIndex]
+}
@@ -0,0 +1,226 @@
+//go:build unit_test
+
+package profiles
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/ptp"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/snapshot"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/iface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTopologyFromSnapshot(t *testing.T) {
+	client, err := snapshot.NewTestClient("testdata/tbc-gm.yaml")
+	require.NoError(t, err)
+
+	topology, err := GetTopology(client)
+	require.NoError(t, err)
+	require.Len(t, topology.Nodes, 2)
+
+	gmNode := topology.GetNodeByName("gm-0")
+	require.NotNil(t, gmNode)
+	assert.Equal(t, ClockRoleGM, gmNode.Role)
+	// The tbc-receiver recommend by node name has a higher priority value, so it is not applied.
+	require.Len(t, gmNode.Profiles, 1)
+
+	grandmaster := gmNode.Profiles[0]
+	assert.Equal(t, ProfileTypeGM, grandmaster.Type)
+	assert.Equal(t, []ptp.PluginType{ptp.PluginTypeE810}, grandmaster.Plugins)
+	assert.Equal(t, &HoldoverPluginSettings{
+		LocalHoldoverTimeout:   14400,
+		LocalMaxHoldoverOffSet: 1500,
+		MaxInSpecOffset:        1500,
+	}, grandmaster.HoldoverSettings)
+	assert.Len(t, grandmaster.GetPortsByRole(ClockTypeServer), 2)
+	assert.Empty(t, gmNode.Links)
+
+	// The topology is built from the same NodeInfo as GetNodeInfoMap returns, so tests can use either.
+	nodeInfoMap, err := GetNodeInfoMap(client)
+	require.NoError(t, err)
+	require.NotNil(t, gmNode.Info)
+	assert.Equal(t, nodeInfoMap["gm-0"].Counts, gmNode.Info.Counts)
+	assert.Same(t, gmNode.Info.Profiles[0], grandmaster.Info)
+
+	tbcNode := topology.GetNodeByName("tbc-0")
+	require.NotNil(t, tbcNode)
+	assert.Equal(t, ClockRoleTBC, tbcNode.Role)
+	require.Len(t, tbcNode.Profiles, 2)
+	assert.Equal(t, ProfileTypeTBCReceiver, tbcNode.Profiles[0].Type)
+	assert.Equal(t, ProfileTypeTBCTransmitter, tbcNode.Profiles[1].Type)
+	assert.Nil(t, tbcNode.Profiles[1].HoldoverSettings)
+
+	assert.Equal(t, []TopologyLink{
+		{
+			Upstream:   TopologyEndpoint{Profile: "tbc-receiver", Interface: "ens2f0"},
+			Downstream: TopologyEndpoint{Profile: "tbc-transmitter", Interface: "ens2f1"},
+			Kind:       TopologyLinkControllingProfile,
+		},
+		{
+			Upstream:   TopologyEndpoint{Profile: "tbc-receiver", Interface: "ens2f0"},
+			Downstream: TopologyEndpoint{Profile: "tbc-transmitter", Interface: "ens2f2"},
+			Kind:       TopologyLinkControllingProfile,
+		},
+	}, tbcNode.Links)
+}
+
+func TestNewTopologyParentPortLinks(t *testing.T) {
+	profileInfo := &ProfileInfo{
+		ProfileType: ProfileTypeBC,
+		Reference:   ProfileReference{ProfileName: "bc"},
+		Interfaces: map[iface.Name]*InterfaceInfo{
+			"ens1f0": {Name: "ens1f0", ClockType: ClockTypeClient, PortIdentity: "a-1", ParentPortIdentity: "b-1"},
+			"ens1f1": {Name: "ens1f1", ClockType: ClockTypeServer, PortIdentity: "b-1", ParentPortIdentity: "c-1"},
+		},
+	}
+
+	for _, interfaceInfo := range profileInfo.Interfaces {
+		interfaceInfo.Profile = profileInfo
+	}
+
+	nodeInfo := &NodeInfo{Name: "worker-0", Profiles: []*ProfileInfo{profileInfo}}
+	nodeInfo.LinkInterfacesByPortIdentities()
+
+	topology := NewTopology(map[string]*NodeInfo{"worker-0": nodeInfo}, nil)
+	require.Len(t, topology.Nodes, 1)
+
+	node := topology.Nodes[0]
+	assert.Equal(t, ClockRoleTBC, node.Role)
+	assert.Equal(t, []TopologyLink{
+		{
+			Upstream:   TopologyEndpoint{Profile: "bc", Interface: "ens1f0"},
+			Downstream: TopologyEndpoint{Profile: "bc", Interface: "ens1f1"},
+			Kind:       TopologyLinkBoundaryClock,
+		},
+		{
+			Upstream:   TopologyEndpoint{Profile: "bc", Interface: "ens1f1"},
+			Downstream: TopologyEndpoint{Profile: "bc", Interface: "ens1f0"},
+			Kind:       TopologyLinkParentPort,
+		},
+	}, node.Links)
+}
+
+func TestTopologyFindNode(t *testing.T) {
+	client, err := snapshot.NewTestClient("testdata/tbc-gm.yaml")
+	require.NoError(t, err)
+
+	topology, err := GetTopology(client)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		requirements []TopologyRequirement
+		expectedNode string
+		expectedErr  string
+	}{
+		{
+			name:         "no requirements",
+			expectedNode: "gm-0",
+		},
+		{
+			name:         "t-bc with two leader ports",
+			requirements: []TopologyRequirement{RequirePorts(ClockRoleTBC, ClockTypeServer, 2)},
+			expectedNode: "tbc-0",
+		},
+		{
+			name: "holdover settings on a grandmaster",
+			requirements: []TopologyRequirement{
+				RequireClockRole(ClockRoleGM), RequireHoldoverSettings(), RequirePlugin(ptp.PluginTypeE810),
+			},
+			expectedNode: "gm-0",
+		},
+		{
+			name:         "profile type",
+			requirements: []TopologyRequirement{RequireProfileType(ProfileTypeBC, ProfileTypeTBCReceiver)},
+			expectedNode: "tbc-0",
+		},
+		{
+			name:         "missing profile type",
+			requirements: []TopologyRequirement{RequireProfileType(ProfileTypeNTPFallback)},
+			expectedErr: "no node satisfies the topology requirements: " +
+				"gm-0 (T-GM) lacks a profile of type NTPFallback; tbc-0 (T-BC) lacks a profile of type NTPFallback",
+		},
+		{
+			name: "t-bc with two follower ports",
+			requirements: []TopologyRequirement{
+				RequirePorts(ClockRoleTBC, ClockTypeClient, 2), RequireHoldoverSettings(),
+			},
+			expectedErr: "no node satisfies the topology requirements: " +
+				"gm-0 (T-GM) lacks at least 2 follower ports in T-BC profiles; " +
+				"tbc-0 (T-BC) lacks at least 2 follower ports in T-BC profiles and a profile with holdover plugin settings",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			node, err := topology.FindNode(testCase.requirements...)
+			if testCase.expectedErr != "" {
+				assert.EqualError(t, err, testCase.expectedErr)
+				assert.Nil(t, node)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedNode, node.Name)
+		})
+	}
+
+	_, err = (&Topology{}).FindNode(RequireClockRole(ClockRoleOC))
+	assert.EqualError(t, err, "topology requires a profile with clock role OC but no nodes have PTP profiles")
+}
+
+func TestTopologyFindNodes(t *testing.T) {
+	client, err := snapshot.NewTestClient("testdata/tbc-gm.yaml")
+	require.NoError(t, err)
+
+	topology, err := GetTopology(client)
+	require.NoError(t, err)
+
+	nodes, err := topology.FindNodes()
+	require.NoError(t, err)
+	require.Len(t, nodes, 2)
+	assert.Equal(t, "gm-0", nodes[0].Name)
+	assert.Equal(t, "tbc-0", nodes[1].Name)
+
+	nodes, err = topology.FindNodes(RequireProfileType(ProfileTypeGM, ProfileTypeMultiNICGM))
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+	assert.Equal(t, uint(1), nodes[0].Info.Counts[ProfileTypeGM])
+
+	nodes, err = topology.FindNodes(RequireClockRole(ClockRoleOC))
+	assert.ErrorContains(t, err, "gm-0 (T-GM) lacks a profile with clock role OC")
+	assert.Nil(t, nodes)
+}
+
+func TestTopologyRender(t *testing.T) {
+	client, err := snapshot.NewTestClient("testdata/tbc-gm.yaml")
+	require.NoError(t, err)
+
+	topology, err := GetTopology(client)
+	require.NoError(t, err)
+
+	assert.Equal(t, `node gm-0: T-GM
+  profile grandmaster: T-GM (GM)
+    port ens1f0: leader
+    port ens1f1: leader
+    plugins e810, holdover timeout 14400s max offset 1500ns in-spec offset 1500ns
+node tbc-0: T-BC
+  profile tbc-receiver: T-BC (TBCReceiver)
+    port ens2f0: follower
+  profile tbc-transmitter: T-BC (TBCTransmitter)
+    port ens2f1: leader
+    port ens2f2: leader
+    plugins e810
+  link tbc-receiver/ens2f0 -> tbc-transmitter/ens2f1 (controlling profile)
+  link tbc-receiver/ens2f0 -> tbc-transmitter/ens2f2 (controlling profile)
+`, topology.String())
+
+	dot := topology.DOT()
+	assert.Contains(t, dot, `subgraph "cluster_tbc-0" {`)
+	assert.Contains(t, dot, `"tbc-0/tbc-receiver/ens2f0" [label="ens2f0\nfollower"];`)
+	assert.Contains(t, dot,
+		`"tbc-0/tbc-receiver/ens2f0" -> "tbc-0/tbc-transmitter/ens2f1" [label="controlling profile"];`)
+}
@@ -0,0 +1,116 @@
+package profiles
+
+import (
+	"fmt"
+	"strings"
+)
+
+// String renders the topology as indented text, with one block per node listing its profiles, their ports, plugin
+// settings, and the links between them.
+func (topology *Topology) String() string {
+	var builder strings.Builder
+
+	for _, node := range topology.Nodes {
+		fmt.Fprintf(&builder, "node %s: %s\n", node.Name, node.Role)
+
+		for _, profile := range node.Profiles {
+			fmt.Fprintf(&builder, "  profile %s: %s (%s)\n", profile.Name, profile.Role, profile.Type)
+
+			for _, port := range profile.Ports {
+				fmt.Fprintf(&builder, "    port %s: %s", port.Name, port.Role)
+
+				if port.PortIdentity != "" {
+					fmt.Fprintf(&builder, " identity %s parent %s", port.PortIdentity, port.ParentPortIdentity)
+				}
+
+				builder.WriteString("\n")
+			}
+
+			if details := getProfileDetails(profile); len(details) > 0 {
+				fmt.Fprintf(&builder, "    %s\n", strings.Join(details, ", "))
+			}
+		}
+
+		for _, link := range node.Links {
+			fmt.Fprintf(&builder, "  link %s -> %s (%s)\n", link.Upstream, link.Downstream, link.Kind)
+		}
+	}
+
+	return builder.String()
+}
+
+// DOT renders the topology as a Graphviz digraph. Each node is a cluster containing a cluster per profile, with a
+// vertex for the profile itself and one for each of its ports. Links are edges from upstream to downstream.
+func (topology *Topology) DOT() string {
+	var builder strings.Builder
+
+	builder.WriteString("digraph ptp {\n  rankdir=LR;\n  node [shape=ellipse];\n")
+
+	for _, node := range topology.Nodes {
+		fmt.Fprintf(&builder, "  subgraph %q {\n    label=%q;\n", "cluster_"+node.Name,
+			fmt.Sprintf("%s (%s)", node.Name, node.Role))
+
+		for _, profile := range node.Profiles {
+			profileLabel := fmt.Sprintf("%s\n%s (%s)", profile.Name, profile.Role, profile.Type)
+			if details := getProfileDetails(profile); len(details) > 0 {
+				profileLabel += "\n" + strings.Join(details, "\n")
+			}
+
+			fmt.Fprintf(&builder, "    subgraph %q {\n      label=%q;\n", "cluster_"+node.Name+"/"+profile.Name,
+				profile.Name)
+			fmt.Fprintf(&builder, "      %q [shape=box, label=%q];\n",
+				getDOTVertex(node.Name, TopologyEndpoint{Profile: profile.Name}), profileLabel)
+
+			for _, port := range profile.Ports {
+				fmt.Fprintf(&builder, "      %q [label=%q];\n",
+					getDOTVertex(node.Name, TopologyEndpoint{Profile: profile.Name, Interface: port.Name}),
+					fmt.Sprintf("%s\n%s", port.Name, port.Role))
+			}
+
+			builder.WriteString("    }\n")
+		}
+
+		builder.WriteString("  }\n")
+
+		for _, link := range node.Links {
+			fmt.Fprintf(&builder, "  %q -> %q [label=%q];\n",
+				getDOTVertex(node.Name, link.Upstream), getDOTVertex(node.Name, link.Downstream), link.Kind)
+		}
+	}
+
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
+
+// getDOTVertex returns the identifier of the vertex for an endpoint on the provided node. Identifiers include the node
+// name since profiles are recommended to multiple nodes.
+func getDOTVertex(nodeName string, endpoint TopologyEndpoint) string {
+	return nodeName + "/" + endpoint.String()
+}
+
+// getProfileDetails returns the plugin settings of the profile formatted for rendering.
+func getProfileDetails(profile *TopologyProfile) []string {
+	var details []string
+
+	if len(profile.Plugins) > 0 {
+		pluginNames := make([]string, 0, len(profile.Plugins))
+		for _, pluginType := range profile.Plugins {
+			pluginNames = append(pluginNames, string(pluginType))
+		}
+
+		details = append(details, "plugins "+strings.Join(pluginNames, " "))
+	}
+
+	if profile.HoldoverSettings != nil {
+		details = append(details, fmt.Sprintf("holdover timeout %ds max offset %dns in-spec offset %dns",
+			profile.HoldoverSettings.LocalHoldoverTimeout, profile.HoldoverSettings.LocalMaxHoldoverOffSet,
+			profile.HoldoverSettings.MaxInSpecOffset))
+	}
+
+	if profile.HardwareConfig != "" {
+		details = append(details, "hardware config "+profile.HardwareConfig)
+	}
+
+	return details
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
 	"k8s.io/klog/v2"
 )
 
@@ -124,7 +125,10 @@ func ExecuteCommandInPtpDaemonPod(
 			continue
 		}
 
-		// In the success case, we do not need to retry and we can return the output.
+		// In the success case, we do not need to retry and we can return the output. It is recorded as a fixture
+		// when recording is enabled so parsers of the output can be tested offline.
+		cluster.RecordExecResult(&cluster.ExecResult{Node: nodeName, Command: command, Stdout: output.String()})
+
 		return output.String(), nil
 	}
 
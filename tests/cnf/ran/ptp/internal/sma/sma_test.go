@@ -0,0 +1,46 @@
+//go:build unit_test
+
+package sma
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDpllPinShow(t *testing.T) {
+	fixtures, err := cluster.ReadExecFixtures("testdata/dpll-pin-show.yaml")
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		pinID         string
+		expectedState dpllPinState
+	}{
+		{
+			name:  "input pin",
+			pinID: "13",
+			expectedState: dpllPinState{parents: []dpllParentDevice{
+				{id: "2", direction: "input", prio: "0", state: "connected"},
+				{id: "3", direction: "input", prio: "0", state: "selectable"},
+			}},
+		},
+		{
+			name:  "output pin",
+			pinID: "15",
+			expectedState: dpllPinState{parents: []dpllParentDevice{
+				{id: "2", direction: "output", state: "connected"},
+				{id: "3", direction: "output", state: "connected"},
+			}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			output, err := fixtures.Stdout("spoke1-e810", "nsenter -t 1 -m -n -- /usr/sbin/dpll pin show id "+testCase.pinID)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedState, parseDpllPinShow(output))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package ptp
 
 import (
 	"runtime"
+	"slices"
 	"testing"
 	"time"
 
@@ -22,7 +23,10 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/mustgather"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
 	_ "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/tests"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/hwinventory"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/reporter"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
 )
 
 var (
@@ -30,6 +34,10 @@ var (
 
 	// savedPtpServiceMonitor holds the original PTP ServiceMonitor state so it can be restored in AfterSuite.
 	savedPtpServiceMonitor *monv1.ServiceMonitor
+
+	// spoke1Hardware holds the hardware inventory of the spoke 1 nodes collected in BeforeSuite so it can be reported
+	// in ReportAfterSuite along with the devices the specs exercised.
+	spoke1Hardware []hwinventory.Node
 )
 
 func TestPTP(t *testing.T) {
@@ -66,10 +74,23 @@ var _ = BeforeSuite(func() {
 	savedPtpServiceMonitor, err = metrics.UpdatePtpServiceMonitorInterval(RANConfig.Spoke1APIClient, "1s")
 	Expect(err).ToNot(HaveOccurred(), "Failed to update PTP ServiceMonitor scrape interval")
 
+	By("registering the PTP must-gather failure collector")
+
+	reporter.RegisterCollector(
+		mustgather.CollectorName, mustgather.CollectorTimeout, mustgather.Collector(RANConfig.Spoke1APIClient))
+
 	By("deploying consumers")
 
 	err = consumer.DeployConsumersOnNodes(RANConfig.Spoke1APIClient)
 	Expect(err).ToNot(HaveOccurred(), "Failed to deploy consumers on nodes with PTP daemons")
+
+	By("collecting the hardware inventory of the spoke 1 nodes")
+
+	// The inventory is only used for reporting, so a partial inventory should not fail the suite.
+	spoke1Hardware, err = hwinventory.CollectNodes(RANConfig.Spoke1APIClient, metav1.ListOptions{})
+	if err != nil {
+		klog.V(tsparams.LogLevel).Infof("Failed to fully collect hardware inventory of spoke 1 nodes: %v", err)
+	}
 })
 
 var _ = AfterSuite(func() {
@@ -106,15 +127,37 @@ var _ = JustAfterEach(func() {
 
 	reporter.ReportIfFailed(
 		CurrentSpecReport(), currentFile, tsparams.ReporterSpokeNamespacesToDump, tsparams.ReporterSpokeCRsToDump)
-	mustgather.MustGatherIfFailed(CurrentSpecReport(), currentFile, RANConfig.Spoke1APIClient)
 })
 
 var _ = ReportAfterSuite("", func(report Report) {
 	reportxml.Create(report, RANConfig.GetReportPath(), RANConfig.TCPrefix)
 
+	By("generating hardware coverage report")
+
+	testedNICs, err := nicinfo.CollectNodes(RANConfig.Spoke1APIClient)
+	Expect(err).ToNot(HaveOccurred(), "Failed to collect network interface inventory")
+
+	// The tested NICs are passed along with the nodes so the usages recorded by nicinfo get their driver and firmware.
+	coverage := hwinventory.NewCoverage(
+		RANConfig.RunID, report.SuiteDescription, append(slices.Clone(spoke1Hardware), testedNICs...))
+	coveragePath := hwinventory.CoveragePath(RANConfig.ReportsDirAbsPath, RANConfig.RunID)
+
+	err = hwinventory.MergeIntoFile(coveragePath, coverage)
+	Expect(err).ToNot(HaveOccurred(), "Failed to save hardware coverage")
+
+	AddReportEntry("hardware coverage", coverage.Report())
+
 	By("generating network interface information report")
 
-	nicinfoReport, err := nicinfo.GenerateReport(RANConfig.Spoke1APIClient)
+	// The inventory file covers every suite of the run so far, so it is written from the merged coverage of the run.
+	runCoverage, err := hwinventory.ReadCoverage(coveragePath)
+	Expect(err).ToNot(HaveOccurred(), "Failed to read hardware coverage of the run")
+
+	err = nicinfo.WriteFile(
+		nicinfo.InventoryPath(RANConfig.ReportsDirAbsPath, RANConfig.RunID), nicinfo.NewInventory(runCoverage))
+	Expect(err).ToNot(HaveOccurred(), "Failed to save network interface inventory")
+
+	nicinfoReport, err := nicinfo.NewInventory(coverage).Report()
 	Expect(err).ToNot(HaveOccurred(), "Failed to generate network interface information report")
 
 	AddReportEntry("nicinfo", nicinfoReport)
@@ -2,6 +2,7 @@ package tests
 
 import (
 	"context"
+	"fmt"
 	"maps"
 	"time"
 
@@ -113,10 +114,9 @@ var _ = Describe("PTP Events and Metrics", Label(tsparams.LabelEventsAndMetrics)
 			testRanAtLeastOnce = true
 			ifaceGroups := iface.GroupInterfacesByNIC(profiles.GetInterfacesNames(clientInterfaces))
 
-			By("getting the event pod for the node")
+			By("getting the events for the node")
 
-			eventPod, err := consumer.GetConsumerPodforNode(RANConfig.Spoke1APIClient, nodeInfo.Name)
-			Expect(err).ToNot(HaveOccurred(), "Failed to get event pod for node %s", nodeInfo.Name)
+			waitForNodeEvent := getNodeEventWaiter(nodeInfo.Name, eventptp.PtpLockState)
 
 			for nic, ifaces := range ifaceGroups {
 				// Include this interface in the interface information report for this suite.
@@ -136,7 +136,7 @@ var _ = Describe("PTP Events and Metrics", Label(tsparams.LabelEventsAndMetrics)
 					events.IsType(eventptp.PtpStateChange),
 					events.HasValue(events.WithSyncState(eventptp.FREERUN)),
 				)
-				err = events.WaitForEvent(eventPod, startTime, 5*time.Minute, filter, events.WithoutCurrentState(true))
+				err = waitForNodeEvent(startTime, 5*time.Minute, filter)
 				Expect(err).ToNot(HaveOccurred(),
 					"Failed to wait for free run event on interface %s on node %s", ifaces[0], nodeInfo.Name)
 
@@ -157,7 +157,7 @@ var _ = Describe("PTP Events and Metrics", Label(tsparams.LabelEventsAndMetrics)
 					events.IsType(eventptp.PtpStateChange),
 					events.HasValue(events.WithSyncState(eventptp.LOCKED)),
 				)
-				err = events.WaitForEvent(eventPod, startTime, 15*time.Minute, filter, events.WithoutCurrentState(true))
+				err = waitForNodeEvent(startTime, 15*time.Minute, filter)
 				Expect(err).ToNot(HaveOccurred(),
 					"Failed to wait for locked event on interface %s on node %s", ifaces[0], nodeInfo.Name)
 			}
@@ -225,3 +225,41 @@ var _ = Describe("PTP Events and Metrics", Label(tsparams.LabelEventsAndMetrics)
 		}
 	})
 })
+
+// nodeEventWaiter waits up to timeout for an event on a single node matching filter to be received at or after
+// startTime. Events about the current state are not considered.
+type nodeEventWaiter func(startTime time.Time, timeout time.Duration, filter events.EventFilter) error
+
+// getNodeEventWaiter returns a nodeEventWaiter for nodeName. If a receiver URL is configured, events for the provided
+// resources of the node are received directly from the publisher, and the receiver is stopped when the spec ends.
+// Otherwise, the logs of the consumer pod on the node are used, in which case the consumer must already be deployed.
+func getNodeEventWaiter(nodeName string, resources ...eventptp.EventResource) nodeEventWaiter {
+	if RANConfig.PtpEventReceiverURL == "" {
+		eventPod, err := consumer.GetConsumerPodforNode(RANConfig.Spoke1APIClient, nodeName)
+		Expect(err).ToNot(HaveOccurred(), "Failed to get event pod for node %s", nodeName)
+
+		return func(startTime time.Time, timeout time.Duration, filter events.EventFilter) error {
+			return events.WaitForEvent(eventPod, startTime, timeout, filter, events.WithoutCurrentState(true))
+		}
+	}
+
+	receiver, stopReceiver, err := events.StartReceiver(RANConfig.Spoke1APIClient, nodeName,
+		RANConfig.PtpEventReceiverListenAddress, RANConfig.PtpEventReceiverURL)
+	Expect(err).ToNot(HaveOccurred(), "Failed to start event receiver for node %s", nodeName)
+
+	DeferCleanup(func(ctx SpecContext) {
+		err := stopReceiver(ctx)
+		Expect(err).ToNot(HaveOccurred(), "Failed to stop event receiver for node %s", nodeName)
+	})
+
+	for _, resource := range resources {
+		_, err := receiver.Subscribe(context.TODO(), fmt.Sprintf("/cluster/node/%s%s", nodeName, resource))
+		Expect(err).ToNot(HaveOccurred(), "Failed to subscribe to %s on node %s", resource, nodeName)
+	}
+
+	return func(startTime time.Time, timeout time.Duration, filter events.EventFilter) error {
+		_, err := receiver.WaitForEvent(context.TODO(), startTime, timeout, filter)
+
+		return err
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/consumer"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/daemonlogs"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/events"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/faults"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/gnss"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/iface"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/metrics"
@@ -176,12 +177,8 @@ var _ = Describe("PTP T-GM GNSS Loss", Label(tsparams.LabelGNSSLoss), func() {
 
 				By("simulating GNSS loss on node " + nodeName)
 
-				DeferCleanup(cleanupGNSSSync(prometheusAPI, nodeName, protocolVersion))
-
 				gpsLossTime := time.Now()
-
-				err = gnss.SimulateSyncLoss(RANConfig.Spoke1APIClient, nodeName, protocolVersion)
-				Expect(err).ToNot(HaveOccurred(), "Failed to simulate GNSS loss on node %s", nodeName)
+				gnssLoss := injectGNSSLoss(prometheusAPI, nodeName, protocolVersion)
 
 				By("getting the event consumer pod for node " + nodeName)
 
@@ -217,7 +214,7 @@ var _ = Describe("PTP T-GM GNSS Loss", Label(tsparams.LabelGNSSLoss), func() {
 
 				By("restoring GNSS sync on node " + nodeName)
 
-				err = gnss.SimulateSyncRecovery(RANConfig.Spoke1APIClient, nodeName, protocolVersion)
+				err = gnssLoss.Restore()
 				Expect(err).ToNot(HaveOccurred(), "Failed to restore GNSS sync on node %s", nodeName)
 
 				By("waiting for SYNCHRONIZED GNSS event")
@@ -361,12 +358,8 @@ var _ = Describe("PTP T-GM GNSS Loss", Label(tsparams.LabelGNSSLoss), func() {
 
 				By("simulating GNSS loss on node " + nodeName)
 
-				DeferCleanup(cleanupGNSSSync(prometheusAPI, nodeName, protocolVersion))
-
 				gpsLossTime := time.Now()
-
-				err = gnss.SimulateSyncLoss(RANConfig.Spoke1APIClient, nodeName, protocolVersion)
-				Expect(err).ToNot(HaveOccurred(), "Failed to simulate GNSS loss on node %s", nodeName)
+				gnssLoss := injectGNSSLoss(prometheusAPI, nodeName, protocolVersion)
 
 				By("waiting for the holdover timeout to expire")
 
@@ -374,7 +367,7 @@ var _ = Describe("PTP T-GM GNSS Loss", Label(tsparams.LabelGNSSLoss), func() {
 
 				By("restoring GNSS sync on node " + nodeName)
 
-				err = gnss.SimulateSyncRecovery(RANConfig.Spoke1APIClient, nodeName, protocolVersion)
+				err = gnssLoss.Restore()
 				Expect(err).ToNot(HaveOccurred(), "Failed to restore GNSS sync on node %s", nodeName)
 
 				By("getting the event consumer pod for node " + nodeName)
@@ -582,12 +575,8 @@ var _ = Describe("PTP T-GM GNSS Loss", Label(tsparams.LabelGNSSLoss), func() {
 				By(fmt.Sprintf("simulating GNSS loss on node %s for %v to reach max in-spec offset",
 					nodeName, timeToReachMaxInSpec))
 
-				DeferCleanup(cleanupGNSSSync(prometheusAPI, nodeName, protocolVersion))
-
 				gpsLossTime := time.Now()
-
-				err = gnss.SimulateSyncLoss(RANConfig.Spoke1APIClient, nodeName, protocolVersion)
-				Expect(err).ToNot(HaveOccurred(), "Failed to simulate GNSS loss on node %s", nodeName)
+				gnssLoss := injectGNSSLoss(prometheusAPI, nodeName, protocolVersion)
 
 				By("waiting for the offset to exceed the max in-spec threshold")
 
@@ -595,7 +584,7 @@ var _ = Describe("PTP T-GM GNSS Loss", Label(tsparams.LabelGNSSLoss), func() {
 
 				By("restoring GNSS sync on node " + nodeName)
 
-				err = gnss.SimulateSyncRecovery(RANConfig.Spoke1APIClient, nodeName, protocolVersion)
+				err = gnssLoss.Restore()
 				Expect(err).ToNot(HaveOccurred(), "Failed to restore GNSS sync on node %s", nodeName)
 
 				By("getting the event consumer pod for node " + nodeName)
@@ -721,6 +710,35 @@ var _ = Describe("PTP T-GM GNSS Loss", Label(tsparams.LabelGNSSLoss), func() {
 		})
 })
 
+// injectGNSSLoss simulates a loss of GNSS sync on nodeName using a fault injector that restores the sync when the spec
+// ends, unless the spec restores the returned fault first. If the spec failed, the ts2phc process is then waited on to
+// lock again so the next spec does not start in holdover.
+func injectGNSSLoss(prometheusAPI prometheusv1.API, nodeName, protocolVersion string) *faults.Fault {
+	GinkgoHelper()
+
+	// Cleanups run in reverse order, so registering this before creating the injector makes it run after the
+	// injector has restored the sync.
+	DeferCleanup(func() {
+		if !CurrentSpecReport().Failed() {
+			return
+		}
+
+		By("ensuring ts2phc process is locked after restoring GNSS sync")
+		ensureTS2PHCProcessLocked(prometheusAPI, nodeName)
+	})
+
+	injector := faults.NewSpecInjector(RANConfig.Spoke1APIClient)
+
+	gnssLoss, err := injector.Inject(faults.Spec{
+		Type:            faults.TypeGNSSLoss,
+		Node:            nodeName,
+		ProtocolVersion: protocolVersion,
+	})
+	Expect(err).ToNot(HaveOccurred(), "Failed to simulate GNSS loss on node %s", nodeName)
+
+	return gnssLoss
+}
+
 func validateGpsdFileEmpty(nodeName string) {
 	GinkgoHelper()
 
@@ -17,6 +17,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/version"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/consumer"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/events"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/faults"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/iface"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/metrics"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/profiles"
@@ -235,13 +236,7 @@ func assertHoldoverInSpecToLocked(
 
 	ifaceDownTime := time.Now()
 
-	err := iface.SetInterfaceStatus(RANConfig.Spoke1APIClient, testData.nodeName,
-		testData.upstreamIface, iface.InterfaceStateDown)
-	Expect(err).ToNot(HaveOccurred(), "Failed to set upstream clock interface down")
-
-	DeferCleanup(func() {
-		restoreInterfaceAndWaitForRelock(testData.prometheusAPI, testData.nodeName, testData.upstreamIface)
-	})
+	upstreamDown := injectUpstreamDown(testData)
 
 	assertHoldoverState(testData.prometheusAPI, testData.nodeName, ifaceDownTime,
 		expected.HoldoverInSpec, clockClassChanges, timeout)
@@ -250,8 +245,7 @@ func assertHoldoverInSpecToLocked(
 
 	ifaceUpTime := time.Now()
 
-	err = iface.SetInterfaceStatus(RANConfig.Spoke1APIClient, testData.nodeName,
-		testData.upstreamIface, iface.InterfaceStateUp)
+	err := upstreamDown.Restore()
 	Expect(err).ToNot(HaveOccurred(), "Failed to set upstream clock interface up")
 
 	assertLockedState(testData.prometheusAPI, testData.nodeName, ifaceUpTime,
@@ -277,13 +271,7 @@ func assertHoldoverInSpecToFreerun(
 
 	ifaceDownTime := time.Now()
 
-	err := iface.SetInterfaceStatus(RANConfig.Spoke1APIClient, testData.nodeName,
-		testData.upstreamIface, iface.InterfaceStateDown)
-	Expect(err).ToNot(HaveOccurred(), "Failed to set upstream clock interface down")
-
-	DeferCleanup(func() {
-		restoreInterfaceAndWaitForRelock(testData.prometheusAPI, testData.nodeName, testData.upstreamIface)
-	})
+	upstreamDown := injectUpstreamDown(testData)
 
 	assertHoldoverState(testData.prometheusAPI, testData.nodeName, ifaceDownTime,
 		expected.HoldoverInSpec, clockClassChanges, timeout)
@@ -295,8 +283,7 @@ func assertHoldoverInSpecToFreerun(
 
 	ifaceUpTime := time.Now()
 
-	err = iface.SetInterfaceStatus(RANConfig.Spoke1APIClient, testData.nodeName,
-		testData.upstreamIface, iface.InterfaceStateUp)
+	err := upstreamDown.Restore()
 	Expect(err).ToNot(HaveOccurred(), "Failed to set upstream clock interface up")
 
 	assertLockedState(testData.prometheusAPI, testData.nodeName, ifaceUpTime,
@@ -321,13 +308,7 @@ func assertHoldoverInSpecToOutOfSpec(
 
 	ifaceDownTime := time.Now()
 
-	err := iface.SetInterfaceStatus(RANConfig.Spoke1APIClient, testData.nodeName,
-		testData.upstreamIface, iface.InterfaceStateDown)
-	Expect(err).ToNot(HaveOccurred(), "Failed to set upstream clock interface down")
-
-	DeferCleanup(func() {
-		restoreInterfaceAndWaitForRelock(testData.prometheusAPI, testData.nodeName, testData.upstreamIface)
-	})
+	upstreamDown := injectUpstreamDown(testData)
 
 	assertHoldoverState(testData.prometheusAPI, testData.nodeName, ifaceDownTime,
 		expected.HoldoverInSpec, clockClassChanges, timeout)
@@ -339,8 +320,7 @@ func assertHoldoverInSpecToOutOfSpec(
 
 	ifaceUpTime := time.Now()
 
-	err = iface.SetInterfaceStatus(RANConfig.Spoke1APIClient, testData.nodeName,
-		testData.upstreamIface, iface.InterfaceStateUp)
+	err := upstreamDown.Restore()
 	Expect(err).ToNot(HaveOccurred(), "Failed to set upstream clock interface up")
 
 	assertLockedState(testData.prometheusAPI, testData.nodeName, ifaceUpTime,
@@ -366,13 +346,7 @@ func assertHoldoverOutOfSpecToFreerun(
 
 	ifaceDownTime := time.Now()
 
-	err := iface.SetInterfaceStatus(RANConfig.Spoke1APIClient, testData.nodeName,
-		testData.upstreamIface, iface.InterfaceStateDown)
-	Expect(err).ToNot(HaveOccurred(), "Failed to set upstream clock interface down")
-
-	DeferCleanup(func() {
-		restoreInterfaceAndWaitForRelock(testData.prometheusAPI, testData.nodeName, testData.upstreamIface)
-	})
+	upstreamDown := injectUpstreamDown(testData)
 
 	assertHoldoverState(testData.prometheusAPI, testData.nodeName, ifaceDownTime,
 		expected.HoldoverInSpec, clockClassChanges, timeout)
@@ -387,37 +361,52 @@ func assertHoldoverOutOfSpecToFreerun(
 
 	ifaceUpTime := time.Now()
 
-	err = iface.SetInterfaceStatus(RANConfig.Spoke1APIClient, testData.nodeName,
-		testData.upstreamIface, iface.InterfaceStateUp)
+	err := upstreamDown.Restore()
 	Expect(err).ToNot(HaveOccurred(), "Failed to set upstream clock interface up")
 
 	assertLockedState(testData.prometheusAPI, testData.nodeName, ifaceUpTime,
 		expected.Locked, clockClassChanges, timeout)
 }
 
-// restoreInterfaceAndWaitForRelock brings the upstream interface back up and waits for the T-BC clock to return
-// to LOCKED state with a 5-second stable duration, matching the OC 2-port restore pattern. The process label
-// is T-BC because the linuxptp-daemon uses the clockType from PtpSettings (set to "T-BC" for T-BC profiles)
-// as the process label for clock state metrics. T-TSC profiles use the same shared helpers and the same process
-// label because cnf-gotests uses ProcessTBC for both T-BC and T-TSC holdover metric assertions.
-func restoreInterfaceAndWaitForRelock(
-	prometheusAPI prometheusv1.API,
-	nodeName string,
-	upstreamIface iface.Name,
-) {
+// injectUpstreamDown sets the upstream clock interface down using a fault injector that sets it back up when the spec
+// ends, unless the spec restores the returned fault first. Either way, the clock is then waited on to relock so the
+// next spec starts from LOCKED.
+func injectUpstreamDown(testData holdoverTestData) *faults.Fault {
 	GinkgoHelper()
 
-	By("restoring upstream interface and waiting for relock")
+	// Cleanups run in reverse order, so registering this before creating the injector makes it run after the
+	// injector has restored the interface.
+	DeferCleanup(func() {
+		waitForRelock(testData.prometheusAPI, testData.nodeName)
+	})
 
-	err := iface.SetInterfaceStatus(RANConfig.Spoke1APIClient, nodeName,
-		upstreamIface, iface.InterfaceStateUp)
-	Expect(err).ToNot(HaveOccurred(), "Failed to restore upstream clock interface")
+	injector := faults.NewSpecInjector(RANConfig.Spoke1APIClient)
+
+	upstreamDown, err := injector.Inject(faults.Spec{
+		Type:      faults.TypeInterfaceDown,
+		Node:      testData.nodeName,
+		Interface: testData.upstreamIface,
+	})
+	Expect(err).ToNot(HaveOccurred(), "Failed to set upstream clock interface down")
+
+	return upstreamDown
+}
+
+// waitForRelock waits for the T-BC clock to return to LOCKED state with a 5-second stable duration, matching the OC
+// 2-port restore pattern. The process label is T-BC because the linuxptp-daemon uses the clockType from PtpSettings
+// (set to "T-BC" for T-BC profiles) as the process label for clock state metrics. T-TSC profiles use the same shared
+// helpers and the same process label because cnf-gotests uses ProcessTBC for both T-BC and T-TSC holdover metric
+// assertions.
+func waitForRelock(prometheusAPI prometheusv1.API, nodeName string) {
+	GinkgoHelper()
+
+	By("waiting for the clock to relock after restoring the upstream interface")
 
 	clockStateQuery := metrics.ClockStateQuery{
 		Node:    metrics.Equals(nodeName),
 		Process: metrics.Equals(metrics.ProcessTBC),
 	}
-	err = metrics.AssertQuery(context.TODO(), prometheusAPI, clockStateQuery, metrics.ClockStateLocked,
+	err := metrics.AssertQuery(context.TODO(), prometheusAPI, clockStateQuery, metrics.ClockStateLocked,
 		metrics.AssertWithStableDuration(5*time.Second),
 		metrics.AssertWithTimeout(3*time.Minute))
 	Expect(err).ToNot(HaveOccurred(), "Clock did not return to LOCKED after restoration")
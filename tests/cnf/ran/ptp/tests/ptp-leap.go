@@ -77,17 +77,18 @@ var _ = Describe("PTP Leap File", Label(tsparams.LabelLeapFile), func() {
 				RANConfig.Spoke1APIClient, tsparams.LeapConfigmapName, ranparam.PtpOperatorNamespace)
 			Expect(err).ToNot(HaveOccurred(), "Failed to pull leap configmap")
 
-			nodeInfoMap, err := profiles.GetNodeInfoMap(RANConfig.Spoke1APIClient)
-			Expect(err).ToNot(HaveOccurred(), "Failed to get node info map")
+			topology, err := profiles.GetTopology(RANConfig.Spoke1APIClient)
+			Expect(err).ToNot(HaveOccurred(), "Failed to get PTP topology")
 
-			for _, nodeInfo := range nodeInfoMap {
-				if nodeInfo.Counts[profiles.ProfileTypeMultiNICGM] == 0 &&
-					nodeInfo.Counts[profiles.ProfileTypeGM] == 0 {
-					continue
-				}
+			gmNodes, err := topology.FindNodes(
+				profiles.RequireProfileType(profiles.ProfileTypeGM, profiles.ProfileTypeMultiNICGM))
+			if err != nil {
+				Skip(err.Error())
+			}
 
+			for _, gmNode := range gmNodes {
 				testRanAtLeastOnce = true
-				nodeName = nodeInfo.Name
+				nodeName = gmNode.Name
 				originalLeapConfigMapData := leapConfigMap.Object.Data[nodeName]
 
 				By(fmt.Sprintf("removing the last leap announcement from the leap configmap for node %s", nodeName))
@@ -123,9 +124,5 @@ var _ = Describe("PTP Leap File", Label(tsparams.LabelLeapFile), func() {
 				Expect(err).ToNot(HaveOccurred(), "Failed to get last announcement")
 				Expect(newLastAnnouncement).NotTo(Equal(originalLastAnnouncement), "Last announcement should be different")
 			}
-
-			if !testRanAtLeastOnce {
-				Skip("Could not find any node to run the test on")
-			}
 		})
 })
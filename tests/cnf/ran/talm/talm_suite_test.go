@@ -1,8 +1,6 @@
 package talm
 
 import (
-	"fmt"
-	"path"
 	"runtime"
 	"testing"
 
@@ -45,33 +43,27 @@ var _ = AfterSuite(func() {
 })
 
 var _ = JustAfterEach(func() {
-	var (
-		currentDir, currentFilename = path.Split(currentFile)
-		hubReportPath               = fmt.Sprintf("%shub_%s", currentDir, currentFilename)
-		spoke2ReportPath            = fmt.Sprintf("%sspoke2_%s", currentDir, currentFilename)
-		report                      = CurrentSpecReport()
-	)
-
-	reporter.ReportIfFailed(
-		report, currentFile, tsparams.ReporterSpokeNamespacesToDump, tsparams.ReporterSpokeCRsToDump)
+	clusters := map[string]reporter.ClusterDump{
+		"spoke1": {Namespaces: tsparams.ReporterSpokeNamespacesToDump, CRs: tsparams.ReporterSpokeCRsToDump},
+	}
 
 	if HubAPIClient != nil {
-		reporter.ReportIfFailedOnCluster(
-			RANConfig.HubKubeconfig,
-			report,
-			hubReportPath,
-			tsparams.ReporterHubNamespacesToDump,
-			tsparams.ReporterHubCRsToDump)
+		clusters["hub"] = reporter.ClusterDump{
+			Kubeconfig: RANConfig.HubKubeconfig,
+			Namespaces: tsparams.ReporterHubNamespacesToDump,
+			CRs:        tsparams.ReporterHubCRsToDump,
+		}
 	}
 
 	if Spoke2APIClient != nil {
-		reporter.ReportIfFailedOnCluster(
-			RANConfig.Spoke2Kubeconfig,
-			report,
-			spoke2ReportPath,
-			tsparams.ReporterSpokeNamespacesToDump,
-			tsparams.ReporterSpokeCRsToDump)
+		clusters["spoke2"] = reporter.ClusterDump{
+			Kubeconfig: RANConfig.Spoke2Kubeconfig,
+			Namespaces: tsparams.ReporterSpokeNamespacesToDump,
+			CRs:        tsparams.ReporterSpokeCRsToDump,
+		}
 	}
+
+	reporter.ReportIfFailedOnClusters(CurrentSpecReport(), currentFile, clusters)
 })
 
 var _ = ReportAfterSuite("", func(report Report) {
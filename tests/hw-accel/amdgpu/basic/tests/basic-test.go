@@ -23,6 +23,7 @@ import (
 	amdgpuparams "github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/amdgpu/params"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/internal/deploy"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/nfd/nfdparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/hwinventory"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -473,8 +474,21 @@ var _ = Describe("AMD GPU Basic Tests", Ordered, Label(amdgpuparams.LabelSuite),
 
 				klog.V(amdgpuparams.AMDGPULogLevel).Infof("Node %s: GPU capacity=%d, allocatable=%d",
 					node.Object.Name, capacity, allocatable)
+
+				hwinventory.RecordUsage(node.Object.Name, hwinventory.DeviceKindGPU, amdgpuparams.AMDGPUCapacityID)
+			}
+
+			By("Recording the AMD GPU Worker Nodes in the hardware coverage")
+
+			// The nodes are collected now since their GPUs are only exposed while the DeviceConfig is deployed.
+			amdNodes, err := hwinventory.CollectNodes(apiClient, amdListOptions)
+			if err != nil {
+				klog.V(amdgpuparams.AMDGPULogLevel).Infof(
+					"Failed to fully collect hardware inventory of AMD GPU nodes: %v", err)
 			}
 
+			hwinventory.RecordNodes(amdNodes...)
+
 			klog.V(amdgpuparams.AMDGPULogLevel).Info("Device Plugin pods running and GPU resources available")
 		})
 
@@ -6,8 +6,8 @@ import (
 
 	"k8s.io/klog/v2"
 
-	"github.com/kelseyhightower/envconfig"
 	amdgpuparams "github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/amdgpu/params"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 )
 
 // amdGPUConfigHelper Helps to convert different strings to bool.
@@ -31,7 +31,7 @@ func NewAMDConfig() *AMDConfig {
 
 	AMDConfig := new(AMDConfig)
 
-	err := envconfig.Process("eco_hwaccel_amd_", AMDConfig)
+	err := config.NewLoader().WithEnvconfigPrefix("eco_hwaccel_amd_").WithEnvPrefix("ECO_HWACCEL_AMD_").Load(AMDConfig)
 	if err != nil {
 		log.Printf("failed to instantiate AMDConfig: %v", err)
 
@@ -40,7 +40,7 @@ func NewAMDConfig() *AMDConfig {
 
 	var configHelper amdGPUConfigHelper
 
-	configHelperErr := envconfig.Process("eco_hwaccel_amd_", &configHelper)
+	configHelperErr := config.NewLoader().WithEnvconfigPrefix("eco_hwaccel_amd_").Load(&configHelper)
 	if configHelperErr != nil {
 		klog.V(amdgpuparams.AMDGPULogLevel).Infof(
 			"failed to process env vars for amdConfigHelper fields: %v", configHelperErr)
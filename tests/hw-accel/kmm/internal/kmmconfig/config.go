@@ -5,9 +5,8 @@ import (
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/internal/kmmparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"k8s.io/klog/v2"
-
-	"github.com/kelseyhightower/envconfig"
 )
 
 // ModulesConfig contains environment information related to kmm tests.
@@ -30,7 +29,7 @@ func NewModulesConfig() *ModulesConfig {
 
 	modulesConfig := new(ModulesConfig)
 
-	err := envconfig.Process("eco_hwaccel_kmm_", modulesConfig)
+	err := config.NewLoader().WithEnvconfigPrefix("eco_hwaccel_kmm_").WithEnvPrefix("ECO_HWACCEL_KMM_").Load(modulesConfig)
 	if err != nil {
 		log.Printf("failed to instantiate ModulesConfig: %v", err)
 
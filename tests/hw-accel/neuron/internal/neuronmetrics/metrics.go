@@ -1,37 +1,24 @@
 package neuronmetrics
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/url"
 	"time"
 
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/internal/neuronparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/params"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/promquery"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/client-go/kubernetes/scheme"
-	"k8s.io/client-go/tools/remotecommand"
 	"k8s.io/klog/v2"
 )
 
-// PrometheusQueryResult represents the result from a Prometheus query.
-type PrometheusQueryResult struct {
-	Status string `json:"status"`
-	Data   struct {
-		ResultType string `json:"resultType"`
-		Result     []struct {
-			Metric map[string]string `json:"metric"`
-			Value  []interface{}     `json:"value"`
-		} `json:"result"`
-	} `json:"data"`
-}
-
 // ServiceMonitorExists checks if a ServiceMonitor exists.
 func ServiceMonitorExists(apiClient *clients.Settings, name, namespace string) (bool, error) {
 	klog.V(params.NeuronLogLevel).Infof("Checking if ServiceMonitor %s exists in namespace %s", name, namespace)
@@ -66,8 +53,10 @@ func ListServiceMonitors(apiClient *clients.Settings, namespace string) (*unstru
 		List(context.Background(), metav1.ListOptions{})
 }
 
-// QueryPrometheus queries Prometheus for a specific metric by executing a query inside a cluster pod.
-func QueryPrometheus(apiClient *clients.Settings, query string) (*PrometheusQueryResult, error) {
+// QueryPrometheus queries Prometheus for a specific metric by executing a query inside a cluster pod. The local
+// endpoints of the pod are tried first, then the authenticated Thanos querier service, and the first to return samples
+// is used.
+func QueryPrometheus(apiClient *clients.Settings, query string) (model.Vector, error) {
 	klog.V(params.NeuronLogLevel).Infof("Querying Prometheus for: %s", query)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
@@ -81,105 +70,81 @@ func QueryPrometheus(apiClient *clients.Settings, query string) (*PrometheusQuer
 
 	klog.V(params.NeuronLogLevel).Infof("Using monitoring pod: %s (container: %s)", podInfo.Name, podInfo.Container)
 
-	encodedQuery := url.QueryEscape(query)
+	monitoringPod, err := pod.Pull(apiClient, podInfo.Name, neuronparams.PrometheusNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull monitoring pod %s: %w", podInfo.Name, err)
+	}
 
-	// Try localhost endpoints inside the thanos-query container
+	// Try localhost endpoints inside the thanos-query container before the authenticated service.
 	endpoints := []struct {
-		name string
-		url  string
+		address string
+		options []promquery.PodClientOption
 	}{
-		{"localhost:9090", fmt.Sprintf("http://localhost:9090/api/v1/query?query=%s", encodedQuery)},
-		{"localhost:9095", fmt.Sprintf("http://localhost:9095/api/v1/query?query=%s", encodedQuery)},
-		{"localhost:10902", fmt.Sprintf("http://localhost:10902/api/v1/query?query=%s", encodedQuery)},
+		{address: "http://localhost:9090"},
+		{address: "http://localhost:9095"},
+		{address: "http://localhost:10902"},
+		{
+			address: fmt.Sprintf("https://%s.%s.svc:9091",
+				neuronparams.ThanosQuerierServiceName, neuronparams.PrometheusNamespace),
+			options: []promquery.PodClientOption{
+				promquery.PodClientWithServiceAccountToken(), promquery.PodClientWithInsecureSkipTLSVerify()},
+		},
 	}
 
 	for _, endpoint := range endpoints {
-		queryCmd := []string{"sh", "-c", fmt.Sprintf("curl -sf '%s' 2>/dev/null", endpoint.url)}
-		resp, err := executeInPod(ctx, apiClient, podInfo.Name, neuronparams.PrometheusNamespace, podInfo.Container, queryCmd)
-
-		if err == nil && resp != "" && !isUnauthorized(resp) {
-			result, parseErr := parsePrometheusResponse(resp)
-			if parseErr == nil && len(result.Data.Result) > 0 {
-				klog.V(params.NeuronLogLevel).Infof("Endpoint %s returned %d results", endpoint.name, len(result.Data.Result))
-
-				return result, nil
-			}
+		podClient, err := promquery.NewPodClient(monitoringPod, podInfo.Container, endpoint.address, endpoint.options...)
+		if err != nil {
+			return nil, err
+		}
 
-			klog.V(params.NeuronLogLevel).Infof("Endpoint %s returned 0 results, trying next", endpoint.name)
+		vector, err := promquery.QueryVector(ctx, prometheusv1.NewAPI(podClient), promquery.Expr(query), time.Time{})
+		if err != nil {
+			klog.V(params.NeuronLogLevel).Infof("Endpoint %s failed: %v", endpoint.address, err)
 
 			continue
 		}
 
-		klog.V(params.NeuronLogLevel).Infof("Endpoint %s failed: err=%v, empty=%t", endpoint.name, err, resp == "")
-	}
+		if len(vector) > 0 {
+			klog.V(params.NeuronLogLevel).Infof("Endpoint %s returned %d results", endpoint.address, len(vector))
 
-	result, authErr := queryThanosAuth(ctx, apiClient, podInfo, encodedQuery)
-	if authErr == nil {
-		return result, nil
-	}
-
-	return nil, fmt.Errorf("all prometheus endpoints failed, thanos auth: %w", authErr)
-}
-
-func queryThanosAuth(ctx context.Context, apiClient *clients.Settings,
-	podInfo *monitoringPodInfo, encodedQuery string) (*PrometheusQueryResult, error) {
-	authURL := fmt.Sprintf("https://%s.%s.svc:9091/api/v1/query?query=%s",
-		neuronparams.ThanosQuerierServiceName, neuronparams.PrometheusNamespace, encodedQuery)
-	authCmd := []string{"sh", "-c", fmt.Sprintf(
-		`curl -sf -k -H "Authorization: Bearer $(cat /var/run/secrets/kubernetes.io/serviceaccount/token)" '%s' 2>/dev/null`,
-		authURL)}
-
-	resp, err := executeInPod(ctx, apiClient, podInfo.Name, neuronparams.PrometheusNamespace, podInfo.Container, authCmd)
-	if err != nil || resp == "" || isUnauthorized(resp) {
-		return nil, fmt.Errorf("thanos auth endpoint failed")
-	}
-
-	result, parseErr := parsePrometheusResponse(resp)
-	if parseErr != nil {
-		return nil, fmt.Errorf("thanos auth endpoint parse error: %w", parseErr)
-	}
+			return vector, nil
+		}
 
-	if len(result.Data.Result) == 0 {
-		return nil, fmt.Errorf("thanos auth endpoint: no results")
+		klog.V(params.NeuronLogLevel).Infof("Endpoint %s returned 0 results, trying next", endpoint.address)
 	}
 
-	klog.V(params.NeuronLogLevel).Infof("Thanos auth endpoint returned %d results", len(result.Data.Result))
-
-	return result, nil
+	return nil, fmt.Errorf("no prometheus endpoint returned results for query %s", query)
 }
 
 // MetricExists checks if a metric exists in Prometheus.
 func MetricExists(apiClient *clients.Settings, metricName string) (bool, error) {
-	result, err := QueryPrometheus(apiClient, metricName)
+	vector, err := QueryPrometheus(apiClient, metricName)
 	if err != nil {
 		return false, err
 	}
 
-	return result.Status == "success" && len(result.Data.Result) > 0, nil
+	return len(vector) > 0, nil
 }
 
 // GetMetricValue gets the value of a metric from Prometheus.
 func GetMetricValue(apiClient *clients.Settings, metricName string) ([]map[string]interface{}, error) {
-	result, err := QueryPrometheus(apiClient, metricName)
+	vector, err := QueryPrometheus(apiClient, metricName)
 	if err != nil {
 		return nil, err
 	}
 
-	if result.Status != "success" {
-		return nil, fmt.Errorf("query failed with status: %s", result.Status)
-	}
-
 	var values []map[string]interface{}
 
-	for _, r := range result.Data.Result {
-		value := map[string]interface{}{
-			"metric": r.Metric,
-		}
-		if len(r.Value) >= 2 {
-			value["value"] = r.Value[1]
+	for _, sample := range vector {
+		labels := make(map[string]string, len(sample.Metric))
+		for name, value := range sample.Metric {
+			labels[string(name)] = string(value)
 		}
 
-		values = append(values, value)
+		values = append(values, map[string]interface{}{
+			"metric": labels,
+			"value":  sample.Value.String(),
+		})
 	}
 
 	return values, nil
@@ -270,49 +235,3 @@ func findMonitoringPod(ctx context.Context, apiClient *clients.Settings) (*monit
 
 	return nil, fmt.Errorf("no running monitoring pods found")
 }
-
-// executeInPod runs a command inside a pod and returns stdout.
-func executeInPod(ctx context.Context, apiClient *clients.Settings,
-	podName, namespace, container string, command []string) (string, error) {
-	execReq := apiClient.CoreV1Interface.RESTClient().Post().
-		Resource("pods").
-		Name(podName).
-		Namespace(namespace).
-		SubResource("exec").
-		VersionedParams(&corev1.PodExecOptions{
-			Container: container,
-			Command:   command,
-			Stdout:    true,
-			Stderr:    true,
-		}, scheme.ParameterCodec)
-
-	exec, err := remotecommand.NewSPDYExecutor(apiClient.Config, "POST", execReq.URL())
-	if err != nil {
-		return "", fmt.Errorf("failed to create executor: %w", err)
-	}
-
-	var stdout, stderr bytes.Buffer
-
-	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
-		Stdout: &stdout,
-		Stderr: &stderr,
-	})
-	if err != nil {
-		return "", fmt.Errorf("exec failed: %w, stderr: %s", err, stderr.String())
-	}
-
-	return stdout.String(), nil
-}
-
-func parsePrometheusResponse(raw string) (*PrometheusQueryResult, error) {
-	var result PrometheusQueryResult
-	if err := json.Unmarshal([]byte(raw), &result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	return &result, nil
-}
-
-func isUnauthorized(resp string) bool {
-	return resp == "Unauthorized" || resp == "Unauthorized\n"
-}
@@ -8,10 +8,20 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
 	_ "github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/metrics/tests"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/params"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/hwinventory"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
 )
 
-var _, currentFile, _, _ = runtime.Caller(0)
+var (
+	_, currentFile, _, _ = runtime.Caller(0)
+
+	// clusterHardware holds the hardware inventory of the nodes collected in BeforeSuite so it can be reported in
+	// ReportAfterSuite along with the devices the specs exercised.
+	clusterHardware []hwinventory.Node
+)
 
 func TestMetrics(t *testing.T) {
 	_, reporterConfig := GinkgoConfiguration()
@@ -23,6 +33,16 @@ func TestMetrics(t *testing.T) {
 
 var _ = BeforeSuite(func() {
 	By("Setting up Neuron Metrics test suite")
+
+	By("Collecting the hardware inventory of the nodes")
+
+	var err error
+
+	// The inventory is only used for reporting, so a partial inventory should not fail the suite.
+	clusterHardware, err = hwinventory.CollectNodes(APIClient, metav1.ListOptions{})
+	if err != nil {
+		klog.V(params.NeuronLogLevel).Infof("Failed to fully collect hardware inventory: %v", err)
+	}
 })
 
 var _ = AfterSuite(func() {
@@ -31,4 +51,12 @@ var _ = AfterSuite(func() {
 
 var _ = ReportAfterSuite("", func(report Report) {
 	reportxml.Create(report, GeneralConfig.GetReportPath(), GeneralConfig.TCPrefix)
+
+	coverage := hwinventory.NewCoverage(GeneralConfig.RunID, report.SuiteDescription, clusterHardware)
+
+	err := hwinventory.MergeIntoFile(
+		hwinventory.CoveragePath(GeneralConfig.ReportsDirAbsPath, GeneralConfig.RunID), coverage)
+	Expect(err).ToNot(HaveOccurred(), "Failed to save hardware coverage")
+
+	AddReportEntry("hardware coverage", coverage.Report())
 })
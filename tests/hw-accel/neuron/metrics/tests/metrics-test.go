@@ -17,6 +17,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/internal/neuronmetrics"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/metrics/internal/tsparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/params"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/hwinventory"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -270,6 +271,8 @@ var _ = Describe("Neuron Metrics Tests", Ordered, Label(params.Label), Label(par
 					klog.V(params.NeuronLogLevel).Infof("Node %s: %d devices, %d cores (from node capacity)",
 						node.Object.Name, neuronDevices, neuronCores)
 
+					hwinventory.RecordUsage(node.Object.Name, hwinventory.DeviceKindAccelerator, params.NeuronCapacityID)
+
 					Expect(neuronDevices).To(BeNumerically(">", 0),
 						"Expected node %s to have at least one Neuron device", node.Object.Name)
 					Expect(neuronCores).To(BeNumerically(">", 0),
@@ -3,7 +3,7 @@ package nfdconfig
 import (
 	"log"
 
-	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 )
 
 // NfdConfig contains environment information related to nfd tests.
@@ -23,7 +23,7 @@ func NewNfdConfig() *NfdConfig {
 
 	nfdConfig := new(NfdConfig)
 
-	err := envconfig.Process("eco_hwaccel_nfd_", nfdConfig)
+	err := config.NewLoader().WithEnvconfigPrefix("eco_hwaccel_nfd_").WithEnvPrefix("ECO_HWACCEL_NFD_").Load(nfdConfig)
 	if err != nil {
 		log.Printf("failed to instantiate NfdConfig: %v", err)
 
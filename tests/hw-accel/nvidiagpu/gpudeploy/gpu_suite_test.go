@@ -9,6 +9,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/nvidiagpu/gpudeploy/internal/tsparams"
 	_ "github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/nvidiagpu/gpudeploy/tests"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/hwinventory"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -28,6 +29,17 @@ func TestGPUDeploy(t *testing.T) {
 var _ = ReportAfterSuite("", func(report Report) {
 	reportxml.Create(
 		report, GeneralConfig.GetReportPath(), GeneralConfig.TCPrefix)
+
+	By("Generating hardware coverage report")
+
+	// The GPU nodes are recorded by the specs, since GPUs are only exposed once the operators are deployed.
+	coverage := hwinventory.NewCoverage(GeneralConfig.RunID, report.SuiteDescription, nil)
+
+	err := hwinventory.MergeIntoFile(
+		hwinventory.CoveragePath(GeneralConfig.ReportsDirAbsPath, GeneralConfig.RunID), coverage)
+	Expect(err).ToNot(HaveOccurred(), "Failed to save hardware coverage")
+
+	AddReportEntry("hardware coverage", coverage.Report())
 })
 
 var _ = JustAfterEach(func() {
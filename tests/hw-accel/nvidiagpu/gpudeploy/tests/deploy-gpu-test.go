@@ -29,8 +29,10 @@ import (
 	gpuburn "github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/nvidiagpu/internal/gpu-burn"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/nvidiagpu/internal/gpuparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/nvidiagpu/internal/wait"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/hwinventory"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/klog/v2"
 
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
@@ -675,6 +677,24 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 
 				Expect(match1 && match2).ToNot(BeFalse(), "gpu-burn pod execution was FAILED")
 				klog.V(gpuparams.GpuLogLevel).Infof("Gpu-burn pod execution was successful")
+
+				By("Record the GPU node exercised by gpu-burn in the hardware coverage")
+
+				// The GPU nodes are collected now since their GPUs are only exposed while the ClusterPolicy is
+				// deployed, and nodes added by the MachineSet are deleted once the spec ends.
+				gpuNodes, err := hwinventory.CollectNodes(APIClient, metav1.ListOptions{
+					LabelSelector: labels.Set(gpuWorkerNodeSelector).String()})
+				if err != nil {
+					klog.V(gpuparams.GpuLogLevel).Infof("Failed to fully collect hardware inventory of GPU nodes: %v",
+						err)
+				}
+
+				hwinventory.RecordNodes(gpuNodes...)
+
+				if gpuPodPulled.Exists() && gpuPodPulled.Object != nil {
+					hwinventory.RecordUsage(
+						gpuPodPulled.Object.Spec.NodeName, hwinventory.DeviceKindGPU, gpuparams.GPUCapacityID)
+				}
 			})
 	})
 })
@@ -6,4 +6,7 @@ const (
 
 	// GpuLogLevel custom loglevel of GPU related functions.
 	GpuLogLevel = 90
+
+	// GPUCapacityID - ID string for NVIDIA GPU capacity.
+	GPUCapacityID = "nvidia.com/gpu"
 )
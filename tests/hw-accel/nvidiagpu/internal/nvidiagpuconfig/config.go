@@ -3,7 +3,7 @@ package nvidiagpuconfig
 import (
 	"log"
 
-	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 )
 
 // NvidiaGPUConfig contains environment information related to nvidiagpu tests.
@@ -19,7 +19,8 @@ func NewNvidiaGPUConfig() *NvidiaGPUConfig {
 
 	nvidiaGPUConfig := new(NvidiaGPUConfig)
 
-	err := envconfig.Process("eco_hwaccel_nvidiagpu_", nvidiaGPUConfig)
+	err := config.NewLoader().WithEnvconfigPrefix("eco_hwaccel_nvidiagpu_").
+		WithEnvPrefix("ECO_HWACCEL_NVIDIAGPU_").Load(nvidiaGPUConfig)
 	if err != nil {
 		log.Printf("failed to instantiate nvidiaGPUConfig: %v", err)
 
@@ -1,12 +1,9 @@
 package cluster
 
 import (
-	"regexp"
-
 	configv1 "github.com/openshift/api/config/v1"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/infrastructure"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/mco"
-	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/klog/v2"
 
@@ -18,7 +15,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
-	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/retry"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/ptr"
@@ -63,9 +60,10 @@ func PullTestImageOnNodes(apiClient *clients.Settings, nodeSelector, image strin
 	return nil
 }
 
-// ExecCmd runc cmd on all nodes that match nodeSelector.
+// ExecCmd runc cmd on all nodes that match nodeSelector using the NodeExecutor of ECO_NODE_EXEC_TRANSPORT. The nodes
+// are run on concurrently and the errors of every node that failed are returned.
 func ExecCmd(apiClient *clients.Settings, nodeSelector string, shellCmd string) error {
-	klog.V(90).Infof("Executing cmd: %v on nodes based on label: %v using mcp pods", shellCmd, nodeSelector)
+	klog.V(90).Infof("Executing cmd: %v on nodes based on label: %v", shellCmd, nodeSelector)
 
 	nodeList, err := nodes.List(
 		apiClient,
@@ -75,52 +73,24 @@ func ExecCmd(apiClient *clients.Settings, nodeSelector string, shellCmd string)
 		return err
 	}
 
-	for _, node := range nodeList {
-		listOptions := metav1.ListOptions{
-			FieldSelector: fields.SelectorFromSet(fields.Set{"spec.nodeName": node.Definition.Name}).String(),
-			LabelSelector: labels.SelectorFromSet(labels.Set{"k8s-app": GeneralConfig.MCOConfigDaemonName}).String(),
-		}
-
-		mcPodList, err := pod.List(apiClient, GeneralConfig.MCONamespace, listOptions)
-		if err != nil {
-			return err
-		}
-
-		for _, mcPod := range mcPodList {
-			err = mcPod.WaitUntilRunning(300 * time.Second)
-			if err != nil {
-				return err
-			}
-
-			cmdToExec := []string{"sh", "-c", fmt.Sprintf("nsenter --mount=/proc/1/ns/mnt -- sh -c '%s'", shellCmd)}
-
-			klog.V(90).Infof("Exec cmd %v on pod %s", cmdToExec, mcPod.Definition.Name)
-
-			buf, err := mcPod.ExecCommand(cmdToExec)
-			if err != nil {
-				return fmt.Errorf("%w\n%s", err, buf.String())
-			}
-		}
+	executor, err := NewNodeExecutor(apiClient)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	defer closeNodeExecutor(executor)
+
+	return ExecOnNodes(context.TODO(), executor, getNodeNames(nodeList), shellCmd, DefaultExecConcurrency).Err()
 }
 
-// ExecCmdWithStdout runs cmd on all selected nodes and returns their stdout.
-//
-//nolint:funlen
+// ExecCmdWithStdout runs cmd on all selected nodes using the NodeExecutor of ECO_NODE_EXEC_TRANSPORT and returns their
+// combined stdout and stderr, keyed by node name. Stderr is included since this used to run commands with a TTY, which
+// merges it into stdout, and callers may rely on it. The nodes are run on concurrently and, if the command fails on any
+// of them, the output of the nodes it succeeded on is returned along with the errors of the others.
 func ExecCmdWithStdout(
 	apiClient *clients.Settings, shellCmd string, options ...metav1.ListOptions) (map[string]string, error) {
 	klog.V(90).Infof("Executing command '%s' with stdout and options ('%v')", shellCmd, options)
 
-	if GeneralConfig.MCOConfigDaemonName == "" {
-		return nil, fmt.Errorf("error: mco config daemon pod name cannot be empty")
-	}
-
-	if GeneralConfig.MCONamespace == "" {
-		return nil, fmt.Errorf("error: mco namespace cannot be empty")
-	}
-
 	logMessage := fmt.Sprintf("Executing cmd: %v on nodes", shellCmd)
 
 	passedOptions := metav1.ListOptions{}
@@ -138,6 +108,13 @@ func ExecCmdWithStdout(
 
 	klog.V(90).Info(logMessage)
 
+	executor, err := NewNodeExecutor(apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	defer closeNodeExecutor(executor)
+
 	nodeList, err := nodes.List(
 		apiClient,
 		passedOptions,
@@ -148,49 +125,14 @@ func ExecCmdWithStdout(
 
 	klog.V(90).Infof("Found %d nodes matching selector", len(nodeList))
 
-	outputMap := make(map[string]string)
-
-	for _, node := range nodeList {
-		listOptions := metav1.ListOptions{
-			FieldSelector: fields.SelectorFromSet(fields.Set{"spec.nodeName": node.Definition.Name}).String(),
-			LabelSelector: labels.SelectorFromSet(labels.Set{"k8s-app": GeneralConfig.MCOConfigDaemonName}).String(),
-		}
-
-		mcPodList, err := pod.List(apiClient, GeneralConfig.MCONamespace, listOptions)
-		if err != nil {
-			return nil, err
-		}
+	results := ExecOnNodes(context.TODO(), executor, getNodeNames(nodeList), shellCmd, DefaultExecConcurrency)
 
-		for _, mcPod := range mcPodList {
-			err = mcPod.WaitUntilRunning(300 * time.Second)
-			if err != nil {
-				return nil, err
-			}
-
-			hostnameCmd := []string{"sh", "-c", "nsenter --mount=/proc/1/ns/mnt -- sh -c 'printf $(hostname)'"}
-
-			hostnameBuf, err := mcPod.ExecCommand(hostnameCmd)
-			if err != nil {
-				return nil, fmt.Errorf("failed gathering node hostname: %w", err)
-			}
-
-			cmdToExec := []string{"sh", "-c", fmt.Sprintf("nsenter --mount=/proc/1/ns/mnt -- sh -c '%s'", shellCmd)}
-
-			klog.V(90).Infof("Exec cmd %v on pod %s", cmdToExec, mcPod.Definition.Name)
-
-			commandBuf, err := mcPod.ExecCommand(cmdToExec)
-			if err != nil {
-				return nil, fmt.Errorf("failed executing command '%s' on node %s: %w", shellCmd, hostnameBuf.String(), err)
-			}
-
-			hostname := regexp.MustCompile(`\r`).ReplaceAllString(hostnameBuf.String(), "")
-			output := regexp.MustCompile(`\r`).ReplaceAllString(commandBuf.String(), "")
-
-			outputMap[hostname] = output
-		}
+	outputMap := results.CombinedOutput()
+	for nodeName, output := range outputMap {
+		outputMap[nodeName] = strings.ReplaceAll(output, "\r", "")
 	}
 
-	return outputMap, nil
+	return outputMap, results.Err()
 }
 
 // ExecCmdWithRetries executes a command on the provided client on each node matching nodeSelector,
@@ -201,25 +143,9 @@ func ExecCmdWithRetries(client *clients.Settings, retries uint,
 	klog.V(90).Infof("Executing command '%s' with %d retries and interval %v. Node Selector: %v",
 		command, retries, interval, nodeSelector)
 
-	retry := 1
-
-	return wait.PollUntilContextTimeout(
-		context.TODO(), interval, time.Duration(retries-1)*interval, true, func(ctx context.Context) (bool, error) {
-			err := ExecCmd(client, nodeSelector, command)
-			if isErrorExecuting(err) {
-				klog.V(90).Infof("Error during command execution, retry %d (%d max): %v", retry, retries, err)
-
-				retry++
-
-				return false, nil
-			}
-
-			if err != nil {
-				return false, err
-			}
-
-			return true, nil
-		})
+	return retry.Do(context.TODO(), execRetryPolicy(retries, interval), func(context.Context) error {
+		return ExecCmd(client, nodeSelector, command)
+	})
 }
 
 // ExecCmdWithStdoutWithRetries executes a command on the provided client,
@@ -231,29 +157,15 @@ func ExecCmdWithStdoutWithRetries(
 	klog.V(90).Infof("Executing command with stdout '%s' with %d retries and interval %v. Options: %v",
 		command, retries, interval, options)
 
-	var (
-		outputs map[string]string
-		err     error
-		retry   = 1
-	)
-
-	err = wait.PollUntilContextTimeout(
-		context.TODO(), interval, time.Duration(retries-1)*interval, true, func(ctx context.Context) (bool, error) {
-			outputs, err = ExecCmdWithStdout(client, command, options...)
-			if isErrorExecuting(err) {
-				klog.V(90).Infof("Error during command execution, retry %d (%d max): %v", retry, retries, err)
+	var outputs map[string]string
 
-				retry++
-
-				return false, nil
-			}
+	err := retry.Do(context.TODO(), execRetryPolicy(retries, interval), func(context.Context) error {
+		var err error
 
-			if err != nil {
-				return false, err
-			}
+		outputs, err = ExecCmdWithStdout(client, command, options...)
 
-			return true, nil
-		})
+		return err
+	})
 
 	return outputs, err
 }
@@ -358,12 +270,30 @@ func waitForReachable(client *clients.Settings, timeout time.Duration) error {
 		})
 }
 
-// isErrorExecuting matches errors that contain the message "error executing command in container".
-func isErrorExecuting(err error) bool {
-	if err == nil {
-		return false
+// execRetryPolicy returns the policy for retrying node commands whose exec session failed, making at most retries
+// attempts with interval between them.
+func execRetryPolicy(retries uint, interval time.Duration) retry.Policy {
+	return retry.Policy{
+		Attempts:   max(int(retries), 1),
+		Backoff:    retry.ConstantBackoff(interval),
+		Classifier: retry.ClassifyExecStream,
+	}
+}
+
+// getNodeNames returns the names of the nodes in nodeList.
+func getNodeNames(nodeList []*nodes.Builder) []string {
+	nodeNames := make([]string, 0, len(nodeList))
+	for _, node := range nodeList {
+		nodeNames = append(nodeNames, node.Definition.Name)
 	}
 
-	return strings.Contains(err.Error(), "error executing command in container") ||
-		strings.Contains(err.Error(), "container not found")
+	return nodeNames
+}
+
+// closeNodeExecutor closes the executor, logging rather than returning any error since the command already ran.
+func closeNodeExecutor(executor NodeExecutor) {
+	err := executor.Close()
+	if err != nil {
+		klog.V(90).Infof("Failed to close node executor using %s: %v", executor.Transport(), err)
+	}
 }
@@ -0,0 +1,206 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
+	"k8s.io/klog/v2"
+)
+
+// NodeExecTransport is the way a NodeExecutor reaches the host of a node.
+type NodeExecTransport string
+
+const (
+	// NodeExecTransportMCD runs commands in the machine config daemon pod of the node, in the mount namespace of the
+	// host.
+	NodeExecTransportMCD NodeExecTransport = "mcd"
+	// NodeExecTransportDebugPod runs commands in a privileged pod created on the node, chrooted to the host.
+	NodeExecTransportDebugPod NodeExecTransport = "debug-pod"
+	// NodeExecTransportPod runs commands in an existing pod on the node, such as the PTP daemon pod.
+	NodeExecTransportPod NodeExecTransport = "pod"
+	// NodeExecTransportSSH runs commands over SSH to the internal IP of the node.
+	NodeExecTransportSSH NodeExecTransport = "ssh"
+	// NodeExecTransportFixture does not reach the node and instead serves outputs recorded by a RecordingExecutor.
+	NodeExecTransportFixture NodeExecTransport = "fixture"
+)
+
+// ExecResult is the outcome of a command run on a node. It is returned whenever the command ran, even if it exited
+// with a non-zero code.
+type ExecResult struct {
+	Node     string
+	Command  string
+	Stdout   string
+	Stderr   string
+	ExitCode int
+	Duration time.Duration
+}
+
+// CombinedOutput returns the stdout of the command followed by its stderr. This is close to the output of commands run
+// with a TTY, which merges the two, except that they are not interleaved.
+func (result *ExecResult) CombinedOutput() string {
+	return result.Stdout + result.Stderr
+}
+
+// ExitError is the error returned by a NodeExecutor when the command ran but exited with a non-zero code. Errors
+// reaching the node or starting the command are returned as is, so errors.As can be used to tell them apart.
+type ExitError struct {
+	Result *ExecResult
+}
+
+// Error returns the exit code and the stderr of the command.
+func (exitError *ExitError) Error() string {
+	return fmt.Sprintf("command %q on node %s exited with code %d: %s",
+		exitError.Result.Command, exitError.Result.Node, exitError.Result.ExitCode,
+		strings.TrimSpace(exitError.Result.Stderr))
+}
+
+// NodeExecutor runs shell commands on the host of a node. Every transport returns the same ExecResult and ExitError,
+// and logs every command it runs, so helpers can run node commands without depending on how the node is reached.
+type NodeExecutor interface {
+	// Exec runs command using sh on the host of the node named nodeName as root. The command is stopped when ctx is
+	// done or its timeout, if any, expires, whichever is first.
+	Exec(ctx context.Context, nodeName, command string, options ...ExecOption) (*ExecResult, error)
+	// Transport returns the transport used to reach the nodes.
+	Transport() NodeExecTransport
+	// Close releases any resources created by the executor on the cluster, such as debug pods.
+	Close() error
+}
+
+// execOptions are the options of a single call to NodeExecutor.Exec. It should not be used directly since the
+// ExecOption type is used to set the options.
+type execOptions struct {
+	timeout time.Duration
+}
+
+// ExecOption is a function type that can be used to set the options of a single call to NodeExecutor.Exec.
+type ExecOption func(*execOptions)
+
+// WithExecTimeout sets the timeout of the command. It defaults to no timeout, so the command runs until it exits or the
+// context passed to Exec is done.
+func WithExecTimeout(timeout time.Duration) ExecOption {
+	return func(options *execOptions) {
+		options.timeout = timeout
+	}
+}
+
+// NewNodeExecutor returns a NodeExecutor for the cluster of apiClient using the transport set by
+// ECO_NODE_EXEC_TRANSPORT, which defaults to the machine config daemon pods. If ECO_NODE_EXEC_RECORD is true, the
+// outcome of every command is also recorded to ECO_NODE_EXEC_FIXTURES. The caller should Close it once done.
+func NewNodeExecutor(apiClient *clients.Settings) (NodeExecutor, error) {
+	return newNodeExecutorForConfig(apiClient, GeneralConfig)
+}
+
+// newNodeExecutorForConfig returns a NodeExecutor for the cluster of apiClient using the transport of generalConfig,
+// wrapped in a RecordingExecutor if recording is enabled.
+func newNodeExecutorForConfig(apiClient *clients.Settings, generalConfig *config.GeneralConfig) (NodeExecutor, error) {
+	if apiClient == nil {
+		return nil, fmt.Errorf("cannot create node executor with nil apiClient")
+	}
+
+	if generalConfig == nil {
+		return nil, fmt.Errorf("cannot create node executor without general config")
+	}
+
+	executor, err := newTransportExecutor(apiClient, generalConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if !generalConfig.NodeExecRecord {
+		return executor, nil
+	}
+
+	if executor.Transport() == NodeExecTransportFixture {
+		return nil, fmt.Errorf("cannot record node commands using the %s transport", NodeExecTransportFixture)
+	}
+
+	if generalConfig.NodeExecFixtures == "" {
+		return nil, fmt.Errorf("cannot record node commands without a fixtures file")
+	}
+
+	return NewRecordingExecutor(executor, generalConfig.NodeExecFixtures), nil
+}
+
+// newTransportExecutor returns a NodeExecutor for the cluster of apiClient using the transport of generalConfig.
+func newTransportExecutor(apiClient *clients.Settings, generalConfig *config.GeneralConfig) (NodeExecutor, error) {
+	switch NodeExecTransport(generalConfig.NodeExecTransport) {
+	case NodeExecTransportMCD, "":
+		return NewMCDExecutor(apiClient, generalConfig.MCONamespace, generalConfig.MCOConfigDaemonName), nil
+	case NodeExecTransportDebugPod:
+		return NewDebugPodExecutor(apiClient, generalConfig.NodeDebugNamespace, generalConfig.NodeDebugImage), nil
+	case NodeExecTransportSSH:
+		return NewSSHExecutor(apiClient, generalConfig.SSHUser, generalConfig.SSHKeyPath).
+			WithSudo(generalConfig.NodeExecSSHSudo), nil
+	case NodeExecTransportFixture:
+		fixtures, err := ReadExecFixtures(generalConfig.NodeExecFixtures)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewFixtureExecutor(fixtures), nil
+	default:
+		return nil, fmt.Errorf("unknown node exec transport %q", generalConfig.NodeExecTransport)
+	}
+}
+
+// execFunc runs the command on the node for a single transport, returning the exit code along with the output. The
+// error is only for failures to run the command, not for non-zero exit codes.
+type execFunc func(ctx context.Context) (stdout, stderr string, exitCode int, err error)
+
+// runNodeCommand applies the options to ctx, runs the command using run, and logs the command and its outcome. It
+// builds the ExecResult shared by every transport and returns an ExitError for non-zero exit codes.
+func runNodeCommand(
+	ctx context.Context,
+	transport NodeExecTransport,
+	nodeName, command string,
+	options []ExecOption,
+	run execFunc) (*ExecResult, error) {
+	execOptions := &execOptions{}
+
+	for _, option := range options {
+		option(execOptions)
+	}
+
+	if execOptions.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, execOptions.timeout)
+		defer cancel()
+	}
+
+	klog.V(90).InfoS("Executing command on node",
+		"transport", transport, "node", nodeName, "command", command, "timeout", execOptions.timeout)
+
+	startTime := time.Now()
+	stdout, stderr, exitCode, err := run(ctx)
+	result := &ExecResult{
+		Node:     nodeName,
+		Command:  command,
+		Stdout:   stdout,
+		Stderr:   stderr,
+		ExitCode: exitCode,
+		Duration: time.Since(startTime),
+	}
+
+	if err != nil {
+		klog.V(90).InfoS("Failed to execute command on node",
+			"transport", transport, "node", nodeName, "command", command, "duration", result.Duration, "err", err)
+
+		return nil, fmt.Errorf("failed to execute command %q on node %s using %s: %w", command, nodeName, transport, err)
+	}
+
+	klog.V(90).InfoS("Executed command on node",
+		"transport", transport, "node", nodeName, "command", command, "exitCode", exitCode,
+		"duration", result.Duration, "stdoutBytes", len(stdout), "stderrBytes", len(stderr))
+
+	if exitCode != 0 {
+		return result, &ExitError{Result: result}
+	}
+
+	return result, nil
+}
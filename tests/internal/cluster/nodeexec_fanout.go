@@ -0,0 +1,133 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"k8s.io/klog/v2"
+)
+
+// DefaultExecConcurrency is the number of nodes ExecOnNodes runs a command on at once when no concurrency is provided.
+const DefaultExecConcurrency = 10
+
+// NodeExecOutcome is the outcome of running a command on a single node as part of ExecOnNodes. Result is set whenever
+// the command ran, including when Err is an ExitError.
+type NodeExecOutcome struct {
+	Result *ExecResult
+	Err    error
+}
+
+// NodeExecResults maps node names to the outcome of running a command on them using ExecOnNodes.
+type NodeExecResults map[string]NodeExecOutcome
+
+// Stdout returns the stdout of every node the command succeeded on, keyed by node name.
+func (results NodeExecResults) Stdout() map[string]string {
+	stdout := make(map[string]string)
+
+	for nodeName, outcome := range results {
+		if outcome.Err == nil && outcome.Result != nil {
+			stdout[nodeName] = outcome.Result.Stdout
+		}
+	}
+
+	return stdout
+}
+
+// CombinedOutput returns the combined stdout and stderr of every node the command succeeded on, keyed by node name.
+func (results NodeExecResults) CombinedOutput() map[string]string {
+	output := make(map[string]string)
+
+	for nodeName, outcome := range results {
+		if outcome.Err == nil && outcome.Result != nil {
+			output[nodeName] = outcome.Result.CombinedOutput()
+		}
+	}
+
+	return output
+}
+
+// Err returns the errors of every node the command failed on, sorted by node name, or nil if it succeeded on all of
+// them.
+func (results NodeExecResults) Err() error {
+	var errs []error
+
+	for _, nodeName := range slices.Sorted(maps.Keys(results)) {
+		if results[nodeName].Err != nil {
+			errs = append(errs, fmt.Errorf("node %s: %w", nodeName, results[nodeName].Err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ExecOnNodes runs command on every node in nodeNames using executor, with at most concurrency nodes at once. A
+// concurrency of 0 uses DefaultExecConcurrency. Failures on a node do not stop the command from running on the others,
+// and are returned in the outcome of that node. Nodes the command was not started on before ctx is done get the error
+// of ctx.
+func ExecOnNodes(
+	ctx context.Context,
+	executor NodeExecutor,
+	nodeNames []string,
+	command string,
+	concurrency int,
+	options ...ExecOption) NodeExecResults {
+	if concurrency <= 0 {
+		concurrency = DefaultExecConcurrency
+	}
+
+	klog.V(90).Infof("Executing command %q on %d nodes using %s with concurrency %d",
+		command, len(nodeNames), executor.Transport(), concurrency)
+
+	var (
+		mutex     sync.Mutex
+		waitGroup sync.WaitGroup
+		results   = make(NodeExecResults, len(nodeNames))
+		semaphore = make(chan struct{}, concurrency)
+	)
+
+	for _, nodeName := range nodeNames {
+		acquired := false
+
+		select {
+		case semaphore <- struct{}{}:
+			acquired = true
+		case <-ctx.Done():
+		}
+
+		// Both cases may be ready at once, so ctx is checked again to avoid starting commands after it is done.
+		if ctx.Err() != nil {
+			if acquired {
+				<-semaphore
+			}
+
+			mutex.Lock()
+			results[nodeName] = NodeExecOutcome{Err: ctx.Err()}
+			mutex.Unlock()
+
+			continue
+		}
+
+		waitGroup.Add(1)
+
+		go func() {
+			defer func() {
+				<-semaphore
+				waitGroup.Done()
+			}()
+
+			result, err := executor.Exec(ctx, nodeName, command, options...)
+
+			mutex.Lock()
+			results[nodeName] = NodeExecOutcome{Result: result, Err: err}
+			mutex.Unlock()
+		}()
+	}
+
+	waitGroup.Wait()
+
+	return results
+}
@@ -0,0 +1,125 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubExecutor is a NodeExecutor that runs commands using a function and records the highest number of commands that
+// ran at once.
+type stubExecutor struct {
+	exec func(ctx context.Context, nodeName string) (*ExecResult, error)
+
+	mutex         sync.Mutex
+	running       int
+	maxConcurrent int
+}
+
+func (executor *stubExecutor) Exec(
+	ctx context.Context, nodeName, command string, options ...ExecOption) (*ExecResult, error) {
+	executor.mutex.Lock()
+	executor.running++
+	executor.maxConcurrent = max(executor.maxConcurrent, executor.running)
+	executor.mutex.Unlock()
+
+	defer func() {
+		executor.mutex.Lock()
+		executor.running--
+		executor.mutex.Unlock()
+	}()
+
+	return executor.exec(ctx, nodeName)
+}
+
+func (executor *stubExecutor) Transport() NodeExecTransport {
+	return NodeExecTransportPod
+}
+
+func (executor *stubExecutor) Close() error {
+	return nil
+}
+
+func TestExecOnNodes(t *testing.T) {
+	executor := &stubExecutor{
+		exec: func(_ context.Context, nodeName string) (*ExecResult, error) {
+			time.Sleep(10 * time.Millisecond)
+
+			result := &ExecResult{Node: nodeName, Stdout: "out-" + nodeName + "\n", Stderr: "err-" + nodeName + "\n"}
+
+			switch nodeName {
+			case "node-1":
+				result.ExitCode = 1
+
+				return result, &ExitError{Result: result}
+			case "node-2":
+				return nil, errors.New("pod not found")
+			default:
+				return result, nil
+			}
+		},
+	}
+
+	var nodeNames []string
+	for index := range 8 {
+		nodeNames = append(nodeNames, fmt.Sprintf("node-%d", index))
+	}
+
+	results := ExecOnNodes(context.TODO(), executor, nodeNames, "true", 3)
+	assert.Len(t, results, len(nodeNames))
+	assert.LessOrEqual(t, executor.maxConcurrent, 3)
+	assert.Greater(t, executor.maxConcurrent, 1)
+
+	stdout := results.Stdout()
+	assert.Len(t, stdout, len(nodeNames)-2)
+	assert.Equal(t, "out-node-0\n", stdout["node-0"])
+	assert.NotContains(t, stdout, "node-1")
+
+	output := results.CombinedOutput()
+	assert.Len(t, output, len(nodeNames)-2)
+	assert.Equal(t, "out-node-0\nerr-node-0\n", output["node-0"])
+
+	var exitError *ExitError
+	assert.ErrorAs(t, results["node-1"].Err, &exitError)
+	assert.NotNil(t, results["node-1"].Result)
+	assert.Nil(t, results["node-2"].Result)
+
+	err := results.Err()
+	assert.ErrorContains(t, err, "node node-1: ")
+	assert.ErrorContains(t, err, "node node-2: pod not found")
+}
+
+func TestExecOnNodesCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	started := make(chan struct{})
+
+	var startOnce sync.Once
+
+	executor := &stubExecutor{
+		exec: func(ctx context.Context, nodeName string) (*ExecResult, error) {
+			startOnce.Do(func() {
+				close(started)
+			})
+			<-ctx.Done()
+
+			return nil, ctx.Err()
+		},
+	}
+
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	results := ExecOnNodes(ctx, executor, []string{"node-0", "node-1", "node-2"}, "true", 1)
+	assert.Len(t, results, 3)
+
+	for nodeName, outcome := range results {
+		assert.ErrorIs(t, outcome.Err, context.Canceled, nodeName)
+	}
+}
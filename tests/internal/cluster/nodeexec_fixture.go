@@ -0,0 +1,283 @@
+package cluster
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
+	"gopkg.in/yaml.v3"
+	"k8s.io/klog/v2"
+)
+
+// ErrNoExecFixture is returned by a FixtureExecutor for commands without a recorded fixture.
+var ErrNoExecFixture = errors.New("no exec fixture recorded")
+
+// ExecFixture is the recorded outcome of a command run on a node, used to replay the command offline.
+type ExecFixture struct {
+	// Node is the name of the node the command ran on. Fixtures without a node match the command on any node.
+	Node     string `yaml:"node,omitempty"`
+	Command  string `yaml:"command"`
+	Stdout   string `yaml:"stdout,omitempty"`
+	Stderr   string `yaml:"stderr,omitempty"`
+	ExitCode int    `yaml:"exitCode,omitempty"`
+}
+
+// ExecFixtures are the recorded outcomes of commands run on nodes, sorted by command and then by node. Each command
+// has at most one fixture per node.
+type ExecFixtures []ExecFixture
+
+// Lookup returns the fixture for command on nodeName. A fixture recorded on nodeName is preferred over one without a
+// node. The second return value is false if neither exists.
+func (fixtures ExecFixtures) Lookup(nodeName, command string) (ExecFixture, bool) {
+	var (
+		anyNodeFixture ExecFixture
+		anyNodeFound   bool
+	)
+
+	for _, fixture := range fixtures {
+		if fixture.Command != command {
+			continue
+		}
+
+		if fixture.Node == nodeName {
+			return fixture, true
+		}
+
+		if fixture.Node == "" {
+			anyNodeFixture, anyNodeFound = fixture, true
+		}
+	}
+
+	return anyNodeFixture, anyNodeFound
+}
+
+// Stdout returns the stdout of the fixture for command on nodeName, as found by Lookup. It is meant for tests of
+// parsers, which fail if the fixture is missing.
+func (fixtures ExecFixtures) Stdout(nodeName, command string) (string, error) {
+	fixture, found := fixtures.Lookup(nodeName, command)
+	if !found {
+		return "", fmt.Errorf("%w for command %q on node %s", ErrNoExecFixture, command, nodeName)
+	}
+
+	return fixture.Stdout, nil
+}
+
+// merge returns fixtures with only the last fixture for each command and node, sorted by command and then by node.
+func (fixtures ExecFixtures) merge() ExecFixtures {
+	type fixtureKey struct{ command, node string }
+
+	var merged ExecFixtures
+
+	indexes := make(map[fixtureKey]int)
+
+	for _, fixture := range fixtures {
+		key := fixtureKey{command: fixture.Command, node: fixture.Node}
+
+		if index, found := indexes[key]; found {
+			merged[index] = fixture
+
+			continue
+		}
+
+		indexes[key] = len(merged)
+		merged = append(merged, fixture)
+	}
+
+	slices.SortFunc(merged, func(a, b ExecFixture) int {
+		return cmp.Or(cmp.Compare(a.Command, b.Command), cmp.Compare(a.Node, b.Node))
+	})
+
+	return merged
+}
+
+// execFixturesRecordingSuffix ends the names of the files fixtures are recorded to by RecordExecFixture.
+const execFixturesRecordingSuffix = ".recording"
+
+// execFixturesRecordingPath returns the file the current process records fixtures for the fixtures file at path to.
+// Each process has its own file so processes recording at the same time, such as parallel ginkgo workers, never write
+// to the same one.
+func execFixturesRecordingPath(path string) string {
+	return fmt.Sprintf("%s.%d%s", path, os.Getpid(), execFixturesRecordingSuffix)
+}
+
+// ReadExecFixtures reads the YAML fixtures file at path, along with the fixtures recorded for it by RecordExecFixture
+// in every process. Recorded fixtures replace those in path for the same command and node. An error wrapping
+// os.ErrNotExist is returned if neither path nor any recording exists.
+func ReadExecFixtures(path string) (ExecFixtures, error) {
+	recordings, err := filepath.Glob(path + ".*" + execFixturesRecordingSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find exec fixtures recordings for %s: %w", path, err)
+	}
+
+	var fixtures ExecFixtures
+
+	for _, file := range append([]string{path}, recordings...) {
+		fileFixtures, err := readExecFixturesFile(file)
+		if errors.Is(err, os.ErrNotExist) && file == path && len(recordings) > 0 {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		fixtures = append(fixtures, fileFixtures...)
+	}
+
+	return fixtures.merge(), nil
+}
+
+// readExecFixturesFile reads the fixtures in the YAML file at path as they are, without merging them.
+func readExecFixturesFile(path string) (ExecFixtures, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exec fixtures %s: %w", path, err)
+	}
+
+	var fixtures ExecFixtures
+
+	err = yaml.Unmarshal(content, &fixtures)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal exec fixtures %s: %w", path, err)
+	}
+
+	return fixtures, nil
+}
+
+// recordMutex serializes RecordExecFixture so concurrent commands append whole fixtures.
+var recordMutex sync.Mutex
+
+// RecordExecFixture records fixture for the fixtures file at path. Rather than rewriting path, the fixture is appended
+// to a recording file next to it that belongs to the current process, so recording a command does not depend on how
+// many were recorded before it and processes recording at the same time do not overwrite each other. ReadExecFixtures
+// reads the recordings along with path.
+func RecordExecFixture(path string, fixture ExecFixture) error {
+	// A sequence with a single fixture appended to a file of sequences keeps the file a valid sequence.
+	content, err := yaml.Marshal(ExecFixtures{fixture})
+	if err != nil {
+		return fmt.Errorf("failed to marshal exec fixture: %w", err)
+	}
+
+	recordMutex.Lock()
+	defer recordMutex.Unlock()
+
+	recordingPath := execFixturesRecordingPath(path)
+
+	recordingFile, err := os.OpenFile(recordingPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open exec fixtures recording %s: %w", recordingPath, err)
+	}
+
+	_, err = recordingFile.Write(content)
+	if closeErr := recordingFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to write exec fixtures recording %s: %w", recordingPath, err)
+	}
+
+	return nil
+}
+
+// FixtureExecutor is a NodeExecutor that serves recorded fixtures instead of running commands, so helpers that parse
+// node command output can be tested offline. Commands are matched exactly.
+type FixtureExecutor struct {
+	fixtures ExecFixtures
+}
+
+// NewFixtureExecutor returns a FixtureExecutor serving fixtures.
+func NewFixtureExecutor(fixtures ExecFixtures) *FixtureExecutor {
+	return &FixtureExecutor{fixtures: fixtures}
+}
+
+// Exec returns the fixture for command on the node. Fixtures with a non-zero exit code return an ExitError like other
+// transports, and commands without a fixture return an error wrapping ErrNoExecFixture.
+func (executor *FixtureExecutor) Exec(
+	ctx context.Context, nodeName, command string, options ...ExecOption) (*ExecResult, error) {
+	return runNodeCommand(ctx, NodeExecTransportFixture, nodeName, command, options,
+		func(context.Context) (string, string, int, error) {
+			fixture, found := executor.fixtures.Lookup(nodeName, command)
+			if !found {
+				return "", "", 0, ErrNoExecFixture
+			}
+
+			return fixture.Stdout, fixture.Stderr, fixture.ExitCode, nil
+		})
+}
+
+// Transport returns NodeExecTransportFixture.
+func (executor *FixtureExecutor) Transport() NodeExecTransport {
+	return NodeExecTransportFixture
+}
+
+// Close does nothing since no resources are created on the cluster.
+func (executor *FixtureExecutor) Close() error {
+	return nil
+}
+
+// RecordingExecutor is a NodeExecutor that runs commands using another NodeExecutor and records the outcome of every
+// command that ran, including those with a non-zero exit code, as fixtures for a FixtureExecutor.
+type RecordingExecutor struct {
+	executor NodeExecutor
+	path     string
+}
+
+// NewRecordingExecutor returns a RecordingExecutor running commands using executor and recording them to the fixtures
+// file at path.
+func NewRecordingExecutor(executor NodeExecutor, path string) *RecordingExecutor {
+	return &RecordingExecutor{executor: executor, path: path}
+}
+
+// Exec runs command on the node using the wrapped executor and records its outcome if the command ran.
+func (executor *RecordingExecutor) Exec(
+	ctx context.Context, nodeName, command string, options ...ExecOption) (*ExecResult, error) {
+	result, err := executor.executor.Exec(ctx, nodeName, command, options...)
+	if result != nil {
+		recordExecResult(executor.path, result)
+	}
+
+	return result, err
+}
+
+// Transport returns the transport of the wrapped executor.
+func (executor *RecordingExecutor) Transport() NodeExecTransport {
+	return executor.executor.Transport()
+}
+
+// Close closes the wrapped executor.
+func (executor *RecordingExecutor) Close() error {
+	return executor.executor.Close()
+}
+
+// RecordExecResult records result to ECO_NODE_EXEC_FIXTURES if ECO_NODE_EXEC_RECORD is true and otherwise does
+// nothing. It is for helpers that run commands on nodes without a NodeExecutor, such as in the PTP daemon pod, so their
+// outputs are recorded along with those of the NodeExecutor.
+func RecordExecResult(result *ExecResult) {
+	if GeneralConfig == nil || !GeneralConfig.NodeExecRecord || GeneralConfig.NodeExecFixtures == "" {
+		return
+	}
+
+	recordExecResult(GeneralConfig.NodeExecFixtures, result)
+}
+
+// recordExecResult records result to the fixtures file at path, only logging failures so recording never changes the
+// outcome of a test.
+func recordExecResult(path string, result *ExecResult) {
+	err := RecordExecFixture(path, ExecFixture{
+		Node:     result.Node,
+		Command:  result.Command,
+		Stdout:   result.Stdout,
+		Stderr:   result.Stderr,
+		ExitCode: result.ExitCode,
+	})
+	if err != nil {
+		klog.V(90).Infof("Failed to record command %q on node %s: %v", result.Command, result.Node, err)
+	}
+}
@@ -0,0 +1,97 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecFixturesLookup(t *testing.T) {
+	fixtures, err := ReadExecFixtures("testdata/exec-fixtures.yaml")
+	assert.NoError(t, err)
+
+	stdout, err := fixtures.Stdout("worker-0", "cat /sys/class/net/bond0/bonding/mode")
+	assert.NoError(t, err)
+	assert.Equal(t, "balance-rr 0\n", stdout)
+
+	stdout, err = fixtures.Stdout("worker-1", "cat /sys/class/net/bond0/bonding/mode")
+	assert.NoError(t, err)
+	assert.Equal(t, "active-backup 1\n", stdout)
+
+	_, err = fixtures.Stdout("worker-1", "clevis luks list -d /dev/sda4")
+	assert.ErrorIs(t, err, ErrNoExecFixture)
+}
+
+func TestFixtureExecutorExec(t *testing.T) {
+	fixtures, err := ReadExecFixtures("testdata/exec-fixtures.yaml")
+	assert.NoError(t, err)
+
+	executor := NewFixtureExecutor(fixtures)
+
+	result, err := executor.Exec(context.TODO(), "worker-1", "cat /sys/class/net/bond0/bonding/mode")
+	assert.NoError(t, err)
+	assert.Equal(t, "active-backup 1\n", result.Stdout)
+
+	result, err = executor.Exec(context.TODO(), "worker-0", "clevis luks list -d /dev/sda4")
+
+	var exitError *ExitError
+
+	assert.True(t, errors.As(err, &exitError))
+	assert.Equal(t, 1, result.ExitCode)
+
+	_, err = executor.Exec(context.TODO(), "worker-0", "uname -r")
+	assert.ErrorIs(t, err, ErrNoExecFixture)
+}
+
+func TestRecordingExecutorExec(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures.yaml")
+	executor := NewRecordingExecutor(NewFixtureExecutor(ExecFixtures{
+		{Command: "uname -r", Stdout: "5.14.0\n"},
+		{Command: "false", ExitCode: 1},
+	}), path)
+
+	for _, command := range []string{"uname -r", "false", "uname -r", "true"} {
+		_, _ = executor.Exec(context.TODO(), "worker-0", command)
+	}
+
+	fixtures, err := ReadExecFixtures(path)
+	assert.NoError(t, err)
+	assert.Equal(t, ExecFixtures{
+		{Node: "worker-0", Command: "false", ExitCode: 1},
+		{Node: "worker-0", Command: "uname -r", Stdout: "5.14.0\n"},
+	}, fixtures)
+	assert.Equal(t, NodeExecTransportFixture, executor.Transport())
+
+	_, err = os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist, "recording should not write the fixtures file itself")
+}
+
+func TestReadExecFixturesRecordings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures.yaml")
+
+	err := os.WriteFile(path,
+		[]byte("- command: uname -r\n  stdout: 5.14.0\n- command: hostname\n  stdout: node\n"), 0o644)
+	assert.NoError(t, err)
+
+	// Another process recording to the same fixtures file, along with this one.
+	err = os.WriteFile(path+".1"+execFixturesRecordingSuffix, []byte("- command: uname -r\n  stdout: 5.14.1\n"), 0o644)
+	assert.NoError(t, err)
+
+	assert.NoError(t, RecordExecFixture(path, ExecFixture{Node: "worker-0", Command: "uname -r", Stdout: "5.14.2"}))
+	assert.NoError(t, RecordExecFixture(path, ExecFixture{Command: "hostname", Stdout: "worker-0"}))
+
+	fixtures, err := ReadExecFixtures(path)
+	assert.NoError(t, err)
+	assert.Equal(t, ExecFixtures{
+		{Command: "hostname", Stdout: "worker-0"},
+		{Command: "uname -r", Stdout: "5.14.1"},
+		{Node: "worker-0", Command: "uname -r", Stdout: "5.14.2"},
+	}, fixtures)
+
+	_, err = ReadExecFixtures(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,376 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// debugPodNamePrefix is the prefix of the names of the pods created by DebugPodExecutor, followed by the node.
+	debugPodNamePrefix = "eco-node-debug-"
+	// debugPodLabel is the label of the pods created by DebugPodExecutor, with the node as the value.
+	debugPodLabel = "eco-gotests/node-debug"
+	// debugPodHostMount is where the root of the host is mounted in the pods created by DebugPodExecutor.
+	debugPodHostMount = "/host"
+	// debugPodDeleteTimeout is how long DebugPodExecutor.Close waits for each of its pods to be deleted.
+	debugPodDeleteTimeout = 2 * time.Minute
+	// podLookupInterval is the interval between lookups of the pod to run commands in until it is running.
+	podLookupInterval = 3 * time.Second
+	// podLookupTimeout is how long to look up the pod to run commands in, since commands have no timeout by default.
+	podLookupTimeout = 5 * time.Minute
+	// debugPodCreateTimeout is how long DebugPodExecutor waits for a new pod to be running when the context passed to
+	// Exec has no deadline.
+	debugPodCreateTimeout = 5 * time.Minute
+)
+
+// PodExecutor is a NodeExecutor that runs commands in an existing pod on the node, found by namespace and label
+// selector. The command can be prefixed, such as with nsenter, to leave the container for the host.
+type PodExecutor struct {
+	apiClient     *clients.Settings
+	namespace     string
+	labelSelector string
+	containerName string
+	commandPrefix []string
+	transport     NodeExecTransport
+}
+
+// NewPodExecutor returns a PodExecutor that runs commands in the container named containerName, or the first container
+// if empty, of the pod in namespace on the node that matches labelSelector. The command is run as sh -c command,
+// preceded by commandPrefix.
+func NewPodExecutor(
+	apiClient *clients.Settings, namespace, labelSelector, containerName string, commandPrefix ...string) *PodExecutor {
+	return &PodExecutor{
+		apiClient:     apiClient,
+		namespace:     namespace,
+		labelSelector: labelSelector,
+		containerName: containerName,
+		commandPrefix: commandPrefix,
+		transport:     NodeExecTransportPod,
+	}
+}
+
+// NewMCDExecutor returns a PodExecutor that runs commands in the machine config daemon pod of the node, found by the
+// k8s-app label set to daemonName in namespace, entering the mount namespace of the host.
+func NewMCDExecutor(apiClient *clients.Settings, namespace, daemonName string) *PodExecutor {
+	// An empty daemon name leaves the label selector empty so that Exec fails rather than matching any pod.
+	labelSelector := ""
+	if daemonName != "" {
+		labelSelector = labels.SelectorFromSet(labels.Set{"k8s-app": daemonName}).String()
+	}
+
+	executor := NewPodExecutor(apiClient, namespace, labelSelector, "", "nsenter", "--mount=/proc/1/ns/mnt", "--")
+	executor.transport = NodeExecTransportMCD
+
+	return executor
+}
+
+// Exec runs command in the pod on the node, waiting for the pod to be running first.
+func (executor *PodExecutor) Exec(
+	ctx context.Context, nodeName, command string, options ...ExecOption) (*ExecResult, error) {
+	return runNodeCommand(ctx, executor.transport, nodeName, command, options,
+		func(ctx context.Context) (string, string, int, error) {
+			if err := executor.validate(); err != nil {
+				return "", "", 0, err
+			}
+
+			nodePod, err := executor.waitForPod(ctx, nodeName)
+			if err != nil {
+				return "", "", 0, err
+			}
+
+			return execInPod(ctx, executor.apiClient, nodePod, executor.containerName,
+				append(append([]string{}, executor.commandPrefix...), "sh", "-c", command))
+		})
+}
+
+// Transport returns NodeExecTransportMCD for executors created using NewMCDExecutor and NodeExecTransportPod otherwise.
+func (executor *PodExecutor) Transport() NodeExecTransport {
+	return executor.transport
+}
+
+// Close does nothing since PodExecutor does not create any resources.
+func (executor *PodExecutor) Close() error {
+	return nil
+}
+
+// validate checks that the executor has everything needed to find the pod.
+func (executor *PodExecutor) validate() error {
+	if executor.apiClient == nil {
+		return fmt.Errorf("cannot execute command in pod with nil apiClient")
+	}
+
+	if executor.namespace == "" {
+		return fmt.Errorf("cannot execute command in pod with empty namespace")
+	}
+
+	if executor.labelSelector == "" {
+		return fmt.Errorf("cannot execute command in pod with empty label selector")
+	}
+
+	return nil
+}
+
+// waitForPod waits for a running pod on the node matching the namespace and label selector of the executor, until ctx
+// is done or podLookupTimeout expires.
+func (executor *PodExecutor) waitForPod(ctx context.Context, nodeName string) (*corev1.Pod, error) {
+	listOptions := metav1.ListOptions{
+		FieldSelector: fields.SelectorFromSet(fields.Set{"spec.nodeName": nodeName}).String(),
+		LabelSelector: executor.labelSelector,
+	}
+
+	var (
+		nodePod *corev1.Pod
+		lastErr error
+	)
+
+	err := wait.PollUntilContextTimeout(ctx, podLookupInterval, podLookupTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			podList, err := pod.List(executor.apiClient, executor.namespace, listOptions)
+			if err != nil {
+				lastErr = err
+
+				return false, nil
+			}
+
+			if len(podList) == 0 {
+				lastErr = fmt.Errorf("no pod matching %q in namespace %s on node %s",
+					executor.labelSelector, executor.namespace, nodeName)
+
+				return false, nil
+			}
+
+			for _, podBuilder := range podList {
+				if podBuilder.Object.Status.Phase == corev1.PodRunning {
+					nodePod = podBuilder.Object
+
+					return true, nil
+				}
+			}
+
+			lastErr = fmt.Errorf("pod %s in namespace %s on node %s is not running",
+				podList[0].Object.Name, executor.namespace, nodeName)
+
+			return false, nil
+		})
+	if err != nil {
+		return nil, errors.Join(err, lastErr)
+	}
+
+	return nodePod, nil
+}
+
+// DebugPodExecutor is a NodeExecutor that runs commands in a privileged pod it creates on each node, with the root of
+// the host mounted and chrooted to, similar to oc debug node. The pods are reused for later commands on the same node
+// and deleted by Close.
+type DebugPodExecutor struct {
+	apiClient *clients.Settings
+	namespace string
+	image     string
+
+	mutex sync.Mutex
+	pods  map[string]*debugPod
+}
+
+// debugPod is the debug pod of a single node. Its mutex is held while the pod is created so that commands on the same
+// node wait for it while commands on other nodes do not.
+type debugPod struct {
+	mutex   sync.Mutex
+	builder *pod.Builder
+}
+
+// NewDebugPodExecutor returns a DebugPodExecutor that creates its pods in namespace using image, which must provide
+// bash and chroot.
+func NewDebugPodExecutor(apiClient *clients.Settings, namespace, image string) *DebugPodExecutor {
+	return &DebugPodExecutor{
+		apiClient: apiClient,
+		namespace: namespace,
+		image:     image,
+		pods:      make(map[string]*debugPod),
+	}
+}
+
+// Exec runs command on the host of the node using the debug pod of the node, creating it if needed.
+func (executor *DebugPodExecutor) Exec(
+	ctx context.Context, nodeName, command string, options ...ExecOption) (*ExecResult, error) {
+	return runNodeCommand(ctx, NodeExecTransportDebugPod, nodeName, command, options,
+		func(ctx context.Context) (string, string, int, error) {
+			debugPodBuilder, err := executor.getDebugPod(ctx, nodeName)
+			if err != nil {
+				return "", "", 0, err
+			}
+
+			return execInPod(ctx, executor.apiClient, debugPodBuilder.Object, "",
+				[]string{"chroot", debugPodHostMount, "sh", "-c", command})
+		})
+}
+
+// Transport returns NodeExecTransportDebugPod.
+func (executor *DebugPodExecutor) Transport() NodeExecTransport {
+	return NodeExecTransportDebugPod
+}
+
+// Close deletes every debug pod created by the executor. It must not be called while commands are running.
+func (executor *DebugPodExecutor) Close() error {
+	executor.mutex.Lock()
+	defer executor.mutex.Unlock()
+
+	var errs []error
+
+	for nodeName, nodePod := range executor.pods {
+		if nodePod.builder == nil {
+			delete(executor.pods, nodeName)
+
+			continue
+		}
+
+		klog.V(90).Infof("Deleting debug pod %s on node %s", nodePod.builder.Definition.Name, nodeName)
+
+		_, err := nodePod.builder.DeleteAndWait(debugPodDeleteTimeout)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete debug pod on node %s: %w", nodeName, err))
+
+			continue
+		}
+
+		delete(executor.pods, nodeName)
+	}
+
+	return errors.Join(errs...)
+}
+
+// getDebugPod returns the debug pod of the node, creating it and waiting until it is running if it does not exist.
+func (executor *DebugPodExecutor) getDebugPod(ctx context.Context, nodeName string) (*pod.Builder, error) {
+	executor.mutex.Lock()
+
+	nodePod, ok := executor.pods[nodeName]
+	if !ok {
+		nodePod = &debugPod{}
+		executor.pods[nodeName] = nodePod
+	}
+
+	executor.mutex.Unlock()
+
+	nodePod.mutex.Lock()
+	defer nodePod.mutex.Unlock()
+
+	if nodePod.builder != nil {
+		return nodePod.builder, nil
+	}
+
+	if executor.apiClient == nil {
+		return nil, fmt.Errorf("cannot create debug pod with nil apiClient")
+	}
+
+	klog.V(90).Infof("Creating debug pod on node %s in namespace %s", nodeName, executor.namespace)
+
+	debugPodBuilder := pod.NewBuilder(executor.apiClient, debugPodName(nodeName), executor.namespace, executor.image).
+		DefineOnNode(nodeName).
+		WithPrivilegedFlag().
+		WithHostNetwork().
+		WithHostPid(true).
+		WithTolerationToControlPlane().
+		WithLabel(debugPodLabel, nodeName).
+		WithVolume(corev1.Volume{
+			Name: "host",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{Path: "/", Type: ptr.To(corev1.HostPathDirectory)},
+			},
+		})
+	if debugPodBuilder == nil {
+		return nil, fmt.Errorf("failed to define debug pod on node %s", nodeName)
+	}
+
+	for index := range debugPodBuilder.Definition.Spec.Containers {
+		debugPodBuilder.Definition.Spec.Containers[index].VolumeMounts = append(
+			debugPodBuilder.Definition.Spec.Containers[index].VolumeMounts,
+			corev1.VolumeMount{Name: "host", MountPath: debugPodHostMount})
+	}
+
+	timeout := debugPodCreateTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	debugPodBuilder, err := debugPodBuilder.CreateAndWaitUntilRunning(timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create debug pod on node %s: %w", nodeName, err)
+	}
+
+	nodePod.builder = debugPodBuilder
+
+	return debugPodBuilder, nil
+}
+
+// debugPodName returns the name of the debug pod of the node, which must be a valid DNS label.
+func debugPodName(nodeName string) string {
+	name := debugPodNamePrefix + strings.ReplaceAll(nodeName, ".", "-")
+	if len(name) > 63 {
+		name = name[:63]
+	}
+
+	return strings.TrimRight(name, "-")
+}
+
+// execInPod runs command in the container of the pod without a TTY, so stdout and stderr are kept apart, and returns
+// the exit code of the command. A non-zero exit code is not an error.
+func execInPod(
+	ctx context.Context,
+	apiClient *clients.Settings,
+	nodePod *corev1.Pod,
+	containerName string,
+	command []string) (string, string, int, error) {
+	if containerName == "" {
+		containerName = nodePod.Spec.Containers[0].Name
+	}
+
+	request := apiClient.CoreV1Interface.RESTClient().
+		Post().
+		Namespace(nodePod.Namespace).
+		Resource("pods").
+		Name(nodePod.Name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: containerName,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(apiClient.Config, "POST", request.URL())
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to create executor for pod %s: %w", nodePod.Name, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &stdout, Stderr: &stderr})
+
+	var exitError utilexec.ExitError
+	if errors.As(err, &exitError) {
+		return stdout.String(), stderr.String(), exitError.ExitStatus(), nil
+	}
+
+	if err != nil {
+		return stdout.String(), stderr.String(), 0, err
+	}
+
+	return stdout.String(), stderr.String(), 0, nil
+}
@@ -0,0 +1,175 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// defaultSSHPort is the port SSHExecutor connects to on the nodes.
+const defaultSSHPort = 22
+
+// SSHExecutor is a NodeExecutor that runs commands over SSH to the internal IP of the node, authenticating with a
+// private key. Host keys are not verified. Since users like core cannot access everything the other transports can,
+// commands are run as root using sudo unless disabled with WithSudo.
+type SSHExecutor struct {
+	apiClient      *clients.Settings
+	user           string
+	privateKeyPath string
+	port           int
+	sudo           bool
+}
+
+// NewSSHExecutor returns an SSHExecutor that connects as user with the private key at privateKeyPath to the nodes of
+// the cluster of apiClient.
+func NewSSHExecutor(apiClient *clients.Settings, user, privateKeyPath string) *SSHExecutor {
+	return &SSHExecutor{
+		apiClient:      apiClient,
+		user:           user,
+		privateKeyPath: privateKeyPath,
+		port:           defaultSSHPort,
+		sudo:           true,
+	}
+}
+
+// WithSudo sets whether commands are run as root using sudo without a password, which fails rather than prompts if
+// the user cannot. It defaults to true and should only be disabled when connecting as root.
+func (executor *SSHExecutor) WithSudo(sudo bool) *SSHExecutor {
+	executor.sudo = sudo
+
+	return executor
+}
+
+// Exec runs command over SSH on the node. The connection is closed when ctx is done or the timeout expires.
+func (executor *SSHExecutor) Exec(
+	ctx context.Context, nodeName, command string, options ...ExecOption) (*ExecResult, error) {
+	return runNodeCommand(ctx, NodeExecTransportSSH, nodeName, command, options,
+		func(ctx context.Context) (string, string, int, error) {
+			address, err := executor.getNodeAddress(nodeName)
+			if err != nil {
+				return "", "", 0, err
+			}
+
+			if executor.sudo {
+				return executor.run(ctx, address, sudoCommand(command))
+			}
+
+			return executor.run(ctx, address, command)
+		})
+}
+
+// Transport returns NodeExecTransportSSH.
+func (executor *SSHExecutor) Transport() NodeExecTransport {
+	return NodeExecTransportSSH
+}
+
+// Close does nothing since every command uses its own connection.
+func (executor *SSHExecutor) Close() error {
+	return nil
+}
+
+// getNodeAddress returns the host and port to connect to for the node, using its first internal IP.
+func (executor *SSHExecutor) getNodeAddress(nodeName string) (string, error) {
+	if executor.apiClient == nil {
+		return "", fmt.Errorf("cannot get address of node %s with nil apiClient", nodeName)
+	}
+
+	node, err := nodes.Pull(executor.apiClient, nodeName)
+	if err != nil {
+		return "", fmt.Errorf("failed to pull node %s: %w", nodeName, err)
+	}
+
+	for _, address := range node.Object.Status.Addresses {
+		if address.Type == corev1.NodeInternalIP {
+			return net.JoinHostPort(address.Address, strconv.Itoa(executor.port)), nil
+		}
+	}
+
+	return "", fmt.Errorf("node %s has no internal IP", nodeName)
+}
+
+// run connects to address and runs command in a new session, closing the connection if ctx is done first.
+func (executor *SSHExecutor) run(ctx context.Context, address, command string) (string, string, int, error) {
+	if executor.user == "" || executor.privateKeyPath == "" {
+		return "", "", 0, fmt.Errorf("cannot execute command over SSH without user and private key")
+	}
+
+	keyBytes, err := os.ReadFile(executor.privateKeyPath)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to read private key %s: %w", executor.privateKeyPath, err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(keyBytes)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to parse private key %s: %w", executor.privateKeyPath, err)
+	}
+
+	connection, err := (&net.Dialer{}).DialContext(ctx, "tcp", address)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	stopCloser := context.AfterFunc(ctx, func() {
+		_ = connection.Close()
+	})
+	defer stopCloser()
+
+	clientConn, channels, requests, err := ssh.NewClientConn(connection, address, &ssh.ClientConfig{
+		User:            executor.user,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	})
+	if err != nil {
+		_ = connection.Close()
+
+		return "", "", 0, errors.Join(err, ctx.Err())
+	}
+
+	client := ssh.NewClient(clientConn, channels, requests)
+
+	defer func() {
+		_ = client.Close()
+	}()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return "", "", 0, errors.Join(err, ctx.Err())
+	}
+
+	defer func() {
+		_ = session.Close()
+	}()
+
+	var stdout, stderr bytes.Buffer
+
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+
+	err = session.Run(command)
+
+	var exitError *ssh.ExitError
+	if errors.As(err, &exitError) {
+		return stdout.String(), stderr.String(), exitError.ExitStatus(), nil
+	}
+
+	if err != nil {
+		return stdout.String(), stderr.String(), 0, errors.Join(err, ctx.Err())
+	}
+
+	return stdout.String(), stderr.String(), 0, nil
+}
+
+// sudoCommand returns command wrapped to run using sh as root with sudo, without prompting for a password.
+func sudoCommand(command string) string {
+	return "sudo -n sh -c '" + strings.ReplaceAll(command, "'", `'\''`) + "'"
+}
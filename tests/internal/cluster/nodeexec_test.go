@@ -0,0 +1,216 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNodeExecutorForConfig(t *testing.T) {
+	testCases := []struct {
+		name              string
+		transport         string
+		fixtures          string
+		record            bool
+		nilClient         bool
+		expectedTransport NodeExecTransport
+		expectedError     string
+	}{
+		{
+			name:              "default",
+			transport:         "",
+			expectedTransport: NodeExecTransportMCD,
+		},
+		{
+			name:              "mcd",
+			transport:         "mcd",
+			expectedTransport: NodeExecTransportMCD,
+		},
+		{
+			name:              "debug pod",
+			transport:         "debug-pod",
+			expectedTransport: NodeExecTransportDebugPod,
+		},
+		{
+			name:              "ssh",
+			transport:         "ssh",
+			expectedTransport: NodeExecTransportSSH,
+		},
+		{
+			name:              "fixture",
+			transport:         "fixture",
+			fixtures:          "testdata/exec-fixtures.yaml",
+			expectedTransport: NodeExecTransportFixture,
+		},
+		{
+			name:      "fixture missing file",
+			transport: "fixture",
+			fixtures:  "testdata/missing.yaml",
+			expectedError: "failed to read exec fixtures testdata/missing.yaml: " +
+				"open testdata/missing.yaml: no such file or directory",
+		},
+		{
+			name:              "record",
+			transport:         "ssh",
+			fixtures:          "testdata/exec-fixtures.yaml",
+			record:            true,
+			expectedTransport: NodeExecTransportSSH,
+		},
+		{
+			name:          "record without fixtures",
+			transport:     "mcd",
+			record:        true,
+			expectedError: "cannot record node commands without a fixtures file",
+		},
+		{
+			name:          "record fixture transport",
+			transport:     "fixture",
+			fixtures:      "testdata/exec-fixtures.yaml",
+			record:        true,
+			expectedError: "cannot record node commands using the fixture transport",
+		},
+		{
+			name:          "unknown",
+			transport:     "telnet",
+			expectedError: `unknown node exec transport "telnet"`,
+		},
+		{
+			name:          "nil client",
+			transport:     "mcd",
+			nilClient:     true,
+			expectedError: "cannot create node executor with nil apiClient",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var apiClient *clients.Settings
+			if !testCase.nilClient {
+				apiClient = clients.GetTestClients(clients.TestClientParams{})
+			}
+
+			executor, err := newNodeExecutorForConfig(apiClient, &config.GeneralConfig{
+				NodeExecTransport:   testCase.transport,
+				NodeExecFixtures:    testCase.fixtures,
+				NodeExecRecord:      testCase.record,
+				MCONamespace:        "openshift-machine-config-operator",
+				MCOConfigDaemonName: "machine-config-daemon",
+			})
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedTransport, executor.Transport())
+			assert.NoError(t, executor.Close())
+		})
+	}
+}
+
+func TestRunNodeCommand(t *testing.T) {
+	testCases := []struct {
+		name             string
+		run              execFunc
+		options          []ExecOption
+		expectedResult   *ExecResult
+		expectedExitCode int
+		expectedError    string
+	}{
+		{
+			name: "success",
+			run: func(context.Context) (string, string, int, error) {
+				return "out", "", 0, nil
+			},
+			expectedResult: &ExecResult{Node: "node", Command: "true", Stdout: "out"},
+		},
+		{
+			name: "non-zero exit",
+			run: func(context.Context) (string, string, int, error) {
+				return "out", "bad things\n", 2, nil
+			},
+			expectedResult: &ExecResult{Node: "node", Command: "true", Stdout: "out", Stderr: "bad things\n", ExitCode: 2},
+			expectedError:  `command "true" on node node exited with code 2: bad things`,
+		},
+		{
+			name: "transport failure",
+			run: func(context.Context) (string, string, int, error) {
+				return "", "", 0, errors.New("connection refused")
+			},
+			expectedError: `failed to execute command "true" on node node using mcd: connection refused`,
+		},
+		{
+			name: "timeout",
+			run: func(ctx context.Context) (string, string, int, error) {
+				<-ctx.Done()
+
+				return "", "", 0, ctx.Err()
+			},
+			options:       []ExecOption{WithExecTimeout(10 * time.Millisecond)},
+			expectedError: `failed to execute command "true" on node node using mcd: context deadline exceeded`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := runNodeCommand(
+				context.TODO(), NodeExecTransportMCD, "node", "true", testCase.options, testCase.run)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if testCase.expectedResult == nil {
+				assert.Nil(t, result)
+
+				return
+			}
+
+			// The duration is not deterministic so it is only checked for being set.
+			assert.NotNil(t, result)
+			testCase.expectedResult.Duration = result.Duration
+			assert.Equal(t, testCase.expectedResult, result)
+
+			var exitError *ExitError
+			assert.Equal(t, testCase.expectedResult.ExitCode != 0, errors.As(err, &exitError))
+		})
+	}
+}
+
+func TestPodExecutorValidate(t *testing.T) {
+	apiClient := clients.GetTestClients(clients.TestClientParams{})
+
+	_, err := NewMCDExecutor(apiClient, "openshift-machine-config-operator", "").Exec(context.TODO(), "node", "true")
+	assert.ErrorContains(t, err, "cannot execute command in pod with empty label selector")
+
+	_, err = NewPodExecutor(apiClient, "", "app=test", "").Exec(context.TODO(), "node", "true")
+	assert.ErrorContains(t, err, "cannot execute command in pod with empty namespace")
+}
+
+func TestDebugPodName(t *testing.T) {
+	assert.Equal(t, "eco-node-debug-worker-0-example-com", debugPodName("worker-0.example.com"))
+
+	longName := debugPodName(strings.Repeat("a", 100))
+	assert.Len(t, longName, 63)
+	assert.True(t, strings.HasPrefix(longName, debugPodNamePrefix))
+}
+
+func TestSudoCommand(t *testing.T) {
+	assert.Equal(t, `sudo -n sh -c 'cat /etc/os-release'`, sudoCommand("cat /etc/os-release"))
+	assert.Equal(t, `sudo -n sh -c 'echo '\''a b'\'' | wc -c'`, sudoCommand("echo 'a b' | wc -c"))
+
+	output, err := exec.Command("sh", "-c", strings.TrimPrefix(sudoCommand("echo 'a b'"), "sudo -n ")).Output()
+	assert.NoError(t, err)
+	assert.Equal(t, "a b\n", string(output))
+}
@@ -7,33 +7,42 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
-
-	"github.com/kelseyhightower/envconfig"
-	"gopkg.in/yaml.v2"
+	"time"
 )
 
 const (
 	// PathToDefaultParamsFile path to config file with default parameters.
 	PathToDefaultParamsFile = "./default.yaml"
+	// RunIDFormat is the time layout of the run ID generated when ECO_RUN_ID is not set.
+	RunIDFormat = "20060102T150405Z"
 )
 
 // GeneralConfig type keeps general configuration.
 type GeneralConfig struct {
-	ReportsDirAbsPath         string `yaml:"reports_dump_dir" envconfig:"ECO_REPORTS_DUMP_DIR"`
+	ReportsDirAbsPath         string `yaml:"reports_dump_dir" envconfig:"ECO_REPORTS_DUMP_DIR" validate:"required"`
+	RunID                     string `yaml:"run_id" envconfig:"ECO_RUN_ID"`
 	VerboseLevel              string `yaml:"verbose_level" envconfig:"ECO_VERBOSE_LEVEL"`
 	DumpFailedTests           bool   `yaml:"dump_failed_tests" envconfig:"ECO_DUMP_FAILED_TESTS"`
+	DumpCompress              bool   `yaml:"dump_compress" envconfig:"ECO_DUMP_COMPRESS"`
+	DumpNamespaceSizeLimit    int64  `yaml:"dump_namespace_size_limit" envconfig:"ECO_DUMP_NAMESPACE_SIZE_LIMIT" validate:"min=0"` //nolint:lll
 	EnableReport              bool   `yaml:"enable_report" envconfig:"ECO_ENABLE_REPORT"`
 	DryRun                    bool   `yaml:"dry_run" envconfig:"ECO_DRY_RUN"`
 	SSHKeyPath                string `envconfig:"ECO_SSH_KEY_PATH"`
 	SSHUser                   string `yaml:"ssh_user" envconfig:"ECO_SSH_USER"`
+	NodeExecSSHSudo           bool   `yaml:"node_exec_ssh_sudo" envconfig:"ECO_NODE_EXEC_SSH_SUDO"`
 	KubernetesRolePrefix      string `yaml:"kubernetes_role_prefix" envconfig:"ECO_KUBERNETES_ROLE_PREFIX"`
 	WorkerLabelEnvVar         string `yaml:"worker_label" envconfig:"ECO_WORKER_LABEL"`
 	WorkerLabel               string
 	ControlPlaneLabel         string `yaml:"control_plane_label" envconfig:"ECO_CONTROL_PLANE_LABEL"`
 	TCPrefix                  string `yaml:"tc_prefix" envconfig:"ECO_TC_PREFIX"`
-	MCONamespace              string `yaml:"mco_namespace" envconfig:"ECO_MCO_NAMESPACE"`
+	MCONamespace              string `yaml:"mco_namespace" envconfig:"ECO_MCO_NAMESPACE" validate:"required"`
 	LoggingOperatorNamespace  string `yaml:"logging_operator_namespace" envconfig:"ECO_LOGGING_OPERATOR_NAMESPACE"`
 	MCOConfigDaemonName       string `yaml:"mco_config_daemon_name" envconfig:"ECO_MCO_CONFIG_DAEMON_NAME"`
+	NodeExecTransport         string `yaml:"node_exec_transport" envconfig:"ECO_NODE_EXEC_TRANSPORT" validate:"oneof=mcd debug-pod ssh fixture"` //nolint:lll
+	NodeExecFixtures          string `yaml:"node_exec_fixtures" envconfig:"ECO_NODE_EXEC_FIXTURES"`
+	NodeExecRecord            bool   `yaml:"node_exec_record" envconfig:"ECO_NODE_EXEC_RECORD"`
+	NodeDebugNamespace        string `yaml:"node_debug_namespace" envconfig:"ECO_NODE_DEBUG_NAMESPACE"`
+	NodeDebugImage            string `yaml:"node_debug_image" envconfig:"ECO_NODE_DEBUG_IMAGE"`
 	SriovOperatorNamespace    string `yaml:"sriov_operator_namespace" envconfig:"ECO_SRIOV_OPERATOR_NAMESPACE"`
 	NMStateOperatorNamespace  string `yaml:"nmstate_operator_namespace" envconfig:"ECO_NMSTATE_OPERATOR_NAMESPACE"`
 	SriovFecOperatorNamespace string `yaml:"sriov_fec_operator_namespace" envconfig:"ECO_SRIOV_FEC_OPERATOR_NAMESPACE"`
@@ -51,18 +60,19 @@ func NewConfig() *GeneralConfig {
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultParamsFile)
 
-	err := readFile(&conf, confFile)
+	err := NewLoader().WithDefaultFile(confFile).Load(&conf)
 	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
+		log.Printf("Error to load config from file %s and environment variables: %v", confFile, err)
 
 		return nil
 	}
 
-	err = readEnv(&conf)
-	if err != nil {
-		log.Print("Error to read environment variables")
+	conf.setNodeLabels()
 
-		return nil
+	// Suites of the same run share the run ID through ECO_RUN_ID, which the test runner sets. Otherwise, each suite
+	// is its own run.
+	if conf.RunID == "" {
+		conf.RunID = time.Now().UTC().Format(RunIDFormat)
 	}
 
 	err = deployReportDir(conf.ReportsDirAbsPath)
@@ -111,38 +121,12 @@ func (cfg *GeneralConfig) GetDumpFailedTestReportLocation(file string) string {
 	return ""
 }
 
-func readFile(cfg *GeneralConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-
-	err = decoder.Decode(&cfg)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(cfg *GeneralConfig) error {
-	err := envconfig.Process("", cfg)
-	if err != nil {
-		return err
-	}
-
+// setNodeLabels sets the full worker and control plane labels based on the configured prefix and label suffixes.
+func (cfg *GeneralConfig) setNodeLabels() {
 	cfg.WorkerLabel = fmt.Sprintf("%s/%s", cfg.KubernetesRolePrefix, cfg.WorkerLabelEnvVar)
 	cfg.ControlPlaneLabel = fmt.Sprintf("%s/%s", cfg.KubernetesRolePrefix, cfg.ControlPlaneLabel)
 	cfg.WorkerLabelMap = map[string]string{cfg.WorkerLabel: ""}
 	cfg.ControlPlaneLabelMap = map[string]string{cfg.ControlPlaneLabel: ""}
-
-	return nil
 }
 
 func deployReportDir(dirName string) error {
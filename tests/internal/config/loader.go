@@ -0,0 +1,540 @@
+package config
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// redactedValue replaces the value of secret fields when dumping a config.
+	redactedValue = "<redacted>"
+)
+
+var (
+	// secretNameFragments are the lowercase substrings of a field name that cause it to be treated as a secret even
+	// without the secret tag.
+	secretNameFragments = []string{"password", "secret", "token"}
+	durationType        = reflect.TypeFor[time.Duration]()
+	// splitWordsRegexp and acronymRegexp are the same as the ones envconfig uses for fields with split_words, so the
+	// keys collected for them match the ones envconfig reads.
+	splitWordsRegexp = regexp.MustCompile("([^A-Z]+|[A-Z]+[^A-Z]+|[A-Z]+)")
+	acronymRegexp    = regexp.MustCompile("([A-Z]+)([A-Z][^A-Z]+)")
+)
+
+// Loader loads a config struct from layered sources. Each layer overrides the values set by the layers before it:
+//
+//  1. The default YAML, either embedded in the binary or read from a file next to the config package.
+//  2. An optional user overlay YAML file whose path is provided by ECO_CONFIG_OVERLAY_FILE.
+//  3. Environment variables, using the envconfig struct tags and the envconfig prefix, if any.
+//
+// After loading, fields are validated using the validate struct tag and all validation errors are returned together.
+// If ECO_DUMP_CONFIG is true, the effective config is logged with secrets redacted.
+type Loader struct {
+	defaultYAML     []byte
+	defaultFile     string
+	envconfigPrefix string
+	envPrefix       string
+	knownEnvVars    []string
+}
+
+// NewLoader returns a Loader with no default YAML. Use the With methods to configure it before calling Load.
+func NewLoader() *Loader {
+	return &Loader{}
+}
+
+// WithDefaultFile sets the path of the default YAML file. It is ignored if WithDefaultYAML is also used.
+func (loader *Loader) WithDefaultFile(path string) *Loader {
+	loader.defaultFile = path
+
+	return loader
+}
+
+// WithDefaultYAML sets the contents of the default YAML, such as a default.yaml embedded using go:embed. It takes
+// precedence over WithDefaultFile.
+func (loader *Loader) WithDefaultYAML(data []byte) *Loader {
+	loader.defaultYAML = data
+
+	return loader
+}
+
+// WithEnvconfigPrefix sets the prefix passed to envconfig.Process. envconfig reads every field from the prefix, an
+// underscore, and the envconfig tag or field name, falling back to the envconfig tag alone for tagged fields. Untagged
+// fields, including those of nested structs, are therefore only read from prefixed variables, such as
+// ECO_HWACCEL_AMD__SKIPCLEANUP for the prefix eco_hwaccel_amd_. Configs that were processed with a prefix before the
+// Loader existed must keep it so their variables are still read.
+func (loader *Loader) WithEnvconfigPrefix(prefix string) *Loader {
+	loader.envconfigPrefix = prefix
+
+	return loader
+}
+
+// WithEnvPrefix enables checking for unknown environment variables. Any environment variable starting with prefix
+// that is not read by envconfig for the config being loaded, nor is one of knownVars, causes Load to return an error.
+// This catches typos that would otherwise silently fall back to the default value. The prefix should be specific to
+// the suite, such as ECO_CNF_RAN_, since variables for other configs sharing the prefix will also be reported. Configs
+// embedded by other suites should not set it, since the suites may add variables under the same prefix. knownVars are
+// the variables under the prefix that are read outside of the config, such as the path of its default file.
+func (loader *Loader) WithEnvPrefix(prefix string, knownVars ...string) *Loader {
+	loader.envPrefix = prefix
+	loader.knownEnvVars = knownVars
+
+	return loader
+}
+
+// Load fills config, which must be a pointer to a struct, from all of the sources of the Loader then validates it.
+// Errors reading a source are returned immediately while validation errors are aggregated into a single error.
+func (loader *Loader) Load(config any) error {
+	err := checkStructPointer(config)
+	if err != nil {
+		return err
+	}
+
+	err = loader.loadDefault(config)
+	if err != nil {
+		return err
+	}
+
+	if overlayFile := os.Getenv("ECO_CONFIG_OVERLAY_FILE"); overlayFile != "" {
+		err = readYAMLFile(config, overlayFile)
+		if err != nil {
+			return fmt.Errorf("failed to read config overlay file %s: %w", overlayFile, err)
+		}
+	}
+
+	err = envconfig.Process(loader.envconfigPrefix, config)
+	if err != nil {
+		return fmt.Errorf("failed to read environment variables: %w", err)
+	}
+
+	var errs []error
+
+	if loader.envPrefix != "" {
+		known := make(map[string]bool)
+		collectEnvKeys(reflect.TypeOf(config), strings.ToUpper(loader.envconfigPrefix), known, nil)
+
+		for _, knownVar := range loader.knownEnvVars {
+			known[knownVar] = true
+		}
+
+		for _, unknown := range unknownEnvVars(loader.envPrefix, known) {
+			errs = append(errs, fmt.Errorf("environment variable %s does not match any field of %T", unknown, config))
+		}
+	}
+
+	errs = append(errs, Validate(config))
+
+	if dump, _ := strconv.ParseBool(os.Getenv("ECO_DUMP_CONFIG")); dump {
+		log.Printf("Effective config for %T:", config)
+
+		err = Dump(config, log.Writer())
+		if err != nil {
+			log.Printf("Failed to dump config for %T: %v", config, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Validate checks every field of config with a validate tag and returns all the failures joined together. Rules in the
+// tag are separated by commas and may be:
+//
+//   - required: the field must not be the zero value.
+//   - oneof=a b c: the field must be formatted as one of the space-separated values. Empty values are allowed unless
+//     the field is also required.
+//   - min=N and max=N: numeric fields, including durations, must be within the bounds. For strings, slices, and maps,
+//     the bounds apply to the length.
+//
+// Fields of embedded structs are validated as well. Errors refer to fields by their environment variable when
+// possible since that is what users are most likely to set.
+func Validate(config any) error {
+	err := checkStructPointer(config)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	walkFields(reflect.ValueOf(config).Elem(), func(field reflect.StructField, value reflect.Value) {
+		rules := field.Tag.Get("validate")
+		if rules == "" {
+			return
+		}
+
+		for rule := range strings.SplitSeq(rules, ",") {
+			err := validateRule(strings.TrimSpace(rule), value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", fieldDisplayName(field), err))
+			}
+		}
+	})
+
+	return errors.Join(errs...)
+}
+
+// Dump writes the effective value of every configurable field of config to writer as a table. Configurable fields are
+// those with yaml, envconfig, or validate tags. Fields that have the tag secret:"true" or whose name contains
+// password, secret, or token have nonempty values redacted.
+func Dump(config any, writer io.Writer) error {
+	err := checkStructPointer(config)
+	if err != nil {
+		return err
+	}
+
+	tabWriter := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+
+	_, err = fmt.Fprintln(tabWriter, "FIELD\tYAML\tENV\tVALUE")
+	if err != nil {
+		return err
+	}
+
+	walkFields(reflect.ValueOf(config).Elem(), func(field reflect.StructField, value reflect.Value) {
+		if err != nil || !isConfigurable(field) {
+			return
+		}
+
+		_, err = fmt.Fprintf(tabWriter, "%s\t%s\t%s\t%s\n",
+			field.Name, yamlKey(field), field.Tag.Get("envconfig"), formatValue(field, value))
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return tabWriter.Flush()
+}
+
+// UnknownEnvVars returns the sorted names of all environment variables starting with prefix that are not read by
+// envconfig, without a prefix, for any of the configs. Only the types of the configs are used, so they may be nil
+// pointers.
+func UnknownEnvVars(prefix string, configs ...any) []string {
+	known := make(map[string]bool)
+
+	for _, config := range configs {
+		collectEnvKeys(reflect.TypeOf(config), "", known, nil)
+	}
+
+	return unknownEnvVars(prefix, known)
+}
+
+// unknownEnvVars returns the sorted names of all environment variables starting with prefix that are not in known.
+func unknownEnvVars(prefix string, known map[string]bool) []string {
+	var unknown []string
+
+	for _, env := range os.Environ() {
+		name, _, _ := strings.Cut(env, "=")
+		if strings.HasPrefix(name, prefix) && !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+
+	slices.Sort(unknown)
+
+	return unknown
+}
+
+// loadDefault decodes the default YAML into config. It is a no-op if neither a default file nor default YAML was set.
+func (loader *Loader) loadDefault(config any) error {
+	if loader.defaultYAML != nil {
+		return unmarshalYAML(loader.defaultYAML, config)
+	}
+
+	if loader.defaultFile != "" {
+		return readYAMLFile(config, loader.defaultFile)
+	}
+
+	return nil
+}
+
+// readYAMLFile decodes the YAML file at path into config. Empty files are allowed.
+func readYAMLFile(config any, path string) error {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return unmarshalYAML(contents, config)
+}
+
+// unmarshalYAML decodes contents into config. Documents without content, such as default files with only comments,
+// leave config unchanged rather than resetting it to the zero value, which would drop embedded configs loaded before.
+func unmarshalYAML(contents []byte, config any) error {
+	var document any
+
+	err := yaml.Unmarshal(contents, &document)
+	if err != nil {
+		return err
+	}
+
+	if document == nil {
+		return nil
+	}
+
+	return yaml.Unmarshal(contents, config)
+}
+
+// checkStructPointer returns an error if config is not a non-nil pointer to a struct.
+func checkStructPointer(config any) error {
+	value := reflect.ValueOf(config)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config must be a non-nil pointer to a struct, got %T", config)
+	}
+
+	return nil
+}
+
+// walkFields calls visit for every exported field of the struct value, recursing into embedded structs and non-nil
+// embedded struct pointers instead of visiting them directly. Embedded structs are recursed into even if their type is
+// unexported, matching how yaml and envconfig promote their fields.
+func walkFields(value reflect.Value, visit func(field reflect.StructField, value reflect.Value)) {
+	for index := range value.NumField() {
+		field := value.Type().Field(index)
+		fieldValue := value.Field(index)
+
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		if field.Anonymous {
+			if fieldValue.Kind() == reflect.Pointer {
+				if fieldValue.IsNil() {
+					continue
+				}
+
+				fieldValue = fieldValue.Elem()
+			}
+
+			if fieldValue.Kind() == reflect.Struct {
+				walkFields(fieldValue, visit)
+
+				continue
+			}
+		}
+
+		visit(field, fieldValue)
+	}
+}
+
+// collectEnvKeys adds every environment variable envconfig reads for configType, processed with prefix, to known. The
+// keys are built the same way as envconfig: the prefix, an underscore, and the upper case envconfig tag or field name,
+// along with the tag alone. Nested structs are recursed into with the key of the field as the prefix, while embedded
+// structs keep the prefix. visiting holds the struct types being recursed into, so recursive types terminate.
+func collectEnvKeys(configType reflect.Type, prefix string, known map[string]bool, visiting map[reflect.Type]bool) {
+	for configType != nil && configType.Kind() == reflect.Pointer {
+		configType = configType.Elem()
+	}
+
+	if configType == nil || configType.Kind() != reflect.Struct || visiting[configType] {
+		return
+	}
+
+	if visiting == nil {
+		visiting = make(map[reflect.Type]bool)
+	}
+
+	visiting[configType] = true
+	defer delete(visiting, configType)
+
+	for index := range configType.NumField() {
+		field := configType.Field(index)
+
+		if ignored, _ := strconv.ParseBool(field.Tag.Get("ignored")); !field.IsExported() || ignored {
+			continue
+		}
+
+		key := envKey(field)
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+
+		key = strings.ToUpper(key)
+		known[key] = true
+
+		if tag := field.Tag.Get("envconfig"); tag != "" {
+			known[strings.ToUpper(tag)] = true
+		}
+
+		if !isDecodable(field.Type) {
+			innerPrefix := key
+			if field.Anonymous {
+				innerPrefix = prefix
+			}
+
+			collectEnvKeys(field.Type, innerPrefix, known, visiting)
+		}
+	}
+}
+
+// envKey returns the key envconfig uses for field before the prefix is added: the envconfig tag if set, otherwise the
+// field name, split into words separated by underscores if the field has split_words.
+func envKey(field reflect.StructField) string {
+	if tag := field.Tag.Get("envconfig"); tag != "" {
+		return tag
+	}
+
+	if split, _ := strconv.ParseBool(field.Tag.Get("split_words")); !split {
+		return field.Name
+	}
+
+	var words []string
+
+	for _, word := range splitWordsRegexp.FindAllString(field.Name, -1) {
+		if match := acronymRegexp.FindStringSubmatch(word); len(match) == 3 {
+			words = append(words, match[1], match[2])
+		} else {
+			words = append(words, word)
+		}
+	}
+
+	if len(words) == 0 {
+		return field.Name
+	}
+
+	return strings.Join(words, "_")
+}
+
+// isDecodable returns true if envconfig decodes fieldType from a single variable rather than recursing into it, such
+// as for time.Time.
+func isDecodable(fieldType reflect.Type) bool {
+	for fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+
+	pointerType := reflect.PointerTo(fieldType)
+
+	return pointerType.Implements(reflect.TypeFor[envconfig.Decoder]()) ||
+		pointerType.Implements(reflect.TypeFor[envconfig.Setter]()) ||
+		pointerType.Implements(reflect.TypeFor[encoding.TextUnmarshaler]()) ||
+		pointerType.Implements(reflect.TypeFor[encoding.BinaryUnmarshaler]())
+}
+
+// validateRule checks value against a single rule from a validate tag.
+func validateRule(rule string, value reflect.Value) error {
+	name, argument, _ := strings.Cut(rule, "=")
+
+	switch name {
+	case "":
+		return nil
+	case "required":
+		if value.IsZero() {
+			return fmt.Errorf("is required but not set")
+		}
+
+		return nil
+	case "oneof":
+		formatted := fmt.Sprint(value.Interface())
+		if value.IsZero() || slices.Contains(strings.Fields(argument), formatted) {
+			return nil
+		}
+
+		return fmt.Errorf("value %q must be one of %s", formatted, argument)
+	case "min", "max":
+		return validateBound(name, argument, value)
+	default:
+		return fmt.Errorf("unknown validation rule %q", rule)
+	}
+}
+
+// validateBound checks that value, or its length for strings, slices, and maps, is within the min or max bound.
+func validateBound(name, argument string, value reflect.Value) error {
+	var (
+		bound, actual float64
+		err           error
+	)
+
+	if value.Type() == durationType {
+		var boundDuration time.Duration
+
+		boundDuration, err = time.ParseDuration(argument)
+		bound = float64(boundDuration)
+	} else {
+		bound, err = strconv.ParseFloat(argument, 64)
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid %s bound %q: %w", name, argument, err)
+	}
+
+	switch {
+	case value.CanInt():
+		actual = float64(value.Int())
+	case value.CanUint():
+		actual = float64(value.Uint())
+	case value.CanFloat():
+		actual = value.Float()
+	case value.Kind() == reflect.String, value.Kind() == reflect.Slice, value.Kind() == reflect.Map:
+		actual = float64(value.Len())
+	default:
+		return fmt.Errorf("%s cannot be applied to a value of type %s", name, value.Type())
+	}
+
+	if name == "min" && actual < bound {
+		return fmt.Errorf("value %v is less than the minimum %s", value.Interface(), argument)
+	}
+
+	if name == "max" && actual > bound {
+		return fmt.Errorf("value %v is greater than the maximum %s", value.Interface(), argument)
+	}
+
+	return nil
+}
+
+// isConfigurable returns true if the field can be set from any of the config sources or is validated.
+func isConfigurable(field reflect.StructField) bool {
+	return yamlKey(field) != "" || field.Tag.Get("envconfig") != "" || field.Tag.Get("validate") != ""
+}
+
+// isSecret returns true if the field is tagged as a secret or its name suggests it contains one.
+func isSecret(field reflect.StructField) bool {
+	if secret, _ := strconv.ParseBool(field.Tag.Get("secret")); secret {
+		return true
+	}
+
+	lowerName := strings.ToLower(field.Name)
+
+	return slices.ContainsFunc(secretNameFragments, func(fragment string) bool {
+		return strings.Contains(lowerName, fragment)
+	})
+}
+
+// formatValue formats the value of the field for Dump, redacting it if the field is a secret and not empty.
+func formatValue(field reflect.StructField, value reflect.Value) string {
+	if isSecret(field) && !value.IsZero() {
+		return redactedValue
+	}
+
+	return fmt.Sprintf("%v", value.Interface())
+}
+
+// fieldDisplayName returns the environment variable for the field if it has one, along with the field name.
+func fieldDisplayName(field reflect.StructField) string {
+	if key := field.Tag.Get("envconfig"); key != "" {
+		return fmt.Sprintf("%s (%s)", key, field.Name)
+	}
+
+	return field.Name
+}
+
+// yamlKey returns the name of the field in YAML from its yaml tag, ignoring any options. It returns an empty string if
+// the field has no yaml tag or it is ignored.
+func yamlKey(field reflect.StructField) string {
+	key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if key == "-" {
+		return ""
+	}
+
+	return key
+}
@@ -0,0 +1,221 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// EmbeddedTestConfig is exported since envconfig only sets the fields of exported embedded structs.
+type EmbeddedTestConfig struct {
+	Namespace string `yaml:"namespace" envconfig:"LOADER_TEST_NAMESPACE" validate:"required"`
+}
+
+type testConfig struct {
+	EmbeddedTestConfig `yaml:",inline"`
+
+	Mode     string        `yaml:"mode" envconfig:"LOADER_TEST_MODE" validate:"oneof=fast slow"`
+	Replicas int           `yaml:"replicas" envconfig:"LOADER_TEST_REPLICAS" validate:"min=1,max=5"`
+	Timeout  time.Duration `yaml:"timeout" envconfig:"LOADER_TEST_TIMEOUT" validate:"max=1m"`
+	Password string        `yaml:"password" envconfig:"LOADER_TEST_PASSWORD"`
+	APIKey   string        `yaml:"api_key" secret:"true"`
+	internal string
+}
+
+const testDefaultYAML = `
+namespace: default-namespace
+mode: fast
+replicas: 1
+timeout: 10s
+`
+
+// NestedTestConfig has no envconfig tags, so its fields are read using the envconfig prefix and the field names.
+type NestedTestConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+type prefixedTestConfig struct {
+	SkipCleanup bool             `yaml:"skip_cleanup"`
+	Nested      NestedTestConfig `yaml:"nested"`
+	Mode        string           `yaml:"mode" envconfig:"LOADER_TEST_MODE"`
+}
+
+func newTestConfig() *testConfig {
+	return &testConfig{}
+}
+
+func TestLoaderLoad(t *testing.T) {
+	testCases := []struct {
+		name      string
+		overlay   string
+		env       map[string]string
+		expected  testConfig
+		expectErr bool
+	}{
+		{
+			name: "default only",
+			expected: testConfig{
+				EmbeddedTestConfig: EmbeddedTestConfig{Namespace: "default-namespace"},
+				Mode:               "fast", Replicas: 1, Timeout: 10 * time.Second,
+			},
+		},
+		{
+			name:    "overlay overrides default",
+			overlay: "mode: slow\nreplicas: 3\n",
+			expected: testConfig{
+				EmbeddedTestConfig: EmbeddedTestConfig{Namespace: "default-namespace"},
+				Mode:               "slow", Replicas: 3, Timeout: 10 * time.Second,
+			},
+		},
+		{
+			name:    "env overrides overlay",
+			overlay: "replicas: 3\n",
+			env:     map[string]string{"LOADER_TEST_REPLICAS": "4", "LOADER_TEST_NAMESPACE": "env-namespace"},
+			expected: testConfig{
+				EmbeddedTestConfig: EmbeddedTestConfig{Namespace: "env-namespace"},
+				Mode:               "fast", Replicas: 4, Timeout: 10 * time.Second,
+			},
+		},
+		{
+			name:      "invalid env value",
+			env:       map[string]string{"LOADER_TEST_REPLICAS": "many"},
+			expectErr: true,
+		},
+		{
+			name:      "validation failure",
+			env:       map[string]string{"LOADER_TEST_REPLICAS": "10"},
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv("ECO_CONFIG_OVERLAY_FILE", "")
+			t.Setenv("ECO_DUMP_CONFIG", "")
+
+			if testCase.overlay != "" {
+				overlayFile := filepath.Join(t.TempDir(), "overlay.yaml")
+				assert.NoError(t, os.WriteFile(overlayFile, []byte(testCase.overlay), 0o600))
+				t.Setenv("ECO_CONFIG_OVERLAY_FILE", overlayFile)
+			}
+
+			for key, value := range testCase.env {
+				t.Setenv(key, value)
+			}
+
+			config := newTestConfig()
+			err := NewLoader().WithDefaultYAML([]byte(testDefaultYAML)).Load(config)
+
+			if testCase.expectErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, *config)
+		})
+	}
+}
+
+func TestLoaderLoadDefaultFile(t *testing.T) {
+	t.Setenv("ECO_CONFIG_OVERLAY_FILE", "")
+
+	defaultFile := filepath.Join(t.TempDir(), "default.yaml")
+	assert.NoError(t, os.WriteFile(defaultFile, []byte(testDefaultYAML), 0o600))
+
+	config := newTestConfig()
+	assert.NoError(t, NewLoader().WithDefaultFile(defaultFile).Load(config))
+	assert.Equal(t, "default-namespace", config.Namespace)
+
+	err := NewLoader().WithDefaultFile(filepath.Join(t.TempDir(), "missing.yaml")).Load(newTestConfig())
+	assert.Error(t, err)
+
+	// Suite default files may only contain comments, which must not reset configs embedded by pointer.
+	commentsFile := filepath.Join(t.TempDir(), "default.yaml")
+	assert.NoError(t, os.WriteFile(commentsFile, []byte("---\n# Suite default configurations.\n...\n"), 0o600))
+
+	suiteConfig := struct{ *EmbeddedTestConfig }{&EmbeddedTestConfig{Namespace: "general-namespace"}}
+	assert.NoError(t, NewLoader().WithDefaultFile(commentsFile).Load(&suiteConfig))
+	assert.Equal(t, "general-namespace", suiteConfig.Namespace)
+
+	err = NewLoader().Load(testConfig{})
+	assert.Error(t, err)
+}
+
+func TestLoaderLoadUnknownEnvVars(t *testing.T) {
+	t.Setenv("ECO_CONFIG_OVERLAY_FILE", "")
+	t.Setenv("LOADER_TEST_REPLICA", "2")
+
+	err := NewLoader().WithDefaultYAML([]byte(testDefaultYAML)).Load(newTestConfig())
+	assert.NoError(t, err)
+
+	err = NewLoader().WithDefaultYAML([]byte(testDefaultYAML)).WithEnvPrefix("LOADER_TEST_").Load(newTestConfig())
+	assert.ErrorContains(t, err, "LOADER_TEST_REPLICA ")
+}
+
+func TestLoaderLoadEnvconfigPrefix(t *testing.T) {
+	t.Setenv("ECO_CONFIG_OVERLAY_FILE", "")
+	t.Setenv("LOADER_TEST__SKIPCLEANUP", "true")
+	t.Setenv("LOADER_TEST__NESTED_ENABLED", "true")
+	t.Setenv("LOADER_TEST_MODE", "slow")
+
+	config := &prefixedTestConfig{}
+	err := NewLoader().WithEnvconfigPrefix("loader_test_").WithEnvPrefix("LOADER_TEST_").Load(config)
+	assert.NoError(t, err)
+	assert.True(t, config.SkipCleanup)
+	assert.True(t, config.Nested.Enabled)
+	assert.Equal(t, "slow", config.Mode)
+
+	config = &prefixedTestConfig{}
+	err = NewLoader().Load(config)
+	assert.NoError(t, err)
+	assert.False(t, config.SkipCleanup)
+	assert.Equal(t, "slow", config.Mode)
+
+	t.Setenv("LOADER_TEST_CONFIG_FILE_PATH", "")
+	t.Setenv("LOADER_TEST__SKIP_CLEANUP", "true")
+
+	err = NewLoader().WithEnvconfigPrefix("loader_test_").
+		WithEnvPrefix("LOADER_TEST_", "LOADER_TEST_CONFIG_FILE_PATH").Load(&prefixedTestConfig{})
+	assert.ErrorContains(t, err, "LOADER_TEST__SKIP_CLEANUP ")
+	assert.NotContains(t, err.Error(), "LOADER_TEST_CONFIG_FILE_PATH ")
+}
+
+func TestValidate(t *testing.T) {
+	config := newTestConfig()
+	config.Mode = "medium"
+	config.Timeout = 2 * time.Minute
+
+	err := Validate(config)
+	assert.Error(t, err)
+
+	message := err.Error()
+	assert.Contains(t, message, "LOADER_TEST_NAMESPACE (Namespace): is required")
+	assert.Contains(t, message, "LOADER_TEST_MODE (Mode): value \"medium\" must be one of fast slow")
+	assert.Contains(t, message, "LOADER_TEST_REPLICAS (Replicas): value 0 is less than the minimum 1")
+	assert.Contains(t, message, "LOADER_TEST_TIMEOUT (Timeout): value 2m0s is greater than the maximum 1m")
+}
+
+func TestDump(t *testing.T) {
+	config := newTestConfig()
+	config.Namespace = "dump-namespace"
+	config.Password = "hunter2"
+	config.APIKey = "abc123"
+	config.internal = "hidden"
+
+	builder := &strings.Builder{}
+	assert.NoError(t, Dump(config, builder))
+
+	output := builder.String()
+	assert.Contains(t, output, "dump-namespace")
+	assert.Contains(t, output, "LOADER_TEST_NAMESPACE")
+	assert.NotContains(t, output, "hunter2")
+	assert.NotContains(t, output, "abc123")
+	assert.NotContains(t, output, "hidden")
+	assert.Equal(t, 2, strings.Count(output, redactedValue))
+}
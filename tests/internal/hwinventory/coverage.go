@@ -0,0 +1,491 @@
+package hwinventory
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"k8s.io/klog/v2"
+)
+
+// CoverageFilePrefix is the prefix of the coverage files written alongside the junit reports by MergeIntoFile, which
+// are named after it and the run ID.
+const CoverageFilePrefix = "hardware_coverage"
+
+// Usage records that a spec exercised a device on a node during a run.
+type Usage struct {
+	RunID  string     `json:"run_id,omitempty"`
+	Suite  string     `json:"suite"`
+	Spec   string     `json:"spec"`
+	Node   string     `json:"node"`
+	Kind   DeviceKind `json:"kind"`
+	Device string     `json:"device"`
+	// Driver, DriverVersion, and FirmwareVersion are those of the device when it was exercised, so usages stay
+	// accurate once the device is updated and coverages of later runs are merged.
+	Driver          string `json:"driver,omitempty"`
+	DriverVersion   string `json:"driver_version,omitempty"`
+	FirmwareVersion string `json:"firmware_version,omitempty"`
+}
+
+// recordedUsages and recordedNodes are the usages and nodes recorded by RecordUsage and RecordNodes during the current
+// suite. They are global since specs recording them do not otherwise share state.
+var (
+	recordedUsagesMutex sync.Mutex
+	recordedUsages      []Usage
+	recordedNodes       []Node
+)
+
+// RecordUsage records that the current spec exercised the device of kind named deviceName on nodeName. Devices are
+// named as in Device.Name, such as the interface name of a NIC. Outside of a spec, such as in BeforeSuite, the usage is
+// recorded with an empty spec name and attributed to the whole suite.
+func RecordUsage(nodeName string, kind DeviceKind, deviceName string) {
+	usage := Usage{
+		Spec:   ginkgo.CurrentSpecReport().FullText(),
+		Node:   nodeName,
+		Kind:   kind,
+		Device: deviceName,
+	}
+
+	klog.V(90).Infof("Recording usage of %s %s on node %s by spec %q", kind, deviceName, nodeName, usage.Spec)
+
+	recordUsages(usage)
+}
+
+// RecordDeviceUsage records that the current spec exercised device on nodeName, along with the inventory of the
+// device as in RecordNodes. Unlike RecordUsage, the driver and firmware of the usage are those of device rather than
+// those found in the inventory once the suite ends, so this should be preferred when the device is already known.
+func RecordDeviceUsage(nodeName string, device Device) {
+	usage := Usage{
+		Spec:            ginkgo.CurrentSpecReport().FullText(),
+		Node:            nodeName,
+		Kind:            device.Kind,
+		Device:          device.Name,
+		Driver:          device.Driver,
+		DriverVersion:   device.DriverVersion,
+		FirmwareVersion: device.FirmwareVersion,
+	}
+
+	klog.V(90).Infof("Recording usage of %s %s with driver %q and firmware %q on node %s by spec %q",
+		device.Kind, device.Name, device.Driver, device.FirmwareVersion, nodeName, usage.Spec)
+
+	RecordNodes(Node{Name: nodeName, Devices: []Device{device}})
+	recordUsages(usage)
+}
+
+// RecordNodeUsages records that the current spec exercised every device of nodes, as in RecordDeviceUsage.
+func RecordNodeUsages(nodes ...Node) {
+	for _, node := range nodes {
+		for _, device := range node.Devices {
+			RecordDeviceUsage(node.Name, device)
+		}
+	}
+}
+
+// recordUsages adds usages to recordedUsages, skipping those already recorded.
+func recordUsages(usages ...Usage) {
+	recordedUsagesMutex.Lock()
+	defer recordedUsagesMutex.Unlock()
+
+	for _, usage := range usages {
+		if !slices.Contains(recordedUsages, usage) {
+			recordedUsages = append(recordedUsages, usage)
+		}
+	}
+}
+
+// RecordNodes records the inventory of nodes collected during a spec, to be added to the coverage of the suite by
+// NewCoverage. This is meant for devices that only exist while a spec runs, such as GPUs exposed once their operator is
+// deployed or nodes added by the spec, which are no longer found once the suite ends.
+func RecordNodes(nodes ...Node) {
+	recordedUsagesMutex.Lock()
+	defer recordedUsagesMutex.Unlock()
+
+	recordedNodes = append(recordedNodes, nodes...)
+}
+
+// Coverage is the hardware of the nodes tested during a run and the specs that exercised each device, possibly across
+// several suites and runs. Nodes are sorted by name and usages by node, device, run ID, suite, and then spec.
+type Coverage struct {
+	// UpdatedAt is the last time nodes or usages were added to the coverage.
+	UpdatedAt time.Time `json:"updated_at"`
+	Nodes     []Node    `json:"nodes"`
+	Usages    []Usage   `json:"usages"`
+}
+
+// NewCoverage returns the coverage of the current suite, named suiteName, during the run identified by runID, with the
+// inventory of nodes and every usage and node recorded so far. Recorded nodes are added after nodes, so they take
+// precedence. Usages recorded without a driver or firmware, such as by RecordUsage, get those of their device in the
+// resulting inventory.
+func NewCoverage(runID, suiteName string, nodes []Node) *Coverage {
+	coverage := &Coverage{UpdatedAt: time.Now().UTC()}
+
+	recordedUsagesMutex.Lock()
+
+	for _, usage := range recordedUsages {
+		usage.RunID = runID
+		usage.Suite = suiteName
+		coverage.Usages = append(coverage.Usages, usage)
+	}
+
+	nodes = append(slices.Clone(nodes), recordedNodes...)
+
+	recordedUsagesMutex.Unlock()
+
+	for _, node := range nodes {
+		coverage.AddNode(node)
+	}
+
+	for index, usage := range coverage.Usages {
+		if usage.Driver != "" || usage.DriverVersion != "" || usage.FirmwareVersion != "" {
+			continue
+		}
+
+		_, device := coverage.lookup(usage)
+		coverage.Usages[index].Driver = device.Driver
+		coverage.Usages[index].DriverVersion = device.DriverVersion
+		coverage.Usages[index].FirmwareVersion = device.FirmwareVersion
+	}
+
+	// Filling in the drivers and firmware may make a usage recorded by RecordUsage the same as one recorded by
+	// RecordDeviceUsage.
+	coverage.sort()
+	coverage.Usages = slices.Compact(coverage.Usages)
+
+	return coverage
+}
+
+// AddNode adds the inventory of node to the coverage, including its devices. If the node is already in the
+// coverage, its non-empty fields replace the existing ones and its devices are merged with the existing devices. This
+// allows devices collected separately, such as tested NICs, to be added to nodes collected at suite start.
+func (coverage *Coverage) AddNode(node Node) {
+	index := slices.IndexFunc(coverage.Nodes, func(existing Node) bool {
+		return existing.Name == node.Name
+	})
+
+	if index < 0 {
+		coverage.Nodes = append(coverage.Nodes, Node{Name: node.Name})
+		index = len(coverage.Nodes) - 1
+	}
+
+	coverage.Nodes[index] = mergeNode(coverage.Nodes[index], node)
+	coverage.sort()
+}
+
+// Merge adds the nodes and usages of other to the coverage. Nodes are combined as in AddNode, keeping the non-empty
+// fields of other, since it is assumed to be more recent. Usages keep their own run ID, driver, and firmware, so the
+// usages of an earlier run are not attributed to the devices as updated by a later one.
+func (coverage *Coverage) Merge(other *Coverage) {
+	if other == nil {
+		return
+	}
+
+	if other.UpdatedAt.After(coverage.UpdatedAt) {
+		coverage.UpdatedAt = other.UpdatedAt
+	}
+
+	for _, node := range other.Nodes {
+		coverage.AddNode(node)
+	}
+
+	for _, usage := range other.Usages {
+		if !slices.Contains(coverage.Usages, usage) {
+			coverage.Usages = append(coverage.Usages, usage)
+		}
+	}
+
+	coverage.sort()
+}
+
+// Find returns the usages of every device for which match returns true, such as every usage of an E810 NIC with a
+// given firmware version. Devices are matched with the driver and firmware they had when exercised. Usages of devices
+// not in the inventory of their node are matched against a device with only the fields of the usage set.
+func (coverage *Coverage) Find(match func(node Node, device Device) bool) []Usage {
+	var usages []Usage
+
+	for _, usage := range coverage.Usages {
+		node, device := coverage.lookup(usage)
+		if match(node, device) {
+			usages = append(usages, usage)
+		}
+	}
+
+	return usages
+}
+
+// Report returns a table with a row for every device in the coverage, listing the hardware of its node and the number
+// of specs that exercised it, suitable for a report entry. A device exercised with several drivers or firmware
+// versions, such as across runs, has a row for each.
+func (coverage *Coverage) Report() string {
+	specCounts := make(map[reportRow]int)
+
+	for _, usage := range coverage.Usages {
+		specCounts[newReportRow(coverage.lookup(usage))]++
+	}
+
+	for _, node := range coverage.Nodes {
+		for _, device := range node.Devices {
+			// Devices that were not exercised still get a row, with no specs.
+			row := newReportRow(node, device)
+			if _, found := specCounts[row]; !found {
+				specCounts[row] = 0
+			}
+		}
+	}
+
+	builder := &strings.Builder{}
+	writer := tabwriter.NewWriter(builder, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(writer, "NODE\tCPU\tBIOS\tKERNEL\tKIND\tDEVICE\tMODEL\tDRIVER\tFIRMWARE\tSPECS")
+
+	for _, row := range slices.SortedFunc(maps.Keys(specCounts), compareReportRows) {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\n",
+			row.node, row.cpu, row.bios, row.kernel, row.kind, row.device, row.model, row.driver, row.firmware,
+			specCounts[row])
+	}
+
+	_ = writer.Flush()
+
+	return builder.String()
+}
+
+// CoveragePath returns the path of the coverage file of the run identified by runID in reportsDir, which should be the
+// directory of the junit reports.
+func CoveragePath(reportsDir, runID string) string {
+	return filepath.Join(reportsDir, fmt.Sprintf("%s_%s.json", CoverageFilePrefix, runID))
+}
+
+// ReadCoverage reads a coverage file written by MergeIntoFile.
+func ReadCoverage(path string) (*Coverage, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hardware coverage %s: %w", path, err)
+	}
+
+	coverage := &Coverage{}
+
+	err = json.Unmarshal(content, coverage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal hardware coverage %s: %w", path, err)
+	}
+
+	return coverage, nil
+}
+
+// MergeIntoFile merges coverage into the coverage file at path, creating it if it does not exist, so every suite of a
+// run contributes to the same file when path is from CoveragePath. The file is replaced atomically, but suites writing
+// it concurrently may lose each other's changes.
+func MergeIntoFile(path string, coverage *Coverage) error {
+	merged, err := ReadCoverage(path)
+	if errors.Is(err, os.ErrNotExist) {
+		merged, err = &Coverage{}, nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	merged.Merge(coverage)
+
+	return WriteJSONFile(path, merged)
+}
+
+// WriteJSONFile writes value as indented JSON to path, replacing it atomically so readers never see a partially
+// written file. It is used for the coverage file and for reports derived from it, such as the NIC inventory.
+func WriteJSONFile(path string, value any) error {
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", path, err)
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for %s: %w", path, err)
+	}
+
+	defer os.Remove(tempFile.Name())
+
+	_, err = tempFile.Write(content)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to write temporary file for %s: %w", path, err)
+	}
+
+	err = os.Rename(tempFile.Name(), path)
+	if err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// lookup returns the node and device of usage from the inventory, or ones with only the fields of usage set if they
+// are not in it. The driver and firmware recorded with usage replace those of the inventory.
+func (coverage *Coverage) lookup(usage Usage) (Node, Device) {
+	node := Node{Name: usage.Node}
+	device := Device{Kind: usage.Kind, Name: usage.Device}
+
+	nodeIndex := slices.IndexFunc(coverage.Nodes, func(existing Node) bool {
+		return existing.Name == usage.Node
+	})
+
+	if nodeIndex >= 0 {
+		node = coverage.Nodes[nodeIndex]
+
+		deviceIndex := slices.IndexFunc(node.Devices, func(existing Device) bool {
+			return existing.Kind == usage.Kind && existing.Name == usage.Device
+		})
+
+		if deviceIndex >= 0 {
+			device = node.Devices[deviceIndex]
+		}
+	}
+
+	device.Driver = cmp.Or(usage.Driver, device.Driver)
+	device.DriverVersion = cmp.Or(usage.DriverVersion, device.DriverVersion)
+	device.FirmwareVersion = cmp.Or(usage.FirmwareVersion, device.FirmwareVersion)
+
+	return node, device
+}
+
+// reportRow is a row of the table returned by Report, without the number of specs.
+type reportRow struct {
+	node, cpu, bios, kernel, kind, device, model, driver, firmware string
+}
+
+// newReportRow returns the row of Report for device on node.
+func newReportRow(node Node, device Device) reportRow {
+	return reportRow{
+		node:     node.Name,
+		cpu:      node.CPUModel,
+		bios:     strings.TrimSpace(node.BIOSVendor + " " + node.BIOSVersion),
+		kernel:   node.KernelVersion,
+		kind:     string(device.Kind),
+		device:   device.Name,
+		model:    cmp.Or(device.Model, device.PCIID),
+		driver:   strings.TrimSpace(device.Driver + " " + device.DriverVersion),
+		firmware: device.FirmwareVersion,
+	}
+}
+
+// compareReportRows orders rows of Report by node, device, driver, and then firmware.
+func compareReportRows(a, b reportRow) int {
+	return cmp.Or(
+		cmp.Compare(a.node, b.node),
+		cmp.Compare(a.kind, b.kind),
+		cmp.Compare(a.device, b.device),
+		cmp.Compare(a.driver, b.driver),
+		cmp.Compare(a.firmware, b.firmware),
+		cmp.Compare(a.model, b.model))
+}
+
+// sort sorts the nodes of the coverage by name, their devices by kind and then name, and the usages by node, device,
+// run ID, suite, spec, and then driver and firmware.
+func (coverage *Coverage) sort() {
+	slices.SortFunc(coverage.Nodes, func(a, b Node) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	for _, node := range coverage.Nodes {
+		slices.SortFunc(node.Devices, compareDevices)
+	}
+
+	slices.SortFunc(coverage.Usages, func(a, b Usage) int {
+		return cmp.Or(
+			cmp.Compare(a.Node, b.Node),
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Device, b.Device),
+			cmp.Compare(a.RunID, b.RunID),
+			cmp.Compare(a.Suite, b.Suite),
+			cmp.Compare(a.Spec, b.Spec),
+			cmp.Compare(a.Driver, b.Driver),
+			cmp.Compare(a.DriverVersion, b.DriverVersion),
+			cmp.Compare(a.FirmwareVersion, b.FirmwareVersion))
+	})
+}
+
+// compareDevices orders devices by kind and then name.
+func compareDevices(a, b Device) int {
+	return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Name, b.Name))
+}
+
+// mergeNode returns existing updated with the non-empty fields of update and its devices merged with those of update.
+func mergeNode(existing, update Node) Node {
+	merged := update
+	merged.Devices = slices.Clone(existing.Devices)
+
+	for _, field := range []struct {
+		merged   *string
+		existing string
+	}{
+		{&merged.CPUModel, existing.CPUModel},
+		{&merged.BIOSVendor, existing.BIOSVendor},
+		{&merged.BIOSVersion, existing.BIOSVersion},
+		{&merged.KernelVersion, existing.KernelVersion},
+		{&merged.OSImage, existing.OSImage},
+	} {
+		if *field.merged == "" {
+			*field.merged = field.existing
+		}
+	}
+
+	for _, device := range update.Devices {
+		index := slices.IndexFunc(merged.Devices, func(existing Device) bool {
+			return compareDevices(existing, device) == 0
+		})
+
+		if index < 0 {
+			merged.Devices = append(merged.Devices, device)
+
+			continue
+		}
+
+		merged.Devices[index] = mergeDevice(merged.Devices[index], device)
+	}
+
+	slices.SortFunc(merged.Devices, compareDevices)
+
+	return merged
+}
+
+// mergeDevice returns existing updated with the non-empty fields of update.
+func mergeDevice(existing, update Device) Device {
+	merged := update
+
+	for _, field := range []struct {
+		merged   *string
+		existing string
+	}{
+		{&merged.Vendor, existing.Vendor},
+		{&merged.Model, existing.Model},
+		{&merged.Driver, existing.Driver},
+		{&merged.DriverVersion, existing.DriverVersion},
+		{&merged.FirmwareVersion, existing.FirmwareVersion},
+		{&merged.PCIAddress, existing.PCIAddress},
+		{&merged.PCIID, existing.PCIID},
+		{&merged.LinkSpeed, existing.LinkSpeed},
+		{&merged.PTPHardwareClock, existing.PTPHardwareClock},
+	} {
+		if *field.merged == "" {
+			*field.merged = field.existing
+		}
+	}
+
+	if merged.Count == 0 {
+		merged.Count = existing.Count
+	}
+
+	return merged
+}
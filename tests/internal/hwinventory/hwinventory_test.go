@@ -0,0 +1,176 @@
+package hwinventory
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNodeFromObject(t *testing.T) {
+	node := nodeFromObject(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "worker-0",
+			Labels: map[string]string{"nvidia.com/gpu.product": "NVIDIA-A100-SXM4-40GB"},
+		},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("64"),
+				"nvidia.com/gpu":      resource.MustParse("4"),
+				"amd.com/gpu":         resource.MustParse("0"),
+				"habana.ai/gaudi":     resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("256Gi"),
+			},
+			NodeInfo: corev1.NodeSystemInfo{KernelVersion: "5.14.0-427.el9.x86_64", OSImage: "RHCOS 4.18"},
+		},
+	})
+
+	assert.Equal(t, &Node{
+		Name:          "worker-0",
+		KernelVersion: "5.14.0-427.el9.x86_64",
+		OSImage:       "RHCOS 4.18",
+		Devices: []Device{
+			{Kind: DeviceKindAccelerator, Name: "habana.ai/gaudi", Vendor: "Habana", Count: 8},
+			{Kind: DeviceKindGPU, Name: "nvidia.com/gpu", Vendor: "NVIDIA", Model: "NVIDIA-A100-SXM4-40GB", Count: 4},
+		},
+	}, node)
+}
+
+func TestApplyHostInfo(t *testing.T) {
+	node := &Node{Name: "worker-0", CPUModel: "stale"}
+	applyHostInfo(node, "cpu_model= Intel(R) Xeon(R) Gold 6338N CPU @ 2.20GHz\nbios_vendor=HPE\nbios_version=U46\n")
+
+	assert.Equal(t, &Node{
+		Name:        "worker-0",
+		CPUModel:    "Intel(R) Xeon(R) Gold 6338N CPU @ 2.20GHz",
+		BIOSVendor:  "HPE",
+		BIOSVersion: "U46",
+	}, node)
+}
+
+func TestCoverageMergeAndFind(t *testing.T) {
+	firstRun := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	coverage := &Coverage{
+		UpdatedAt: firstRun,
+		Nodes: []Node{{Name: "node-b", CPUModel: "Xeon", Devices: []Device{
+			{Kind: DeviceKindNIC, Name: "ens1f0", Driver: "ice", FirmwareVersion: "4.40"},
+		}}},
+		Usages: []Usage{{Suite: "RAN PTP Suite", Spec: "ptp a", Node: "node-b", Kind: DeviceKindNIC, Device: "ens1f0"}},
+	}
+
+	coverage.Merge(&Coverage{
+		UpdatedAt: firstRun.Add(time.Hour),
+		Nodes: []Node{
+			{Name: "node-b", KernelVersion: "5.14", Devices: []Device{
+				{Kind: DeviceKindNIC, Name: "ens1f0", FirmwareVersion: "4.50", PCIID: "8086:1593"},
+			}},
+			{Name: "node-a", Devices: []Device{{Kind: DeviceKindNIC, Name: "ens2f0", Driver: "i40e"}}},
+		},
+		Usages: []Usage{
+			{Suite: "SR-IOV Suite", Spec: "sriov a", Node: "node-b", Kind: DeviceKindNIC, Device: "ens1f0"},
+			{Suite: "SR-IOV Suite", Spec: "sriov b", Node: "node-a", Kind: DeviceKindNIC, Device: "ens2f0"},
+			{Suite: "RAN PTP Suite", Spec: "ptp a", Node: "node-b", Kind: DeviceKindNIC, Device: "ens1f0"},
+		},
+	})
+
+	assert.Equal(t, firstRun.Add(time.Hour), coverage.UpdatedAt)
+	assert.Equal(t, []Node{
+		{Name: "node-a", Devices: []Device{{Kind: DeviceKindNIC, Name: "ens2f0", Driver: "i40e"}}},
+		{Name: "node-b", CPUModel: "Xeon", KernelVersion: "5.14", Devices: []Device{
+			{Kind: DeviceKindNIC, Name: "ens1f0", Driver: "ice", FirmwareVersion: "4.50", PCIID: "8086:1593"},
+		}},
+	}, coverage.Nodes)
+	assert.Len(t, coverage.Usages, 3)
+
+	e810Usages := coverage.Find(func(_ Node, device Device) bool {
+		return device.PCIID == "8086:1593" && device.FirmwareVersion == "4.50"
+	})
+	assert.Equal(t, []Usage{
+		{Suite: "RAN PTP Suite", Spec: "ptp a", Node: "node-b", Kind: DeviceKindNIC, Device: "ens1f0"},
+		{Suite: "SR-IOV Suite", Spec: "sriov a", Node: "node-b", Kind: DeviceKindNIC, Device: "ens1f0"},
+	}, e810Usages)
+
+	assert.Contains(t, coverage.Report(), "8086:1593")
+}
+
+func TestCoverageMergeRuns(t *testing.T) {
+	coverage := &Coverage{
+		Nodes: []Node{{Name: "node-a", Devices: []Device{
+			{Kind: DeviceKindNIC, Name: "ens1f0", Driver: "ice", FirmwareVersion: "4.40"},
+		}}},
+		Usages: []Usage{{RunID: "run-1", Suite: "RAN PTP Suite", Spec: "ptp a", Node: "node-a",
+			Kind: DeviceKindNIC, Device: "ens1f0", Driver: "ice", FirmwareVersion: "4.40"}},
+	}
+
+	coverage.Merge(&Coverage{
+		Nodes: []Node{{Name: "node-a", Devices: []Device{
+			{Kind: DeviceKindNIC, Name: "ens1f0", Driver: "ice", FirmwareVersion: "4.50"},
+		}}},
+		Usages: []Usage{{RunID: "run-2", Suite: "RAN PTP Suite", Spec: "ptp a", Node: "node-a",
+			Kind: DeviceKindNIC, Device: "ens1f0", Driver: "ice", FirmwareVersion: "4.50"}},
+	})
+
+	assert.Len(t, coverage.Usages, 2)
+
+	firmwareUsages := coverage.Find(func(_ Node, device Device) bool {
+		return device.FirmwareVersion == "4.40"
+	})
+	assert.Len(t, firmwareUsages, 1)
+	assert.Equal(t, "run-1", firmwareUsages[0].RunID)
+
+	report := coverage.Report()
+	assert.Contains(t, report, "4.40")
+	assert.Contains(t, report, "4.50")
+}
+
+func TestNewCoverage(t *testing.T) {
+	recordedUsages = nil
+	recordedNodes = nil
+
+	gpu := Device{Kind: DeviceKindGPU, Name: "nvidia.com/gpu", Vendor: "NVIDIA", Count: 1}
+
+	nic := Device{Kind: DeviceKindNIC, Name: "ens1f0", Driver: "ice", FirmwareVersion: "4.40"}
+
+	RecordUsage("node-a", DeviceKindGPU, "nvidia.com/gpu")
+	RecordUsage("node-a", DeviceKindGPU, "nvidia.com/gpu")
+	RecordNodes(Node{Name: "node-a", Devices: []Device{gpu}})
+	RecordUsage("node-a", DeviceKindNIC, "ens1f0")
+	RecordDeviceUsage("node-a", nic)
+
+	nodes := []Node{{Name: "node-a", CPUModel: "Xeon"}}
+
+	coverage := NewCoverage("run-1", "GPU Suite", nodes)
+	assert.Equal(t, []Usage{
+		{RunID: "run-1", Suite: "GPU Suite", Node: "node-a", Kind: DeviceKindGPU, Device: "nvidia.com/gpu"},
+		{RunID: "run-1", Suite: "GPU Suite", Node: "node-a", Kind: DeviceKindNIC, Device: "ens1f0",
+			Driver: "ice", FirmwareVersion: "4.40"},
+	}, coverage.Usages)
+	assert.Equal(t, []Node{{Name: "node-a", CPUModel: "Xeon", Devices: []Device{gpu, nic}}}, coverage.Nodes)
+	assert.Len(t, nodes[0].Devices, 0)
+}
+
+func TestMergeIntoFile(t *testing.T) {
+	path := CoveragePath(t.TempDir(), "run-1")
+	assert.Equal(t, "hardware_coverage_run-1.json", filepath.Base(path))
+
+	for _, suite := range []string{"a", "b"} {
+		err := MergeIntoFile(path, &Coverage{
+			Nodes:  []Node{{Name: "node-a"}},
+			Usages: []Usage{{Suite: suite, Node: "node-a", Kind: DeviceKindNIC, Device: "ens1f0"}},
+		})
+		assert.NoError(t, err)
+	}
+
+	coverage, err := ReadCoverage(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []Node{{Name: "node-a"}}, coverage.Nodes)
+	assert.Len(t, coverage.Usages, 2)
+
+	matches, err := filepath.Glob(path + ".*")
+	assert.NoError(t, err)
+	assert.Empty(t, matches)
+}
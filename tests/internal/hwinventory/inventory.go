@@ -0,0 +1,206 @@
+// Package hwinventory records the hardware of the nodes under test and which specs exercised which devices, so a run
+// produces a single coverage report answering questions like which tests ran on an E810 NIC with a given firmware.
+// Suites collect node inventories at suite start using CollectNodes, specs record the devices they exercise using
+// RecordUsage or RecordDeviceUsage, along with any nodes only collected during the spec using RecordNodes, and suites
+// merge their Coverage into a file shared by every suite of the run using MergeIntoFile and CoveragePath. Each usage
+// keeps the run it belongs to and the driver and firmware of its device, so coverages of several runs can be merged.
+package hwinventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// DeviceKind is the kind of a hardware device.
+type DeviceKind string
+
+const (
+	// DeviceKindNIC is a network interface, named by its interface name.
+	DeviceKindNIC DeviceKind = "nic"
+	// DeviceKindGPU is a GPU, named by the extended resource it is exposed as.
+	DeviceKindGPU DeviceKind = "gpu"
+	// DeviceKindAccelerator is an accelerator other than a GPU, such as AWS Neuron, named by the extended resource it
+	// is exposed as.
+	DeviceKindAccelerator DeviceKind = "accelerator"
+)
+
+// Device is a hardware device on a node. Fields that do not apply to a device or could not be found are empty.
+type Device struct {
+	Kind            DeviceKind `json:"kind"`
+	Name            string     `json:"name"`
+	Vendor          string     `json:"vendor,omitempty"`
+	Model           string     `json:"model,omitempty"`
+	Driver          string     `json:"driver,omitempty"`
+	DriverVersion   string     `json:"driver_version,omitempty"`
+	FirmwareVersion string     `json:"firmware_version,omitempty"`
+	PCIAddress      string     `json:"pci_address,omitempty"`
+	PCIID           string     `json:"pci_id,omitempty"`
+	LinkSpeed       string     `json:"link_speed,omitempty"`
+	// PTPHardwareClock is the index of the PTP hardware clock of a NIC, if it has one.
+	PTPHardwareClock string `json:"ptp_hardware_clock,omitempty"`
+	// Count is the number of devices exposed as an extended resource, or 0 for devices that are not.
+	Count int64 `json:"count,omitempty"`
+}
+
+// Node is the hardware inventory of a node.
+type Node struct {
+	Name          string   `json:"name"`
+	CPUModel      string   `json:"cpu_model,omitempty"`
+	BIOSVendor    string   `json:"bios_vendor,omitempty"`
+	BIOSVersion   string   `json:"bios_version,omitempty"`
+	KernelVersion string   `json:"kernel_version,omitempty"`
+	OSImage       string   `json:"os_image,omitempty"`
+	Devices       []Device `json:"devices,omitempty"`
+}
+
+// acceleratorResource describes an extended resource exposed by an accelerator device plugin.
+type acceleratorResource struct {
+	kind   DeviceKind
+	vendor string
+	// modelLabel is the node label holding the model of the device, if the device plugin or its feature discovery
+	// sets one.
+	modelLabel string
+}
+
+// awsNeuronResource describes the extended resources of AWS Neuron devices, which are identified by the instance type
+// since each type has a single accelerator model.
+var awsNeuronResource = acceleratorResource{
+	kind: DeviceKindAccelerator, vendor: "AWS", modelLabel: corev1.LabelInstanceTypeStable}
+
+// acceleratorResources are the extended resources of the accelerators tested by the hw-accel suites.
+var acceleratorResources = map[corev1.ResourceName]acceleratorResource{
+	"nvidia.com/gpu":              {kind: DeviceKindGPU, vendor: "NVIDIA", modelLabel: "nvidia.com/gpu.product"},
+	"amd.com/gpu":                 {kind: DeviceKindGPU, vendor: "AMD", modelLabel: "amd.com/gpu.device-id"},
+	"gpu.intel.com/i915":          {kind: DeviceKindGPU, vendor: "Intel"},
+	"aws.amazon.com/neuron":       awsNeuronResource,
+	"aws.amazon.com/neurondevice": awsNeuronResource,
+	"aws.amazon.com/neuroncore":   awsNeuronResource,
+	"habana.ai/gaudi":             {kind: DeviceKindAccelerator, vendor: "Habana"},
+}
+
+// hostInfoCommand prints the CPU model and BIOS of a node as key=value lines. Each value is empty if it cannot be
+// read, such as the CPU model on architectures whose cpuinfo has no model name.
+const hostInfoCommand = `echo "cpu_model=$(grep -m1 '^model name' /proc/cpuinfo | cut -d: -f2-)"; ` +
+	`echo "bios_vendor=$(cat /sys/class/dmi/id/bios_vendor 2>/dev/null)"; ` +
+	`echo "bios_version=$(cat /sys/class/dmi/id/bios_version 2>/dev/null)"`
+
+// CollectNodes returns the inventory of every node matching options, sorted by name. The kernel, OS image, and
+// accelerators come from the node objects, while the CPU model and BIOS are read on the nodes using the configured
+// node executor. Collection is best effort: nodes whose CPU model and BIOS could not be read are still returned,
+// along with an error describing what failed.
+func CollectNodes(apiClient *clients.Settings, options metav1.ListOptions) ([]Node, error) {
+	nodeBuilders, err := nodes.List(apiClient, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	inventories := make(map[string]*Node, len(nodeBuilders))
+
+	for _, nodeBuilder := range nodeBuilders {
+		inventories[nodeBuilder.Definition.Name] = nodeFromObject(nodeBuilder.Definition)
+	}
+
+	hostErr := collectHostInfo(apiClient, inventories)
+
+	var collected []Node
+	for _, nodeName := range slices.Sorted(maps.Keys(inventories)) {
+		collected = append(collected, *inventories[nodeName])
+	}
+
+	return collected, hostErr
+}
+
+// nodeFromObject returns the inventory of the parts of a node available from its object.
+func nodeFromObject(node *corev1.Node) *Node {
+	inventory := &Node{
+		Name:          node.Name,
+		KernelVersion: node.Status.NodeInfo.KernelVersion,
+		OSImage:       node.Status.NodeInfo.OSImage,
+	}
+
+	for _, resourceName := range slices.Sorted(maps.Keys(node.Status.Capacity)) {
+		resource, ok := acceleratorResources[resourceName]
+		if !ok {
+			continue
+		}
+
+		count := node.Status.Capacity[resourceName]
+		if count.Value() == 0 {
+			continue
+		}
+
+		device := Device{
+			Kind:   resource.kind,
+			Name:   string(resourceName),
+			Vendor: resource.vendor,
+			Count:  count.Value(),
+		}
+
+		if resource.modelLabel != "" {
+			device.Model = node.Labels[resource.modelLabel]
+		}
+
+		inventory.Devices = append(inventory.Devices, device)
+	}
+
+	return inventory
+}
+
+// collectHostInfo reads the CPU model and BIOS of every node in inventories on the node itself.
+func collectHostInfo(apiClient *clients.Settings, inventories map[string]*Node) error {
+	executor, err := cluster.NewNodeExecutor(apiClient)
+	if err != nil {
+		return fmt.Errorf("failed to create node executor: %w", err)
+	}
+
+	defer func() {
+		if err := executor.Close(); err != nil {
+			klog.V(90).Infof("Failed to close node executor: %v", err)
+		}
+	}()
+
+	results := cluster.ExecOnNodes(context.TODO(), executor, slices.Sorted(maps.Keys(inventories)),
+		hostInfoCommand, cluster.DefaultExecConcurrency)
+
+	for nodeName, stdout := range results.Stdout() {
+		applyHostInfo(inventories[nodeName], stdout)
+	}
+
+	if err := results.Err(); err != nil {
+		return errors.Join(errors.New("failed to read CPU model and BIOS on some nodes"), err)
+	}
+
+	return nil
+}
+
+// applyHostInfo sets the CPU model and BIOS of node from the output of hostInfoCommand.
+func applyHostInfo(node *Node, output string) {
+	for line := range strings.Lines(output) {
+		key, value, found := strings.Cut(strings.TrimSpace(line), "=")
+		if !found {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "cpu_model":
+			node.CPUModel = value
+		case "bios_vendor":
+			node.BIOSVendor = value
+		case "bios_version":
+			node.BIOSVersion = value
+		}
+	}
+}
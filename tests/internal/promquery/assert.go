@@ -0,0 +1,385 @@
+package promquery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DefaultPollInterval is the poll interval used by assertions when a timeout is specified but no poll interval is
+	// provided.
+	DefaultPollInterval = 5 * time.Second
+	// DefaultStep is the resolution of range assertions when no step is provided.
+	DefaultStep = 30 * time.Second
+)
+
+// Condition is a condition every sample of a query must satisfy for an assertion to pass.
+type Condition struct {
+	// Description describes the condition for error messages, such as "below 100".
+	Description string
+	// IsSatisfied returns whether the value of a sample satisfies the condition.
+	IsSatisfied func(value float64) bool
+}
+
+// Below returns a condition satisfied by values strictly less than limit.
+func Below(limit float64) Condition {
+	return Condition{
+		Description: fmt.Sprintf("below %v", limit),
+		IsSatisfied: func(value float64) bool { return value < limit },
+	}
+}
+
+// AtMost returns a condition satisfied by values less than or equal to limit.
+func AtMost(limit float64) Condition {
+	return Condition{
+		Description: fmt.Sprintf("at most %v", limit),
+		IsSatisfied: func(value float64) bool { return value <= limit },
+	}
+}
+
+// Above returns a condition satisfied by values strictly greater than limit.
+func Above(limit float64) Condition {
+	return Condition{
+		Description: fmt.Sprintf("above %v", limit),
+		IsSatisfied: func(value float64) bool { return value > limit },
+	}
+}
+
+// AtLeast returns a condition satisfied by values greater than or equal to limit.
+func AtLeast(limit float64) Condition {
+	return Condition{
+		Description: fmt.Sprintf("at least %v", limit),
+		IsSatisfied: func(value float64) bool { return value >= limit },
+	}
+}
+
+// EqualTo returns a condition satisfied by values exactly equal to expected. It is intended for metrics with integer
+// values, such as states, that are stored as floats.
+func EqualTo(expected float64) Condition {
+	return Condition{
+		Description: fmt.Sprintf("equal to %v", expected),
+		IsSatisfied: func(value float64) bool { return value == expected },
+	}
+}
+
+// Between returns a condition satisfied by values between low and high, inclusive.
+func Between(low, high float64) Condition {
+	return Condition{
+		Description: fmt.Sprintf("between %v and %v", low, high),
+		IsSatisfied: func(value float64) bool { return value >= low && value <= high },
+	}
+}
+
+// assertOptions holds the options for the assertions in this package. It is unexported since the AssertOption
+// functions should be used to configure it.
+type assertOptions struct {
+	timeout        time.Duration
+	pollInterval   time.Duration
+	stableDuration time.Duration
+	startTime      time.Time
+	endTime        time.Time
+	step           time.Duration
+}
+
+// newAssertOptions creates a new assertOptions struct with default values and applies options to it.
+func newAssertOptions(options []AssertOption) *assertOptions {
+	opts := &assertOptions{
+		pollInterval: DefaultPollInterval,
+		startTime:    time.Now(),
+		step:         DefaultStep,
+	}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return opts
+}
+
+// AssertOption is a function that configures the assertions in this package.
+type AssertOption func(*assertOptions)
+
+// noopAssertOption is an AssertOption that does nothing. It is used when the value provided to a function returning an
+// AssertOption is invalid.
+func noopAssertOption(*assertOptions) {}
+
+// AssertWithTimeout sets how long polling assertions retry for. If the timeout is less than or equal to zero, it does
+// nothing. The timeout is never less than the stable duration, since timeout = max(timeout, stableDuration).
+func AssertWithTimeout(timeout time.Duration) AssertOption {
+	if timeout <= 0 {
+		return noopAssertOption
+	}
+
+	return func(options *assertOptions) {
+		options.timeout = max(timeout, options.stableDuration)
+	}
+}
+
+// AssertWithPollInterval sets the time between queries of polling assertions. If the poll interval is less than or
+// equal to zero, it does nothing.
+func AssertWithPollInterval(pollInterval time.Duration) AssertOption {
+	if pollInterval <= 0 {
+		return noopAssertOption
+	}
+
+	return func(options *assertOptions) {
+		options.pollInterval = pollInterval
+	}
+}
+
+// AssertWithStableDuration sets how long polling assertions must keep passing before they succeed. If the stable
+// duration is less than or equal to zero, it does nothing. The timeout is raised to the stable duration if it is
+// shorter.
+func AssertWithStableDuration(stableDuration time.Duration) AssertOption {
+	if stableDuration <= 0 {
+		return noopAssertOption
+	}
+
+	return func(options *assertOptions) {
+		options.timeout = max(options.timeout, stableDuration)
+		options.stableDuration = stableDuration
+	}
+}
+
+// AssertWithStartTime sets the time of the first query of polling assertions. Since metrics are stored by Prometheus,
+// starting in the past checks the metrics from then on without waiting. If the start time is zero or in the future, it
+// does nothing.
+func AssertWithStartTime(startTime time.Time) AssertOption {
+	if startTime.IsZero() || startTime.After(time.Now()) {
+		return noopAssertOption
+	}
+
+	return func(options *assertOptions) {
+		options.startTime = startTime
+	}
+}
+
+// AssertWithEndTime sets the end of the range checked by [AssertRange]. If the end time is in the future, AssertRange
+// waits until then before querying. If the end time is zero, it does nothing.
+func AssertWithEndTime(endTime time.Time) AssertOption {
+	if endTime.IsZero() {
+		return noopAssertOption
+	}
+
+	return func(options *assertOptions) {
+		options.endTime = endTime
+	}
+}
+
+// AssertWithStep sets the resolution of the range checked by [AssertRange]. If the step is less than or equal to zero,
+// it does nothing.
+func AssertWithStep(step time.Duration) AssertOption {
+	if step <= 0 {
+		return noopAssertOption
+	}
+
+	return func(options *assertOptions) {
+		options.step = step
+	}
+}
+
+// Assert executes query and checks that it has at least one sample and every sample satisfies condition. With no
+// options, the query is executed once at the current time.
+//
+// With the timeout, poll interval, stable duration, and start time options, the query is polled from the start time
+// until the call time plus the timeout, and must pass on every poll over the stable duration, which restarts after each
+// failure. The PTP metrics AssertQuery is built on this function.
+//
+// SECURITY: This function does not perform any sort of sanitization on the query. It should only be used with trusted
+// queries.
+func Assert(
+	ctx context.Context, client prometheusv1.API, query Query, condition Condition, options ...AssertOption) error {
+	if client == nil {
+		return fmt.Errorf("cannot assert query with nil client")
+	}
+
+	return poll(ctx, newAssertOptions(options), func(queryTime time.Time) error {
+		vector, err := QueryVector(ctx, client, query, queryTime)
+		if err != nil {
+			return err
+		}
+
+		if len(vector) == 0 {
+			return fmt.Errorf("query %s returned no samples", query)
+		}
+
+		for _, sample := range vector {
+			if sample != nil && !condition.IsSatisfied(float64(sample.Value)) {
+				return fmt.Errorf("query %s returned sample %s which is not %s", query, sample, condition.Description)
+			}
+		}
+
+		return nil
+	})
+}
+
+// AssertAbsent checks that query has no samples, such as after a metric stops being exported. It accepts the same
+// options as [Assert].
+func AssertAbsent(ctx context.Context, client prometheusv1.API, query Query, options ...AssertOption) error {
+	if client == nil {
+		return fmt.Errorf("cannot assert query with nil client")
+	}
+
+	return poll(ctx, newAssertOptions(options), func(queryTime time.Time) error {
+		vector, err := QueryVector(ctx, client, query, queryTime)
+		if err != nil {
+			return err
+		}
+
+		if len(vector) > 0 {
+			return fmt.Errorf("query %s returned %d samples but expected none", query, len(vector))
+		}
+
+		return nil
+	})
+}
+
+// AssertPresent checks that query has at least one sample, regardless of value. It accepts the same options as
+// [Assert].
+func AssertPresent(ctx context.Context, client prometheusv1.API, query Query, options ...AssertOption) error {
+	if client == nil {
+		return fmt.Errorf("cannot assert query with nil client")
+	}
+
+	return poll(ctx, newAssertOptions(options), func(queryTime time.Time) error {
+		absent, err := IsAbsent(ctx, client, query, queryTime)
+		if err != nil {
+			return err
+		}
+
+		if absent {
+			return fmt.Errorf("query %s returned no samples", query)
+		}
+
+		return nil
+	})
+}
+
+// AssertRange checks that every sample of query satisfied condition over the window ending at the end time, such as a
+// metric staying below a limit for the last 10 minutes. The end time defaults to the current time and can be set with
+// [AssertWithEndTime]; if it is in the future, AssertRange waits until then. The resolution is set with
+// [AssertWithStep]. The polling options are ignored.
+//
+// The assertion fails if the query has no samples in the window, since a missing metric cannot be said to have stayed
+// within the condition.
+//
+// SECURITY: This function does not perform any sort of sanitization on the query. It should only be used with trusted
+// queries.
+func AssertRange(ctx context.Context, client prometheusv1.API, query Query, condition Condition,
+	window time.Duration, options ...AssertOption) error {
+	if client == nil {
+		return fmt.Errorf("cannot assert query range with nil client")
+	}
+
+	opts := newAssertOptions(options)
+
+	endTime := opts.endTime
+	if endTime.IsZero() {
+		endTime = time.Now()
+	}
+
+	select {
+	case <-time.After(time.Until(endTime)):
+	case <-ctx.Done():
+		return fmt.Errorf("failed to assert query range: context finished: %w", ctx.Err())
+	}
+
+	queryRange := prometheusv1.Range{Start: endTime.Add(-window), End: endTime, Step: opts.step}
+
+	matrix, err := QueryMatrix(ctx, client, query, queryRange)
+	if err != nil {
+		return fmt.Errorf("failed to assert query range: %w", err)
+	}
+
+	var (
+		total      int
+		violations int
+		first      model.SamplePair
+		firstIn    model.Metric
+	)
+
+	for _, series := range matrix {
+		if series == nil {
+			continue
+		}
+
+		for _, pair := range series.Values {
+			total++
+
+			if condition.IsSatisfied(float64(pair.Value)) {
+				continue
+			}
+
+			if violations == 0 || pair.Timestamp.Before(first.Timestamp) {
+				first = pair
+				firstIn = series.Metric
+			}
+
+			violations++
+		}
+	}
+
+	if total == 0 {
+		return fmt.Errorf("query %s returned no samples between %s and %s", query, queryRange.Start, queryRange.End)
+	}
+
+	if violations > 0 {
+		return fmt.Errorf("query %s was not %s for %s: %d of %d samples violated it, first %v at %s in %s",
+			query, condition.Description, window, violations, total, first.Value, first.Timestamp.Time().UTC(), firstIn)
+	}
+
+	klog.V(90).Infof("Query %s was %s for %s over %d samples", query, condition.Description, window, total)
+
+	return nil
+}
+
+// poll calls check at the start time and every poll interval after until it succeeds for the stable duration, the
+// timeout is exceeded, or ctx is done. Query times in the past are checked immediately, while future ones are waited
+// for. With no timeout, check is called exactly once.
+func poll(ctx context.Context, opts *assertOptions, check func(queryTime time.Time) error) error {
+	// queryTime is the time at which check is called. stableTime is the first query time of the current run of
+	// successes, so the running stable duration is the time between them. lastTime is the time after which check is
+	// not called again.
+	queryTime := opts.startTime
+	stableTime := queryTime
+	lastTime := time.Now().Add(opts.timeout)
+
+	var lastErr error
+
+	for !queryTime.After(lastTime) {
+		select {
+		case <-time.After(time.Until(queryTime)):
+			err := check(queryTime)
+			if err == nil && queryTime.Sub(stableTime) >= opts.stableDuration {
+				return nil
+			}
+
+			queryTime = queryTime.Add(opts.pollInterval)
+
+			if err != nil {
+				klog.V(90).Infof("Query assert failed: %v", err)
+
+				lastErr = err
+				stableTime = queryTime
+			}
+		case <-ctx.Done():
+			return fmt.Errorf("failed to assert query eventually: context finished: %w", ctx.Err())
+		}
+	}
+
+	if opts.timeout == 0 {
+		return fmt.Errorf("failed to assert query: %w", lastErr)
+	}
+
+	if lastErr == nil {
+		return fmt.Errorf("failed to assert query eventually: not stable for %s before timeout of %s",
+			opts.stableDuration, opts.timeout)
+	}
+
+	return fmt.Errorf("failed to assert query eventually: timeout of %s exceeded: %w", opts.timeout, lastErr)
+}
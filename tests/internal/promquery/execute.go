@@ -0,0 +1,98 @@
+package promquery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"k8s.io/klog/v2"
+)
+
+// QueryVector executes query at the provided time and returns the resulting vector. If at is zero, the current time is
+// used. Options, such as prometheusv1.WithTimeout for a server-side timeout, are passed to Prometheus. Warnings
+// returned by Prometheus are logged.
+func QueryVector(
+	ctx context.Context,
+	client prometheusv1.API,
+	query Query,
+	at time.Time,
+	options ...prometheusv1.Option) (model.Vector, error) {
+	if client == nil {
+		return nil, fmt.Errorf("cannot execute query with nil client")
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	klog.V(90).Infof("Executing query at %s: %s", at, query)
+
+	result, warnings, err := client.Query(ctx, query.String(), at, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query %s: %w", query, err)
+	}
+
+	logWarnings(warnings)
+
+	vector, ok := result.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type %s for query %s", result.Type(), query)
+	}
+
+	return vector, nil
+}
+
+// QueryMatrix executes query over the provided range and returns the resulting matrix. Options are passed to Prometheus
+// the same as in QueryVector. Warnings returned by Prometheus are logged.
+func QueryMatrix(
+	ctx context.Context,
+	client prometheusv1.API,
+	query Query,
+	queryRange prometheusv1.Range,
+	options ...prometheusv1.Option) (model.Matrix, error) {
+	if client == nil {
+		return nil, fmt.Errorf("cannot execute query range with nil client")
+	}
+
+	klog.V(90).Infof("Executing query range from %s to %s with step %s: %s",
+		queryRange.Start, queryRange.End, queryRange.Step, query)
+
+	result, warnings, err := client.QueryRange(ctx, query.String(), queryRange, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query range %s: %w", query, err)
+	}
+
+	logWarnings(warnings)
+
+	matrix, ok := result.(model.Matrix)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type %s for query range %s", result.Type(), query)
+	}
+
+	return matrix, nil
+}
+
+// IsAbsent returns whether query has no samples at the provided time, such as when a metric is no longer exported. If
+// at is zero, the current time is used. Options are passed to Prometheus the same as in QueryVector.
+func IsAbsent(
+	ctx context.Context,
+	client prometheusv1.API,
+	query Query,
+	at time.Time,
+	options ...prometheusv1.Option) (bool, error) {
+	vector, err := QueryVector(ctx, client, query, at, options...)
+	if err != nil {
+		return false, err
+	}
+
+	return len(vector) == 0, nil
+}
+
+// logWarnings logs every warning returned with a query result.
+func logWarnings(warnings prometheusv1.Warnings) {
+	for _, warning := range warnings {
+		klog.V(90).Infof("Query returned warning: %s", warning)
+	}
+}
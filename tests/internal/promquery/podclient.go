@@ -0,0 +1,209 @@
+package promquery
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	prometheusapi "github.com/prometheus/client_golang/api"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"k8s.io/klog/v2"
+)
+
+// serviceAccountTokenPath is the path of the service account token mounted in pods, used as the bearer token by
+// [PodClientWithServiceAccountToken].
+const serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// PodClient is a Prometheus API client that sends requests by running curl in a pod, such as the Prometheus or Thanos
+// pod itself. It is for suites that cannot reach the Prometheus route directly and implements prometheusapi.Client, so
+// it can be passed to prometheusv1.NewAPI and used with the rest of this package.
+type PodClient struct {
+	endpoint              *url.URL
+	serviceAccountToken   bool
+	insecureSkipTLSVerify bool
+	// exec runs command in the pod and returns its output. It is a field so unit tests can replace the pod.
+	exec func(command []string) (string, error)
+}
+
+// PodClientOption is a function that configures a PodClient.
+type PodClientOption func(*PodClient)
+
+// PodClientWithServiceAccountToken authenticates requests with the service account token of the pod, as required by
+// the Thanos querier service.
+func PodClientWithServiceAccountToken() PodClientOption {
+	return func(client *PodClient) {
+		client.serviceAccountToken = true
+	}
+}
+
+// PodClientWithInsecureSkipTLSVerify skips verification of the server certificate, for services whose certificates
+// are not trusted inside the pod.
+func PodClientWithInsecureSkipTLSVerify() PodClientOption {
+	return func(client *PodClient) {
+		client.insecureSkipTLSVerify = true
+	}
+}
+
+// NewPodClient returns a client that sends requests to address, such as http://localhost:9090, by running curl in
+// container of the pod, or its first container if container is empty. The pod must exist and the container must have
+// curl. Since pod exec does not take a context, requests only honor the deadline of their context, which is passed to
+// curl as --max-time, and not its cancellation.
+func NewPodClient(
+	podBuilder *pod.Builder, container, address string, options ...PodClientOption) (*PodClient, error) {
+	if podBuilder == nil {
+		return nil, fmt.Errorf("cannot create pod client with nil pod")
+	}
+
+	var containers []string
+	if container != "" {
+		containers = append(containers, container)
+	}
+
+	client, err := newPodClient(address, func(command []string) (string, error) {
+		output, err := podBuilder.ExecCommand(command, containers...)
+
+		return output.String(), err
+	}, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// newPodClient returns a client for address that runs curl using exec.
+func newPodClient(
+	address string, exec func(command []string) (string, error), options ...PodClientOption) (*PodClient, error) {
+	endpoint, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Prometheus address %s: %w", address, err)
+	}
+
+	endpoint.Path = strings.TrimRight(endpoint.Path, "/")
+
+	client := &PodClient{endpoint: endpoint, exec: exec}
+
+	for _, option := range options {
+		option(client)
+	}
+
+	return client, nil
+}
+
+// URL returns the URL of endpoint ep with args substituted, the same as the HTTP client of prometheusapi.
+func (client *PodClient) URL(ep string, args map[string]string) *url.URL {
+	endpointPath := path.Join(client.endpoint.Path, ep)
+
+	for arg, value := range args {
+		endpointPath = strings.ReplaceAll(endpointPath, ":"+arg, value)
+	}
+
+	endpoint := *client.endpoint
+	endpoint.Path = endpointPath
+
+	return &endpoint
+}
+
+// Do sends request by running curl in the pod and returns the response along with its body. Only the status code of
+// the response is set, since curl is not asked for the response headers. If ctx has a deadline, it limits how long
+// curl runs for. Otherwise ctx is only checked before curl starts: cancelling it does not stop a running curl, which
+// keeps running until the server responds, so callers that may cancel should also set a deadline.
+func (client *PodClient) Do(ctx context.Context, request *http.Request) (*http.Response, []byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	command, err := client.curlCommand(ctx, request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	output, err := client.exec(command)
+	if err != nil {
+		klog.V(90).Infof("Failed to send request to %s in pod: %s", request.URL, output)
+
+		return nil, nil, fmt.Errorf("failed to execute curl for %s in pod: %w", request.URL, err)
+	}
+
+	body, statusCode, err := parseCurlOutput(output)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse response from %s: %w", request.URL, err)
+	}
+
+	response := &http.Response{
+		Status:     fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode: statusCode,
+		Header:     http.Header{},
+		Request:    request,
+	}
+
+	return response, body, nil
+}
+
+// curlCommand returns the command to send request with curl. The status code is written on its own line after the
+// body so Do can separate them.
+func (client *PodClient) curlCommand(ctx context.Context, request *http.Request) ([]string, error) {
+	args := []string{"curl", "-s", "-w", `\n%{http_code}`, "-X", request.Method}
+
+	if client.insecureSkipTLSVerify {
+		args = append(args, "-k")
+	}
+
+	for name, values := range request.Header {
+		for _, value := range values {
+			args = append(args, "-H", name+": "+value)
+		}
+	}
+
+	if request.Body != nil {
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read body of request to %s: %w", request.URL, err)
+		}
+
+		args = append(args, "--data-binary", string(body))
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		maxTime := math.Ceil(time.Until(deadline).Seconds())
+		args = append(args, "--max-time", strconv.FormatFloat(max(maxTime, 1), 'f', -1, 64))
+	}
+
+	args = append(args, request.URL.String())
+
+	if !client.serviceAccountToken {
+		return args, nil
+	}
+
+	// The token is read by the shell in the pod so it never leaves the pod or appears in the logs.
+	script := fmt.Sprintf(`exec curl -H "Authorization: Bearer $(cat %s)" "$@"`, serviceAccountTokenPath)
+
+	return append([]string{"sh", "-c", script, "sh"}, args[1:]...), nil
+}
+
+// parseCurlOutput splits the output of the curl command into the body and the status code on the last line. Since
+// commands run with a TTY, line endings may include carriage returns.
+func parseCurlOutput(output string) ([]byte, int, error) {
+	output = strings.TrimRight(output, "\r\n")
+
+	separator := strings.LastIndex(output, "\n")
+	if separator < 0 {
+		return nil, 0, fmt.Errorf("no status code in output %q", output)
+	}
+
+	statusCode, err := strconv.Atoi(strings.TrimSpace(output[separator+1:]))
+	if err != nil || statusCode == 0 {
+		return nil, 0, fmt.Errorf("invalid status code in output %q", output)
+	}
+
+	return []byte(strings.TrimSuffix(output[:separator], "\r")), statusCode, nil
+}
+
+var _ prometheusapi.Client = (*PodClient)(nil)
@@ -0,0 +1,384 @@
+package promquery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	prometheusapi "github.com/prometheus/client_golang/api"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePrometheus serves the query and query_range endpoints of the Prometheus HTTP API. The values of the series are
+// computed from the query time by the functions in series, keyed by the node label of the series.
+type fakePrometheus struct {
+	series   map[string]func(time.Time) (float64, bool)
+	queries  []string
+	timeouts []string
+}
+
+// newFakeClient starts a fake Prometheus server for series and returns a client for it.
+func newFakeClient(
+	t *testing.T, series map[string]func(time.Time) (float64, bool)) (prometheusv1.API, *fakePrometheus) {
+	t.Helper()
+
+	fake := &fakePrometheus{series: series}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client, err := prometheusapi.NewClient(prometheusapi.Config{Address: server.URL})
+	require.NoError(t, err)
+
+	return prometheusv1.NewAPI(client), fake
+}
+
+// newFakeCurl returns an exec function for a PodClient that sends the requests of its curl commands to handler and
+// writes the output the same as curl in a pod with a TTY. The commands are recorded in commands.
+func newFakeCurl(t *testing.T, handler http.Handler, commands *[][]string) func([]string) (string, error) {
+	t.Helper()
+
+	return func(command []string) (string, error) {
+		*commands = append(*commands, command)
+
+		// Commands with a token are run through a shell, with the curl arguments after the script name.
+		if command[0] == "sh" {
+			command = command[3:]
+		}
+
+		var (
+			method = http.MethodGet
+			body   string
+			header = http.Header{}
+		)
+
+		for index := 1; index < len(command)-1; index++ {
+			switch command[index] {
+			case "-X":
+				index++
+				method = command[index]
+			case "-H":
+				index++
+				name, value, _ := strings.Cut(command[index], ": ")
+				header.Add(name, value)
+			case "--data-binary":
+				index++
+				body = command[index]
+			case "-w", "--max-time":
+				index++
+			}
+		}
+
+		request := httptest.NewRequest(method, command[len(command)-1], strings.NewReader(body))
+		request.Header = header
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+
+		response, err := io.ReadAll(recorder.Result().Body)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("%s\r\n%d", response, recorder.Code), nil
+	}
+}
+
+func (fake *fakePrometheus) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if err := request.ParseForm(); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	fake.queries = append(fake.queries, request.Form.Get("query"))
+	fake.timeouts = append(fake.timeouts, request.Form.Get("timeout"))
+
+	var data map[string]any
+
+	switch request.URL.Path {
+	case "/api/v1/query":
+		queryTime := parseFormTime(request.Form.Get("time"))
+		vector := []any{}
+
+		for node, value := range fake.series {
+			if sampleValue, ok := value(queryTime); ok {
+				vector = append(vector, map[string]any{
+					"metric": map[string]string{"node": node},
+					"value":  formatSample(queryTime, sampleValue),
+				})
+			}
+		}
+
+		data = map[string]any{"resultType": "vector", "result": vector}
+	case "/api/v1/query_range":
+		start := parseFormTime(request.Form.Get("start"))
+		end := parseFormTime(request.Form.Get("end"))
+		step, _ := strconv.ParseFloat(request.Form.Get("step"), 64)
+		matrix := []any{}
+
+		for node, value := range fake.series {
+			values := []any{}
+
+			for queryTime := start; !queryTime.After(end); queryTime = queryTime.Add(time.Duration(step) * time.Second) {
+				if sampleValue, ok := value(queryTime); ok {
+					values = append(values, formatSample(queryTime, sampleValue))
+				}
+			}
+
+			if len(values) > 0 {
+				matrix = append(matrix, map[string]any{"metric": map[string]string{"node": node}, "values": values})
+			}
+		}
+
+		data = map[string]any{"resultType": "matrix", "result": matrix}
+	default:
+		http.NotFound(writer, request)
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(writer).Encode(map[string]any{"status": "success", "data": data})
+}
+
+func parseFormTime(value string) time.Time {
+	seconds, _ := strconv.ParseFloat(value, 64)
+
+	return time.UnixMilli(int64(seconds * 1000))
+}
+
+func formatSample(sampleTime time.Time, value float64) []any {
+	return []any{float64(sampleTime.UnixMilli()) / 1000, strconv.FormatFloat(value, 'f', -1, 64)}
+}
+
+// constant returns a series function that always has value.
+func constant(value float64) func(time.Time) (float64, bool) {
+	return func(time.Time) (float64, bool) {
+		return value, true
+	}
+}
+
+func TestQueryString(t *testing.T) {
+	offset := Metric("openshift_ptp_offset_ns", Equals("process", "ptp4l"), OneOf("node", "worker-0", "worker.1"))
+
+	testCases := []struct {
+		name     string
+		query    Query
+		expected string
+	}{
+		{name: "metric only", query: Metric("up"), expected: "up"},
+		{
+			name:     "matchers",
+			query:    offset,
+			expected: `openshift_ptp_offset_ns{process="ptp4l",node=~"worker-0|worker\\.1"}`,
+		},
+		{
+			name:     "escaped value",
+			query:    Metric("up", NotEquals("job", `say "hi"`), NotMatches("pod", `dns-\d+`)),
+			expected: `up{job!="say \"hi\"",pod!~"dns-\\d+"}`,
+		},
+		{
+			name:     "function over range",
+			query:    MaxOverTime(offset.With(Equals("iface", "ens1f0")).Over(10 * time.Minute)),
+			expected: `max_over_time(openshift_ptp_offset_ns{process="ptp4l",node=~"worker-0|worker\\.1",iface="ens1f0"}[10m])`,
+		},
+		{
+			name:     "aggregation",
+			query:    Max(Abs(Metric("offset")), "node", "iface"),
+			expected: "max by (node, iface) (abs(offset))",
+		},
+		{name: "rate", query: Sum(Rate(Metric("requests").Over(5 * time.Minute))), expected: "sum(rate(requests[5m]))"},
+		{name: "absent", query: Absent(Expr(`up{job="ptp"}`)), expected: `absent(up{job="ptp"})`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, testCase.query.String())
+		})
+	}
+
+	// With must not modify the selector it is called on.
+	assert.Len(t, offset.Matchers, 2)
+}
+
+func TestQueryVector(t *testing.T) {
+	client, fake := newFakeClient(t, map[string]func(time.Time) (float64, bool){
+		"worker-0": constant(-5),
+		"worker-1": constant(12),
+	})
+
+	vector, err := QueryVector(context.TODO(), client, Abs(Metric("offset")), time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abs(offset)"}, fake.queries)
+	assert.ElementsMatch(t, []float64{-5, 12}, Values(vector))
+
+	values, err := ByLabel(vector, "node")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"worker-0": -5, "worker-1": 12}, values)
+
+	_, err = ByLabel(vector, "iface")
+	assert.ErrorContains(t, err, "has no label iface")
+
+	_, err = Single(vector)
+	assert.EqualError(t, err, "expected exactly 1 sample but found 2")
+
+	value, err := Single(vector[:1])
+	require.NoError(t, err)
+	assert.Contains(t, []float64{-5, 12}, value)
+
+	_, err = QueryVector(context.TODO(), client, Metric("up"), time.Time{}, prometheusv1.WithTimeout(25*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "25s"}, fake.timeouts)
+
+	_, err = QueryVector(context.TODO(), nil, Metric("up"), time.Time{})
+	assert.EqualError(t, err, "cannot execute query with nil client")
+}
+
+func TestPodClient(t *testing.T) {
+	fake := &fakePrometheus{series: map[string]func(time.Time) (float64, bool){"worker-0": constant(3)}}
+
+	var commands [][]string
+
+	client, err := newPodClient("http://localhost:9090/", newFakeCurl(t, fake, &commands))
+	require.NoError(t, err)
+
+	vector, err := QueryVector(context.TODO(), prometheusv1.NewAPI(client), Metric("up"), time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{3}, Values(vector))
+	assert.Equal(t, []string{"up"}, fake.queries)
+	require.Len(t, commands, 1)
+	assert.Equal(t, []string{"curl", "-s", "-w", `\n%{http_code}`, "-X", http.MethodPost},
+		commands[0][:6])
+	assert.Equal(t, "http://localhost:9090/api/v1/query", commands[0][len(commands[0])-1])
+
+	tokenClient, err := newPodClient("https://thanos-querier:9091", newFakeCurl(t, fake, &commands),
+		PodClientWithServiceAccountToken(), PodClientWithInsecureSkipTLSVerify())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+
+	_, err = QueryVector(ctx, prometheusv1.NewAPI(tokenClient), Metric("up"), time.Time{})
+	require.NoError(t, err)
+	require.Len(t, commands, 2)
+	assert.Equal(t, []string{"sh", "-c"}, commands[1][:2])
+	assert.Contains(t, commands[1][2], serviceAccountTokenPath)
+	assert.Subset(t, commands[1], []string{"-k", "--max-time", "30"})
+
+	failingClient, err := newPodClient("http://localhost:9090", func([]string) (string, error) {
+		return "command terminated with exit code 7", fmt.Errorf("exit code 7")
+	})
+	require.NoError(t, err)
+
+	_, err = QueryVector(context.TODO(), prometheusv1.NewAPI(failingClient), Metric("up"), time.Time{})
+	assert.ErrorContains(t, err, "failed to execute curl for http://localhost:9090/api/v1/query in pod: exit code 7")
+
+	_, _, err = parseCurlOutput("curl: (7) Failed to connect")
+	assert.ErrorContains(t, err, "no status code in output")
+}
+
+func TestAssert(t *testing.T) {
+	start := time.Now().Add(-time.Minute).Truncate(time.Second)
+	// The offset is only locked from 20 seconds after start on, so the assertion must be stable from then on.
+	client, _ := newFakeClient(t, map[string]func(time.Time) (float64, bool){
+		"worker-0": func(queryTime time.Time) (float64, bool) {
+			if queryTime.Before(start.Add(20 * time.Second)) {
+				return 500, true
+			}
+
+			return 10, true
+		},
+	})
+
+	err := Assert(context.TODO(), client, Metric("offset"), Below(5))
+	assert.ErrorContains(t, err, `returned sample {node="worker-0"} => 10`)
+	assert.ErrorContains(t, err, "which is not below 5")
+
+	err = Assert(context.TODO(), client, Metric("offset"), Below(100),
+		AssertWithStartTime(start),
+		AssertWithPollInterval(10*time.Second),
+		AssertWithStableDuration(30*time.Second))
+	assert.NoError(t, err)
+
+	err = Assert(context.TODO(), client, Metric("offset"), Between(-100, 100))
+	assert.NoError(t, err)
+}
+
+func TestAssertAbsent(t *testing.T) {
+	removed := time.Now().Add(-30 * time.Second).Truncate(time.Second)
+	client, _ := newFakeClient(t, map[string]func(time.Time) (float64, bool){
+		"worker-0": func(queryTime time.Time) (float64, bool) {
+			return 1, queryTime.Before(removed)
+		},
+	})
+
+	absent, err := IsAbsent(context.TODO(), client, Metric("up"), removed.Add(-time.Second))
+	require.NoError(t, err)
+	assert.False(t, absent)
+
+	absent, err = IsAbsent(context.TODO(), client, Metric("up"), time.Time{})
+	require.NoError(t, err)
+	assert.True(t, absent)
+
+	err = AssertAbsent(context.TODO(), client, Metric("up"),
+		AssertWithStartTime(removed.Add(-20*time.Second)), AssertWithPollInterval(10*time.Second))
+	assert.NoError(t, err)
+
+	err = AssertPresent(context.TODO(), client, Metric("up"))
+	assert.EqualError(t, err, "failed to assert query: query up returned no samples")
+}
+
+func TestAssertRange(t *testing.T) {
+	end := time.Now().Truncate(time.Second)
+	spike := end.Add(-3 * time.Minute)
+	client, _ := newFakeClient(t, map[string]func(time.Time) (float64, bool){
+		"worker-0": constant(20),
+		"worker-1": func(queryTime time.Time) (float64, bool) {
+			if queryTime.Equal(spike) {
+				return 150, true
+			}
+
+			return -20, true
+		},
+	})
+
+	offset := Abs(Metric("offset"))
+
+	err := AssertRange(context.TODO(), client, offset, AtMost(200), 10*time.Minute, AssertWithEndTime(end))
+	assert.NoError(t, err)
+
+	err = AssertRange(context.TODO(), client, offset, Below(100), 10*time.Minute, AssertWithEndTime(end))
+	assert.EqualError(t, err, fmt.Sprintf(
+		`query abs(offset) was not below 100 for 10m0s: 1 of 42 samples violated it, first 150 at %s in {node="worker-1"}`,
+		spike.UTC()))
+
+	err = AssertRange(context.TODO(), client, offset, Below(100), 2*time.Minute,
+		AssertWithEndTime(end), AssertWithStep(10*time.Second))
+	assert.NoError(t, err)
+
+	emptyClient, _ := newFakeClient(t, nil)
+	err = AssertRange(context.TODO(), emptyClient, offset, Below(100), time.Minute, AssertWithEndTime(end))
+	assert.ErrorContains(t, err, "query abs(offset) returned no samples between")
+}
+
+func TestAggregate(t *testing.T) {
+	matrix := model.Matrix{
+		{Values: []model.SamplePair{{Value: 1}, {Value: 5}}},
+		{Values: []model.SamplePair{{Value: -3}}},
+	}
+
+	aggregate := Aggregate(matrix)
+	assert.Equal(t, 3, aggregate.Count())
+	assert.Equal(t, -3.0, aggregate.Min())
+	assert.Equal(t, 5.0, aggregate.Max())
+	assert.Equal(t, []float64{1, 5}, SeriesValues(matrix[0]))
+}
@@ -0,0 +1,217 @@
+// Package promquery provides typed PromQL builders, query execution, result helpers, and polling assertions shared by
+// suites that check Prometheus metrics. Everything takes a prometheusv1.API, so RAN suites can use the client from
+// querier.CreatePrometheusAPIForCluster while other suites use a [PodClient] that runs curl in a Prometheus pod, and
+// unit tests can point one at a fake Prometheus HTTP server.
+package promquery
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+// Query is a PromQL expression. Selectors, range selectors, and the expressions returned by the builders in this
+// package all implement it.
+type Query interface {
+	String() string
+}
+
+// Expr is a PromQL expression given as a string, used for the results of the builders and for queries the builders do
+// not cover. No sanitization is done, so it should only be used with trusted queries.
+type Expr string
+
+// String returns the expression unchanged.
+func (expr Expr) String() string {
+	return string(expr)
+}
+
+// MatchOperator is the operator of a label matcher.
+type MatchOperator string
+
+const (
+	// MatchEqual selects labels exactly equal to the value.
+	MatchEqual MatchOperator = "="
+	// MatchNotEqual selects labels not equal to the value.
+	MatchNotEqual MatchOperator = "!="
+	// MatchRegexp selects labels matching the value as a regular expression.
+	MatchRegexp MatchOperator = "=~"
+	// MatchNotRegexp selects labels not matching the value as a regular expression.
+	MatchNotRegexp MatchOperator = "!~"
+)
+
+// Matcher is a label matcher in a selector, such as node="worker-0".
+type Matcher struct {
+	Label    string
+	Operator MatchOperator
+	Value    string
+}
+
+// String returns the matcher in PromQL syntax. The value is quoted and escaped, so it may contain double quotes.
+func (matcher Matcher) String() string {
+	return matcher.Label + string(matcher.Operator) + strconv.Quote(matcher.Value)
+}
+
+// Equals returns a matcher for label being exactly value.
+func Equals(label, value string) Matcher {
+	return Matcher{Label: label, Operator: MatchEqual, Value: value}
+}
+
+// NotEquals returns a matcher for label not being value.
+func NotEquals(label, value string) Matcher {
+	return Matcher{Label: label, Operator: MatchNotEqual, Value: value}
+}
+
+// Matches returns a matcher for label matching the regular expression pattern.
+func Matches(label, pattern string) Matcher {
+	return Matcher{Label: label, Operator: MatchRegexp, Value: pattern}
+}
+
+// NotMatches returns a matcher for label not matching the regular expression pattern.
+func NotMatches(label, pattern string) Matcher {
+	return Matcher{Label: label, Operator: MatchNotRegexp, Value: pattern}
+}
+
+// OneOf returns a matcher for label being exactly one of values. Unlike [Matches], the values are not regular
+// expressions and are escaped.
+func OneOf(label string, values ...string) Matcher {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, regexp.QuoteMeta(value))
+	}
+
+	return Matches(label, strings.Join(quoted, "|"))
+}
+
+// Selector is an instant vector selector, such as up{job="node-exporter"}.
+type Selector struct {
+	Metric   string
+	Matchers []Matcher
+}
+
+// Metric returns a selector for the metric name with the provided matchers.
+func Metric(name string, matchers ...Matcher) Selector {
+	return Selector{Metric: name, Matchers: matchers}
+}
+
+// With returns a copy of the selector with matchers added. The original selector is not modified, so a common selector
+// can be narrowed in several ways.
+func (selector Selector) With(matchers ...Matcher) Selector {
+	combined := make([]Matcher, 0, len(selector.Matchers)+len(matchers))
+	combined = append(combined, selector.Matchers...)
+	combined = append(combined, matchers...)
+
+	return Selector{Metric: selector.Metric, Matchers: combined}
+}
+
+// Over returns a range selector over the last window of the selector, such as for use with [Rate].
+func (selector Selector) Over(window time.Duration) RangeSelector {
+	return RangeSelector{Selector: selector, Window: window}
+}
+
+// String returns the selector in PromQL syntax. Braces are omitted when there are no matchers.
+func (selector Selector) String() string {
+	if len(selector.Matchers) == 0 {
+		return selector.Metric
+	}
+
+	matchers := make([]string, 0, len(selector.Matchers))
+	for _, matcher := range selector.Matchers {
+		matchers = append(matchers, matcher.String())
+	}
+
+	return fmt.Sprintf("%s{%s}", selector.Metric, strings.Join(matchers, ","))
+}
+
+// RangeSelector is a range vector selector, such as up[5m].
+type RangeSelector struct {
+	Selector Selector
+	Window   time.Duration
+}
+
+// String returns the range selector in PromQL syntax.
+func (rangeSelector RangeSelector) String() string {
+	return fmt.Sprintf("%s[%s]", rangeSelector.Selector, model.Duration(rangeSelector.Window))
+}
+
+// Call returns an expression calling the PromQL function with args.
+func Call(function string, args ...Query) Expr {
+	formatted := make([]string, 0, len(args))
+	for _, arg := range args {
+		formatted = append(formatted, arg.String())
+	}
+
+	return Expr(fmt.Sprintf("%s(%s)", function, strings.Join(formatted, ", ")))
+}
+
+// Rate returns the per-second rate of increase of a counter over the range.
+func Rate(rangeSelector RangeSelector) Expr {
+	return Call("rate", rangeSelector)
+}
+
+// Increase returns the increase of a counter over the range.
+func Increase(rangeSelector RangeSelector) Expr {
+	return Call("increase", rangeSelector)
+}
+
+// MaxOverTime returns the largest value of each series over the range.
+func MaxOverTime(rangeSelector RangeSelector) Expr {
+	return Call("max_over_time", rangeSelector)
+}
+
+// MinOverTime returns the smallest value of each series over the range.
+func MinOverTime(rangeSelector RangeSelector) Expr {
+	return Call("min_over_time", rangeSelector)
+}
+
+// AvgOverTime returns the average value of each series over the range.
+func AvgOverTime(rangeSelector RangeSelector) Expr {
+	return Call("avg_over_time", rangeSelector)
+}
+
+// Abs returns the absolute value of every sample of query.
+func Abs(query Query) Expr {
+	return Call("abs", query)
+}
+
+// Absent returns an expression that has a single sample with value 1 if query has no samples and no samples otherwise.
+func Absent(query Query) Expr {
+	return Call("absent", query)
+}
+
+// Sum returns the sum of the samples of query, grouped by the labels in by if any are provided.
+func Sum(query Query, by ...string) Expr {
+	return aggregate("sum", query, by)
+}
+
+// Max returns the largest sample of query, grouped by the labels in by if any are provided.
+func Max(query Query, by ...string) Expr {
+	return aggregate("max", query, by)
+}
+
+// Min returns the smallest sample of query, grouped by the labels in by if any are provided.
+func Min(query Query, by ...string) Expr {
+	return aggregate("min", query, by)
+}
+
+// Avg returns the average of the samples of query, grouped by the labels in by if any are provided.
+func Avg(query Query, by ...string) Expr {
+	return aggregate("avg", query, by)
+}
+
+// Count returns the number of samples of query, grouped by the labels in by if any are provided.
+func Count(query Query, by ...string) Expr {
+	return aggregate("count", query, by)
+}
+
+// aggregate returns an expression applying the aggregation operator to query, with a by clause if by is not empty.
+func aggregate(operator string, query Query, by []string) Expr {
+	if len(by) == 0 {
+		return Expr(fmt.Sprintf("%s(%s)", operator, query))
+	}
+
+	return Expr(fmt.Sprintf("%s by (%s) (%s)", operator, strings.Join(by, ", "), query))
+}
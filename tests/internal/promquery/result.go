@@ -0,0 +1,84 @@
+package promquery
+
+import (
+	"fmt"
+
+	"github.com/prometheus/common/model"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/stats"
+)
+
+// Values returns the values of every sample in the vector, in the same order.
+func Values(vector model.Vector) []float64 {
+	values := make([]float64, 0, len(vector))
+
+	for _, sample := range vector {
+		if sample == nil {
+			continue
+		}
+
+		values = append(values, float64(sample.Value))
+	}
+
+	return values
+}
+
+// Single returns the value of the only sample in the vector. It returns an error if the vector does not have exactly
+// one sample, such as when a selector is not specific enough.
+func Single(vector model.Vector) (float64, error) {
+	if len(vector) != 1 || vector[0] == nil {
+		return 0, fmt.Errorf("expected exactly 1 sample but found %d", len(vector))
+	}
+
+	return float64(vector[0].Value), nil
+}
+
+// ByLabel returns the values of the samples in the vector keyed by the value of label. It returns an error if a sample
+// does not have the label or two samples have the same value for it.
+func ByLabel(vector model.Vector, label string) (map[string]float64, error) {
+	values := make(map[string]float64, len(vector))
+
+	for _, sample := range vector {
+		if sample == nil {
+			continue
+		}
+
+		key, ok := sample.Metric[model.LabelName(label)]
+		if !ok {
+			return nil, fmt.Errorf("sample %s has no label %s", sample.Metric, label)
+		}
+
+		if _, exists := values[string(key)]; exists {
+			return nil, fmt.Errorf("found multiple samples with %s=%q", label, key)
+		}
+
+		values[string(key)] = float64(sample.Value)
+	}
+
+	return values, nil
+}
+
+// SeriesValues returns the values of every sample in the series, in the order they were sampled.
+func SeriesValues(series *model.SampleStream) []float64 {
+	if series == nil {
+		return nil
+	}
+
+	values := make([]float64, 0, len(series.Values))
+	for _, pair := range series.Values {
+		values = append(values, float64(pair.Value))
+	}
+
+	return values
+}
+
+// Aggregate returns the aggregate of every sample in every series of the matrix, such as to get the largest value
+// across all nodes during a range.
+func Aggregate(matrix model.Matrix) stats.Aggregate {
+	var aggregate stats.Aggregate
+
+	for _, series := range matrix {
+		aggregate.Add(SeriesValues(series)...)
+	}
+
+	return aggregate
+}
@@ -0,0 +1,153 @@
+// Package ptprecommend resolves which PTP profiles the PTP operator recommends to a node. It is shared by the suites
+// that need to know the profiles applied to a node without waiting for the operator to report them.
+package ptprecommend
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+
+	ptpv1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/ptp/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Recommendation is a single recommend of a PtpConfig along with the profile in that PtpConfig it refers to.
+type Recommendation struct {
+	// Config is the PtpConfig containing both the recommend and the profile.
+	Config *ptpv1.PtpConfig
+	// ProfileIndex is the index of the profile in Config.Spec.Profile.
+	ProfileIndex int
+	// ProfileName is the name of the profile, which is assumed to be unique across all PtpConfigs.
+	ProfileName string
+	// Priority is the priority of the recommend. Lower values take precedence. Recommends without a priority have
+	// the lowest precedence, math.MaxInt64.
+	Priority int64
+	// Match is the match criteria of the recommend.
+	Match []ptpv1.MatchRule
+}
+
+// Profile returns the profile this recommendation refers to.
+func (recommendation Recommendation) Profile() *ptpv1.PtpProfile {
+	return &recommendation.Config.Spec.Profile[recommendation.ProfileIndex]
+}
+
+// All returns the recommendations of all the provided PtpConfigs sorted by priority in ascending order. Recommends
+// without a priority are kept with the lowest precedence, the same as the PTP operator sorts them. Recommends without
+// profiles or matches are filtered out, as are recommends that do not refer to a profile in their PtpConfig. Nil
+// PtpConfigs are ignored.
+func All(configs []*ptpv1.PtpConfig) []Recommendation {
+	var recommendations []Recommendation
+
+	for _, config := range configs {
+		if config == nil {
+			continue
+		}
+
+		for _, recommend := range config.Spec.Recommend {
+			if recommend.Profile == nil || len(recommend.Match) == 0 {
+				continue
+			}
+
+			profileIndex := slices.IndexFunc(config.Spec.Profile, func(profile ptpv1.PtpProfile) bool {
+				return profile.Name != nil && *profile.Name == *recommend.Profile
+			})
+
+			// If the recommend does not match any profile, it is ignored.
+			if profileIndex == -1 {
+				continue
+			}
+
+			priority := int64(math.MaxInt64)
+			if recommend.Priority != nil {
+				priority = *recommend.Priority
+			}
+
+			recommendations = append(recommendations, Recommendation{
+				Config:       config,
+				ProfileIndex: profileIndex,
+				ProfileName:  *recommend.Profile,
+				Priority:     priority,
+				Match:        recommend.Match,
+			})
+		}
+	}
+
+	// A stable sort keeps recommends of the same priority in the order they were defined.
+	slices.SortStableFunc(recommendations, func(a, b Recommendation) int {
+		return cmp.Compare(a.Priority, b.Priority)
+	})
+
+	return recommendations
+}
+
+// ForNode returns the recommendations that apply to node. The provided recommendations must be sorted by priority in
+// ascending order, as returned by All.
+//
+// The algorithm is the same as the one used in the PTP operator
+// (https://github.com/openshift/ptp-operator/blob/main/controllers/recommend.go):
+//   - Loop through all recommendations in order and ignore those that do not match the node.
+//   - The lowest priority that matches the node is used to determine which profiles are recommended.
+//   - If a recommendation with a different priority is found, the loop is broken and no further recommendations are
+//     considered.
+func ForNode(node *corev1.Node, recommendations []Recommendation) []Recommendation {
+	var (
+		forNode  []Recommendation
+		priority = int64(-1)
+	)
+
+	for _, recommendation := range recommendations {
+		if !NodeMatches(node, recommendation.Match) {
+			continue
+		}
+
+		if priority >= 0 && recommendation.Priority != priority {
+			break
+		}
+
+		priority = recommendation.Priority
+		forNode = append(forNode, recommendation)
+	}
+
+	return forNode
+}
+
+// NodeMatches returns whether node matches any of the provided match rules. A rule matches if its nodeName is the
+// name of the node or its nodeLabel is the key of one of the node labels, regardless of the label value. A nodeLabel
+// of the form key=value only matches if the node has the label with that value. Since label keys cannot contain an
+// equals sign, this does not change which nodes match a nodeLabel the PTP operator accepts.
+func NodeMatches(node *corev1.Node, rules []ptpv1.MatchRule) bool {
+	if node == nil {
+		return false
+	}
+
+	for _, rule := range rules {
+		if rule.NodeName != nil && *rule.NodeName == node.Name {
+			return true
+		}
+
+		if rule.NodeLabel != nil && labelMatches(node.Labels, *rule.NodeLabel) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// labelMatches returns whether labels has the key of nodeLabel or, if nodeLabel is of the form key=value, whether it
+// has the key with that value. Whitespace around the key and value is ignored.
+func labelMatches(labels map[string]string, nodeLabel string) bool {
+	nodeLabel = strings.TrimSpace(nodeLabel)
+	if nodeLabel == "" {
+		return false
+	}
+
+	key, value, hasValue := strings.Cut(nodeLabel, "=")
+	actual, ok := labels[strings.TrimSpace(key)]
+
+	if !hasValue {
+		return ok
+	}
+
+	return ok && actual == strings.TrimSpace(value)
+}
@@ -0,0 +1,121 @@
+package ptprecommend
+
+import (
+	"math"
+	"testing"
+
+	ptpv1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/ptp/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestForNode(t *testing.T) {
+	config := &ptpv1.PtpConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "openshift-ptp"},
+		Spec: ptpv1.PtpConfigSpec{
+			Profile: []ptpv1.PtpProfile{{Name: ptr.To("first")}, {Name: ptr.To("second")}, {Name: ptr.To("third")}},
+			Recommend: []ptpv1.PtpRecommend{
+				{
+					Profile:  ptr.To("third"),
+					Priority: ptr.To[int64](10),
+					Match:    []ptpv1.MatchRule{{NodeName: ptr.To("worker-0")}},
+				},
+				{
+					Profile:  ptr.To("first"),
+					Priority: ptr.To[int64](4),
+					Match:    []ptpv1.MatchRule{{NodeLabel: ptr.To("ptp/slave")}},
+				},
+				{
+					Profile:  ptr.To("second"),
+					Priority: ptr.To[int64](4),
+					Match:    []ptpv1.MatchRule{{NodeName: ptr.To("worker-0")}},
+				},
+				{
+					Profile:  ptr.To("second"),
+					Priority: ptr.To[int64](6),
+					Match:    []ptpv1.MatchRule{{NodeLabel: ptr.To("ptp/role = grandmaster")}},
+				},
+				// Recommends without a priority have the lowest precedence.
+				{Profile: ptr.To("first"), Match: []ptpv1.MatchRule{{NodeName: ptr.To("worker-1")}}},
+				// Recommends for a missing profile are ignored.
+				{
+					Profile:  ptr.To("missing"),
+					Priority: ptr.To[int64](1),
+					Match:    []ptpv1.MatchRule{{NodeName: ptr.To("worker-1")}},
+				},
+			},
+		},
+	}
+
+	recommendations := All([]*ptpv1.PtpConfig{config, nil})
+	assert.Len(t, recommendations, 5)
+	assert.Equal(t, int64(math.MaxInt64), recommendations[len(recommendations)-1].Priority)
+
+	testCases := []struct {
+		name     string
+		node     *corev1.Node
+		expected []string
+	}{
+		{
+			name: "lowest priority matches by name and label",
+			node: &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name: "worker-0", Labels: map[string]string{"ptp/slave": ""},
+			}},
+			expected: []string{"first", "second"},
+		},
+		{
+			name: "label value is ignored",
+			node: &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name: "worker-2", Labels: map[string]string{"ptp/slave": "false"},
+			}},
+			expected: []string{"first"},
+		},
+		{
+			name: "label with value matches",
+			node: &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name: "worker-3", Labels: map[string]string{"ptp/role": "grandmaster"},
+			}},
+			expected: []string{"second"},
+		},
+		{
+			name: "label with different value does not match",
+			node: &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name: "worker-4", Labels: map[string]string{"ptp/role": "slave"},
+			}},
+			expected: nil,
+		},
+		{
+			name:     "recommend without priority",
+			node:     &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}},
+			expected: []string{"first"},
+		},
+		{
+			name: "recommend without priority is overridden",
+			node: &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name: "worker-1", Labels: map[string]string{"ptp/role": "grandmaster"},
+			}},
+			expected: []string{"second"},
+		},
+		{
+			name:     "no matches",
+			node:     &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-5"}},
+			expected: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var names []string
+
+			for _, recommendation := range ForNode(testCase.node, recommendations) {
+				assert.Equal(t, recommendation.ProfileName, *recommendation.Profile().Name)
+
+				names = append(names, recommendation.ProfileName)
+			}
+
+			assert.Equal(t, testCase.expected, names)
+		})
+	}
+}
@@ -0,0 +1,541 @@
+package reporter
+
+import (
+	"archive/tar"
+	"cmp"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clusterversion"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// ManifestFileName is the name of the manifest inside every bundle.
+	ManifestFileName = "manifest.json"
+	// IndexFileName is the name of the index in the dump directory of a suite. Each line is the JSON manifest of one
+	// bundle, appended when the bundle is finished.
+	IndexFileName = "index.jsonl"
+	// ArchiveExtension is the extension of compressed bundles.
+	ArchiveExtension = ".tar.gz"
+	// ManifestVersion is incremented whenever a field of the manifest changes meaning or is removed.
+	ManifestVersion = 1
+
+	// specIDLabelPrefix is the prefix of the label reportxml.ID adds alongside the bare ID.
+	specIDLabelPrefix = "test_id:"
+	// specHashLength is the number of hex characters of the spec text hash used in bundle names.
+	specHashLength = 12
+	// truncationNotice is written at the start of files that were truncated to fit the namespace size limit.
+	truncationNotice = "[truncated by eco-gotests reporter, %d of %d bytes kept]\n"
+)
+
+// BundleManifest describes a failure artifact bundle. It is written to ManifestFileName inside the bundle and appended
+// to IndexFileName in the dump directory so tools can find bundles by ID, labels, or spec text without opening them.
+type BundleManifest struct {
+	Version int `json:"version"`
+	// Bundle is the name of the bundle directory, or archive if compressed, relative to the dump directory.
+	Bundle   string        `json:"bundle"`
+	Suite    string        `json:"suite"`
+	SpecText string        `json:"specText"`
+	ID       string        `json:"id,omitempty"`
+	Labels   []string      `json:"labels,omitempty"`
+	State    string        `json:"state"`
+	Failure  BundleFailure `json:"failure"`
+	// Cluster is the cluster of a bundle dumped from a single cluster. Bundles dumped from several clusters use
+	// Clusters instead, keyed by the role of each cluster, which is also the directory its artifacts are in.
+	Cluster  BundleCluster            `json:"cluster"`
+	Clusters map[string]BundleCluster `json:"clusters,omitempty"`
+	// StartTime and EndTime are those of the spec, while DumpTime is when the bundle was created.
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	DumpTime  time.Time `json:"dumpTime"`
+	// Collectors lists the outcome of every collector that ran, in the order they ran.
+	Collectors []CollectorResult `json:"collectors,omitempty"`
+	// Files lists every file in the bundle other than the manifest, sorted by path.
+	Files []BundleFile `json:"files"`
+}
+
+// BundleFailure is the failure of the spec a bundle was dumped for.
+type BundleFailure struct {
+	Message string `json:"message"`
+	// Location is the file and line of the failed assertion.
+	Location string `json:"location"`
+	// NodeType is the type of the node that failed, such as It or BeforeEach, and NodeLocation is where it is defined.
+	NodeType     string `json:"nodeType"`
+	NodeLocation string `json:"nodeLocation"`
+}
+
+// BundleCluster identifies the cluster a bundle was dumped from. Fields that could not be determined are left empty.
+type BundleCluster struct {
+	// Name is the name of the cluster of the current context in the kubeconfig.
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+	ID      string `json:"id,omitempty"`
+}
+
+// BundleFile is a single dumped file in a bundle.
+type BundleFile struct {
+	// Path is relative to the root of the bundle.
+	Path string `json:"path"`
+	// Cluster is the role of the cluster the file was dumped from, or empty if the bundle has a single cluster or the
+	// file does not belong to any cluster.
+	Cluster string `json:"cluster,omitempty"`
+	// Namespace is the namespace the file was dumped from, or empty for cluster scoped files such as nodes and events.
+	Namespace string `json:"namespace,omitempty"`
+	Size      int64  `json:"size"`
+	// OriginalSize is only set if the file was truncated to fit the namespace size limit.
+	OriginalSize int64 `json:"originalSize,omitempty"`
+}
+
+// Bundle is a failure artifact bundle being created in the dump directory of a suite.
+type Bundle struct {
+	dumpDir  string
+	manifest BundleManifest
+	// clusterNamespaces maps the role of every cluster added using AddCluster to the namespaces dumped from it.
+	clusterNamespaces map[string]map[string]string
+}
+
+// NewBundle creates the directory for a bundle of the failed spec in dumpDir. The bundle name is derived from the
+// reportxml.ID and a hash of the spec text, so it is stable across runs and safe to use as a directory name. If a
+// bundle with the same name already exists, such as when a spec is retried, a numeric suffix is added.
+func NewBundle(dumpDir, suite string, report types.SpecReport, cluster BundleCluster) (*Bundle, error) {
+	bundle := &Bundle{
+		dumpDir: dumpDir,
+		manifest: BundleManifest{
+			Version:   ManifestVersion,
+			Suite:     suite,
+			SpecText:  report.FullText(),
+			ID:        specID(report),
+			Labels:    report.Labels(),
+			State:     report.State.String(),
+			Cluster:   cluster,
+			StartTime: report.StartTime,
+			EndTime:   report.EndTime,
+			DumpTime:  time.Now(),
+			Failure: BundleFailure{
+				Message:      report.Failure.Message,
+				Location:     report.Failure.Location.String(),
+				NodeType:     report.Failure.FailureNodeType.String(),
+				NodeLocation: report.Failure.FailureNodeLocation.String(),
+			},
+		},
+	}
+
+	err := os.MkdirAll(dumpDir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	baseName := bundleName(bundle.manifest.ID, bundle.manifest.SpecText)
+	bundle.manifest.Bundle = baseName
+
+	for attempt := 2; ; attempt++ {
+		_, err = os.Stat(bundle.Dir() + ArchiveExtension)
+		if errors.Is(err, fs.ErrNotExist) {
+			err = os.Mkdir(bundle.Dir(), 0755)
+			if err == nil {
+				return bundle, nil
+			}
+		}
+
+		if err != nil && !errors.Is(err, fs.ErrExist) {
+			return nil, err
+		}
+
+		bundle.manifest.Bundle = fmt.Sprintf("%s_%d", baseName, attempt)
+	}
+}
+
+// Name returns the name of the bundle directory, which is also the subpath passed to the k8sreporter.
+func (bundle *Bundle) Name() string {
+	return bundle.manifest.Bundle
+}
+
+// AddCluster records a cluster the bundle contains artifacts from. The artifacts of the cluster are expected in the
+// directory of the bundle named after role, and the files in it are attributed to the namespaces dumped from that
+// cluster rather than those passed to Finish.
+func (bundle *Bundle) AddCluster(role string, cluster BundleCluster, namespaces map[string]string) {
+	if bundle.manifest.Clusters == nil {
+		bundle.manifest.Clusters = make(map[string]BundleCluster)
+		bundle.clusterNamespaces = make(map[string]map[string]string)
+	}
+
+	bundle.manifest.Clusters[role] = cluster
+	bundle.clusterNamespaces[role] = namespaces
+}
+
+// Dir returns the path of the bundle directory.
+func (bundle *Bundle) Dir() string {
+	return filepath.Join(bundle.dumpDir, bundle.manifest.Bundle)
+}
+
+// Finish lists the dumped files, truncates the files of each namespace in namespaces, or those of the cluster the file
+// is from, to fit namespaceSizeLimit bytes, writes the manifest, optionally compresses the bundle into a single
+// archive, and appends the manifest to the index. A namespaceSizeLimit of 0 means no limit. The returned manifest is
+// the one added to the index.
+func (bundle *Bundle) Finish(
+	namespaces map[string]string, namespaceSizeLimit int64, compress bool) (*BundleManifest, error) {
+	files, err := listBundleFiles(bundle.Dir(), namespaces, bundle.clusterNamespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	if namespaceSizeLimit > 0 {
+		err = limitNamespaceSizes(bundle.Dir(), files, namespaceSizeLimit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bundle.manifest.Files = files
+
+	manifestBytes, err := json.MarshalIndent(bundle.manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.WriteFile(filepath.Join(bundle.Dir(), ManifestFileName), manifestBytes, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	if compress {
+		err = archiveDir(bundle.Dir(), bundle.Dir()+ArchiveExtension)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress bundle %s: %w", bundle.Dir(), err)
+		}
+
+		err = os.RemoveAll(bundle.Dir())
+		if err != nil {
+			return nil, err
+		}
+
+		bundle.manifest.Bundle += ArchiveExtension
+	}
+
+	err = appendToIndex(filepath.Join(bundle.dumpDir, IndexFileName), bundle.manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add bundle %s to index: %w", bundle.manifest.Bundle, err)
+	}
+
+	return &bundle.manifest, nil
+}
+
+// ReadIndex returns the manifests of every bundle in the index of dumpDir, in the order they were finished. It returns
+// an empty slice if the index does not exist yet.
+func ReadIndex(dumpDir string) ([]BundleManifest, error) {
+	indexBytes, err := os.ReadFile(filepath.Join(dumpDir, IndexFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return []BundleManifest{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := []BundleManifest{}
+
+	for line := range strings.SplitSeq(string(indexBytes), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		var manifest BundleManifest
+
+		err = json.Unmarshal([]byte(line), &manifest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse index of %s: %w", dumpDir, err)
+		}
+
+		manifests = append(manifests, manifest)
+	}
+
+	return manifests, nil
+}
+
+// GetBundleCluster returns the name, version, and ID of the cluster of kubeconfig, or of the KUBECONFIG environment
+// variable if it is empty. Any errors are only logged since the bundle is still useful without them.
+func GetBundleCluster(kubeconfig string) BundleCluster {
+	var cluster BundleCluster
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+
+	rawConfig, err := loadingRules.Load()
+	if err != nil {
+		klog.V(100).Infof("Failed to load kubeconfig for bundle cluster name: %v", err)
+	} else if kubeContext, ok := rawConfig.Contexts[rawConfig.CurrentContext]; ok {
+		cluster.Name = kubeContext.Cluster
+	}
+
+	client := clients.New(kubeconfig)
+	if client == nil {
+		klog.V(100).Infof("Failed to create client for bundle cluster version")
+
+		return cluster
+	}
+
+	clusterVersion, err := clusterversion.Pull(client)
+	if err != nil || clusterVersion.Object == nil {
+		klog.V(100).Infof("Failed to pull ClusterVersion for bundle cluster version: %v", err)
+
+		return cluster
+	}
+
+	cluster.Version = clusterVersion.Object.Status.Desired.Version
+	cluster.ID = string(clusterVersion.Object.Spec.ClusterID)
+
+	return cluster
+}
+
+// specID returns the reportxml.ID of the spec or an empty string if it has none.
+func specID(report types.SpecReport) string {
+	for _, label := range report.Labels() {
+		if id, found := strings.CutPrefix(label, specIDLabelPrefix); found {
+			return id
+		}
+	}
+
+	return ""
+}
+
+// bundleName returns the name of the bundle for a spec. The ID comes first so bundles of the same test case sort
+// together, followed by a hash of the spec text to tell apart specs sharing an ID, such as entries of a table.
+func bundleName(id, specText string) string {
+	hash := sha256.Sum256([]byte(specText))
+	hashString := hex.EncodeToString(hash[:])[:specHashLength]
+
+	if id == "" {
+		return "spec_" + hashString
+	}
+
+	return cleanFileName(id) + "_" + hashString
+}
+
+// cleanFileName replaces the characters in name that are not safe to use in a file name.
+func cleanFileName(name string) string {
+	return strings.Map(func(char rune) rune {
+		switch {
+		case char >= 'a' && char <= 'z', char >= 'A' && char <= 'Z', char >= '0' && char <= '9', char == '-', char == '.':
+			return char
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+// listBundleFiles returns every file under bundleDir, sorted by path, along with the cluster and namespace each one
+// was dumped from. Files under the directory of a cluster in clusterNamespaces belong to that cluster and are matched
+// against its namespaces, while other files are matched against namespaces. The k8sreporter names pod files
+// <namespace>_<pod>_<kind>.log and CR files <kind>_<namespace>.log, and neither namespaces nor kinds contain
+// underscores, so the namespace is the first or last element of the name.
+func listBundleFiles(
+	bundleDir string, namespaces map[string]string, clusterNamespaces map[string]map[string]string) ([]BundleFile, error) {
+	var files []BundleFile
+
+	err := filepath.WalkDir(bundleDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(bundleDir, path)
+		if err != nil {
+			return err
+		}
+
+		if relPath == ManifestFileName {
+			return nil
+		}
+
+		elems := strings.Split(strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())), "_")
+		file := BundleFile{Path: relPath, Size: info.Size()}
+		fileNamespaces := namespaces
+
+		if role, _, found := strings.Cut(filepath.ToSlash(relPath), "/"); found {
+			if roleNamespaces, ok := clusterNamespaces[role]; ok {
+				file.Cluster = role
+				fileNamespaces = roleNamespaces
+			}
+		}
+
+		if _, ok := fileNamespaces[elems[0]]; ok {
+			file.Namespace = elems[0]
+		} else if _, ok := fileNamespaces[elems[len(elems)-1]]; ok {
+			file.Namespace = elems[len(elems)-1]
+		}
+
+		files = append(files, file)
+
+		return nil
+	})
+
+	return files, err
+}
+
+// limitNamespaceSizes truncates the files of each namespace of each cluster so their total size is at most limit bytes.
+// Smaller files are kept whole first, so specs and CRs survive while the largest logs are cut. Truncated files keep
+// their end, since that is where the failure is, and are updated in files.
+func limitNamespaceSizes(bundleDir string, files []BundleFile, limit int64) error {
+	remaining := make(map[string]int64)
+	order := make([]int, 0, len(files))
+
+	for index, file := range files {
+		if file.Namespace != "" {
+			remaining[namespaceKey(file)] = limit
+			order = append(order, index)
+		}
+	}
+
+	slices.SortStableFunc(order, func(indexA, indexB int) int {
+		return cmp.Compare(files[indexA].Size, files[indexB].Size)
+	})
+
+	for _, index := range order {
+		file := &files[index]
+		budget := remaining[namespaceKey(*file)]
+
+		if file.Size <= budget {
+			remaining[namespaceKey(*file)] -= file.Size
+
+			continue
+		}
+
+		klog.V(100).Infof("Truncating %s from %d to %d bytes to fit the size limit of namespace %s",
+			file.Path, file.Size, budget, file.Namespace)
+
+		err := truncateFileTail(filepath.Join(bundleDir, file.Path), file.Size, budget)
+		if err != nil {
+			return err
+		}
+
+		file.OriginalSize = file.Size
+		file.Size = budget
+		remaining[namespaceKey(*file)] = 0
+	}
+
+	return nil
+}
+
+// namespaceKey returns the key of the namespace of file that the size limit is applied to.
+func namespaceKey(file BundleFile) string {
+	return file.Cluster + "/" + file.Namespace
+}
+
+// truncateFileTail replaces the file at path with a notice followed by the end of its contents, so that the new file
+// is at most keep bytes. If keep is too small for the notice, the file is emptied.
+func truncateFileTail(path string, size, keep int64) error {
+	notice := fmt.Sprintf(truncationNotice, keep, size)
+	tailSize := keep - int64(len(notice))
+
+	if tailSize <= 0 {
+		return os.Truncate(path, 0)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	tail := make([]byte, tailSize)
+
+	_, err = file.ReadAt(tail, size-tailSize)
+	_ = file.Close()
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append([]byte(notice), tail...), 0644)
+}
+
+// archiveDir writes a gzip-compressed tar archive of dir to archivePath. Paths in the archive start with the base name
+// of dir so the archive extracts to a single directory.
+func archiveDir(dir, archivePath string) error {
+	archiveFile, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+
+	gzipWriter := gzip.NewWriter(archiveFile)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(filepath.Dir(dir), path)
+		if err != nil {
+			return err
+		}
+
+		header.Name = filepath.ToSlash(relPath)
+
+		err = tarWriter.WriteHeader(header)
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			_ = file.Close()
+		}()
+
+		_, err = io.Copy(tarWriter, file)
+
+		return err
+	})
+
+	return errors.Join(err, tarWriter.Close(), gzipWriter.Close(), archiveFile.Close())
+}
+
+// appendToIndex appends the manifest as a single line to the index at indexPath, creating it if needed. Each manifest
+// is written with one call so that parallel processes appending to the same index do not interleave lines.
+func appendToIndex(indexPath string, manifest BundleManifest) error {
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+
+	indexFile, err := os.OpenFile(indexPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	_, err = indexFile.Write(append(manifestBytes, '\n'))
+
+	return errors.Join(err, indexFile.Close())
+}
@@ -0,0 +1,214 @@
+package reporter
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var testNamespaces = map[string]string{"test-ns": "", "other-ns": ""}
+
+func newTestSpecReport(labels ...string) types.SpecReport {
+	return types.SpecReport{
+		ContainerHierarchyTexts:  []string{"Bundle"},
+		ContainerHierarchyLabels: [][]string{labels},
+		LeafNodeText:             "dumps files",
+		State:                    types.SpecStateFailed,
+		Failure: types.Failure{
+			Message:         "expected failure",
+			Location:        types.CodeLocation{FileName: "bundle_test.go", LineNumber: 10},
+			FailureNodeType: types.NodeTypeIt,
+		},
+	}
+}
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, contents := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0644)
+		assert.NoError(t, err)
+	}
+}
+
+func TestNewBundleName(t *testing.T) {
+	dumpDir := t.TempDir()
+
+	first, err := NewBundle(dumpDir, "suite", newTestSpecReport("12345", "test_id:12345"), BundleCluster{})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(first.Name(), "12345_"))
+	assert.DirExists(t, first.Dir())
+
+	second, err := NewBundle(dumpDir, "suite", newTestSpecReport("12345", "test_id:12345"), BundleCluster{})
+	assert.NoError(t, err)
+	assert.Equal(t, first.Name()+"_2", second.Name())
+
+	noID, err := NewBundle(dumpDir, "suite", newTestSpecReport(), BundleCluster{})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(noID.Name(), "spec_"))
+}
+
+func TestBundleFinish(t *testing.T) {
+	testCases := []struct {
+		name          string
+		sizeLimit     int64
+		expectedFiles []BundleFile
+	}{
+		{
+			name:      "no limit",
+			sizeLimit: 0,
+			expectedFiles: []BundleFile{
+				{Path: "Pod_other-ns.log", Namespace: "other-ns", Size: 10},
+				{Path: "events.log", Size: 100},
+				{Path: "test-ns_pod_pods_logs.log", Namespace: "test-ns", Size: 100},
+				{Path: "test-ns_pod_pods_specs.log", Namespace: "test-ns", Size: 20},
+			},
+		},
+		{
+			name:      "limit truncates largest file",
+			sizeLimit: 80,
+			expectedFiles: []BundleFile{
+				{Path: "Pod_other-ns.log", Namespace: "other-ns", Size: 10},
+				{Path: "events.log", Size: 100},
+				{Path: "test-ns_pod_pods_logs.log", Namespace: "test-ns", Size: 60, OriginalSize: 100},
+				{Path: "test-ns_pod_pods_specs.log", Namespace: "test-ns", Size: 20},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dumpDir := t.TempDir()
+
+			bundle, err := NewBundle(dumpDir, "suite", newTestSpecReport("12345", "test_id:12345"), BundleCluster{})
+			assert.NoError(t, err)
+
+			writeTestFiles(t, bundle.Dir(), map[string]string{
+				"test-ns_pod_pods_logs.log":  strings.Repeat("l", 99) + "\n",
+				"test-ns_pod_pods_specs.log": strings.Repeat("s", 20),
+				"Pod_other-ns.log":           strings.Repeat("p", 10),
+				"events.log":                 strings.Repeat("e", 100),
+			})
+
+			manifest, err := bundle.Finish(testNamespaces, testCase.sizeLimit, false)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedFiles, manifest.Files)
+			assert.Equal(t, "12345", manifest.ID)
+			assert.Equal(t, "bundle_test.go:10", manifest.Failure.Location)
+			assert.FileExists(t, filepath.Join(bundle.Dir(), ManifestFileName))
+
+			for _, file := range manifest.Files {
+				info, err := os.Stat(filepath.Join(bundle.Dir(), file.Path))
+				assert.NoError(t, err)
+				assert.Equal(t, file.Size, info.Size())
+			}
+
+			if testCase.sizeLimit > 0 {
+				contents, err := os.ReadFile(filepath.Join(bundle.Dir(), "test-ns_pod_pods_logs.log"))
+				assert.NoError(t, err)
+				assert.True(t, strings.HasPrefix(string(contents), "[truncated"))
+				assert.True(t, strings.HasSuffix(string(contents), "l\n"))
+			}
+
+			index, err := ReadIndex(dumpDir)
+			assert.NoError(t, err)
+			assert.Len(t, index, 1)
+			assert.Equal(t, manifest.Bundle, index[0].Bundle)
+			assert.Equal(t, manifest.Files, index[0].Files)
+			assert.True(t, manifest.DumpTime.Equal(index[0].DumpTime))
+		})
+	}
+}
+
+func TestBundleFinishCompress(t *testing.T) {
+	dumpDir := t.TempDir()
+
+	bundle, err := NewBundle(dumpDir, "suite", newTestSpecReport(), BundleCluster{Name: "test-cluster"})
+	assert.NoError(t, err)
+
+	writeTestFiles(t, bundle.Dir(), map[string]string{"nodes.log": "nodes"})
+
+	manifest, err := bundle.Finish(testNamespaces, 0, true)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(manifest.Bundle, ArchiveExtension))
+	assert.NoDirExists(t, bundle.Dir())
+
+	archiveFile, err := os.Open(filepath.Join(dumpDir, manifest.Bundle))
+	assert.NoError(t, err)
+
+	defer func() {
+		_ = archiveFile.Close()
+	}()
+
+	gzipReader, err := gzip.NewReader(archiveFile)
+	assert.NoError(t, err)
+
+	var names []string
+
+	tarReader := tar.NewReader(gzipReader)
+
+	for header, err := tarReader.Next(); err == nil; header, err = tarReader.Next() {
+		names = append(names, header.Name)
+	}
+
+	archiveRoot := strings.TrimSuffix(manifest.Bundle, ArchiveExtension)
+	assert.ElementsMatch(t, []string{archiveRoot, archiveRoot + "/" + ManifestFileName, archiveRoot + "/nodes.log"}, names)
+
+	// A later bundle for the same spec must not overwrite the archive.
+	next, err := NewBundle(dumpDir, "suite", newTestSpecReport(), BundleCluster{})
+	assert.NoError(t, err)
+	assert.Equal(t, archiveRoot+"_2", next.Name())
+}
+
+func TestBundleFinishClusters(t *testing.T) {
+	dumpDir := t.TempDir()
+
+	bundle, err := NewBundle(dumpDir, "suite", newTestSpecReport(), BundleCluster{})
+	assert.NoError(t, err)
+
+	bundle.AddCluster("hub", BundleCluster{Name: "hub-cluster"}, map[string]string{"hub-ns": ""})
+	bundle.AddCluster("spoke1", BundleCluster{Name: "spoke-cluster"}, map[string]string{"test-ns": ""})
+
+	for _, role := range []string{"hub", "spoke1"} {
+		err = os.MkdirAll(filepath.Join(bundle.Dir(), role, CRCollectorName), 0755)
+		assert.NoError(t, err)
+	}
+
+	writeTestFiles(t, filepath.Join(bundle.Dir(), "hub", CRCollectorName), map[string]string{
+		"hub-ns_pod_pods_logs.log": strings.Repeat("h", 100),
+		"events.log":               strings.Repeat("e", 10),
+	})
+	writeTestFiles(t, filepath.Join(bundle.Dir(), "spoke1", CRCollectorName), map[string]string{
+		"test-ns_pod_pods_logs.log": strings.Repeat("l", 100),
+		"hub-ns_pod_pods_logs.log":  strings.Repeat("x", 10),
+	})
+
+	manifest, err := bundle.Finish(nil, 80, false)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]BundleCluster{
+		"hub":    {Name: "hub-cluster"},
+		"spoke1": {Name: "spoke-cluster"},
+	}, manifest.Clusters)
+	assert.Equal(t, []BundleFile{
+		{Path: "hub/resources/events.log", Cluster: "hub", Size: 10},
+		{Path: "hub/resources/hub-ns_pod_pods_logs.log", Cluster: "hub", Namespace: "hub-ns", Size: 80, OriginalSize: 100},
+		// The spoke does not dump hub-ns, so the file is not attributed to it even though the name matches.
+		{Path: "spoke1/resources/hub-ns_pod_pods_logs.log", Cluster: "spoke1", Size: 10},
+		{
+			Path: "spoke1/resources/test-ns_pod_pods_logs.log", Cluster: "spoke1", Namespace: "test-ns", Size: 80,
+			OriginalSize: 100,
+		},
+	}, manifest.Files)
+}
+
+func TestReadIndexMissing(t *testing.T) {
+	index, err := ReadIndex(t.TempDir())
+	assert.NoError(t, err)
+	assert.Empty(t, index)
+}
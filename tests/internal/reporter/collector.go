@@ -0,0 +1,222 @@
+package reporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"k8s.io/klog/v2"
+)
+
+// DefaultCollectorTimeout is the timeout of collectors registered without one. It is longer than the k8sreporter takes
+// to time out on an unreachable cluster.
+const DefaultCollectorTimeout = 5 * time.Minute
+
+// FailedSpec is the spec a Collector gathers diagnostics for.
+type FailedSpec struct {
+	Report types.SpecReport
+	// Suite is the test suite file passed to ReportIfFailed.
+	Suite string
+	// Kubeconfig is the path of the kubeconfig of the cluster being reported on. It is empty for the cluster of the
+	// KUBECONFIG environment variable.
+	Kubeconfig string
+	// Dir is the directory reserved for the collector. It exists when Collect is called and is moved into the failure
+	// bundle once Collect returns.
+	Dir string
+}
+
+// Collector gathers diagnostics about a failed spec into the directory of the FailedSpec. Collectors should return
+// once ctx is done, but a collector that does not is abandoned when its timeout expires so the other collectors still
+// run. The files of an abandoned collector are discarded rather than added to the bundle.
+type Collector interface {
+	Collect(ctx context.Context, spec FailedSpec) error
+}
+
+// CollectorFunc adapts a function to the Collector interface.
+type CollectorFunc func(ctx context.Context, spec FailedSpec) error
+
+// Collect calls collectorFunc.
+func (collectorFunc CollectorFunc) Collect(ctx context.Context, spec FailedSpec) error {
+	return collectorFunc(ctx, spec)
+}
+
+// CollectorResult is the outcome of running a single collector, recorded in the manifest of the failure bundle.
+type CollectorResult struct {
+	Name string `json:"name"`
+	// Cluster is the role of the cluster the collector ran against, if it was run for a single cluster of several.
+	Cluster  string `json:"cluster,omitempty"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+	TimedOut bool   `json:"timedOut,omitempty"`
+}
+
+// namedCollector is a Collector along with the name and timeout it was registered with.
+type namedCollector struct {
+	name      string
+	timeout   time.Duration
+	collector Collector
+}
+
+var (
+	collectorsMutex sync.Mutex
+	// collectors are the collectors registered using RegisterCollector, in the order they were registered.
+	collectors []namedCollector
+)
+
+// RegisterCollector adds a collector run by ReportIfFailed and ReportIfFailedOnCluster for every failed spec, after the
+// requested CRs are dumped. The name is used as the directory of the collector in the failure bundle, and registering
+// a name again replaces the previous collector in place. A timeout of 0 uses DefaultCollectorTimeout.
+//
+// Suites typically register their collectors in BeforeSuite, or in an init function of a package shared by several
+// suites.
+func RegisterCollector(name string, timeout time.Duration, collector Collector) {
+	collectorsMutex.Lock()
+	defer collectorsMutex.Unlock()
+
+	if timeout <= 0 {
+		timeout = DefaultCollectorTimeout
+	}
+
+	registered := namedCollector{name: cleanFileName(name), timeout: timeout, collector: collector}
+
+	index := slices.IndexFunc(collectors, func(existing namedCollector) bool {
+		return existing.name == registered.name
+	})
+
+	if index >= 0 {
+		collectors[index] = registered
+
+		return
+	}
+
+	collectors = append(collectors, registered)
+}
+
+// UnregisterCollector removes the collector with the provided name, if there is one.
+func UnregisterCollector(name string) {
+	collectorsMutex.Lock()
+	defer collectorsMutex.Unlock()
+
+	collectors = slices.DeleteFunc(collectors, func(existing namedCollector) bool {
+		return existing.name == cleanFileName(name)
+	})
+}
+
+// RegisteredCollectors returns the names of the registered collectors in the order they are run.
+func RegisteredCollectors() []string {
+	collectorsMutex.Lock()
+	defer collectorsMutex.Unlock()
+
+	names := make([]string, 0, len(collectors))
+	for _, registered := range collectors {
+		names = append(names, registered.name)
+	}
+
+	return names
+}
+
+// registeredCollectors returns a copy of the registered collectors so they can be run without holding the lock.
+func registeredCollectors() []namedCollector {
+	collectorsMutex.Lock()
+	defer collectorsMutex.Unlock()
+
+	return slices.Clone(collectors)
+}
+
+// runCollectors runs each collector in turn for the spec, giving each its own directory under bundleDir. Errors,
+// panics, and timeouts are recorded in the results and logged rather than stopping the remaining collectors.
+func runCollectors(dumpDir, bundleDir string, spec FailedSpec, toRun []namedCollector) []CollectorResult {
+	results := make([]CollectorResult, 0, len(toRun))
+
+	for _, registered := range toRun {
+		result := runCollector(dumpDir, filepath.Join(bundleDir, registered.name), spec, registered)
+		if result.Error != "" {
+			klog.Errorf("Failure collector %s failed for test %s: %s", result.Name, spec.Report.FullText(), result.Error)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// runCollector runs a single collector with its timeout, moving the files it writes to collectorDir. The collector
+// runs in its own goroutine so that it can be abandoned if it ignores the context.
+//
+// Collectors write to a staging directory in dumpDir rather than to collectorDir directly, so that an abandoned
+// collector cannot write to the bundle while it is being finished. The staging directory of an abandoned collector is
+// removed once the collector returns.
+func runCollector(dumpDir, collectorDir string, spec FailedSpec, registered namedCollector) (result CollectorResult) {
+	klog.V(100).Infof("Running failure collector %s with timeout %s", registered.name, registered.timeout)
+
+	result = CollectorResult{Name: registered.name}
+	startTime := time.Now()
+
+	defer func() {
+		result.Duration = time.Since(startTime).Round(time.Millisecond).String()
+	}()
+
+	err := os.MkdirAll(filepath.Dir(collectorDir), 0755)
+	if err != nil {
+		result.Error = err.Error()
+
+		return result
+	}
+
+	spec.Dir, err = os.MkdirTemp(dumpDir, ".collector-"+registered.name+"-")
+	if err != nil {
+		result.Error = err.Error()
+
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), registered.timeout)
+	defer cancel()
+
+	errChannel := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				errChannel <- fmt.Errorf("collector panicked: %v\n%s", recovered, debug.Stack())
+			}
+		}()
+
+		errChannel <- registered.collector.Collect(ctx, spec)
+	}()
+
+	select {
+	case err = <-errChannel:
+	case <-ctx.Done():
+		result.TimedOut = true
+		result.Error = fmt.Sprintf("collector did not finish within %s", registered.timeout)
+
+		go func() {
+			<-errChannel
+
+			_ = os.RemoveAll(spec.Dir)
+		}()
+
+		return result
+	}
+
+	moveErr := os.Rename(spec.Dir, collectorDir)
+	if moveErr != nil {
+		_ = os.RemoveAll(spec.Dir)
+
+		err = errors.Join(err, fmt.Errorf("failed to move collector output to %s: %w", collectorDir, moveErr))
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	return result
+}
@@ -0,0 +1,109 @@
+package reporter
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterCollector(t *testing.T) {
+	t.Cleanup(func() {
+		UnregisterCollector("first")
+		UnregisterCollector("second")
+	})
+
+	noop := CollectorFunc(func(context.Context, FailedSpec) error { return nil })
+
+	RegisterCollector("first", 0, noop)
+	RegisterCollector("second", time.Second, noop)
+	RegisterCollector("first", time.Minute, noop)
+
+	assert.Equal(t, []string{"first", "second"}, RegisteredCollectors())
+
+	registered := registeredCollectors()
+	assert.Equal(t, time.Minute, registered[0].timeout)
+
+	UnregisterCollector("first")
+	assert.Equal(t, []string{"second"}, RegisteredCollectors())
+}
+
+func TestRunCollectors(t *testing.T) {
+	dumpDir := t.TempDir()
+	bundleDir := filepath.Join(dumpDir, "bundle")
+	blocked := make(chan struct{})
+	abandoned := make(chan struct{})
+
+	toRun := []namedCollector{
+		{
+			name:    "writes",
+			timeout: time.Second,
+			collector: CollectorFunc(func(_ context.Context, spec FailedSpec) error {
+				return os.WriteFile(filepath.Join(spec.Dir, "output.log"), []byte("output"), 0644)
+			}),
+		},
+		{
+			name:    "fails",
+			timeout: time.Second,
+			collector: CollectorFunc(func(context.Context, FailedSpec) error {
+				return errors.New("collector failed")
+			}),
+		},
+		{
+			name:    "panics",
+			timeout: time.Second,
+			collector: CollectorFunc(func(context.Context, FailedSpec) error {
+				panic("collector panicked")
+			}),
+		},
+		{
+			name:    "ignores-context",
+			timeout: 10 * time.Millisecond,
+			collector: CollectorFunc(func(_ context.Context, spec FailedSpec) error {
+				defer close(abandoned)
+
+				<-blocked
+
+				return os.WriteFile(filepath.Join(spec.Dir, "late.log"), []byte("late"), 0644)
+			}),
+		},
+	}
+
+	results := runCollectors(dumpDir, bundleDir, FailedSpec{Report: newTestSpecReport()}, toRun)
+	assert.Len(t, results, len(toRun))
+
+	for _, result := range results {
+		_, err := time.ParseDuration(result.Duration)
+		assert.NoError(t, err, "duration of collector %s", result.Name)
+	}
+
+	// Durations are rounded to milliseconds, so only the collector that timed out is certain to have a nonzero one.
+	duration, _ := time.ParseDuration(results[3].Duration)
+	assert.GreaterOrEqual(t, duration, 10*time.Millisecond)
+
+	assert.Equal(t, "writes", results[0].Name)
+	assert.Empty(t, results[0].Error)
+	assert.FileExists(t, filepath.Join(bundleDir, "writes", "output.log"))
+
+	assert.Equal(t, "collector failed", results[1].Error)
+	assert.Contains(t, results[2].Error, "collector panicked")
+
+	assert.True(t, results[3].TimedOut)
+	assert.NotEmpty(t, results[3].Error)
+
+	// The abandoned collector finishes writing after the bundle would have been finished, so its files must be
+	// discarded rather than appearing in the bundle.
+	close(blocked)
+	<-abandoned
+
+	assert.Eventually(t, func() bool {
+		entries, err := os.ReadDir(dumpDir)
+
+		return err == nil && len(entries) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.NoDirExists(t, filepath.Join(bundleDir, "ignores-context"))
+}
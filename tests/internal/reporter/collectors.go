@@ -0,0 +1,200 @@
+package reporter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/openshift-kni/k8sreporter"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+)
+
+// CRCollectorName is the name of the collector that dumps the namespaces and CRs passed to ReportIfFailed.
+const CRCollectorName = "resources"
+
+// commandFileNameRegexp matches runs of characters in a command that are not kept in the name of its output file.
+var commandFileNameRegexp = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+var (
+	silenceMutex sync.Mutex
+	// silenceCount is the number of CR collectors currently running with klog silenced.
+	silenceCount int
+)
+
+// CRCollector returns a Collector that uses the k8sreporter to dump the nodes, the events, pods, and pod logs of the
+// namespaces in nSpaces, and the CRs in cRDs. This is the collector ReportIfFailed always runs first.
+func CRCollector(nSpaces map[string]string, cRDs []k8sreporter.CRData) Collector {
+	return CollectorFunc(func(ctx context.Context, spec FailedSpec) error {
+		reportPath, dumpSubpath := filepath.Split(spec.Dir)
+
+		reporter, err := newReporter(reportPath, spec.Kubeconfig, nSpaces, setReporterSchemes, cRDs)
+		if err != nil {
+			return fmt.Errorf("failed to create log reporter: %w", err)
+		}
+
+		// Workaround for the fact we are unable to pass a context to specify a logger for the client used by
+		// the reporter. Otherwise, we get megabytes of verbose logging.
+		defer silenceKlog()()
+
+		reporter.Dump(spec.Report.RunTime, dumpSubpath)
+
+		return nil
+	})
+}
+
+// NodeCommandCollector returns a Collector that runs each command on every node using the machine config daemon pods
+// and writes the output to a file per node and command.
+func NodeCommandCollector(apiClient *clients.Settings, commands []string) Collector {
+	return CollectorFunc(func(ctx context.Context, spec FailedSpec) error {
+		if apiClient == nil {
+			return fmt.Errorf("cannot run node commands with nil apiClient")
+		}
+
+		var errs []error
+
+		for _, command := range commands {
+			if ctx.Err() != nil {
+				return errors.Join(append(errs, ctx.Err())...)
+			}
+
+			output, err := cluster.ExecCmdWithStdout(apiClient, command)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to run command %q: %w", command, err))
+
+				continue
+			}
+
+			for node, stdout := range output {
+				fileName := fmt.Sprintf("%s_%s.log", node, commandFileNameRegexp.ReplaceAllString(command, "_"))
+
+				err = os.WriteFile(filepath.Join(spec.Dir, fileName), []byte(stdout), 0644)
+				if err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+
+		return errors.Join(errs...)
+	})
+}
+
+// PodLogCollector returns a Collector that saves the logs of every container of every pod in the namespaces, starting
+// from when the spec started. Unlike CRCollector, it does not include the previous logs of restarted containers.
+func PodLogCollector(apiClient *clients.Settings, namespaces ...string) Collector {
+	return CollectorFunc(func(ctx context.Context, spec FailedSpec) error {
+		if apiClient == nil {
+			return fmt.Errorf("cannot collect pod logs with nil apiClient")
+		}
+
+		logStartTime := time.Since(spec.Report.StartTime)
+
+		var errs []error
+
+		for _, namespace := range namespaces {
+			pods, err := pod.List(apiClient, namespace)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err))
+
+				continue
+			}
+
+			for _, podBuilder := range pods {
+				if ctx.Err() != nil {
+					return errors.Join(append(errs, ctx.Err())...)
+				}
+
+				for _, container := range podBuilder.Object.Spec.Containers {
+					logs, err := podBuilder.GetLog(logStartTime, container.Name)
+					if err != nil {
+						errs = append(errs, fmt.Errorf("failed to get logs of %s/%s container %s: %w",
+							namespace, podBuilder.Object.Name, container.Name, err))
+
+						continue
+					}
+
+					fileName := fmt.Sprintf("%s_%s_%s.log", namespace, podBuilder.Object.Name, container.Name)
+
+					err = os.WriteFile(filepath.Join(spec.Dir, fileName), []byte(logs), 0644)
+					if err != nil {
+						errs = append(errs, err)
+					}
+				}
+			}
+		}
+
+		return errors.Join(errs...)
+	})
+}
+
+// PrometheusCollector returns a Collector that runs each query in queries at the time the collector runs and saves the
+// result as JSON in a file named after the key of the query.
+func PrometheusCollector(prometheusAPI prometheusv1.API, queries map[string]string) Collector {
+	return CollectorFunc(func(ctx context.Context, spec FailedSpec) error {
+		if prometheusAPI == nil {
+			return fmt.Errorf("cannot take Prometheus snapshot with nil API")
+		}
+
+		var errs []error
+
+		for name, query := range queries {
+			value, warnings, err := prometheusAPI.Query(ctx, query, time.Now())
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to run query %s: %w", name, err))
+
+				continue
+			}
+
+			snapshot, err := json.MarshalIndent(map[string]any{
+				"query":    query,
+				"warnings": warnings,
+				"result":   value,
+			}, "", "  ")
+			if err != nil {
+				errs = append(errs, err)
+
+				continue
+			}
+
+			err = os.WriteFile(filepath.Join(spec.Dir, cleanFileName(name)+".json"), snapshot, 0644)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		return errors.Join(errs...)
+	})
+}
+
+// silenceKlog sets the klog verbosity to 0 and returns a function to restore it. Since CR collectors for several
+// clusters may run at once, the verbosity is only restored once every caller has restored it.
+func silenceKlog() func() {
+	silenceMutex.Lock()
+	defer silenceMutex.Unlock()
+
+	if silenceCount == 0 {
+		_ = flag.Set("v", "0")
+	}
+
+	silenceCount++
+
+	return func() {
+		silenceMutex.Lock()
+		defer silenceMutex.Unlock()
+
+		silenceCount--
+
+		if silenceCount == 0 && generalCfg != nil {
+			_ = flag.Set("v", generalCfg.VerboseLevel)
+		}
+	}
+}
@@ -2,12 +2,14 @@ package reporter
 
 import (
 	"errors"
-	"flag"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"path"
-	"strings"
+	"path/filepath"
+	"slices"
+	"sync"
 
 	"github.com/onsi/ginkgo/v2/types"
 	"github.com/openshift-kni/k8sreporter"
@@ -74,13 +76,45 @@ func ReportIfFailed(
 }
 
 // ReportIfFailedOnCluster dumps the requested cluster CRs on the cluster specified by kubeconfig if TC is failed to the
-// given directory.
+// given directory, then runs every collector registered using RegisterCollector. The output is stored as a Bundle with
+// a manifest describing the spec, failure, cluster, collectors, and dumped files, and the manifest is added to the
+// index of the directory so the bundle can be found by ID or spec text. Failures of individual collectors are logged
+// and recorded in the manifest rather than stopping the run.
 func ReportIfFailedOnCluster(
 	kubeconfig string,
 	report types.SpecReport,
 	testSuite string,
 	nSpaces map[string]string,
 	cRDs []k8sreporter.CRData) {
+	reportIfFailed(report, testSuite, func(dumpDir string) {
+		dumpBundle(dumpDir, FailedSpec{Report: report, Suite: testSuite, Kubeconfig: kubeconfig}, nSpaces, cRDs)
+	})
+}
+
+// ClusterDump is the cluster and the namespaces and CRs to dump from it for one of the clusters passed to
+// ReportIfFailedOnClusters.
+type ClusterDump struct {
+	// Kubeconfig is the path of the kubeconfig of the cluster. It is empty for the cluster of the KUBECONFIG
+	// environment variable.
+	Kubeconfig string
+	Namespaces map[string]string
+	CRs        []k8sreporter.CRData
+}
+
+// ReportIfFailedOnClusters is like ReportIfFailedOnCluster but dumps several clusters, keyed by their role such as hub
+// or spoke1, into a single bundle. The clusters are dumped concurrently, each into a directory of the bundle named
+// after its role, and the manifest records every cluster as well as the cluster each dumped file came from. The
+// namespace size limit applies to the namespaces of each cluster separately. The registered collectors run once, after
+// all the clusters are dumped.
+func ReportIfFailedOnClusters(report types.SpecReport, testSuite string, clusters map[string]ClusterDump) {
+	reportIfFailed(report, testSuite, func(dumpDir string) {
+		dumpMultiClusterBundle(dumpDir, FailedSpec{Report: report, Suite: testSuite}, clusters)
+	})
+}
+
+// reportIfFailed calls dump with the dump directory of testSuite if the spec failed and dumping is enabled, then
+// removes the pod exec logs.
+func reportIfFailed(report types.SpecReport, testSuite string, dump func(dumpDir string)) {
 	if !types.SpecStateFailureStates.Is(report.State) {
 		return
 	}
@@ -95,34 +129,109 @@ func ReportIfFailedOnCluster(
 	dumpDir := generalCfg.GetDumpFailedTestReportLocation(testSuite)
 
 	if dumpDir != "" {
-		reporter, err := newReporter(dumpDir, kubeconfig, nSpaces, setReporterSchemes, cRDs)
-		if err != nil {
-			klog.Fatalf("Failed to create log reporter due to %s", err)
-		}
+		dump(dumpDir)
+	}
+
+	err := removeFile(pathToPodExecLogs)
+	if err != nil {
+		klog.Errorf("Failed to remove pod exec logs: %v", err)
+	}
+}
 
-		tcReportFolderName := strings.ReplaceAll(report.FullText(), " ", "_")
+// dumpBundle creates a failure bundle in dumpDir for the spec, runs the CR collector followed by the registered
+// collectors, moves the pod exec logs into it, and finishes it. Errors are only logged.
+func dumpBundle(dumpDir string, spec FailedSpec, nSpaces map[string]string, cRDs []k8sreporter.CRData) {
+	bundle, err := NewBundle(dumpDir, spec.Suite, spec.Report, GetBundleCluster(spec.Kubeconfig))
+	if err != nil {
+		klog.Errorf("Failed to create failure bundle in %s: %v", dumpDir, err)
 
-		// Workaround for the fact we are unable to pass a context to specify a logger for the client used by
-		// the reporter. Otherwise, we get megabytes of verbose logging.
-		_ = flag.Set("v", "0")
+		return
+	}
 
-		reporter.Dump(report.RunTime, tcReportFolderName)
+	results := runCollectors(dumpDir, bundle.Dir(), spec, []namedCollector{{
+		name:      CRCollectorName,
+		timeout:   DefaultCollectorTimeout,
+		collector: CRCollector(nSpaces, cRDs),
+	}})
 
-		_ = flag.Set("v", generalCfg.VerboseLevel)
+	finishBundle(bundle, spec, results, nSpaces)
+}
 
-		_, podExecLogsFName := path.Split(pathToPodExecLogs)
+// dumpMultiClusterBundle creates a failure bundle in dumpDir for the spec, concurrently runs the CR collector of each
+// cluster in the directory of its role, then runs the registered collectors and finishes the bundle like dumpBundle.
+func dumpMultiClusterBundle(dumpDir string, spec FailedSpec, clusters map[string]ClusterDump) {
+	bundle, err := NewBundle(dumpDir, spec.Suite, spec.Report, BundleCluster{})
+	if err != nil {
+		klog.Errorf("Failed to create failure bundle in %s: %v", dumpDir, err)
 
-		err = moveFile(
-			pathToPodExecLogs, path.Join(generalCfg.ReportsDirAbsPath, tcReportFolderName, podExecLogsFName))
-		if err != nil {
-			klog.Fatalf("Failed to move pod exec logs %s to report folder: %s", pathToPodExecLogs, err)
+		return
+	}
+
+	roles := slices.Sorted(maps.Keys(clusters))
+	bundleClusters := make([]BundleCluster, len(roles))
+	results := make([]CollectorResult, len(roles))
+	allNamespaces := make(map[string]string)
+
+	var waitGroup sync.WaitGroup
+
+	for index, role := range roles {
+		clusterDump := clusters[role]
+		maps.Copy(allNamespaces, clusterDump.Namespaces)
+
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			clusterSpec := spec
+			clusterSpec.Kubeconfig = clusterDump.Kubeconfig
+
+			bundleClusters[index] = GetBundleCluster(clusterDump.Kubeconfig)
+			collectorDir := filepath.Join(bundle.Dir(), cleanFileName(role), CRCollectorName)
+			results[index] = runCollector(dumpDir, collectorDir, clusterSpec, namedCollector{
+				name:      CRCollectorName,
+				timeout:   DefaultCollectorTimeout,
+				collector: CRCollector(clusterDump.Namespaces, clusterDump.CRs),
+			})
+			results[index].Cluster = cleanFileName(role)
+		}()
+	}
+
+	waitGroup.Wait()
+
+	for index, role := range roles {
+		if results[index].Error != "" {
+			klog.Errorf("Failure collector %s failed on cluster %s for test %s: %s",
+				CRCollectorName, role, spec.Report.FullText(), results[index].Error)
 		}
+
+		bundle.AddCluster(cleanFileName(role), bundleClusters[index], clusters[role].Namespaces)
 	}
 
-	err := removeFile(pathToPodExecLogs)
+	finishBundle(bundle, spec, results, allNamespaces)
+}
+
+// finishBundle runs the registered collectors for the spec, records them after the CR collector results, moves the pod
+// exec logs into the bundle, and finishes it. Errors are only logged.
+func finishBundle(bundle *Bundle, spec FailedSpec, results []CollectorResult, nSpaces map[string]string) {
+	registeredResults := runCollectors(bundle.dumpDir, bundle.Dir(), spec, registeredCollectors())
+	bundle.manifest.Collectors = append(results, registeredResults...)
+
+	_, podExecLogsFName := path.Split(pathToPodExecLogs)
+
+	err := moveFile(pathToPodExecLogs, path.Join(bundle.Dir(), podExecLogsFName))
 	if err != nil {
-		klog.Fatal(err.Error())
+		klog.Errorf("Failed to move pod exec logs %s to report folder: %v", pathToPodExecLogs, err)
 	}
+
+	manifest, err := bundle.Finish(nSpaces, generalCfg.DumpNamespaceSizeLimit, generalCfg.DumpCompress)
+	if err != nil {
+		klog.Errorf("Failed to finish failure bundle %s: %v", bundle.Dir(), err)
+
+		return
+	}
+
+	klog.Infof("Dumped failure bundle %s for test: %s", path.Join(bundle.dumpDir, manifest.Bundle), manifest.SpecText)
 }
 
 func moveFile(sourcePath, destPath string) error {
@@ -0,0 +1,171 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Class is the kind of transient error a Classifier found. It is used to decide whether to retry and to count
+// retries.
+type Class string
+
+const (
+	// ClassNone is returned by classifiers for errors that should not be retried.
+	ClassNone Class = ""
+	// ClassConflict is an update that lost a race with another writer of the same object.
+	ClassConflict Class = "conflict"
+	// ClassConnection is a failure to reach the API server or a node, such as a refused or reset connection or a TLS
+	// handshake timeout, which is common while the API server restarts.
+	ClassConnection Class = "connection"
+	// ClassAPIUnavailable is an API server that responded but could not serve the request right now.
+	ClassAPIUnavailable Class = "api-unavailable"
+	// ClassExecStream is an exec session into a container that failed to start or was dropped, such as when the
+	// container restarts.
+	ClassExecStream Class = "exec-stream"
+	// ClassRebootDisconnect is an exec session or connection dropped because the node it ran on rebooted.
+	ClassRebootDisconnect Class = "reboot-disconnect"
+)
+
+// Classifier returns the Class of err, or ClassNone if it should not be retried. It is never called with a nil error.
+type Classifier func(err error) Class
+
+var (
+	// connectionMessages are the messages of errors reaching an endpoint, which are often only available as strings
+	// since many helpers format rather than wrap errors.
+	connectionMessages = []string{
+		"TLS handshake timeout",
+		"connection reset by peer",
+		"connection refused",
+		"did you specify the right host or port?",
+		"i/o timeout",
+		"no route to host",
+		"http2: client connection lost",
+	}
+	// execStreamMessages are the messages of exec sessions that failed to start or were dropped.
+	execStreamMessages = []string{
+		"error executing command in container",
+		"container not found",
+		"unable to upgrade connection",
+		"error dialing backend",
+	}
+	// rebootMessages are the lower case messages of sessions cut off by a node going down, in addition to those of
+	// connection and exec stream errors. They are too broad to retry on in general since a failing command also
+	// matches them.
+	rebootMessages = []string{
+		"eof",
+		"broken pipe",
+		"command terminated",
+		"connection reset",
+		"connection refused",
+		"use of closed network connection",
+	}
+	// conflictMessage is part of the message of conflict errors that were formatted rather than wrapped.
+	conflictMessage = "the object has been modified; please apply your changes to the latest version and try again"
+)
+
+// ClassifyConflict returns ClassConflict for update conflicts.
+func ClassifyConflict(err error) Class {
+	if k8serrors.IsConflict(err) || strings.Contains(err.Error(), conflictMessage) {
+		return ClassConflict
+	}
+
+	return ClassNone
+}
+
+// ClassifyConnection returns ClassConnection for errors connecting to or talking with an endpoint.
+func ClassifyConnection(err error) Class {
+	if containsAny(err.Error(), connectionMessages) {
+		return ClassConnection
+	}
+
+	return ClassNone
+}
+
+// ClassifyAPIUnavailable returns ClassAPIUnavailable for API server responses that mean the request may succeed if
+// retried, such as timeouts and throttling.
+func ClassifyAPIUnavailable(err error) Class {
+	switch {
+	case k8serrors.IsServerTimeout(err),
+		k8serrors.IsTimeout(err),
+		k8serrors.IsTooManyRequests(err),
+		k8serrors.IsServiceUnavailable(err),
+		k8serrors.IsUnexpectedServerError(err):
+		return ClassAPIUnavailable
+	default:
+		return ClassNone
+	}
+}
+
+// ClassifyExecStream returns ClassExecStream for exec sessions that failed to start or were dropped. Commands that ran
+// and exited with a non-zero code are not matched.
+func ClassifyExecStream(err error) Class {
+	if containsAny(err.Error(), execStreamMessages) {
+		return ClassExecStream
+	}
+
+	return ClassNone
+}
+
+// ClassifyRebootDisconnect returns ClassRebootDisconnect for any error an exec session or connection to a node may end
+// with when the node reboots, including the context being done. It is meant for commands that reboot the node, where
+// these errors are expected, rather than for retrying.
+func ClassifyRebootDisconnect(err error) Class {
+	if ClassifyConnection(err) != ClassNone ||
+		ClassifyExecStream(err) != ClassNone ||
+		containsAny(strings.ToLower(err.Error()), rebootMessages) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		return ClassRebootDisconnect
+	}
+
+	return ClassNone
+}
+
+// ClassifyMessages returns a Classifier that returns class for errors containing any of the messages.
+func ClassifyMessages(class Class, messages ...string) Classifier {
+	return func(err error) Class {
+		if containsAny(err.Error(), messages) {
+			return class
+		}
+
+		return ClassNone
+	}
+}
+
+// Classifiers returns a Classifier that returns the first class other than ClassNone found by classifiers.
+func Classifiers(classifiers ...Classifier) Classifier {
+	return func(err error) Class {
+		for _, classifier := range classifiers {
+			if class := classifier(err); class != ClassNone {
+				return class
+			}
+		}
+
+		return ClassNone
+	}
+}
+
+// DefaultClassifier retries conflicts, connection errors, and an unavailable API server, which covers most API
+// requests made while the cluster is disrupted.
+func DefaultClassifier(err error) Class {
+	return Classifiers(ClassifyConflict, ClassifyConnection, ClassifyAPIUnavailable)(err)
+}
+
+// Matches returns whether classifier finds err to be of any class other than ClassNone. A nil error never matches.
+func Matches(err error, classifier Classifier) bool {
+	return err != nil && classifier(err) != ClassNone
+}
+
+// containsAny returns whether message contains any of the substrings.
+func containsAny(message string, substrings []string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(message, substring) {
+			return true
+		}
+	}
+
+	return false
+}
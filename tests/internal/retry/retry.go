@@ -0,0 +1,202 @@
+// Package retry retries operations that fail with transient errors, such as API requests and node commands made while
+// the API server or a node restarts. A Classifier decides which errors are transient and what class they are, a Backoff
+// decides how long to wait between attempts, and the number of retries of each class is counted so suites can report
+// how disrupted the cluster was.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// Backoff returns how long to wait before the retry numbered retry, starting from 1 for the second attempt.
+type Backoff func(retry int) time.Duration
+
+// ConstantBackoff waits delay before every retry.
+func ConstantBackoff(delay time.Duration) Backoff {
+	return func(int) time.Duration {
+		return delay
+	}
+}
+
+// ExponentialBackoff waits initial before the first retry and factor times longer before each following retry, up to
+// maxDelay.
+func ExponentialBackoff(initial, maxDelay time.Duration, factor float64) Backoff {
+	return func(retry int) time.Duration {
+		delay := float64(initial) * math.Pow(factor, float64(retry-1))
+		if delay > float64(maxDelay) {
+			return maxDelay
+		}
+
+		return time.Duration(delay)
+	}
+}
+
+// Policy is how Do retries an operation.
+type Policy struct {
+	// Attempts is the maximum number of times the operation is run, including the first attempt. If 0, the operation
+	// is retried until it succeeds, fails with an error that is not retried, or the context is done.
+	Attempts int
+	// Backoff is the delay between attempts. If nil, attempts are made without delay.
+	Backoff Backoff
+	// Classifier decides which errors are retried. If nil, DefaultClassifier is used.
+	Classifier Classifier
+}
+
+// DefaultPolicy retries the errors of DefaultClassifier up to 5 attempts, waiting 1 second before the first retry and
+// doubling the delay each time.
+var DefaultPolicy = Policy{
+	Attempts:   5,
+	Backoff:    ExponentialBackoff(time.Second, 30*time.Second, 2),
+	Classifier: DefaultClassifier,
+}
+
+// ReportEntryName is the name of the report entry suites attach Report to.
+const ReportEntryName = "retry counts"
+
+// Operation is an operation that can be retried. It should stop once ctx is done.
+type Operation func(ctx context.Context) error
+
+// Count is how often errors of a single class were seen by Do.
+type Count struct {
+	// Retries is the number of times an operation was retried after an error of the class.
+	Retries int
+	// Exhausted is the number of times an operation failed with an error of the class but was not retried because it
+	// ran out of attempts or its context was done.
+	Exhausted int
+}
+
+var (
+	countsMutex sync.Mutex
+	// counts are the counts of every class seen by Do since the last ResetCounts.
+	counts = make(map[Class]Count)
+)
+
+// Do runs operation until it succeeds, fails with an error the classifier of policy does not retry, runs out of
+// attempts, or ctx is done. The error of the last attempt is returned, wrapped with the number of attempts if they ran
+// out, or joined with the error of ctx if it was done.
+func Do(ctx context.Context, policy Policy, operation Operation) error {
+	classifier := policy.Classifier
+	if classifier == nil {
+		classifier = DefaultClassifier
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := operation(ctx)
+		if err == nil {
+			return nil
+		}
+
+		class := classifier(err)
+		if class == ClassNone {
+			return err
+		}
+
+		if policy.Attempts > 0 && attempt >= policy.Attempts {
+			recordCount(class, false)
+
+			return fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+
+		var delay time.Duration
+		if policy.Backoff != nil {
+			delay = policy.Backoff(attempt)
+		}
+
+		klog.V(90).Infof("Retrying after %s error in %s (attempt %d of %s): %v",
+			class, delay, attempt, formatAttempts(policy.Attempts), err)
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			recordCount(class, false)
+
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+
+		recordCount(class, true)
+	}
+}
+
+// DoWithTimeout is Do with a context that is done after timeout.
+func DoWithTimeout(timeout time.Duration, policy Policy, operation Operation) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return Do(ctx, policy, operation)
+}
+
+// Counts returns a copy of how often Do saw errors of each class since the process started or ResetCounts was last
+// called.
+func Counts() map[Class]Count {
+	countsMutex.Lock()
+	defer countsMutex.Unlock()
+
+	return maps.Clone(counts)
+}
+
+// Report returns a table of the counts returned by Counts, sorted by class, suitable for a report entry. Suites that
+// disrupt the cluster add it to their report in ReportAfterSuite under ReportEntryName so it shows how often each class
+// of error was retried during the run.
+func Report() string {
+	currentCounts := Counts()
+
+	builder := &strings.Builder{}
+	writer := tabwriter.NewWriter(builder, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(writer, "CLASS\tRETRIES\tEXHAUSTED")
+
+	for _, class := range slices.Sorted(maps.Keys(currentCounts)) {
+		fmt.Fprintf(writer, "%s\t%d\t%d\n", class, currentCounts[class].Retries, currentCounts[class].Exhausted)
+	}
+
+	_ = writer.Flush()
+
+	return builder.String()
+}
+
+// ResetCounts clears the counts returned by Counts, such as at the start of a spec.
+func ResetCounts() {
+	countsMutex.Lock()
+	defer countsMutex.Unlock()
+
+	clear(counts)
+}
+
+// recordCount adds a retry or an exhausted error of class to the counts.
+func recordCount(class Class, retried bool) {
+	countsMutex.Lock()
+	defer countsMutex.Unlock()
+
+	count := counts[class]
+
+	if retried {
+		count.Retries++
+	} else {
+		count.Exhausted++
+	}
+
+	counts[class] = count
+}
+
+// formatAttempts returns the number of attempts for logging, where 0 means unlimited.
+func formatAttempts(attempts int) string {
+	if attempts <= 0 {
+		return "unlimited"
+	}
+
+	return fmt.Sprint(attempts)
+}
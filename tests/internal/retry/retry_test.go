@@ -0,0 +1,217 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestDefaultClassifier(t *testing.T) {
+	resource := schema.GroupResource{Group: "lca.openshift.io", Resource: "imagebasedupgrades"}
+
+	testCases := []struct {
+		name          string
+		err           error
+		expectedClass Class
+	}{
+		{
+			name:          "conflict",
+			err:           k8serrors.NewConflict(resource, "upgrade", errors.New("stale")),
+			expectedClass: ClassConflict,
+		},
+		{
+			name: "formatted conflict",
+			err: fmt.Errorf("failed to update: %s", "Operation cannot be fulfilled: the object has been modified; "+
+				"please apply your changes to the latest version and try again"),
+			expectedClass: ClassConflict,
+		},
+		{
+			name:          "tls handshake timeout",
+			err:           errors.New(`Get "https://api:6443/apis": net/http: TLS handshake timeout`),
+			expectedClass: ClassConnection,
+		},
+		{
+			name:          "connection reset",
+			err:           errors.New("read tcp 10.0.0.1:443: read: connection reset by peer"),
+			expectedClass: ClassConnection,
+		},
+		{
+			name:          "service unavailable",
+			err:           k8serrors.NewServiceUnavailable("apiserver is shutting down"),
+			expectedClass: ClassAPIUnavailable,
+		},
+		{
+			name:          "too many requests",
+			err:           k8serrors.NewTooManyRequests("throttled", 1),
+			expectedClass: ClassAPIUnavailable,
+		},
+		{
+			name:          "not found",
+			err:           k8serrors.NewNotFound(resource, "upgrade"),
+			expectedClass: ClassNone,
+		},
+		{
+			name:          "exec stream",
+			err:           errors.New("error executing command in container: container not found"),
+			expectedClass: ClassNone,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedClass, DefaultClassifier(testCase.err))
+		})
+	}
+}
+
+func TestClassifyRebootDisconnect(t *testing.T) {
+	assert.Equal(t, ClassRebootDisconnect, ClassifyRebootDisconnect(errors.New("unexpected EOF")))
+	assert.Equal(t, ClassRebootDisconnect, ClassifyRebootDisconnect(errors.New("command terminated with exit code 137")))
+	assert.Equal(t, ClassRebootDisconnect, ClassifyRebootDisconnect(fmt.Errorf("exec: %w", context.DeadlineExceeded)))
+	assert.Equal(t, ClassRebootDisconnect, ClassifyRebootDisconnect(errors.New("error dialing backend: dial tcp")))
+	assert.Equal(t, ClassNone, ClassifyRebootDisconnect(errors.New("permission denied")))
+
+	assert.True(t, Matches(errors.New("write: broken pipe"), ClassifyRebootDisconnect))
+	assert.True(t, Matches(errors.New("read: use of closed network connection"), ClassifyRebootDisconnect))
+	assert.False(t, Matches(nil, ClassifyRebootDisconnect))
+}
+
+func TestClassifyMessages(t *testing.T) {
+	classifier := Classifiers(ClassifyConflict, ClassifyMessages("not-ready", "webhook not ready"))
+
+	assert.Equal(t, Class("not-ready"), classifier(errors.New("admission webhook not ready")))
+	assert.Equal(t, ClassNone, classifier(errors.New("forbidden")))
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(time.Second, 5*time.Second, 2)
+
+	assert.Equal(t, time.Second, backoff(1))
+	assert.Equal(t, 2*time.Second, backoff(2))
+	assert.Equal(t, 4*time.Second, backoff(3))
+	assert.Equal(t, 5*time.Second, backoff(4))
+	assert.Equal(t, 3*time.Second, ConstantBackoff(3*time.Second)(10))
+}
+
+func TestDo(t *testing.T) {
+	connectionError := errors.New("dial tcp: connection refused")
+	notFoundError := k8serrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "pod")
+
+	testCases := []struct {
+		name              string
+		errs              []error
+		attempts          int
+		expectedAttempts  int
+		expectedError     string
+		expectedRetries   int
+		expectedExhausted int
+	}{
+		{
+			name:             "success",
+			errs:             []error{nil},
+			attempts:         3,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "success after retries",
+			errs:             []error{connectionError, connectionError, nil},
+			attempts:         3,
+			expectedAttempts: 3,
+			expectedRetries:  2,
+		},
+		{
+			name:             "not retried",
+			errs:             []error{notFoundError, nil},
+			attempts:         3,
+			expectedAttempts: 1,
+			expectedError:    `pods "pod" not found`,
+		},
+		{
+			name:              "out of attempts",
+			errs:              []error{connectionError, connectionError, connectionError},
+			attempts:          2,
+			expectedAttempts:  2,
+			expectedError:     "giving up after 2 attempts: dial tcp: connection refused",
+			expectedRetries:   1,
+			expectedExhausted: 1,
+		},
+		{
+			name:             "unlimited attempts",
+			errs:             []error{connectionError, connectionError, connectionError, connectionError, nil},
+			expectedAttempts: 5,
+			expectedRetries:  4,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ResetCounts()
+
+			attempts := 0
+			err := Do(context.TODO(), Policy{Attempts: testCase.attempts, Backoff: ConstantBackoff(time.Millisecond)},
+				func(context.Context) error {
+					attempts++
+
+					return testCase.errs[attempts-1]
+				})
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.expectedAttempts, attempts)
+
+			expectedCounts := map[Class]Count{}
+			if testCase.expectedRetries > 0 || testCase.expectedExhausted > 0 {
+				expectedCounts[ClassConnection] = Count{
+					Retries:   testCase.expectedRetries,
+					Exhausted: testCase.expectedExhausted,
+				}
+			}
+
+			assert.Equal(t, expectedCounts, Counts())
+		})
+	}
+}
+
+func TestDoContextDone(t *testing.T) {
+	ResetCounts()
+
+	connectionError := errors.New("connection refused")
+
+	err := DoWithTimeout(50*time.Millisecond, Policy{Backoff: ConstantBackoff(10 * time.Millisecond)},
+		func(context.Context) error {
+			return connectionError
+		})
+
+	assert.ErrorIs(t, err, connectionError)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, Counts()[ClassConnection].Exhausted)
+	assert.Positive(t, Counts()[ClassConnection].Retries)
+}
+
+func TestReport(t *testing.T) {
+	ResetCounts()
+
+	recordCount(ClassExecStream, true)
+	recordCount(ClassConnection, true)
+	recordCount(ClassConnection, true)
+	recordCount(ClassConnection, false)
+
+	expectedReport := "CLASS        RETRIES  EXHAUSTED\n" +
+		"connection   2        1\n" +
+		"exec-stream  1        0\n"
+	assert.Equal(t, expectedReport, Report())
+
+	ResetCounts()
+
+	assert.Equal(t, "CLASS  RETRIES  EXHAUSTED\n", Report())
+}
@@ -2,13 +2,17 @@ package sriovoperator
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/daemonset"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/sriov"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/hwinventory"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 )
 
@@ -216,3 +220,52 @@ func DiscoverInterfaceUnderTestVendorID(
 
 	return "", fmt.Errorf("interface %s not found", srIovInterfaceUnderTest)
 }
+
+// CollectInterfacesUnderTest returns the hardware inventory of the SR-IOV interfaces named interfaceNames that are up
+// on the nodes matching options, as reported by the SriovNetworkNodeState of each node. Only the NIC devices of the
+// nodes are set, and nodes without any of the interfaces are not returned.
+func CollectInterfacesUnderTest(
+	apiClient *clients.Settings,
+	sriovOperatorNamespace string,
+	interfaceNames []string,
+	options metav1.ListOptions) ([]hwinventory.Node, error) {
+	nodeBuilders, err := nodes.List(apiClient, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var inventories []hwinventory.Node
+
+	for _, nodeBuilder := range nodeBuilders {
+		nodeName := nodeBuilder.Definition.Name
+
+		sriovInterfaces, err := sriov.NewNetworkNodeStateBuilder(
+			apiClient, nodeName, sriovOperatorNamespace).GetUpNICs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get SR-IOV interfaces of node %s: %w", nodeName, err)
+		}
+
+		inventory := hwinventory.Node{Name: nodeName}
+
+		for _, srIovInterface := range sriovInterfaces {
+			if !slices.Contains(interfaceNames, srIovInterface.Name) {
+				continue
+			}
+
+			inventory.Devices = append(inventory.Devices, hwinventory.Device{
+				Kind:       hwinventory.DeviceKindNIC,
+				Name:       srIovInterface.Name,
+				Driver:     srIovInterface.Driver,
+				PCIAddress: srIovInterface.PciAddress,
+				PCIID:      fmt.Sprintf("%s:%s", srIovInterface.Vendor, srIovInterface.DeviceID),
+				LinkSpeed:  srIovInterface.LinkSpeed,
+			})
+		}
+
+		if len(inventory.Devices) > 0 {
+			inventories = append(inventories, inventory)
+		}
+	}
+
+	return inventories, nil
+}
@@ -2,7 +2,10 @@ package systemreporter
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	re "regexp"
 	"strings"
@@ -11,6 +14,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/reporter"
 	"golang.org/x/crypto/ssh"
 	"k8s.io/klog/v2"
 )
@@ -28,6 +32,9 @@ var (
 
 // ReportIfFailedFromClient dumps the requested command output
 // from nodes pulled from specified apiClient if test case fails.
+//
+// Deprecated: register reporter.NodeCommandCollector with reporter.RegisterCollector so the output is added to the
+// failure bundle of ReportIfFailed.
 func ReportIfFailedFromClient(
 	report types.SpecReport, testSuite string, commands []string, apiClient *clients.Settings) {
 	if types.SpecStateFailureStates.Is(report.State) {
@@ -55,6 +62,9 @@ func ReportIfFailedFromClient(
 }
 
 // ReportIfFailedFromNodeList dumps the requested command output from specified nodes through SSH if test case fails.
+//
+// Deprecated: register SSHCollector with reporter.RegisterCollector so the output is added to the failure bundle of
+// ReportIfFailed.
 func ReportIfFailedFromNodeList(report types.SpecReport, testSuite string, commands []string, nodes []string) {
 	if types.SpecStateFailureStates.Is(report.State) {
 		dumpDir := GeneralConfig.GetDumpFailedTestReportLocation(testSuite)
@@ -74,27 +84,40 @@ func ReportIfFailedFromNodeList(report types.SpecReport, testSuite string, comma
 	}
 }
 
+// SSHCollector returns a reporter.Collector that runs each command on each of the nodes through SSH, using the key at
+// GeneralConfig.SSHKeyPath, and writes the output to a file per node and command. Unlike GatherInfoThroughSSH, it
+// returns the errors running the commands and stops once ctx is done.
+func SSHCollector(commands []string, nodes []string) reporter.Collector {
+	return reporter.CollectorFunc(func(ctx context.Context, spec reporter.FailedSpec) error {
+		return gatherInfoThroughSSH(ctx, commands, spec.Dir, GeneralConfig.SSHKeyPath, nodes)
+	})
+}
+
 // GatherInfoThroughSSH gathers command output from specified nodes
 // and writes output to specified directory.
 func GatherInfoThroughSSH(commands []string, outputdir string, sshKeyPath string, nodes []string) {
-	if sshKeyPath == "" {
-		klog.Errorf("cannot gather system information without providing ssh key path")
+	err := gatherInfoThroughSSH(context.TODO(), commands, outputdir, sshKeyPath, nodes)
+	if err != nil {
+		klog.Errorf("failed to gather system information through SSH: %s", err)
+	}
+}
 
-		return
+// gatherInfoThroughSSH runs each command on each of the nodes through SSH and writes the output to outputdir,
+// continuing past failed nodes and commands until ctx is done. The errors are joined in the returned error.
+func gatherInfoThroughSSH(
+	ctx context.Context, commands []string, outputdir string, sshKeyPath string, nodes []string) error {
+	if sshKeyPath == "" {
+		return fmt.Errorf("cannot gather system information without providing ssh key path")
 	}
 
 	privateKey, err := os.ReadFile(sshKeyPath)
 	if err != nil {
-		klog.Errorf("failed to read private ssh key from system: %s", err)
-
-		return
+		return fmt.Errorf("failed to read private ssh key from system: %w", err)
 	}
 
 	signer, err := ssh.ParsePrivateKey(privateKey)
 	if err != nil {
-		klog.Errorf("failed to parse private ssh key: %s", err)
-
-		return
+		return fmt.Errorf("failed to parse private ssh key: %w", err)
 	}
 
 	config := ssh.ClientConfig{
@@ -105,44 +128,86 @@ func GatherInfoThroughSSH(commands []string, outputdir string, sshKeyPath string
 		},
 	}
 
+	var errs []error
+
 	for _, node := range nodes {
-		client, err := ssh.Dial("tcp", fmt.Sprintf("%s:22", node), &config)
+		if ctx.Err() != nil {
+			return errors.Join(append(errs, ctx.Err())...)
+		}
+
+		err = gatherNodeInfoThroughSSH(ctx, &config, commands, outputdir, node)
 		if err != nil {
-			klog.Errorf("failed to establish SSH connection to %s: %s", node, err)
+			errs = append(errs, err)
+		}
+	}
 
-			continue
+	return errors.Join(errs...)
+}
+
+// gatherNodeInfoThroughSSH runs each command on node over a single SSH connection and writes the output to outputdir.
+// The connection is closed once ctx is done, which stops the running command.
+func gatherNodeInfoThroughSSH(
+	ctx context.Context, config *ssh.ClientConfig, commands []string, outputdir string, node string) error {
+	address := net.JoinHostPort(node, "22")
+
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to establish SSH connection to %s: %w", node, err)
+	}
+
+	stopCloseOnDone := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	defer stopCloseOnDone()
+
+	clientConn, channels, requests, err := ssh.NewClientConn(conn, address, config)
+	if err != nil {
+		_ = conn.Close()
+
+		return fmt.Errorf("failed to establish SSH connection to %s: %w", node, err)
+	}
+
+	client := ssh.NewClient(clientConn, channels, requests)
+	defer client.Close()
+
+	var errs []error
+
+	for _, command := range commands {
+		if ctx.Err() != nil {
+			return errors.Join(append(errs, ctx.Err())...)
 		}
 
-		defer client.Close()
+		var output bytes.Buffer
 
-		for _, command := range commands {
-			var output bytes.Buffer
+		var stderr bytes.Buffer
 
-			var stderr bytes.Buffer
+		session, err := client.NewSession()
+		if err != nil {
+			return errors.Join(append(errs, fmt.Errorf("failed to create SSH session on %s: %w", node, err))...)
+		}
 
-			session, err := client.NewSession()
-			if err != nil {
-				klog.Errorf("failed to create SSH session on %s: %s", node, err)
+		session.Stdout = &output
+		session.Stderr = &stderr
 
-				break
-			}
+		err = session.Run(command)
 
-			defer session.Close()
+		_ = session.Close()
 
-			session.Stdout = &output
-			session.Stderr = &stderr
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error executing command '%s' on %s: %s", command, node, stderr.String()))
 
-			err = session.Run(command)
-			if err == nil {
-				err = os.WriteFile(outputdir+"/"+node+"_"+fileNameFromCommand(command), output.Bytes(), 0650)
-				if err != nil {
-					klog.Errorf("error writing to file: %s", err)
-				}
-			} else {
-				klog.Errorf("error executing command '%s' on %s: %s", command, node, stderr.String())
-			}
+			continue
+		}
+
+		err = os.WriteFile(outputdir+"/"+node+"_"+fileNameFromCommand(command), output.Bytes(), 0650)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error writing to file: %w", err))
 		}
 	}
+
+	return errors.Join(errs...)
 }
 
 // GatherInfoThroughKubeClient gathers command output from nodes accessible from APIClient
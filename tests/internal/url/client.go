@@ -0,0 +1,338 @@
+package url
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/proxy"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/retry"
+	"golang.org/x/net/http/httpproxy"
+	"k8s.io/klog/v2"
+)
+
+// DefaultRequestTimeout is how long a single attempt of Client.Do may take when no timeout is provided.
+const DefaultRequestTimeout = time.Minute
+
+// DefaultRetryPolicy is the retry policy of a Client when none is provided. It retries connection errors and the
+// statuses in retryableStatusCodes up to 3 attempts.
+var DefaultRetryPolicy = retry.Policy{
+	Attempts:   3,
+	Backoff:    retry.ExponentialBackoff(2*time.Second, 30*time.Second, 2),
+	Classifier: ClassifyHTTP,
+}
+
+// retryableStatusCodes are the HTTP statuses that mean the same request may succeed later.
+var retryableStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// idempotentMethods are the HTTP methods whose requests can be sent again without changing the result, which are the
+// only ones Client.Do retries unless WithRetryNonIdempotent is set.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// Response is the response to a request made by Client.Do, with the body already read.
+type Response struct {
+	StatusCode int
+	Status     string
+	Header     http.Header
+	Body       []byte
+}
+
+// StatusError is returned by attempts of Client.Do that got a response with a retryable status. It is only returned
+// to callers through the retry classifier, since Client.Do returns the last response rather than an error once retries
+// run out.
+type StatusError struct {
+	StatusCode int
+	Status     string
+}
+
+// Error returns the status of the response.
+func (statusError *StatusError) Error() string {
+	return fmt.Sprintf("received retryable status %s", statusError.Status)
+}
+
+// ClassifyHTTP is the default classifier of a Client. It returns retry.ClassConnection for connection errors and
+// retry.ClassAPIUnavailable for responses with a retryable status.
+func ClassifyHTTP(err error) retry.Class {
+	var statusError *StatusError
+	if errors.As(err, &statusError) {
+		return retry.ClassAPIUnavailable
+	}
+
+	return retry.ClassifyConnection(err)
+}
+
+// Client is an HTTP client for reaching endpoints exposed by clusters under test, such as routes and artifact servers.
+// It supports custom CA pools, client certificates, bearer tokens, proxies, and retries. It should be created with
+// NewClient and is safe for concurrent use.
+type Client struct {
+	httpClient         *http.Client
+	retryPolicy        retry.Policy
+	retryNonIdempotent bool
+	requestTimeout     time.Duration
+}
+
+type clientOptions struct {
+	tlsConfig          *tls.Config
+	proxy              func(*http.Request) (*neturl.URL, error)
+	header             http.Header
+	retryPolicy        retry.Policy
+	retryNonIdempotent bool
+	requestTimeout     time.Duration
+}
+
+// ClientOption is a function type that can be used to set the options for NewClient. It should not be implemented
+// outside of the functions provided by this package.
+type ClientOption func(*clientOptions)
+
+// WithInsecureSkipVerify sets whether to skip verifying the certificate of the server. It defaults to false.
+func WithInsecureSkipVerify(skipCertVerify bool) ClientOption {
+	return func(options *clientOptions) {
+		options.tlsConfig.InsecureSkipVerify = skipCertVerify
+	}
+}
+
+// WithCAPool sets the pool of CAs used to verify the certificate of the server, such as the one returned by
+// rancluster.GetClusterDefaultRouterCAPool. It defaults to the system CA pool.
+func WithCAPool(caPool *x509.CertPool) ClientOption {
+	return func(options *clientOptions) {
+		options.tlsConfig.RootCAs = caPool
+	}
+}
+
+// WithClientCertificate adds a certificate to present to servers that require client certificate authentication.
+func WithClientCertificate(certificate tls.Certificate) ClientOption {
+	return func(options *clientOptions) {
+		options.tlsConfig.Certificates = append(options.tlsConfig.Certificates, certificate)
+	}
+}
+
+// WithBearerToken sets the token sent in the Authorization header of every request. It defaults to no token.
+func WithBearerToken(token string) ClientOption {
+	return WithHeader("Authorization", "Bearer "+token)
+}
+
+// WithHeader sets a header sent with every request, replacing any previous values of it.
+func WithHeader(key, value string) ClientOption {
+	return func(options *clientOptions) {
+		options.header.Set(key, value)
+	}
+}
+
+// WithProxy sets the proxies used for HTTP and HTTPS requests and the comma separated list of hosts that bypass them,
+// using the same rules as the HTTP_PROXY, HTTPS_PROXY, and NO_PROXY environment variables. It defaults to the proxy
+// from those environment variables.
+func WithProxy(httpProxy, httpsProxy, noProxy string) ClientOption {
+	proxyFunc := (&httpproxy.Config{HTTPProxy: httpProxy, HTTPSProxy: httpsProxy, NoProxy: noProxy}).ProxyFunc()
+
+	return func(options *clientOptions) {
+		options.proxy = func(request *http.Request) (*neturl.URL, error) {
+			return proxyFunc(request.URL)
+		}
+	}
+}
+
+// WithoutProxy disables proxies, including any set in the environment.
+func WithoutProxy() ClientOption {
+	return func(options *clientOptions) {
+		options.proxy = nil
+	}
+}
+
+// WithRetryPolicy sets the policy for retrying failed requests and downloads. It defaults to DefaultRetryPolicy. An
+// Attempts of 1 disables retries and a nil Classifier uses ClassifyHTTP.
+func WithRetryPolicy(policy retry.Policy) ClientOption {
+	return func(options *clientOptions) {
+		options.retryPolicy = policy
+	}
+}
+
+// WithRetryNonIdempotent sets whether requests with methods that are not idempotent, such as POST and PATCH, are
+// retried. It defaults to false since a request that failed after reaching the server may have taken effect, so only
+// set it for endpoints where sending the same request twice is safe.
+func WithRetryNonIdempotent(retryNonIdempotent bool) ClientOption {
+	return func(options *clientOptions) {
+		options.retryNonIdempotent = retryNonIdempotent
+	}
+}
+
+// WithRequestTimeout sets how long each attempt of Client.Do may take, including reading the body. Downloads are not
+// limited by it. It defaults to DefaultRequestTimeout.
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return func(options *clientOptions) {
+		options.requestTimeout = timeout
+	}
+}
+
+// ClusterProxyOption returns an option that sets the proxy of the client to the proxy configured for the cluster, so
+// requests reach endpoints the same way the cluster does. If the cluster has no proxy, the option disables proxies.
+func ClusterProxyOption(apiClient *clients.Settings) (ClientOption, error) {
+	proxyBuilder, err := proxy.Pull(apiClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull cluster proxy: %w", err)
+	}
+
+	status := proxyBuilder.Object.Status
+	if status.HTTPProxy == "" && status.HTTPSProxy == "" {
+		return WithoutProxy(), nil
+	}
+
+	return WithProxy(status.HTTPProxy, status.HTTPSProxy, status.NoProxy), nil
+}
+
+// NewClient creates a new Client with the provided options.
+func NewClient(options ...ClientOption) *Client {
+	clientOpts := &clientOptions{
+		tlsConfig:      &tls.Config{},
+		proxy:          http.ProxyFromEnvironment,
+		header:         make(http.Header),
+		retryPolicy:    DefaultRetryPolicy,
+		requestTimeout: DefaultRequestTimeout,
+	}
+
+	for _, option := range options {
+		option(clientOpts)
+	}
+
+	if clientOpts.retryPolicy.Classifier == nil {
+		clientOpts.retryPolicy.Classifier = ClassifyHTTP
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = clientOpts.tlsConfig
+	transport.Proxy = clientOpts.proxy
+
+	var roundTripper http.RoundTripper = transport
+	if len(clientOpts.header) > 0 {
+		roundTripper = &headerRoundTripper{header: clientOpts.header, next: transport}
+	}
+
+	return &Client{
+		httpClient:         &http.Client{Transport: roundTripper},
+		retryPolicy:        clientOpts.retryPolicy,
+		retryNonIdempotent: clientOpts.retryNonIdempotent,
+		requestTimeout:     clientOpts.requestTimeout,
+	}
+}
+
+// Do sends a request with the provided method and body to url, retrying according to the retry policy of the client.
+// Requests with methods that are not idempotent are only sent once unless WithRetryNonIdempotent is set. A nil body
+// sends no body. Responses with any status are returned without an error, including a retryable status
+// once retries run out, so callers should check Response.StatusCode.
+func (client *Client) Do(ctx context.Context, method, url string, body []byte) (*Response, error) {
+	method = strings.ToUpper(method)
+
+	klog.V(90).Infof("Sending %s request to %s", method, url)
+
+	policy := client.retryPolicy
+	if !idempotentMethods[method] && !client.retryNonIdempotent {
+		policy.Attempts = 1
+	}
+
+	var response *Response
+
+	err := retry.Do(ctx, policy, func(ctx context.Context) error {
+		var err error
+
+		response, err = client.doOnce(ctx, method, url, body)
+		if err != nil {
+			return err
+		}
+
+		if retryableStatusCodes[response.StatusCode] {
+			return &StatusError{StatusCode: response.StatusCode, Status: response.Status}
+		}
+
+		return nil
+	})
+
+	var statusError *StatusError
+	if errors.As(err, &statusError) && response != nil {
+		return response, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to send %s request to %s: %w", method, url, err)
+	}
+
+	klog.V(50).Infof("Received status %s from %s", response.Status, url)
+
+	return response, nil
+}
+
+// Get sends a GET request to url using Do.
+func (client *Client) Get(ctx context.Context, url string) (*Response, error) {
+	return client.Do(ctx, http.MethodGet, url, nil)
+}
+
+// doOnce sends a single request and reads the entire body of the response.
+func (client *Client) doOnce(ctx context.Context, method, url string, body []byte) (*Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.requestTimeout)
+	defer cancel()
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpResponse, err := client.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer httpResponse.Body.Close()
+
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return &Response{
+		StatusCode: httpResponse.StatusCode,
+		Status:     httpResponse.Status,
+		Header:     httpResponse.Header,
+		Body:       responseBody,
+	}, nil
+}
+
+// headerRoundTripper sets headers on every request before passing it to the next RoundTripper.
+type headerRoundTripper struct {
+	header http.Header
+	next   http.RoundTripper
+}
+
+// RoundTrip sets the headers on a copy of request and sends it using the next RoundTripper.
+func (roundTripper *headerRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	request = request.Clone(request.Context())
+
+	for key, values := range roundTripper.header {
+		request.Header[key] = values
+	}
+
+	return roundTripper.next.RoundTrip(request)
+}
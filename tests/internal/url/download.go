@@ -0,0 +1,132 @@
+package url
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+
+	"github.com/cavaliergopher/grab/v3"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/retry"
+	"k8s.io/klog/v2"
+)
+
+type downloadOptions struct {
+	checksumHash hash.Hash
+	checksum     []byte
+	noResume     bool
+	err          error
+}
+
+// DownloadOption is a function type that can be used to set the options for Client.Download. It should not be
+// implemented outside of the functions provided by this package.
+type DownloadOption func(*downloadOptions)
+
+// WithChecksum sets the checksum the downloaded file must have when hashed using checksumHash. Files that do not match
+// are deleted. It defaults to no verification.
+func WithChecksum(checksumHash hash.Hash, checksum []byte) DownloadOption {
+	return func(options *downloadOptions) {
+		options.checksumHash = checksumHash
+		options.checksum = checksum
+	}
+}
+
+// WithSHA256 sets the hex encoded SHA-256 checksum the downloaded file must have. Files that do not match are deleted.
+// An invalid checksum makes the download fail.
+func WithSHA256(checksum string) DownloadOption {
+	return func(options *downloadOptions) {
+		sum, err := hex.DecodeString(checksum)
+		if err != nil || len(sum) == 0 {
+			options.err = fmt.Errorf("invalid SHA-256 checksum %q", checksum)
+
+			return
+		}
+
+		options.checksumHash = sha256.New()
+		options.checksum = sum
+	}
+}
+
+// WithNoResume sets whether to start the download over rather than resume it when part of the file already exists.
+// It defaults to false so that large artifacts, such as ISOs, resume after a retry or an earlier failed attempt.
+func WithNoResume(noResume bool) DownloadOption {
+	return func(options *downloadOptions) {
+		options.noResume = noResume
+	}
+}
+
+// Download saves the content at url to destination, which is either a file or an existing directory to save the file
+// in using the name from the URL or response. It returns the path of the saved file. Downloads are retried according
+// to the retry policy of the client, resuming from the data already saved when the server supports range requests.
+func (client *Client) Download(
+	ctx context.Context, url, destination string, options ...DownloadOption) (string, error) {
+	downloadOpts := &downloadOptions{}
+	for _, option := range options {
+		option(downloadOpts)
+	}
+
+	if downloadOpts.err != nil {
+		return "", fmt.Errorf("cannot download %s: %w", url, downloadOpts.err)
+	}
+
+	grabClient := grab.NewClient()
+	grabClient.HTTPClient = client.httpClient
+
+	policy := client.retryPolicy
+	policy.Classifier = retry.Classifiers(
+		policy.Classifier, retry.ClassifyMessages(retry.ClassConnection, "unexpected EOF"))
+
+	klog.V(50).Infof("Attempting to save content from %s into %s", url, destination)
+
+	var filename string
+
+	err := retry.Do(ctx, policy, func(ctx context.Context) error {
+		grabRequest, err := grab.NewRequest(destination, url)
+		if err != nil {
+			return fmt.Errorf("failed to create download request: %w", err)
+		}
+
+		grabRequest = grabRequest.WithContext(ctx)
+		grabRequest.NoResume = downloadOpts.noResume
+
+		if downloadOpts.checksumHash != nil {
+			downloadOpts.checksumHash.Reset()
+			grabRequest.SetChecksum(downloadOpts.checksumHash, downloadOpts.checksum, true)
+		}
+
+		grabResponse := grabClient.Do(grabRequest)
+		err = grabResponse.Err()
+
+		if grabResponse.HTTPResponse != nil {
+			klog.V(50).Infof("HTTP response status: %v", grabResponse.HTTPResponse.Status)
+		}
+
+		var statusCodeError grab.StatusCodeError
+		if errors.As(err, &statusCodeError) && retryableStatusCodes[int(statusCodeError)] {
+			statusCode := int(statusCodeError)
+
+			return &StatusError{StatusCode: statusCode, Status: fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode))}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if grabResponse.DidResume {
+			klog.V(50).Infof("Resumed download of %s after %d bytes", url, grabResponse.BytesComplete())
+		}
+
+		filename = grabResponse.Filename
+
+		return nil
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s to %s: %w", url, destination, err)
+	}
+
+	return filename, nil
+}
@@ -0,0 +1,167 @@
+package url
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/retry"
+	"github.com/stretchr/testify/assert"
+)
+
+var testRetryPolicy = retry.Policy{Attempts: 3, Backoff: retry.ConstantBackoff(time.Millisecond)}
+
+func TestClientDo(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if requests.Add(1) == 1 {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		if request.Header.Get("Authorization") != "Bearer token" {
+			writer.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		body, _ := io.ReadAll(request.Body)
+		_, _ = writer.Write(append([]byte(request.Method+" "), body...))
+	}))
+	defer server.Close()
+
+	caPool := server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+	client := NewClient(WithCAPool(caPool), WithBearerToken("token"), WithRetryPolicy(testRetryPolicy),
+		WithRetryNonIdempotent(true))
+
+	response, err := client.Do(context.TODO(), "post", server.URL, []byte("body"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "POST body", string(response.Body))
+	assert.Equal(t, int32(2), requests.Load())
+
+	_, err = NewClient(WithRetryPolicy(retry.Policy{Attempts: 1})).Get(context.TODO(), server.URL)
+	assert.ErrorContains(t, err, "certificate")
+}
+
+func TestClientDoRetriesExhausted(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+		writer.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	response, err := NewClient(WithRetryPolicy(testRetryPolicy)).Get(context.TODO(), server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, response.StatusCode)
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestClientDoNonIdempotent(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithRetryPolicy(testRetryPolicy))
+
+	response, err := client.Do(context.TODO(), http.MethodPost, server.URL, []byte("body"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+	assert.Equal(t, int32(1), requests.Load())
+
+	requests.Store(0)
+
+	_, err = client.Do(context.TODO(), http.MethodPut, server.URL, []byte("body"))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNotFound)
+		_, _ = writer.Write([]byte("missing"))
+	}))
+	defer server.Close()
+
+	body, statusCode, err := Fetch(server.URL, "Get", false)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+	assert.Equal(t, "missing", body)
+
+	_, _, err = Fetch(server.URL, "delete", false)
+	assert.EqualError(t, err, "unsupported method delete")
+}
+
+func TestClientDownload(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	checksum := sha256.Sum256(content)
+
+	var (
+		getRequests   atomic.Int32
+		rangeRequests atomic.Int32
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodGet {
+			getRequests.Add(1)
+		}
+
+		if request.Header.Get("Range") != "" {
+			rangeRequests.Add(1)
+		}
+
+		http.ServeContent(writer, request, "image.iso", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithRetryPolicy(testRetryPolicy))
+
+	t.Run("resume", func(t *testing.T) {
+		destination := filepath.Join(t.TempDir(), "image.iso")
+		assert.NoError(t, os.WriteFile(destination, content[:4000], 0o600))
+
+		filename, err := client.Download(
+			context.TODO(), server.URL+"/image.iso", destination, WithSHA256(hex.EncodeToString(checksum[:])))
+		assert.NoError(t, err)
+		assert.Equal(t, destination, filename)
+		assert.Positive(t, rangeRequests.Load())
+
+		saved, err := os.ReadFile(filename)
+		assert.NoError(t, err)
+		assert.Equal(t, content, saved)
+	})
+
+	t.Run("bad checksum", func(t *testing.T) {
+		getRequests.Store(0)
+
+		destinationDir := t.TempDir()
+
+		_, err := client.Download(
+			context.TODO(), server.URL+"/image.iso", destinationDir, WithSHA256(hex.EncodeToString(make([]byte, 32))))
+		assert.ErrorContains(t, err, "checksum mismatch")
+		assert.Equal(t, int32(1), getRequests.Load())
+		assert.NoFileExists(t, filepath.Join(destinationDir, "image.iso"))
+	})
+
+	t.Run("invalid checksum", func(t *testing.T) {
+		_, err := client.Download(context.TODO(), server.URL+"/image.iso", t.TempDir(), WithSHA256("not-hex"))
+		assert.ErrorContains(t, err, `invalid SHA-256 checksum "not-hex"`)
+	})
+}
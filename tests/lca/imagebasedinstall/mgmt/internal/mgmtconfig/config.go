@@ -3,7 +3,7 @@ package mgmtconfig
 import (
 	"os"
 
-	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedinstall/internal/ibiconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedinstall/mgmt/internal/mgmtparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/internal/seedimage"
@@ -99,7 +99,7 @@ func NewMGMTConfig() *MGMTConfig {
 
 	mgmtConfig.IBIConfig = ibiconfig.NewIBIConfig()
 
-	err := envconfig.Process("eco_lca_ibi_mgmt_", &mgmtConfig)
+	err := config.NewLoader().WithEnvconfigPrefix("eco_lca_ibi_mgmt_").WithEnvPrefix("ECO_LCA_IBI_").Load(&mgmtConfig)
 	if err != nil {
 		return nil
 	}
@@ -1,8 +1,6 @@
 package upgrade_test
 
 import (
-	"fmt"
-	"path"
 	"runtime"
 	"testing"
 
@@ -47,28 +45,25 @@ var _ = ReportAfterSuite("", func(report Report) {
 })
 
 var _ = JustAfterEach(func() {
-	var (
-		currentDir, currentFilename = path.Split(currentFile)
-		hubReportPath               = fmt.Sprintf("%shub_%s", currentDir, currentFilename)
-		spokeReportPath             = fmt.Sprintf("%sspoke_%s", currentDir, currentFilename)
-		report                      = CurrentSpecReport()
-	)
+	clusters := map[string]reporter.ClusterDump{}
 
 	if TargetSNOAPIClient != nil {
-		reporter.ReportIfFailedOnCluster(
-			CNFConfig.TargetSNOKubeConfig,
-			report,
-			spokeReportPath,
-			tsparams.ReporterSpokeNamespacesToDump,
-			tsparams.ReporterSpokeCRsToDump)
+		clusters["spoke"] = reporter.ClusterDump{
+			Kubeconfig: CNFConfig.TargetSNOKubeConfig,
+			Namespaces: tsparams.ReporterSpokeNamespacesToDump,
+			CRs:        tsparams.ReporterSpokeCRsToDump,
+		}
 	}
 
 	if TargetHubAPIClient != nil {
-		reporter.ReportIfFailedOnCluster(
-			CNFConfig.TargetHubKubeConfig,
-			report,
-			hubReportPath,
-			tsparams.ReporterHubNamespacesToDump,
-			tsparams.ReporterHubCRsToDump)
+		clusters["hub"] = reporter.ClusterDump{
+			Kubeconfig: CNFConfig.TargetHubKubeConfig,
+			Namespaces: tsparams.ReporterHubNamespacesToDump,
+			CRs:        tsparams.ReporterHubCRsToDump,
+		}
+	}
+
+	if len(clusters) > 0 {
+		reporter.ReportIfFailedOnClusters(CurrentSpecReport(), currentFile, clusters)
 	}
 })
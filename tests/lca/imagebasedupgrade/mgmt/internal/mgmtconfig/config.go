@@ -1,7 +1,7 @@
 package mgmtconfig
 
 import (
-	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/internal/ibuconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/mgmt/internal/mgmtparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/internal/seedimage"
@@ -33,7 +33,7 @@ func NewMGMTConfig() *MGMTConfig {
 
 	mgmtConfig.IBUConfig = ibuconfig.NewIBUConfig()
 
-	err := envconfig.Process("eco_lca_ibu_mgmt_", &mgmtConfig)
+	err := config.NewLoader().WithEnvconfigPrefix("eco_lca_ibu_mgmt_").WithEnvPrefix("ECO_LCA_IBU_MGMT_").Load(&mgmtConfig)
 	if err != nil {
 		return nil
 	}
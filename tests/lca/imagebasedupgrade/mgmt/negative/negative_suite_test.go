@@ -1,6 +1,7 @@
 package negative_test
 
 import (
+	"context"
 	"runtime"
 	"testing"
 	"time"
@@ -11,10 +12,10 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/lca"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/reporter"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/retry"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/mgmt/internal/mgmtinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/mgmt/negative/internal/tsparams"
 
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/internal/safeapirequest"
 	_ "github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/mgmt/negative/tests"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/internal/seedimage"
 )
@@ -45,7 +46,7 @@ var _ = AfterEach(func() {
 	Expect(err).NotTo(HaveOccurred(), "error pulling imagebasedupgrade resource")
 
 	if ibu.Object.Spec.Stage != "Idle" {
-		err = safeapirequest.Do(func() error {
+		err = retry.Do(context.TODO(), retry.DefaultPolicy, func(context.Context) error {
 			ibu, err = lca.PullImageBasedUpgrade(APIClient)
 			if err != nil {
 				return err
@@ -85,6 +86,8 @@ var _ = AfterEach(func() {
 var _ = ReportAfterSuite("", func(report Report) {
 	reportxml.Create(
 		report, MGMTConfig.GetReportPath(), MGMTConfig.TCPrefix)
+
+	AddReportEntry(retry.ReportEntryName, retry.Report())
 })
 
 var _ = JustAfterEach(func() {
@@ -37,9 +37,9 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/service"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/serviceaccount"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/retry"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/url"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/internal/nodestate"
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/internal/safeapirequest"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/mgmt/internal/mgmtinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/mgmt/internal/mgmtparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/mgmt/upgrade/internal/tsparams"
@@ -864,7 +864,7 @@ func upgrade() {
 
 	By("Wait until all nodes are reporting as Ready")
 
-	err = safeapirequest.Do(func() error {
+	err = retry.Do(context.TODO(), retry.DefaultPolicy, func(context.Context) error {
 		_, err := nodes.WaitForAllNodesAreReady(APIClient, time.Minute*10)
 
 		return err
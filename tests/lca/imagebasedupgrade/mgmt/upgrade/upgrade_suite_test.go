@@ -10,6 +10,7 @@ import (
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/reporter"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/retry"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/mgmt/internal/mgmtinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/mgmt/upgrade/internal/tsparams"
 	_ "github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/mgmt/upgrade/tests"
@@ -38,6 +39,8 @@ var _ = BeforeSuite(func() {
 var _ = ReportAfterSuite("", func(report Report) {
 	reportxml.Create(
 		report, MGMTConfig.GetReportPath(), MGMTConfig.TCPrefix)
+
+	AddReportEntry(retry.ReportEntryName, retry.Report())
 })
 
 var _ = JustAfterEach(func() {
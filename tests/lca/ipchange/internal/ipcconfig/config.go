@@ -1,7 +1,7 @@
 package ipcconfig
 
 import (
-	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/internal/lcaconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/ipchange/internal/ipcparams"
 	"k8s.io/klog/v2"
@@ -33,7 +33,7 @@ func NewIPCConfig() *IPCConfig {
 		return nil
 	}
 
-	err := envconfig.Process("", &ipcConfig)
+	err := config.NewLoader().WithEnvPrefix("ECO_LCA_IPC_", "ECO_LCA_IPC_KUBECONFIG_TARGET_SNO").Load(&ipcConfig)
 	if err != nil {
 		klog.V(ipcparams.IPCLogLevel).Infof("Error reading environment variables: %v", err)
 
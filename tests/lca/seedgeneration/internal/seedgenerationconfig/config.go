@@ -1,7 +1,6 @@
 package seedgenerationconfig
 
 import (
-	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"k8s.io/klog/v2"
@@ -27,7 +26,7 @@ func NewSeedGenerationConfig() *SeedGenerationConfig {
 		return nil
 	}
 
-	err := envconfig.Process("", &seedConfig)
+	err := config.NewLoader().Load(&seedConfig)
 	if err != nil {
 		klog.V(90).Infof("Error reading environment variables: %v", err)
 
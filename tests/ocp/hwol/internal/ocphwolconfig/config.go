@@ -2,18 +2,14 @@
 package ocphwolconfig
 
 import (
-	"errors"
 	"fmt"
-	"io"
 	"log"
-	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 
-	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/ocp/internal/ocpconfig"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -32,10 +28,10 @@ type DeviceConfig struct {
 // HwolOcpConfig type keeps HWOL configuration.
 type HwolOcpConfig struct {
 	*ocpconfig.OcpConfig
-	OcpHwolOperatorNamespace string `yaml:"sriov_operator_namespace" envconfig:"ECO_OCP_HWOL_OPERATOR_NAMESPACE"`
-	OcpHwolTestContainer     string `yaml:"ocp_hwol_test_container" envconfig:"ECO_OCP_HWOL_TEST_CONTAINER"`
-	MCPLabel                 string `yaml:"mcp_label" envconfig:"ECO_OCP_HWOL_MCP_LABEL"`
-	VFNum                    int    `yaml:"vf_num" envconfig:"ECO_OCP_HWOL_VF_NUM"`
+	OcpHwolOperatorNamespace string `yaml:"sriov_operator_namespace" envconfig:"ECO_OCP_HWOL_OPERATOR_NAMESPACE" validate:"required"` //nolint:lll
+	OcpHwolTestContainer     string `yaml:"ocp_hwol_test_container" envconfig:"ECO_OCP_HWOL_TEST_CONTAINER" validate:"required"`      //nolint:lll
+	MCPLabel                 string `yaml:"mcp_label" envconfig:"ECO_OCP_HWOL_MCP_LABEL" validate:"required"`
+	VFNum                    int    `yaml:"vf_num" envconfig:"ECO_OCP_HWOL_VF_NUM" validate:"min=3"`
 	DevicesEnv               string `envconfig:"ECO_OCP_HWOL_DEVICES"`
 }
 
@@ -63,16 +59,9 @@ func NewHwolOcpConfig() *HwolOcpConfig {
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultOcpHwolParamsFile)
 
-	err := readFile(&hwolOcpConf, confFile)
+	err := config.NewLoader().WithDefaultFile(confFile).WithEnvPrefix("ECO_OCP_HWOL_").Load(&hwolOcpConf)
 	if err != nil {
-		log.Printf("Error to read config file %s: %v", confFile, err)
-
-		return nil
-	}
-
-	err = readEnv(&hwolOcpConf)
-	if err != nil {
-		log.Printf("Error to read environment variables: %v", err)
+		log.Printf("Error to load config file %s: %v", confFile, err)
 
 		return nil
 	}
@@ -148,32 +137,3 @@ func (hwolOcpConfig *HwolOcpConfig) GetVFNum() (int, error) {
 
 	return hwolOcpConfig.VFNum, nil
 }
-
-func readFile(hwolOcpConfig *HwolOcpConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-
-	err = decoder.Decode(hwolOcpConfig)
-	if err != nil && !errors.Is(err, io.EOF) {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(hwolOcpConfig *HwolOcpConfig) error {
-	err := envconfig.Process("", hwolOcpConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
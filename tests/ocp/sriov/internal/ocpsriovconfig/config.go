@@ -2,19 +2,15 @@
 package ocpsriovconfig
 
 import (
-	"errors"
 	"fmt"
-	"io"
 	"log"
-	"os"
 	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
 
-	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/ocp/internal/ocpconfig"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -36,15 +32,15 @@ type DeviceConfig struct {
 // SriovOcpConfig type keeps sriov configuration.
 type SriovOcpConfig struct {
 	*ocpconfig.OcpConfig
-	OcpSriovOperatorNamespace   string         `yaml:"sriov_operator_namespace" envconfig:"ECO_OCP_SRIOV_OPERATOR_NAMESPACE"` //nolint:lll
-	OcpSriovTestContainer       string         `yaml:"ocp_sriov_test_container" envconfig:"ECO_OCP_SRIOV_TEST_CONTAINER"`
+	OcpSriovOperatorNamespace   string         `yaml:"sriov_operator_namespace" envconfig:"ECO_OCP_SRIOV_OPERATOR_NAMESPACE" validate:"required"` //nolint:lll
+	OcpSriovTestContainer       string         `yaml:"ocp_sriov_test_container" envconfig:"ECO_OCP_SRIOV_TEST_CONTAINER" validate:"required"`     //nolint:lll
 	DpdkTestContainer           string         `yaml:"dpdk_test_container" envconfig:"ECO_OCP_SRIOV_DPDK_TEST_CONTAINER"`
 	PrometheusOperatorNamespace string         `yaml:"prometheus_operator_namespace" envconfig:"ECO_OCP_SRIOV_PROMETHEUS_OPERATOR_NAMESPACE"` //nolint:lll
-	MCPLabel                    string         `yaml:"mcp_label" envconfig:"ECO_OCP_SRIOV_MCP_LABEL"`
+	MCPLabel                    string         `yaml:"mcp_label" envconfig:"ECO_OCP_SRIOV_MCP_LABEL" validate:"required"`
 	SriovInterfaces             string         `envconfig:"ECO_OCP_SRIOV_INTERFACE_LIST"`
 	Devices                     []DeviceConfig `yaml:"devices"`
 	DevicesEnv                  string         `envconfig:"ECO_OCP_SRIOV_DEVICES"`
-	VFNum                       int            `yaml:"vf_num" envconfig:"ECO_OCP_SRIOV_VF_NUM"`
+	VFNum                       int            `yaml:"vf_num" envconfig:"ECO_OCP_SRIOV_VF_NUM" validate:"min=1"`
 	SwitchUser                  string         `envconfig:"ECO_OCP_SRIOV_SWITCH_USER"`
 	SwitchPass                  string         `envconfig:"ECO_OCP_SRIOV_SWITCH_PASS"`
 	SwitchIP                    string         `envconfig:"ECO_OCP_SRIOV_SWITCH_IP"`
@@ -76,16 +72,9 @@ func NewSriovOcpConfig() *SriovOcpConfig {
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultOcpSriovParamsFile)
 
-	err := readFile(&sriovOcpConf, confFile)
+	err := config.NewLoader().WithDefaultFile(confFile).WithEnvPrefix("ECO_OCP_SRIOV_").Load(&sriovOcpConf)
 	if err != nil {
-		log.Printf("Error to read config file %s: %v", confFile, err)
-
-		return nil
-	}
-
-	err = readEnv(&sriovOcpConf)
-	if err != nil {
-		log.Printf("Error to read environment variables: %v", err)
+		log.Printf("Error to load config file %s: %v", confFile, err)
 
 		return nil
 	}
@@ -261,32 +250,3 @@ func (sriovOcpConfig *SriovOcpConfig) GetSwitchInterfaces() ([]string, error) {
 
 	return envValue, nil
 }
-
-func readFile(sriovOcpConfig *SriovOcpConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-
-	err = decoder.Decode(sriovOcpConfig)
-	if err != nil && !errors.Is(err, io.EOF) {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(sriovOcpConfig *SriovOcpConfig) error {
-	err := envconfig.Process("", sriovOcpConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
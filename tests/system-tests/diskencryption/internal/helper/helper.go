@@ -28,9 +28,13 @@ func GetClevisLuksListOutput() (string, error) {
 		return "", err
 	}
 
-	cmdToExec := fmt.Sprintf("sudo clevis luks list -d %s", rootDisk)
+	return cluster.ExecCommandOnSNOWithRetries(APIClient, tsparams.RetryCount, tsparams.RetryInterval,
+		clevisLuksListCommand(rootDisk))
+}
 
-	return cluster.ExecCommandOnSNOWithRetries(APIClient, tsparams.RetryCount, tsparams.RetryInterval, cmdToExec)
+// clevisLuksListCommand returns the command listing the clevis pins bound to the LUKS device disk, one slot per line.
+func clevisLuksListCommand(disk string) string {
+	return fmt.Sprintf("sudo clevis luks list -d %s", disk)
 }
 
 // getRootDisk returns the name of the encrypted root disk in the form /dev/sdaX.
@@ -139,7 +143,13 @@ func LuksListContainsPCR1And7(input string) (found bool) {
 func LuksListContainsReservedSlot(input string) bool {
 	RefReservedSlot := TPM2ReservedSlot + TPM2ReservedSlotContent
 
-	return RefReservedSlot == input
+	for line := range strings.Lines(input) {
+		if strings.TrimSpace(line) == RefReservedSlot {
+			return true
+		}
+	}
+
+	return false
 }
 
 // StringInSlice checks a slice for a given string.
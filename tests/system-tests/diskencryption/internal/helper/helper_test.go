@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -453,3 +454,29 @@ func TestGetTPMLockoutCounter(t *testing.T) {
 		})
 	}
 }
+
+func TestClevisLuksListParsers(t *testing.T) {
+	fixtures, err := cluster.ReadExecFixtures("testdata/clevis-luks-list.yaml")
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name                 string
+		nodeName             string
+		expectedPCR1And7     bool
+		expectedReservedSlot bool
+	}{
+		{name: "pcr 1 and 7", nodeName: "sno-pcr-1-7", expectedPCR1And7: true},
+		{name: "pcr 7 only", nodeName: "sno-pcr-7"},
+		{name: "reserved slot", nodeName: "sno-reserved-slot", expectedPCR1And7: true, expectedReservedSlot: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			output, err := fixtures.Stdout(testCase.nodeName, clevisLuksListCommand("/dev/sda4"))
+			assert.NoError(t, err)
+
+			assert.Equal(t, testCase.expectedPCR1And7, LuksListContainsPCR1And7(output))
+			assert.Equal(t, testCase.expectedReservedSlot, LuksListContainsReservedSlot(output))
+		})
+	}
+}
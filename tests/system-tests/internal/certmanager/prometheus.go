@@ -13,12 +13,12 @@ import (
 	promapi "github.com/prometheus/client_golang/api"
 	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/prometheus/common/config"
-	"github.com/prometheus/common/model"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/rbac"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/route"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/secret"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/serviceaccount"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/promquery"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/klog/v2"
@@ -31,7 +31,7 @@ func BuildCertRenewalPrometheusRule(namespace, certName string,
 	klog.V(100).Infof("Building PrometheusRule for cert %s with thresholds info=%d, warning=%d, critical=%d",
 		certName, infoThreshold, warningThreshold, criticalThreshold)
 
-	metricSelector := fmt.Sprintf(`certmanager_certificate_renewal_timestamp_seconds{name="%s"}`, certName)
+	metricSelector := renewalTimestampMetric(certName)
 
 	return &unstructured.Unstructured{
 		Object: map[string]interface{}{
@@ -124,25 +124,30 @@ func QueryPrometheusAlertState(promAPI promv1.API, alertName string) (string, er
 func QueryPrometheusRenewalMetric(promAPI promv1.API, certName string) (float64, error) {
 	klog.V(100).Infof("Querying Prometheus renewal metric for certificate %s", certName)
 
-	query := fmt.Sprintf(`certmanager_certificate_renewal_timestamp_seconds{name="%s"} - time()`, certName)
+	query := promquery.Expr(fmt.Sprintf("%s - time()", renewalTimestampMetric(certName)))
 
-	result, _, err := promAPI.Query(context.TODO(), query, time.Now())
+	vector, err := promquery.QueryVector(context.TODO(), promAPI, query, time.Time{})
 	if err != nil {
 		return 0, fmt.Errorf("failed to query Prometheus renewal metric: %w", err)
 	}
 
-	vector, ok := result.(model.Vector)
-	if !ok || len(vector) == 0 {
+	values := promquery.Values(vector)
+	if len(values) == 0 {
 		return 0, fmt.Errorf("no renewal metric data found for certificate %s", certName)
 	}
 
-	remaining := float64(vector[0].Value)
+	remaining := values[0]
 
 	klog.V(100).Infof("Certificate %s has %.0f seconds remaining until renewal", certName, remaining)
 
 	return remaining, nil
 }
 
+// renewalTimestampMetric returns the selector for the renewal timestamp metric of the certificate.
+func renewalTimestampMetric(certName string) promquery.Selector {
+	return promquery.Metric("certmanager_certificate_renewal_timestamp_seconds", promquery.Equals("name", certName))
+}
+
 // NewPrometheusAPI returns a Prometheus v1 API interface, creating necessary authentication
 // resources (ServiceAccount and ClusterRoleBinding) if they do not exist. It connects via
 // the Thanos Querier route and falls back to dialing via the API server hostname when the
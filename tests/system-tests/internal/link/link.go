@@ -44,6 +44,12 @@ type Link struct {
 
 type links []Link
 
+// ShowCommand returns the command printing the statistics of the link named linkName as JSON, which is the output
+// parsed by NewBuilder.
+func ShowCommand(linkName string) string {
+	return fmt.Sprintf("ip --json -s link show dev %s", linkName)
+}
+
 // NewBuilder returns Link struct.
 func NewBuilder(jsonOutput bytes.Buffer) (*Link, error) {
 	var link links
@@ -0,0 +1,45 @@
+package link
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBuilder(t *testing.T) {
+	fixtures, err := cluster.ReadExecFixtures("testdata/ip-link-show.yaml")
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name           string
+		linkName       string
+		expectedState  string
+		expectedRxByte int
+		expectedError  bool
+	}{
+		{name: "link with traffic", linkName: "net1", expectedState: "UP", expectedRxByte: 1048576000},
+		{name: "link without carrier", linkName: "net2", expectedState: "DOWN", expectedRxByte: 0},
+		{name: "missing link", linkName: "net3", expectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			output, err := fixtures.Stdout("worker-0", ShowCommand(testCase.linkName))
+			assert.NoError(t, err)
+
+			link, err := NewBuilder(*bytes.NewBufferString(output))
+			if testCase.expectedError {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.linkName, link.Ifname)
+			assert.Equal(t, testCase.expectedState, link.Operstate)
+			assert.Equal(t, testCase.expectedRxByte, link.GetRxByte())
+		})
+	}
+}
@@ -2,14 +2,12 @@ package ptp
 
 import (
 	"fmt"
-	"math"
-	"sort"
-	"strings"
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
 	infraptp "github.com/rh-ecosystem-edge/eco-goinfra/pkg/ptp"
 	ptpv1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/ptp/v1"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/ptprecommend"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 )
 
@@ -49,43 +47,6 @@ func ubloxFromProfileName(ptpConfig *ptpv1.PtpConfig, profileName string) (strin
 	return "", false
 }
 
-func nodeLabelMatches(rule string, nodeLabels map[string]string) bool {
-	rule = strings.TrimSpace(rule)
-	if rule == "" {
-		return false
-	}
-
-	if idx := strings.Index(rule, "="); idx >= 0 {
-		k := strings.TrimSpace(rule[:idx])
-		v := strings.TrimSpace(rule[idx+1:])
-		actual, ok := nodeLabels[k]
-
-		return ok && actual == v
-	}
-
-	_, ok := nodeLabels[rule]
-
-	return ok
-}
-
-func recommendPriority(rec ptpv1.PtpRecommend) int64 {
-	if rec.Priority == nil {
-		return math.MaxInt64
-	}
-
-	return *rec.Priority
-}
-
-func sortedRecommendations(ptpConfig *ptpv1.PtpConfig) []ptpv1.PtpRecommend {
-	recs := make([]ptpv1.PtpRecommend, len(ptpConfig.Spec.Recommend))
-	copy(recs, ptpConfig.Spec.Recommend)
-	sort.Slice(recs, func(i, j int) bool {
-		return recommendPriority(recs[i]) < recommendPriority(recs[j])
-	})
-
-	return recs
-}
-
 func pickFromStatusMatchList(ptpConfig *ptpv1.PtpConfig, nodeName string) (string, bool) {
 	for _, match := range ptpConfig.Status.MatchList {
 		if match.NodeName == nil || match.Profile == nil {
@@ -104,69 +65,6 @@ func pickFromStatusMatchList(ptpConfig *ptpv1.PtpConfig, nodeName string) (strin
 	return "", false
 }
 
-func pickFromRecommendByNodeName(
-	ptpConfig *ptpv1.PtpConfig,
-	nodeName string,
-	recs []ptpv1.PtpRecommend,
-) (string, bool) {
-	for _, rec := range recs {
-		if rec.Profile == nil {
-			continue
-		}
-
-		for _, rule := range rec.Match {
-			if rule.NodeName != nil && *rule.NodeName == nodeName {
-				if v, ok := ubloxFromProfileName(ptpConfig, *rec.Profile); ok {
-					return v, true
-				}
-			}
-		}
-	}
-
-	return "", false
-}
-
-func pickFromRecommendByNodeLabel(
-	ptpConfig *ptpv1.PtpConfig,
-	nodeLabels map[string]string,
-	recs []ptpv1.PtpRecommend,
-) (string, bool) {
-	for _, rec := range recs {
-		if rec.Profile == nil {
-			continue
-		}
-
-		for _, rule := range rec.Match {
-			if rule.NodeLabel == nil {
-				continue
-			}
-
-			if !nodeLabelMatches(*rule.NodeLabel, nodeLabels) {
-				continue
-			}
-
-			if v, ok := ubloxFromProfileName(ptpConfig, *rec.Profile); ok {
-				return v, true
-			}
-		}
-	}
-
-	return "", false
-}
-
-func pickUbloxFromPtpConfig(ptpConfig *ptpv1.PtpConfig, nodeName string, nodeLabels map[string]string) (string, bool) {
-	if v, ok := pickFromStatusMatchList(ptpConfig, nodeName); ok {
-		return v, true
-	}
-
-	recs := sortedRecommendations(ptpConfig)
-	if v, ok := pickFromRecommendByNodeName(ptpConfig, nodeName, recs); ok {
-		return v, true
-	}
-
-	return pickFromRecommendByNodeLabel(ptpConfig, nodeLabels, recs)
-}
-
 // GetUbloxProtocolVersion returns ubxtool -P based on the PtpConfig profile applied to nodeName.
 func GetUbloxProtocolVersion(apiClient *clients.Settings, nodeName string) (string, error) {
 	ptpConfigs, err := infraptp.ListPtpConfigs(apiClient)
@@ -179,7 +77,7 @@ func GetUbloxProtocolVersion(apiClient *clients.Settings, nodeName string) (stri
 		return "", fmt.Errorf("failed to pull node %s: %w", nodeName, err)
 	}
 
-	nodeLabels := nodeBuilder.Object.Labels
+	var definitions []*ptpv1.PtpConfig
 
 	for _, cfg := range ptpConfigs {
 		ptpConfig := cfg.Object
@@ -191,7 +89,16 @@ func GetUbloxProtocolVersion(apiClient *clients.Settings, nodeName string) (stri
 			continue
 		}
 
-		if v, ok := pickUbloxFromPtpConfig(ptpConfig, nodeName, nodeLabels); ok {
+		// The profiles the operator reports as applied take precedence over resolving the recommends.
+		if v, ok := pickFromStatusMatchList(ptpConfig, nodeName); ok {
+			return v, nil
+		}
+
+		definitions = append(definitions, ptpConfig)
+	}
+
+	for _, recommendation := range ptprecommend.ForNode(nodeBuilder.Object, ptprecommend.All(definitions)) {
+		if v, ok := UbloxProtocolFromPlugins(recommendation.Profile().Plugins); ok {
 			return v, nil
 		}
 	}
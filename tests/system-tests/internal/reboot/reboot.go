@@ -5,9 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net"
-	"strings"
 	"time"
 
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/retry"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/remote"
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/deployment"
@@ -265,28 +265,10 @@ func KernelCrashKdump(nodeName string) error {
 
 	_, err = remote.ExecuteOnNodeWithDebugPodWithTimeout(cmdToExec, nodeName, 15*time.Second)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			klog.V(90).Infof("Context timeout exceeded while triggering kernel crash, assuming it was triggered")
-
-			return nil // context timeout exceeded, so we can assume the kernel crash was triggered
-		}
-
-		// Check if it's a network operation error (expected during kernel crash)
+		// When triggering a kernel crash, the command times out or its connection is dropped as the node crashes
+		// immediately, so these errors actually indicate successful crash initiation.
 		var opErr *net.OpError
-		if errors.As(err, &opErr) {
-			klog.V(90).Infof("Network operation error during kernel crash (expected): %v", err)
-
-			return nil
-		}
-
-		// Also check for common wrapped errors that indicate successful kernel crash
-		// When triggering a kernel crash, connection errors are expected as the node
-		// crashes immediately. These errors actually indicate successful crash initiation.
-		errStr := err.Error()
-		if strings.Contains(errStr, "connection reset") ||
-			strings.Contains(errStr, "broken pipe") ||
-			strings.Contains(errStr, "EOF") ||
-			strings.Contains(errStr, "use of closed network connection") {
+		if errors.As(err, &opErr) || retry.Matches(err, retry.ClassifyRebootDisconnect) {
 			klog.V(90).Infof("Connection error during kernel crash (expected): %v", err)
 
 			return nil
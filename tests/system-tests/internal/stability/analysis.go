@@ -0,0 +1,204 @@
+package stability
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// Transition is a change in one of the values observed by a probe between two consecutive successful samples. A
+// value that appears or disappears changes from or to the empty string.
+type Transition struct {
+	Probe string
+	Key   string
+	Time  time.Time
+	From  string
+	To    string
+}
+
+// String returns a description of the transition.
+func (transition Transition) String() string {
+	return fmt.Sprintf("%s: %s changed from %q to %q at %s",
+		transition.Probe, transition.Key, transition.From, transition.To, transition.Time.Format(time.RFC3339))
+}
+
+// ProbeReport is the analysis of every sample recorded for a single probe.
+type ProbeReport struct {
+	Probe string
+	// Samples is the number of samples recorded, including those where the probe failed to observe the cluster.
+	Samples int
+	// Errors is the number of samples where the probe failed to observe the cluster.
+	Errors int
+	// Failures is the number of samples where the probe observed a failure.
+	Failures int
+	// FirstFailure is the time of the first sample where the probe observed a failure, or the zero time if there were
+	// none.
+	FirstFailure time.Time
+	// Transitions are the changes in observed values, in the order they happened.
+	Transitions []Transition
+	// Flaps is the number of transitions that returned a value to what it was before the previous transition.
+	Flaps int
+}
+
+// Stable returns whether the probe observed no transitions and no failures. Samples where the probe could not observe
+// the cluster are ignored.
+func (report ProbeReport) Stable() bool {
+	return len(report.Transitions) == 0 && report.Failures == 0
+}
+
+// Err returns an error describing the transitions and failures of the probe, or nil if it was stable.
+func (report ProbeReport) Err() error {
+	if report.Stable() {
+		return nil
+	}
+
+	var errs []error
+
+	if report.Failures > 0 {
+		errs = append(errs, fmt.Errorf("%s: observed %d failures out of %d samples, first at %s",
+			report.Probe, report.Failures, report.Samples, report.FirstFailure.Format(time.RFC3339)))
+	}
+
+	for _, transition := range report.Transitions {
+		errs = append(errs, errors.New(transition.String()))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Transitions returns the changes in the values observed by probe, in the order they happened. Samples where the probe
+// could not observe the cluster are skipped.
+func Transitions(samples []Sample, probe string) []Transition {
+	var (
+		transitions []Transition
+		previous    map[string]string
+	)
+
+	for _, sample := range probeSamples(samples, probe) {
+		if sample.Error != "" {
+			continue
+		}
+
+		if previous != nil {
+			keys := slices.Sorted(maps.Keys(previous))
+			for _, key := range slices.Sorted(maps.Keys(sample.Values)) {
+				if _, ok := previous[key]; !ok {
+					keys = append(keys, key)
+				}
+			}
+
+			for _, key := range keys {
+				if previous[key] != sample.Values[key] {
+					transitions = append(transitions, Transition{
+						Probe: probe,
+						Key:   key,
+						Time:  sample.Time,
+						From:  previous[key],
+						To:    sample.Values[key],
+					})
+				}
+			}
+		}
+
+		previous = sample.Values
+		if previous == nil {
+			previous = map[string]string{}
+		}
+	}
+
+	return transitions
+}
+
+// FirstFailure returns the time of the first sample where probe observed a failure, and whether there was one.
+func FirstFailure(samples []Sample, probe string) (time.Time, bool) {
+	for _, sample := range probeSamples(samples, probe) {
+		if sample.Failed {
+			return sample.Time, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// FlapCount returns the number of transitions that returned a value to what it was before the previous transition of
+// the same value, such as a state going from Sync to Unsync and back to Sync.
+func FlapCount(transitions []Transition) int {
+	flaps := 0
+	lastTransitions := make(map[string]Transition)
+
+	for _, transition := range transitions {
+		key := transition.Probe + "/" + transition.Key
+
+		if last, ok := lastTransitions[key]; ok && last.From == transition.To {
+			flaps++
+		}
+
+		lastTransitions[key] = transition
+	}
+
+	return flaps
+}
+
+// Analyze returns a report for every probe in samples, sorted by probe name.
+func Analyze(samples []Sample) []ProbeReport {
+	probes := make(map[string]bool)
+	for _, sample := range samples {
+		probes[sample.Probe] = true
+	}
+
+	var reports []ProbeReport
+
+	for _, probe := range slices.Sorted(maps.Keys(probes)) {
+		report := ProbeReport{Probe: probe}
+
+		for _, sample := range probeSamples(samples, probe) {
+			report.Samples++
+
+			if sample.Error != "" {
+				report.Errors++
+			}
+
+			if sample.Failed {
+				report.Failures++
+			}
+		}
+
+		report.FirstFailure, _ = FirstFailure(samples, probe)
+		report.Transitions = Transitions(samples, probe)
+		report.Flaps = FlapCount(report.Transitions)
+
+		reports = append(reports, report)
+	}
+
+	return reports
+}
+
+// AnalyzeFile reads the samples from a file written by a Recorder and returns a report for every probe in it.
+func AnalyzeFile(filePath string) ([]ProbeReport, error) {
+	samples, err := ReadSamples(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return Analyze(samples), nil
+}
+
+// probeSamples returns the samples of probe sorted by time. Probes run concurrently, so samples may be written slightly
+// out of order.
+func probeSamples(samples []Sample, probe string) []Sample {
+	var filtered []Sample
+
+	for _, sample := range samples {
+		if sample.Probe == probe {
+			filtered = append(filtered, sample)
+		}
+	}
+
+	slices.SortStableFunc(filtered, func(a, b Sample) int {
+		return a.Time.Compare(b.Time)
+	})
+
+	return filtered
+}
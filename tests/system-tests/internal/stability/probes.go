@@ -0,0 +1,112 @@
+package stability
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/ocm"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/ptp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ptpSynced and ptpUnsynced are the values of the state observed by PTPProbe.
+	ptpSynced   = "Sync"
+	ptpUnsynced = "Unsync"
+)
+
+// tunedAppliedRegex matches the tuned log line for applying a profile, which happens every time tuned restarts.
+var tunedAppliedRegex = regexp.MustCompile("static tuning from profile .* applied")
+
+// PTPProbe returns a probe that observes whether PTP is in sync, checking the PTP daemon logs from the last logWindow
+// for errors. The probe fails if PTP is out of sync or the logs contain errors.
+func PTPProbe(apiClient *clients.Settings, logWindow time.Duration) ProbeFunc {
+	return func(context.Context) (Observation, error) {
+		ptpOnSync, err := ptp.ValidatePTPStatus(apiClient, logWindow)
+
+		observation := Observation{Values: map[string]string{"state": ptpUnsynced}, Failed: !ptpOnSync || err != nil}
+		if ptpOnSync {
+			observation.Values["state"] = ptpSynced
+		}
+
+		if err != nil {
+			observation.Detail = err.Error()
+		}
+
+		return observation, nil
+	}
+}
+
+// PodRestartsProbe returns a probe that observes the total container restarts of every pod in namespace, so any restart
+// is a transition.
+func PodRestartsProbe(apiClient *clients.Settings, namespace string) ProbeFunc {
+	return func(context.Context) (Observation, error) {
+		podList, err := pod.List(apiClient, namespace, metav1.ListOptions{})
+		if err != nil {
+			return Observation{}, fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err)
+		}
+
+		observation := Observation{Values: make(map[string]string)}
+
+		for _, podBuilder := range podList {
+			totalRestarts := 0
+			for _, containerStatus := range podBuilder.Object.Status.ContainerStatuses {
+				totalRestarts += int(containerStatus.RestartCount)
+			}
+
+			observation.Values[podBuilder.Object.Name] = fmt.Sprintf("%d", totalRestarts)
+		}
+
+		return observation, nil
+	}
+}
+
+// PolicyStatusProbe returns a probe that observes the compliance state of every policy in the namespace of
+// clusterName, so any change in compliance is a transition.
+func PolicyStatusProbe(apiClient *clients.Settings, clusterName string) ProbeFunc {
+	return func(context.Context) (Observation, error) {
+		allPolicies, err := ocm.ListPoliciesInAllNamespaces(apiClient,
+			runtimeclient.ListOptions{Namespace: clusterName})
+		if err != nil {
+			return Observation{}, fmt.Errorf("failed to get policies in %q NS: %w", clusterName, err)
+		}
+
+		observation := Observation{Values: make(map[string]string)}
+
+		for _, policy := range allPolicies {
+			observation.Values[policy.Definition.Name] = string(policy.Object.Status.ComplianceState)
+		}
+
+		return observation, nil
+	}
+}
+
+// TunedRestartsProbe returns a probe that observes how many times each tuned pod applied its profile, which increases
+// every time tuned restarts.
+func TunedRestartsProbe(apiClient *clients.Settings) ProbeFunc {
+	return func(context.Context) (Observation, error) {
+		tunedPods, err := pod.ListByNamePattern(apiClient, "tuned", "openshift-cluster-node-tuning-operator")
+		if err != nil {
+			return Observation{}, fmt.Errorf("failed to list tuned pods: %w", err)
+		}
+
+		observation := Observation{Values: make(map[string]string)}
+
+		for _, tunedPod := range tunedPods {
+			tunedLog, err := tunedPod.GetFullLog("tuned")
+			if err != nil {
+				return Observation{}, fmt.Errorf("failed to get log of tuned pod %s: %w", tunedPod.Object.Name, err)
+			}
+
+			tunedApplyCount := len(tunedAppliedRegex.FindAllString(tunedLog, -1))
+			observation.Values[tunedPod.Object.Name] = fmt.Sprintf("%d", tunedApplyCount)
+		}
+
+		return observation, nil
+	}
+}
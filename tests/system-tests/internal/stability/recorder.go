@@ -0,0 +1,226 @@
+package stability
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// Observation is what a probe observed about the cluster at a single point in time.
+type Observation struct {
+	// Values are the states of the things the probe watches, keyed by name, such as the restart count of each pod. A
+	// change in any value between samples is a transition.
+	Values map[string]string `json:"values,omitempty"`
+	// Failed is whether the probe considers the observed state a failure, such as PTP being out of sync.
+	Failed bool `json:"failed,omitempty"`
+	// Detail is a human readable explanation of the observation. It is not compared between samples.
+	Detail string `json:"detail,omitempty"`
+}
+
+// ProbeFunc observes part of the cluster. It returns an error only if it could not observe it, not if the observed
+// state is bad; the Failed field of the Observation is used for that instead.
+type ProbeFunc func(ctx context.Context) (Observation, error)
+
+// Sample is a single observation of a probe stored by a Recorder.
+type Sample struct {
+	Probe string    `json:"probe"`
+	Time  time.Time `json:"time"`
+	Observation
+	// Error is the error of the probe if it could not observe the cluster, in which case the observation is empty.
+	Error string `json:"error,omitempty"`
+}
+
+// probe is a probe registered with a Recorder.
+type probe struct {
+	name     string
+	interval time.Duration
+	probe    ProbeFunc
+}
+
+// Recorder periodically runs named probes and writes their samples as JSON lines to an output file. Probes should be
+// registered using Register before calling Run.
+type Recorder struct {
+	outputFile string
+	probes     []probe
+
+	mutex sync.Mutex
+	// file is the output file while Run is running.
+	file *os.File
+}
+
+// NewRecorder creates a new Recorder that writes samples to outputFile. The file is truncated when Run starts, so it
+// only ever holds the samples of a single run.
+func NewRecorder(outputFile string) *Recorder {
+	return &Recorder{outputFile: outputFile}
+}
+
+// Register adds a probe named name that Run calls every interval. Names must be unique and the interval must be
+// positive.
+func (recorder *Recorder) Register(name string, interval time.Duration, probeFunc ProbeFunc) error {
+	if name == "" {
+		return fmt.Errorf("cannot register probe with empty name")
+	}
+
+	if interval <= 0 {
+		return fmt.Errorf("cannot register probe %s with non-positive interval %s", name, interval)
+	}
+
+	if probeFunc == nil {
+		return fmt.Errorf("cannot register probe %s with nil probe function", name)
+	}
+
+	for _, registered := range recorder.probes {
+		if registered.name == name {
+			return fmt.Errorf("probe %s is already registered", name)
+		}
+	}
+
+	recorder.probes = append(recorder.probes, probe{name: name, interval: interval, probe: probeFunc})
+
+	return nil
+}
+
+// Run samples every registered probe immediately and then once per its interval until ctx is done. Probes run
+// concurrently with each other, but each probe only runs once at a time. The errors of writing samples are returned
+// once all probes stop; probe errors are recorded in the samples instead.
+func (recorder *Recorder) Run(ctx context.Context) error {
+	if len(recorder.probes) == 0 {
+		return fmt.Errorf("cannot run recorder with no registered probes")
+	}
+
+	klog.V(90).Infof("Recording %d stability probes to %s", len(recorder.probes), recorder.outputFile)
+
+	file, err := os.Create(recorder.outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to create stability output file %s: %w", recorder.outputFile, err)
+	}
+
+	recorder.mutex.Lock()
+	recorder.file = file
+	recorder.mutex.Unlock()
+
+	var (
+		waitGroup sync.WaitGroup
+		errsMutex sync.Mutex
+		errs      []error
+	)
+
+	for _, registered := range recorder.probes {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			ticker := time.NewTicker(registered.interval)
+			defer ticker.Stop()
+
+			for {
+				err := recorder.sample(ctx, registered)
+				if err != nil {
+					errsMutex.Lock()
+					errs = append(errs, err)
+					errsMutex.Unlock()
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+	}
+
+	waitGroup.Wait()
+
+	recorder.mutex.Lock()
+	recorder.file = nil
+	recorder.mutex.Unlock()
+
+	if err := file.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to close stability output file %s: %w", recorder.outputFile, err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// sample runs a single probe and appends its sample to the output file.
+func (recorder *Recorder) sample(ctx context.Context, registered probe) error {
+	observation, err := registered.probe(ctx)
+
+	// Probes that were cut off by the end of the run are not recorded, since they would look like probe failures.
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	sample := Sample{Probe: registered.name, Time: time.Now(), Observation: observation}
+
+	if err != nil {
+		klog.V(90).Infof("Stability probe %s failed: %v", registered.name, err)
+
+		sample = Sample{Probe: registered.name, Time: sample.Time, Error: err.Error()}
+	}
+
+	return recorder.write(sample)
+}
+
+// write appends sample to the output file as a single JSON line. It must only be called while Run is running.
+func (recorder *Recorder) write(sample Sample) error {
+	line, err := json.Marshal(sample)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sample of probe %s: %w", sample.Probe, err)
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	_, err = recorder.file.Write(append(line, '\n'))
+	if err != nil {
+		return fmt.Errorf("failed to write sample of probe %s: %w", sample.Probe, err)
+	}
+
+	return nil
+}
+
+// ReadSamples reads the samples from a file written by a Recorder, in the order they were recorded.
+func ReadSamples(filePath string) ([]Sample, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stability output file %s: %w", filePath, err)
+	}
+
+	defer file.Close()
+
+	var samples []Sample
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var sample Sample
+
+		err := json.Unmarshal(scanner.Bytes(), &sample)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse line %d of %s: %w", lineNumber, filePath, err)
+		}
+
+		samples = append(samples, sample)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read stability output file %s: %w", filePath, err)
+	}
+
+	return samples, nil
+}
@@ -0,0 +1,129 @@
+package stability
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorderRun(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "stability.jsonl")
+	recorder := NewRecorder(outputFile)
+
+	var calls atomic.Int32
+
+	err := recorder.Register("counter", 5*time.Millisecond, func(context.Context) (Observation, error) {
+		if calls.Add(1) == 2 {
+			return Observation{}, errors.New("api unavailable")
+		}
+
+		return Observation{Values: map[string]string{"pod": "0"}}, nil
+	})
+	assert.NoError(t, err)
+
+	err = recorder.Register("counter", time.Second, func(context.Context) (Observation, error) {
+		return Observation{}, nil
+	})
+	assert.EqualError(t, err, "probe counter is already registered")
+
+	err = recorder.Register("zero", 0, func(context.Context) (Observation, error) {
+		return Observation{}, nil
+	})
+	assert.Error(t, err)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+	defer cancel()
+
+	assert.NoError(t, recorder.Run(ctx))
+
+	samples, err := ReadSamples(outputFile)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, len(samples), 3)
+	assert.Equal(t, "counter", samples[0].Probe)
+	assert.Equal(t, map[string]string{"pod": "0"}, samples[0].Values)
+	assert.Equal(t, "api unavailable", samples[1].Error)
+
+	reports := Analyze(samples)
+	assert.Len(t, reports, 1)
+	assert.Equal(t, 1, reports[0].Errors)
+	assert.True(t, reports[0].Stable())
+	assert.NoError(t, reports[0].Err())
+}
+
+func TestRecorderRunTruncates(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "stability.jsonl")
+	err := os.WriteFile(outputFile, []byte(`{"probe":"previous-run","time":"2026-01-01T00:00:00Z"}`+"\n"), 0644)
+	assert.NoError(t, err)
+
+	recorder := NewRecorder(outputFile)
+
+	err = recorder.Register("ptp", time.Hour, func(context.Context) (Observation, error) {
+		return Observation{Values: map[string]string{"state": "Sync"}}, nil
+	})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 20*time.Millisecond)
+	defer cancel()
+
+	assert.NoError(t, recorder.Run(ctx))
+
+	samples, err := ReadSamples(outputFile)
+	assert.NoError(t, err)
+	assert.Len(t, samples, 1)
+	assert.Equal(t, "ptp", samples[0].Probe)
+}
+
+func TestAnalyze(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sampleAt := func(minute int, probe string, failed bool, values map[string]string) Sample {
+		return Sample{
+			Probe:       probe,
+			Time:        start.Add(time.Duration(minute) * time.Minute),
+			Observation: Observation{Values: values, Failed: failed},
+		}
+	}
+
+	samples := []Sample{
+		sampleAt(0, "ptp", false, map[string]string{"state": "Sync"}),
+		sampleAt(0, "pods", false, map[string]string{"etcd-0": "0"}),
+		sampleAt(2, "ptp", true, map[string]string{"state": "Unsync"}),
+		// Written out of order, as concurrent probes may do.
+		sampleAt(1, "ptp", false, map[string]string{"state": "Sync"}),
+		sampleAt(1, "pods", false, map[string]string{"etcd-0": "0"}),
+		{Probe: "pods", Time: start.Add(2 * time.Minute), Error: "api unavailable"},
+		sampleAt(3, "ptp", false, map[string]string{"state": "Sync"}),
+		sampleAt(3, "pods", false, map[string]string{"etcd-0": "0"}),
+		sampleAt(4, "ptp", true, map[string]string{"state": "Unsync"}),
+		sampleAt(4, "pods", false, map[string]string{"etcd-0": "1", "etcd-1": "0"}),
+	}
+
+	reports := Analyze(samples)
+	assert.Len(t, reports, 2)
+
+	pods := reports[0]
+	assert.Equal(t, "pods", pods.Probe)
+	assert.Equal(t, 5, pods.Samples)
+	assert.Equal(t, 1, pods.Errors)
+	assert.Equal(t, 0, pods.Failures)
+	assert.True(t, pods.FirstFailure.IsZero())
+	assert.Equal(t, []Transition{
+		{Probe: "pods", Key: "etcd-0", Time: start.Add(4 * time.Minute), From: "0", To: "1"},
+		{Probe: "pods", Key: "etcd-1", Time: start.Add(4 * time.Minute), From: "", To: "0"},
+	}, pods.Transitions)
+	assert.False(t, pods.Stable())
+
+	ptp := reports[1]
+	assert.Equal(t, "ptp", ptp.Probe)
+	assert.Equal(t, 2, ptp.Failures)
+	assert.Equal(t, start.Add(2*time.Minute), ptp.FirstFailure)
+	assert.Len(t, ptp.Transitions, 3)
+	assert.Equal(t, 2, ptp.Flaps)
+	assert.ErrorContains(t, ptp.Err(), "ptp: observed 2 failures out of 5 samples, first at 2026-01-01T00:02:00Z")
+	assert.ErrorContains(t, ptp.Err(), `ptp: state changed from "Sync" to "Unsync" at 2026-01-01T00:02:00Z`)
+}
@@ -2,15 +2,10 @@ package systemtestsconfig
 
 import (
 	"log"
-	"os"
 	"path/filepath"
 	"runtime"
 
-	"github.com/kelseyhightower/envconfig"
-
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
-
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -39,48 +34,12 @@ func NewSystemTestsConfig() *SystemTestsConfig {
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultSystemTestsParamsFile)
 
-	err := readFile(&systemConf, confFile)
+	err := config.NewLoader().WithDefaultFile(confFile).Load(&systemConf)
 	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
-
-		return nil
-	}
-
-	err = readEnv(&systemConf)
-	if err != nil {
-		log.Print("Error to read environment variables")
+		log.Printf("Error to load config file %s: %v", confFile, err)
 
 		return nil
 	}
 
 	return &systemConf
 }
-
-func readFile(systemtestsConfig *SystemTestsConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-
-	err = decoder.Decode(&systemtestsConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(systemtestsConfig *SystemTestsConfig) error {
-	err := envconfig.Process("", systemtestsConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
@@ -48,11 +48,11 @@ var (
 	// command in the container.
 	ContainerCmdSleep = append(slices.Clone(ContainerCmdBash), "sleep infinity")
 
-	// IpsecCmdShow IPSec command string to check for active/open tunnels.
-	IpsecCmdShow = append(slices.Clone(ContainerCmdChroot), "ipsec show")
+	// IpsecCmdShow IPSec command run on the node to check for active/open tunnels.
+	IpsecCmdShow = "ipsec show"
 
-	// IpsecCmdTrafficStatus IPSec command string to check for tunnel packets.
-	IpsecCmdTrafficStatus = append(slices.Clone(ContainerCmdChroot), "ipsec trafficstatus")
+	// IpsecCmdTrafficStatus IPSec command run on the node to check for tunnel packets.
+	IpsecCmdTrafficStatus = "ipsec trafficstatus"
 
 	// Iperf3OptionBind option to bind to an IP.
 	Iperf3OptionBind = "-B"
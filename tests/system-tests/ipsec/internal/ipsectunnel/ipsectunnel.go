@@ -1,11 +1,13 @@
 package ipsectunnel
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
 
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/remote"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ipsec/internal/ipsecinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ipsec/internal/ipsecparams"
 	"k8s.io/klog/v2"
 )
@@ -23,7 +25,7 @@ func TunnelConnected(nodeName string) error {
 	klog.V(ipsecparams.IpsecLogLevel).Infof("Checking IPSec tunnel connection status. Exec cmd: %v",
 		ipsecparams.IpsecCmdShow)
 
-	ipsecShowStr, err := remote.ExecuteOnNodeWithDebugPod(ipsecparams.IpsecCmdShow, nodeName)
+	ipsecShowStr, err := execOnNode(nodeName, ipsecparams.IpsecCmdShow)
 	if err != nil {
 		klog.V(ipsecparams.IpsecLogLevel).Infof("error could not execute command: %s", err)
 
@@ -50,7 +52,7 @@ func TunnelPackets(nodeName string) *IpsecTunnelPackets {
 	klog.V(ipsecparams.IpsecLogLevel).Infof("Checking IPSec tunnel traffic status. Exec cmd: %v",
 		ipsecparams.IpsecCmdTrafficStatus)
 
-	ipsecOutput, err := remote.ExecuteOnNodeWithDebugPod(ipsecparams.IpsecCmdTrafficStatus, nodeName)
+	ipsecOutput, err := execOnNode(nodeName, ipsecparams.IpsecCmdTrafficStatus)
 	if err != nil {
 		klog.V(ipsecparams.IpsecLogLevel).Infof("error could not execute command: %s", err)
 
@@ -59,15 +61,25 @@ func TunnelPackets(nodeName string) *IpsecTunnelPackets {
 
 	klog.V(ipsecparams.IpsecLogLevel).Infof("IPSec packets: %s", ipsecOutput)
 
+	tunnelPackets, err := parseTunnelPackets(ipsecOutput)
+	if err != nil {
+		klog.V(ipsecparams.IpsecLogLevel).Infof("Error %v", err)
+
+		return nil
+	}
+
+	return tunnelPackets
+}
+
+// parseTunnelPackets parses the tunnel ingress and egress packets from the output of the ipsec trafficstatus command.
+func parseTunnelPackets(ipsecOutput string) (*IpsecTunnelPackets, error) {
 	// Example output string:
 	//   (output will be empty if there are no tunnels connected)
 	// [core@sno ~]$ sudo ipsec trafficstatus
 	// 006 #12: "21939ab9-6546-4652-8eaf-1be04415ac24", type=ESP, add_time=1714739598, \
 	//		inBytes=0, outBytes=0, maxBytes=2^63B, id='CN=north'
 	if len(ipsecOutput) < 1 {
-		klog.V(ipsecparams.IpsecLogLevel).Infof("Error IPSec tunnel is not up for traffic status")
-
-		return nil
+		return nil, fmt.Errorf("IPSec tunnel is not up for traffic status")
 	}
 
 	//
@@ -78,25 +90,21 @@ func TunnelPackets(nodeName string) *IpsecTunnelPackets {
 
 	startIndex := strings.Index(ipsecOutput, inBytesStr)
 	if startIndex < 1 {
-		klog.V(ipsecparams.IpsecLogLevel).Infof("Error Cannot parse IPSec traffic status inBytes: %v", ipsecOutput)
-
-		return nil
+		return nil, fmt.Errorf("cannot parse IPSec traffic status inBytes: %v", ipsecOutput)
 	}
 
 	endIndex := strings.Index(ipsecOutput[startIndex:], commaStr)
 	if endIndex < 1 {
-		klog.V(ipsecparams.IpsecLogLevel).Infof("Error Cannot parse IPSec traffic status inBytes ending: %v", ipsecOutput)
-
-		return nil
+		return nil, fmt.Errorf("cannot parse IPSec traffic status inBytes ending: %v", ipsecOutput)
 	}
 
 	tunnelPackets := &IpsecTunnelPackets{}
 
+	var err error
+
 	tunnelPackets.InBytes, err = strconv.Atoi(ipsecOutput[startIndex+len(inBytesStr) : startIndex+endIndex])
 	if err != nil {
-		klog.V(ipsecparams.IpsecLogLevel).Infof("Error Cannot parse IPSec traffic status inBytes value: %v", ipsecOutput)
-
-		return nil
+		return nil, fmt.Errorf("cannot parse IPSec traffic status inBytes value: %v", ipsecOutput)
 	}
 
 	//
@@ -106,24 +114,39 @@ func TunnelPackets(nodeName string) *IpsecTunnelPackets {
 
 	startIndex = strings.Index(ipsecOutput, outBytesStr)
 	if startIndex < 1 {
-		klog.V(ipsecparams.IpsecLogLevel).Infof("Error Cannot parse IPSec traffic status outBytes: %v", ipsecOutput)
-
-		return nil
+		return nil, fmt.Errorf("cannot parse IPSec traffic status outBytes: %v", ipsecOutput)
 	}
 
 	endIndex = strings.Index(ipsecOutput[startIndex:], commaStr)
 	if endIndex < 1 {
-		klog.V(ipsecparams.IpsecLogLevel).Infof("Error Cannot parse IPSec traffic status outBytes ending: %v", ipsecOutput)
-
-		return nil
+		return nil, fmt.Errorf("cannot parse IPSec traffic status outBytes ending: %v", ipsecOutput)
 	}
 
 	tunnelPackets.OutBytes, err = strconv.Atoi(ipsecOutput[startIndex+len(outBytesStr) : startIndex+endIndex])
 	if err != nil {
-		klog.V(ipsecparams.IpsecLogLevel).Infof("Error Cannot parse IPSec traffic status outBytes value: %v", ipsecOutput)
+		return nil, fmt.Errorf("cannot parse IPSec traffic status outBytes value: %v", ipsecOutput)
+	}
 
-		return nil
+	return tunnelPackets, nil
+}
+
+// execOnNode runs command on the host of nodeName using the node executor and returns its stdout.
+func execOnNode(nodeName, command string) (string, error) {
+	executor, err := cluster.NewNodeExecutor(APIClient)
+	if err != nil {
+		return "", fmt.Errorf("failed to create node executor: %w", err)
 	}
 
-	return tunnelPackets
+	defer func() {
+		if err := executor.Close(); err != nil {
+			klog.V(ipsecparams.IpsecLogLevel).Infof("Failed to close node executor: %v", err)
+		}
+	}()
+
+	result, err := executor.Exec(context.TODO(), nodeName, command)
+	if err != nil {
+		return "", err
+	}
+
+	return result.Stdout, nil
 }
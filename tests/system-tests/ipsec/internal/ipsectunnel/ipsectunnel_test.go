@@ -0,0 +1,42 @@
+package ipsectunnel
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ipsec/internal/ipsecparams"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTunnelPackets(t *testing.T) {
+	fixtures, err := cluster.ReadExecFixtures("testdata/ipsec-trafficstatus.yaml")
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		nodeName        string
+		expectedPackets *IpsecTunnelPackets
+		expectedError   bool
+	}{
+		{name: "no traffic", nodeName: "sno-0", expectedPackets: &IpsecTunnelPackets{}},
+		{name: "traffic", nodeName: "sno-1", expectedPackets: &IpsecTunnelPackets{InBytes: 1234567, OutBytes: 7654321}},
+		{name: "no tunnel", nodeName: "sno-2", expectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			output, err := fixtures.Stdout(testCase.nodeName, ipsecparams.IpsecCmdTrafficStatus)
+			assert.NoError(t, err)
+
+			tunnelPackets, err := parseTunnelPackets(output)
+			if testCase.expectedError {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedPackets, tunnelPackets)
+		})
+	}
+}
@@ -2,15 +2,13 @@ package ocloudconfig
 
 import (
 	"log"
-	"os"
 	"path/filepath"
 	"runtime"
 	"time"
 
-	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/systemtestsconfig"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -153,16 +151,9 @@ func NewOCloudConfig() *OCloudConfig {
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultOCloudParamsFile)
 
-	err := readFile(&ocloudConf, confFile)
+	err := config.NewLoader().WithDefaultFile(confFile).WithEnvPrefix("ECO_OCLOUD_").Load(&ocloudConf)
 	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
-
-		return nil
-	}
-
-	err = readEnv(&ocloudConf)
-	if err != nil {
-		log.Print("Error to read environment variables")
+		log.Printf("Error to load config file %s: %v", confFile, err)
 
 		return nil
 	}
@@ -179,32 +170,3 @@ func NewOCloudConfig() *OCloudConfig {
 
 	return &ocloudConf
 }
-
-func readFile(ocloudConfig *OCloudConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-
-	err = decoder.Decode(ocloudConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(ocloudConfig *OCloudConfig) error {
-	err := envconfig.Process("", ocloudConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
@@ -1,6 +1,7 @@
 package ran_du_system_test
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -12,6 +13,8 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/shell"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/stability"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuinittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuparams"
+	"k8s.io/klog/v2"
 )
 
 var _ = Describe(
@@ -38,94 +41,59 @@ var _ = Describe(
 			Expect(err).ToNot(HaveOccurred(), "Failed to get cluster name")
 		})
 		It("StabilityNoWorkload", reportxml.ID("74522"), Label("StabilityNoWorkload"), func() {
-			outputDir := RanDuTestConfig.StabilityOutputPath
-			policiesOutputFile := fmt.Sprintf("%s/stability_no_workload_policies.log", outputDir)
-			ptpOutputFile := fmt.Sprintf("%s/stability_no_workload_ptp.log", outputDir)
-			tunedRestartsOutputFile := fmt.Sprintf("%s/stability_no_workload_tuned_restarts.log", outputDir)
+			outputFile := fmt.Sprintf(
+				"%s/stability_no_workload_%s.jsonl", RanDuTestConfig.StabilityOutputPath, RanDuTestConfig.RunID)
 			namespaces := []string{"openshift-etcd", "openshift-apiserver"}
 
 			totalDuration := time.Duration(RanDuTestConfig.StabilityNoWorkloadDurMins) * time.Minute
 			interval := time.Duration(RanDuTestConfig.StabilityNoWorkloadIntMins) * time.Minute
-			startTime := time.Now()
 
-			By(fmt.Sprintf("Collecting metrics during %d minutes", RanDuTestConfig.StabilityNoWorkloadDurMins))
-
-			for time.Since(startTime) < totalDuration {
-				if RanDuTestConfig.PtpEnabled {
-					err = stability.SavePTPStatus(APIClient, ptpOutputFile, interval)
-					if err != nil {
-						fmt.Printf("Error, could not save PTP")
-					}
-				}
-
-				if RanDuTestConfig.StabilityPoliciesCheck {
-					err = stability.SavePolicyStatus(APIClient, clusterName, policiesOutputFile)
-					if err != nil {
-						fmt.Printf("Error, could not save policies status")
-					}
-				}
+			recorder := stability.NewRecorder(outputFile)
 
-				for _, namespace := range namespaces {
-					err = stability.SavePodsRestartsInNamespace(APIClient,
-						namespace, fmt.Sprintf("%s/stability_no_workload_%s.log", outputDir, namespace))
-					if err != nil {
-						fmt.Printf("Error, could not save Pod restarts")
-					}
-				}
+			if RanDuTestConfig.PtpEnabled {
+				err := recorder.Register("ptp", interval, stability.PTPProbe(APIClient, interval))
+				Expect(err).ToNot(HaveOccurred(), "Failed to register PTP probe")
+			}
 
-				err = stability.SaveTunedRestarts(APIClient, tunedRestartsOutputFile)
-				if err != nil {
-					fmt.Printf("Error, could not save tuned restarts")
-				}
+			if RanDuTestConfig.StabilityPoliciesCheck {
+				err := recorder.Register("policies", interval, stability.PolicyStatusProbe(APIClient, clusterName))
+				Expect(err).ToNot(HaveOccurred(), "Failed to register policies probe")
+			}
 
-				time.Sleep(interval)
+			for _, namespace := range namespaces {
+				err := recorder.Register(
+					"pod-restarts-"+namespace, interval, stability.PodRestartsProbe(APIClient, namespace))
+				Expect(err).ToNot(HaveOccurred(), "Failed to register pod restarts probe for %s", namespace)
 			}
 
-			// Final check of all values
-			By("Check all results")
+			err := recorder.Register("tuned-restarts", interval, stability.TunedRestartsProbe(APIClient))
+			Expect(err).ToNot(HaveOccurred(), "Failed to register tuned restarts probe")
 
-			var stabilityErrors []string
+			By(fmt.Sprintf("Collecting metrics during %d minutes", RanDuTestConfig.StabilityNoWorkloadDurMins))
 
-			// Verify policies
-			By("Check Policy changes")
+			ctx, cancel := context.WithTimeout(context.TODO(), totalDuration)
+			defer cancel()
 
-			if RanDuTestConfig.StabilityPoliciesCheck {
-				_, err := stability.VerifyStabilityStatusChange(policiesOutputFile)
-				if err != nil {
-					stabilityErrors = append(stabilityErrors, err.Error())
-				}
-			}
+			err = recorder.Run(ctx)
+			Expect(err).ToNot(HaveOccurred(), "Failed to record stability samples")
 
-			// Verify podRestarts
-			By("Check Pod restarts")
+			By("Check all results")
 
-			for _, namespace := range namespaces {
-				_, err := stability.VerifyStabilityStatusChange(fmt.Sprintf("%s/stability_no_workload_%s.log",
-					outputDir,
-					namespace))
-				if err != nil {
-					stabilityErrors = append(stabilityErrors, err.Error())
-				}
-			}
+			reports, err := stability.AnalyzeFile(outputFile)
+			Expect(err).ToNot(HaveOccurred(), "Failed to analyze stability samples")
+
+			var stabilityErrors []string
 
-			// Verify PTP output
-			By("Check PTP results")
+			for _, report := range reports {
+				klog.V(randuparams.RanDuLogLevel).Infof("Stability probe %s: %d samples, %d errors, %d failures, "+
+					"%d transitions, %d flaps", report.Probe, report.Samples, report.Errors, report.Failures,
+					len(report.Transitions), report.Flaps)
 
-			if RanDuTestConfig.PtpEnabled {
-				_, err = stability.VerifyStabilityStatusChange(ptpOutputFile)
-				if err != nil {
+				if err := report.Err(); err != nil {
 					stabilityErrors = append(stabilityErrors, err.Error())
 				}
 			}
 
-			// Verify tuned restarts
-			By("Check tuneds restarts")
-
-			_, err = stability.VerifyStabilityStatusChange(tunedRestartsOutputFile)
-			if err != nil {
-				stabilityErrors = append(stabilityErrors, err.Error())
-			}
-
 			By("Check if there been any error")
 
 			if len(stabilityErrors) > 0 {
@@ -2,19 +2,21 @@ package rdscorecommon
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/url"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	. "github.com/onsi/gomega"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nto"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/rh-ecosystem-edge/eco-gotests/internal/stats"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/promquery"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/rdscore/internal/rdscoreinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/rdscore/internal/rdscoreparams"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -73,7 +75,8 @@ func discoverPrometheusPod(apiClient *clients.Settings) (*pod.Builder, error) {
 	return promPods[0], nil
 }
 
-// ExecPromQuery executes a Prometheus query via curl in the Prometheus pod with retry logic.
+// ExecPromQuery executes a Prometheus query via curl in the Prometheus pod with retry logic, using a promquery pod
+// client.
 // Uses exponential backoff (k8s wait.ExponentialBackoffWithContext) for retries.
 // Returns errors gracefully instead of panicking on timeout.
 func ExecPromQuery(apiClient *clients.Settings, query string) ([]rdscoreparams.PromMetric, error) {
@@ -115,41 +118,26 @@ func ExecPromQuery(apiClient *clients.Settings, query string) ([]rdscoreparams.P
 				return false, nil // Retry
 			}
 
-			// URL encode the query
-			encodedQuery := url.QueryEscape(query)
-
-			// Execute curl command with timeout
-			cmd := []string{
-				"curl", "-s",
-				"--max-time", rdscoreparams.PromQueryCurlTimeout,
-				fmt.Sprintf("http://localhost:9090/api/v1/query?query=%s&timeout=25s", encodedQuery),
-			}
-
-			output, err := promPod.ExecCommand(cmd, rdscoreparams.PrometheusContainerName)
+			promClient, err := promquery.NewPodClient(
+				promPod, rdscoreparams.PrometheusContainerName, "http://localhost:9090")
 			if err != nil {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
-					"Retry: failed to execute Prometheus query: %v", err)
-
-				return false, nil // Retry
+				return false, err
 			}
 
-			var response rdscoreparams.PromQueryResponse
-			if err := json.Unmarshal(output.Bytes(), &response); err != nil {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
-					"Retry: failed to parse Prometheus response: %v", err)
-
-				return false, nil // Retry
-			}
+			attemptCtx, cancelAttempt := context.WithTimeout(ctx, rdscoreparams.PromQueryCurlTimeout)
+			defer cancelAttempt()
 
-			if response.Status != "success" {
+			vector, err := promquery.QueryVector(attemptCtx, prometheusv1.NewAPI(promClient), promquery.Expr(query),
+				time.Time{}, prometheusv1.WithTimeout(rdscoreparams.PromQueryTimeout))
+			if err != nil {
 				klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
-					"Retry: query failed with status %s: %s", response.Status, response.Error)
+					"Retry: failed to execute Prometheus query: %v", err)
 
 				return false, nil // Retry
 			}
 
 			// Success - store result
-			metrics = response.Data.Result
+			metrics = toPromMetrics(vector)
 
 			return true, nil // Success, stop retrying
 		})
@@ -166,6 +154,30 @@ func ExecPromQuery(apiClient *clients.Settings, query string) ([]rdscoreparams.P
 	return metrics, nil
 }
 
+// toPromMetrics converts the samples of a query result to PromMetrics, with the value as the timestamp in seconds and
+// the sample value as a string, the same as in the Prometheus API response.
+func toPromMetrics(vector model.Vector) []rdscoreparams.PromMetric {
+	metrics := make([]rdscoreparams.PromMetric, 0, len(vector))
+
+	for _, sample := range vector {
+		if sample == nil {
+			continue
+		}
+
+		labels := make(map[string]string, len(sample.Metric))
+		for name, value := range sample.Metric {
+			labels[string(name)] = string(value)
+		}
+
+		metrics = append(metrics, rdscoreparams.PromMetric{
+			Metric: labels,
+			Value:  []interface{}{float64(sample.Timestamp) / 1000, sample.Value.String()},
+		})
+	}
+
+	return metrics
+}
+
 // ParseCPUValue parses CPU value from Prometheus result.
 func ParseCPUValue(value interface{}) (float64, error) {
 	strVal, ok := value.(string)
@@ -197,24 +209,11 @@ func CalculateStats(values []float64) (minimum, maximum, average float64) {
 		return 0, 0, 0
 	}
 
-	minimum, maximum = values[0], values[0]
-	sum := 0.0
-
-	for _, value := range values {
-		if value < minimum {
-			minimum = value
-		}
-
-		if value > maximum {
-			maximum = value
-		}
-
-		sum += value
-	}
+	var aggregate stats.Aggregate
 
-	average = sum / float64(len(values))
+	aggregate.Add(values...)
 
-	return
+	return aggregate.Min(), aggregate.Max(), aggregate.Mean()
 }
 
 // DiscoverTargetNodes finds nodes matching the configured label selector with retry logic.
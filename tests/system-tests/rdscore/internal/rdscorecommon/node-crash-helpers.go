@@ -157,23 +157,28 @@ func cleanupVarCrashDirectory(ctx SpecContext, nodeName string) {
 // DumpNodeStatus dumps comprehensive node status information for all nodes in the cluster.
 // This function is typically called in AfterEach hooks when a test fails to provide
 // debugging information about the cluster state.
-//
-//nolint:gocognit,funlen
 func DumpNodeStatus(ctx SpecContext) {
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Node Status Dump - Test Failed")
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
-
 	// Check if the incoming context was already canceled
 	if ctx.Err() != nil {
 		klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
 			"WARNING: SpecContext was already canceled (%v), using fresh context for dump", ctx.Err())
 	}
 
+	// Use a fresh context to ensure dump works even if spec context is canceled
+	dumpNodeStatus(context.Background(), statusDump{})
+}
+
+// dumpNodeStatus writes the node status dump to dump, giving up once ctx is done.
+//
+//nolint:gocognit,funlen
+func dumpNodeStatus(ctx context.Context, dump statusDump) {
+	dump.Infof("========================================")
+	dump.Infof("Node Status Dump - Test Failed")
+	dump.Infof("========================================")
+
 	var allNodes []*nodes.Builder
 
-	// Create a fresh context to ensure dump works even if spec context is canceled
-	dumpCtx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	dumpCtx, cancel := context.WithTimeout(ctx, 1*time.Minute)
 	defer cancel()
 
 	err := wait.PollUntilContextTimeout(dumpCtx, 15*time.Second, 1*time.Minute, true,
@@ -182,13 +187,13 @@ func DumpNodeStatus(ctx SpecContext) {
 
 			allNodes, listErr = nodes.List(APIClient)
 			if listErr != nil {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Failed to list nodes (retrying...): %v", listErr)
+				dump.Infof("Failed to list nodes (retrying...): %v", listErr)
 
 				return false, nil
 			}
 
 			if len(allNodes) == 0 {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof("No nodes found in the cluster (retrying...)")
+				dump.Infof("No nodes found in the cluster (retrying...)")
 
 				return false, nil
 			}
@@ -196,80 +201,80 @@ func DumpNodeStatus(ctx SpecContext) {
 			return true, nil
 		})
 	if err != nil {
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Failed to retrieve node list after retries: %v", err)
+		dump.Infof("Failed to retrieve node list after retries: %v", err)
 
 		return
 	}
 
 	for _, node := range allNodes {
 		if node.Object == nil {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Skipping node with nil Object")
+			dump.Infof("Skipping node with nil Object")
 
 			continue
 		}
 
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Node: %s", node.Object.Name)
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("----------------------------------------")
+		dump.Infof("")
+		dump.Infof("Node: %s", node.Object.Name)
+		dump.Infof("----------------------------------------")
 
 		// Dump Spec Information
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Spec Information:")
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Unschedulable: %v", node.Object.Spec.Unschedulable)
+		dump.Infof("Spec Information:")
+		dump.Infof("  Unschedulable: %v", node.Object.Spec.Unschedulable)
 
 		if len(node.Object.Spec.Taints) > 0 {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Taints:")
+			dump.Infof("  Taints:")
 
 			for _, taint := range node.Object.Spec.Taints {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof("    - Key: %s, Value: %s, Effect: %s",
+				dump.Infof("    - Key: %s, Value: %s, Effect: %s",
 					taint.Key, taint.Value, taint.Effect)
 			}
 		} else {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Taints: <none>")
+			dump.Infof("  Taints: <none>")
 		}
 
 		// Dump Status Information
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Status Information:")
+		dump.Infof("")
+		dump.Infof("Status Information:")
 
 		// Dump Allocatable Resources
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Allocatable Resources:")
+		dump.Infof("  Allocatable Resources:")
 
 		if len(node.Object.Status.Allocatable) > 0 {
 			for resourceName, quantity := range node.Object.Status.Allocatable {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof("    - %s: %s", resourceName, quantity.String())
+				dump.Infof("    - %s: %s", resourceName, quantity.String())
 			}
 		} else {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("    <none>")
+			dump.Infof("    <none>")
 		}
 
 		// Dump Capacity Resources
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Capacity Resources:")
+		dump.Infof("")
+		dump.Infof("  Capacity Resources:")
 
 		if len(node.Object.Status.Capacity) > 0 {
 			for resourceName, quantity := range node.Object.Status.Capacity {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof("    - %s: %s", resourceName, quantity.String())
+				dump.Infof("    - %s: %s", resourceName, quantity.String())
 			}
 		} else {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("    <none>")
+			dump.Infof("    <none>")
 		}
 
 		// Dump Conditions
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Conditions:")
+		dump.Infof("")
+		dump.Infof("  Conditions:")
 
 		if len(node.Object.Status.Conditions) > 0 {
 			for _, condition := range node.Object.Status.Conditions {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof("    - Type: %s, Status: %s, Reason: %s, Message: %s",
+				dump.Infof("    - Type: %s, Status: %s, Reason: %s, Message: %s",
 					condition.Type, condition.Status, condition.Reason, condition.Message)
 			}
 		} else {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("    <none>")
+			dump.Infof("    <none>")
 		}
 	}
 
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("End of Node Status Dump")
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
+	dump.Infof("")
+	dump.Infof("========================================")
+	dump.Infof("End of Node Status Dump")
+	dump.Infof("========================================")
 }
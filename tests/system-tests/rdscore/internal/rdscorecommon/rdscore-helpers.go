@@ -3,14 +3,13 @@ package rdscorecommon
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/retry"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/rdscore/internal/rdscoreparams"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 )
 
@@ -25,37 +24,30 @@ const (
 	drainNodeRetryTimeout = 2 * time.Minute  // Retry window for transient failures
 )
 
+var (
+	// uncordonRetryClassifier retries uncordoning while the API server or the infra config cache of the node are
+	// unavailable.
+	uncordonRetryClassifier = retry.Classifiers(
+		retry.ClassifyMessages(retry.ClassAPIUnavailable, "ManagedNode infra config cache not synchronized"),
+		retry.ClassifyConnection)
+	// drainRetryClassifier retries draining after transient failures such as gRPC keepalive timeouts.
+	drainRetryClassifier = retry.Classifiers(
+		retry.ClassifyMessages(retry.ClassAPIUnavailable, "keepalive", "Unavailable"),
+		retry.ClassifyConnection)
+)
+
 // UncordonNode uncordons a node referenced by nodeToUncordon parameter.
 // It retries uncordoning for the specified timeout duration at regular intervals.
 // Returns error if uncordon fails after timeout to allow caller to handle appropriately.
 func UncordonNode(nodeToUncordon *nodes.Builder, interval, timeout time.Duration) error {
 	By(fmt.Sprintf("Uncordoning node %q", nodeToUncordon.Definition.Name))
 
-	err := wait.PollUntilContextTimeout(context.TODO(), interval, timeout, true,
-		func(context.Context) (bool, error) {
-			err := nodeToUncordon.Uncordon()
-			if err != nil {
-				errorMsg := err.Error()
-				// Log retryable errors differently from permanent failures
-				if strings.Contains(errorMsg, "ManagedNode infra config cache not synchronized") ||
-					strings.Contains(errorMsg, "connection reset") ||
-					strings.Contains(errorMsg, "connection refused") {
-					klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
-						"Uncordon failed with retryable error, will retry: %v", err)
-
-					return false, nil
-				}
-
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
-					"Failed to uncordon %q: %v", nodeToUncordon.Definition.Name, err)
-
-				return false, err
-			}
-
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Successfully uncordon %q", nodeToUncordon.Definition.Name)
-
-			return true, nil
-		})
+	err := retry.DoWithTimeout(timeout, retry.Policy{
+		Backoff:    retry.ConstantBackoff(interval),
+		Classifier: uncordonRetryClassifier,
+	}, func(context.Context) error {
+		return nodeToUncordon.Uncordon()
+	})
 	if err != nil {
 		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Failed to uncordon %q after %v: %v",
 			nodeToUncordon.Definition.Name, timeout, err)
@@ -63,6 +55,8 @@ func UncordonNode(nodeToUncordon *nodes.Builder, interval, timeout time.Duration
 		return fmt.Errorf("failed to uncordon %q within %v: %w", nodeToUncordon.Definition.Name, timeout, err)
 	}
 
+	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Successfully uncordon %q", nodeToUncordon.Definition.Name)
+
 	return nil
 }
 
@@ -88,45 +82,25 @@ func DrainNodeWithRetry(ctx context.Context, nodeToDrain *nodes.Builder) error {
 
 	startTime := time.Now()
 
-	var lastErr error
-
-	// Retry for transient failures (gRPC keepalive timeouts, etc.)
-	err := wait.PollUntilContextTimeout(ctx, 15*time.Second,
-		drainNodeRetryTimeout, true,
-		func(context.Context) (bool, error) {
-			lastErr = nodeToDrain.Drain()
-			if lastErr == nil {
-				duration := time.Since(startTime)
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
-					"Successfully drained node %q in %v",
-					nodeToDrain.Definition.Name, duration)
-
-				return true, nil
-			}
-
-			// Check if error is retryable (gRPC/network issues)
-			errorMsg := lastErr.Error()
-			if strings.Contains(errorMsg, "keepalive") ||
-				strings.Contains(errorMsg, "Unavailable") ||
-				strings.Contains(errorMsg, "connection refused") {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
-					"Drain failed with retryable error, will retry: %v", lastErr)
-
-				return false, nil // Retry
-			}
-
-			// Non-retryable error - fail immediately
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
-				"Drain failed with non-retryable error: %v", lastErr)
-
-			return false, lastErr
-		})
+	retryCtx, cancel := context.WithTimeout(ctx, drainNodeRetryTimeout)
+	defer cancel()
+
+	err := retry.Do(retryCtx, retry.Policy{
+		Backoff:    retry.ConstantBackoff(15 * time.Second),
+		Classifier: drainRetryClassifier,
+	}, func(context.Context) error {
+		return nodeToDrain.Drain()
+	})
 	if err != nil {
 		duration := time.Since(startTime)
 
 		return fmt.Errorf("failed to drain node %q after %v: %w",
-			nodeToDrain.Definition.Name, duration, lastErr)
+			nodeToDrain.Definition.Name, duration, err)
 	}
 
-	return lastErr
+	klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
+		"Successfully drained node %q in %v",
+		nodeToDrain.Definition.Name, time.Since(startTime))
+
+	return nil
 }
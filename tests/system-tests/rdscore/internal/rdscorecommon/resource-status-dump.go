@@ -3,6 +3,9 @@ package rdscorecommon
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -18,10 +21,26 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/statefulset"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/storage"
 
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/reporter"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/rdscore/internal/rdscoreinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/rdscore/internal/rdscoreparams"
 )
 
+// statusDump writes the lines of a status dump to klog and, if out is set, to out as well so the dump can be saved in
+// a failure bundle.
+type statusDump struct {
+	out io.Writer
+}
+
+// Infof logs a line of the dump.
+func (dump statusDump) Infof(format string, args ...any) {
+	klog.V(rdscoreparams.RDSCoreLogLevel).Infof(format, args...)
+
+	if dump.out != nil {
+		_, _ = fmt.Fprintf(dump.out, format+"\n", args...)
+	}
+}
+
 // DumpPodStatusOnFailure dumps comprehensive pod status information when a pod fails to become ready.
 // This function provides detailed debugging information including pod conditions, container statuses,
 // and scheduling information to help diagnose test failures.
@@ -207,23 +226,28 @@ func DumpPodStatusOnFailure(podBuilder *pod.Builder, err error) {
 //   - namespace: The namespace to query for deployments
 //
 // The function creates a fresh context if the spec context is canceled and logs deployment details.
-//
-//nolint:funlen
 func DumpDeploymentStatus(ctx SpecContext, namespace string) {
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Deployment Status Dump for Namespace: %s", namespace)
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
-
 	// Check if the incoming context was already canceled
 	if ctx.Err() != nil {
 		klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
 			"WARNING: SpecContext was already canceled (%v), using fresh context for dump", ctx.Err())
 	}
 
+	// Use a fresh context to ensure dump works even if spec context is canceled
+	dumpDeploymentStatus(context.Background(), statusDump{}, namespace)
+}
+
+// dumpDeploymentStatus writes the deployment status dump to dump, giving up once ctx is done.
+//
+//nolint:funlen
+func dumpDeploymentStatus(ctx context.Context, dump statusDump, namespace string) {
+	dump.Infof("========================================")
+	dump.Infof("Deployment Status Dump for Namespace: %s", namespace)
+	dump.Infof("========================================")
+
 	var deployments []*deployment.Builder
 
-	// Create a fresh context to ensure dump works even if spec context is canceled
-	dumpCtx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	dumpCtx, cancel := context.WithTimeout(ctx, 1*time.Minute)
 	defer cancel()
 
 	err := wait.PollUntilContextTimeout(dumpCtx, 15*time.Second, 1*time.Minute, true,
@@ -232,7 +256,7 @@ func DumpDeploymentStatus(ctx SpecContext, namespace string) {
 
 			deployments, listErr = deployment.List(APIClient, namespace)
 			if listErr != nil {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Failed to list deployments (retrying...): %v", listErr)
+				dump.Infof("Failed to list deployments (retrying...): %v", listErr)
 
 				return false, nil
 			}
@@ -240,28 +264,28 @@ func DumpDeploymentStatus(ctx SpecContext, namespace string) {
 			return true, nil
 		})
 	if err != nil {
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Failed to retrieve deployment list after retries: %v", err)
+		dump.Infof("Failed to retrieve deployment list after retries: %v", err)
 
 		return
 	}
 
 	if len(deployments) == 0 {
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("No deployments found in namespace %q", namespace)
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
+		dump.Infof("No deployments found in namespace %q", namespace)
+		dump.Infof("========================================")
 
 		return
 	}
 
 	for _, deploy := range deployments {
 		if deploy.Object == nil {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Skipping deployment with nil Object")
+			dump.Infof("Skipping deployment with nil Object")
 
 			continue
 		}
 
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Deployment: %s", deploy.Object.Name)
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("----------------------------------------")
+		dump.Infof("")
+		dump.Infof("Deployment: %s", deploy.Object.Name)
+		dump.Infof("----------------------------------------")
 
 		// Replica Status
 		desiredReplicas := int32(0)
@@ -269,50 +293,50 @@ func DumpDeploymentStatus(ctx SpecContext, namespace string) {
 			desiredReplicas = *deploy.Object.Spec.Replicas
 		}
 
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Replicas:")
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Desired: %d", desiredReplicas)
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Current: %d", deploy.Object.Status.Replicas)
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Ready: %d", deploy.Object.Status.ReadyReplicas)
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Available: %d", deploy.Object.Status.AvailableReplicas)
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Updated: %d", deploy.Object.Status.UpdatedReplicas)
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Unavailable: %d", deploy.Object.Status.UnavailableReplicas)
+		dump.Infof("Replicas:")
+		dump.Infof("  Desired: %d", desiredReplicas)
+		dump.Infof("  Current: %d", deploy.Object.Status.Replicas)
+		dump.Infof("  Ready: %d", deploy.Object.Status.ReadyReplicas)
+		dump.Infof("  Available: %d", deploy.Object.Status.AvailableReplicas)
+		dump.Infof("  Updated: %d", deploy.Object.Status.UpdatedReplicas)
+		dump.Infof("  Unavailable: %d", deploy.Object.Status.UnavailableReplicas)
 
 		// Deployment Conditions
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Conditions:")
+		dump.Infof("")
+		dump.Infof("Conditions:")
 
 		if len(deploy.Object.Status.Conditions) > 0 {
 			for _, cond := range deploy.Object.Status.Conditions {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  - Type: %s, Status: %s, Reason: %s",
+				dump.Infof("  - Type: %s, Status: %s, Reason: %s",
 					cond.Type, cond.Status, cond.Reason)
 
 				if cond.Message != "" {
-					klog.V(rdscoreparams.RDSCoreLogLevel).Infof("    Message: %s", cond.Message)
+					dump.Infof("    Message: %s", cond.Message)
 				}
 			}
 		} else {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  <none>")
+			dump.Infof("  <none>")
 		}
 
 		// Strategy
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Strategy: %s", deploy.Object.Spec.Strategy.Type)
+		dump.Infof("")
+		dump.Infof("Strategy: %s", deploy.Object.Spec.Strategy.Type)
 
 		// Selector
 		if deploy.Object.Spec.Selector != nil && len(deploy.Object.Spec.Selector.MatchLabels) > 0 {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Selector Labels:")
+			dump.Infof("")
+			dump.Infof("Selector Labels:")
 
 			for key, value := range deploy.Object.Spec.Selector.MatchLabels {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  %s: %s", key, value)
+				dump.Infof("  %s: %s", key, value)
 			}
 		}
 	}
 
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("End of Deployment Status Dump for Namespace: %s", namespace)
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
+	dump.Infof("")
+	dump.Infof("========================================")
+	dump.Infof("End of Deployment Status Dump for Namespace: %s", namespace)
+	dump.Infof("========================================")
 }
 
 // DumpStatefulSetStatus dumps comprehensive status information for all StatefulSets in a given namespace.
@@ -323,23 +347,28 @@ func DumpDeploymentStatus(ctx SpecContext, namespace string) {
 //   - namespace: The namespace to query for statefulsets
 //
 // The function creates a fresh context if the spec context is canceled and logs statefulset details.
-//
-//nolint:funlen
 func DumpStatefulSetStatus(ctx SpecContext, namespace string) {
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("StatefulSet Status Dump for Namespace: %s", namespace)
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
-
 	// Check if the incoming context was already canceled
 	if ctx.Err() != nil {
 		klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
 			"WARNING: SpecContext was already canceled (%v), using fresh context for dump", ctx.Err())
 	}
 
+	// Use a fresh context to ensure dump works even if spec context is canceled
+	dumpStatefulSetStatus(context.Background(), statusDump{}, namespace)
+}
+
+// dumpStatefulSetStatus writes the statefulset status dump to dump, giving up once ctx is done.
+//
+//nolint:funlen
+func dumpStatefulSetStatus(ctx context.Context, dump statusDump, namespace string) {
+	dump.Infof("========================================")
+	dump.Infof("StatefulSet Status Dump for Namespace: %s", namespace)
+	dump.Infof("========================================")
+
 	var statefulsets []*statefulset.Builder
 
-	// Create a fresh context to ensure dump works even if spec context is canceled
-	dumpCtx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	dumpCtx, cancel := context.WithTimeout(ctx, 1*time.Minute)
 	defer cancel()
 
 	err := wait.PollUntilContextTimeout(dumpCtx, 15*time.Second, 1*time.Minute, true,
@@ -348,7 +377,7 @@ func DumpStatefulSetStatus(ctx SpecContext, namespace string) {
 
 			statefulsets, listErr = statefulset.List(APIClient, namespace)
 			if listErr != nil {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Failed to list statefulsets (retrying...): %v", listErr)
+				dump.Infof("Failed to list statefulsets (retrying...): %v", listErr)
 
 				return false, nil
 			}
@@ -356,28 +385,28 @@ func DumpStatefulSetStatus(ctx SpecContext, namespace string) {
 			return true, nil
 		})
 	if err != nil {
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Failed to retrieve statefulset list after retries: %v", err)
+		dump.Infof("Failed to retrieve statefulset list after retries: %v", err)
 
 		return
 	}
 
 	if len(statefulsets) == 0 {
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("No statefulsets found in namespace %q", namespace)
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
+		dump.Infof("No statefulsets found in namespace %q", namespace)
+		dump.Infof("========================================")
 
 		return
 	}
 
 	for _, sts := range statefulsets {
 		if sts.Object == nil {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Skipping statefulset with nil Object")
+			dump.Infof("Skipping statefulset with nil Object")
 
 			continue
 		}
 
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("StatefulSet: %s", sts.Object.Name)
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("----------------------------------------")
+		dump.Infof("")
+		dump.Infof("StatefulSet: %s", sts.Object.Name)
+		dump.Infof("----------------------------------------")
 
 		// Replica Status
 		desiredReplicas := int32(0)
@@ -385,60 +414,60 @@ func DumpStatefulSetStatus(ctx SpecContext, namespace string) {
 			desiredReplicas = *sts.Object.Spec.Replicas
 		}
 
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Replicas:")
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Desired: %d", desiredReplicas)
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Current: %d", sts.Object.Status.Replicas)
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Ready: %d", sts.Object.Status.ReadyReplicas)
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Updated: %d", sts.Object.Status.UpdatedReplicas)
+		dump.Infof("Replicas:")
+		dump.Infof("  Desired: %d", desiredReplicas)
+		dump.Infof("  Current: %d", sts.Object.Status.Replicas)
+		dump.Infof("  Ready: %d", sts.Object.Status.ReadyReplicas)
+		dump.Infof("  Updated: %d", sts.Object.Status.UpdatedReplicas)
 
 		// StatefulSet-specific status
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Current Revision: %s", sts.Object.Status.CurrentRevision)
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Update Revision: %s", sts.Object.Status.UpdateRevision)
+		dump.Infof("")
+		dump.Infof("Current Revision: %s", sts.Object.Status.CurrentRevision)
+		dump.Infof("Update Revision: %s", sts.Object.Status.UpdateRevision)
 
 		if sts.Object.Status.ObservedGeneration > 0 {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Observed Generation: %d", sts.Object.Status.ObservedGeneration)
+			dump.Infof("Observed Generation: %d", sts.Object.Status.ObservedGeneration)
 		}
 
 		// StatefulSet Conditions
 		if len(sts.Object.Status.Conditions) > 0 {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Conditions:")
+			dump.Infof("")
+			dump.Infof("Conditions:")
 
 			for _, cond := range sts.Object.Status.Conditions {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  - Type: %s, Status: %s, Reason: %s",
+				dump.Infof("  - Type: %s, Status: %s, Reason: %s",
 					cond.Type, cond.Status, cond.Reason)
 
 				if cond.Message != "" {
-					klog.V(rdscoreparams.RDSCoreLogLevel).Infof("    Message: %s", cond.Message)
+					dump.Infof("    Message: %s", cond.Message)
 				}
 			}
 		}
 
 		// Update Strategy
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Update Strategy: %s", sts.Object.Spec.UpdateStrategy.Type)
+		dump.Infof("")
+		dump.Infof("Update Strategy: %s", sts.Object.Spec.UpdateStrategy.Type)
 
 		// Service Name
 		if sts.Object.Spec.ServiceName != "" {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Service Name: %s", sts.Object.Spec.ServiceName)
+			dump.Infof("Service Name: %s", sts.Object.Spec.ServiceName)
 		}
 
 		// Selector
 		if sts.Object.Spec.Selector != nil && len(sts.Object.Spec.Selector.MatchLabels) > 0 {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Selector Labels:")
+			dump.Infof("")
+			dump.Infof("Selector Labels:")
 
 			for key, value := range sts.Object.Spec.Selector.MatchLabels {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  %s: %s", key, value)
+				dump.Infof("  %s: %s", key, value)
 			}
 		}
 	}
 
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("End of StatefulSet Status Dump for Namespace: %s", namespace)
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
+	dump.Infof("")
+	dump.Infof("========================================")
+	dump.Infof("End of StatefulSet Status Dump for Namespace: %s", namespace)
+	dump.Infof("========================================")
 }
 
 // DumpPersistentVolumeStatus dumps comprehensive status information for all PersistentVolumes in the cluster.
@@ -448,23 +477,28 @@ func DumpStatefulSetStatus(ctx SpecContext, namespace string) {
 //   - ctx: The SpecContext for the current test (can be canceled)
 //
 // The function creates a fresh context if the spec context is canceled and logs PV details.
-//
-//nolint:funlen
 func DumpPersistentVolumeStatus(ctx SpecContext) {
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("PersistentVolume Status Dump (Cluster-wide)")
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
-
 	// Check if the incoming context was already canceled
 	if ctx.Err() != nil {
 		klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
 			"WARNING: SpecContext was already canceled (%v), using fresh context for dump", ctx.Err())
 	}
 
+	// Use a fresh context to ensure dump works even if spec context is canceled
+	dumpPersistentVolumeStatus(context.Background(), statusDump{})
+}
+
+// dumpPersistentVolumeStatus writes the PersistentVolume status dump to dump, giving up once ctx is done.
+//
+//nolint:funlen
+func dumpPersistentVolumeStatus(ctx context.Context, dump statusDump) {
+	dump.Infof("========================================")
+	dump.Infof("PersistentVolume Status Dump (Cluster-wide)")
+	dump.Infof("========================================")
+
 	var pvs []*storage.PVBuilder
 
-	// Create a fresh context to ensure dump works even if spec context is canceled
-	dumpCtx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	dumpCtx, cancel := context.WithTimeout(ctx, 1*time.Minute)
 	defer cancel()
 
 	err := wait.PollUntilContextTimeout(dumpCtx, 15*time.Second, 1*time.Minute, true,
@@ -473,7 +507,7 @@ func DumpPersistentVolumeStatus(ctx SpecContext) {
 
 			pvs, listErr = storage.ListPV(APIClient)
 			if listErr != nil {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Failed to list PersistentVolumes (retrying...): %v", listErr)
+				dump.Infof("Failed to list PersistentVolumes (retrying...): %v", listErr)
 
 				return false, nil
 			}
@@ -481,75 +515,75 @@ func DumpPersistentVolumeStatus(ctx SpecContext) {
 			return true, nil
 		})
 	if err != nil {
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Failed to retrieve PersistentVolume list after retries: %v", err)
+		dump.Infof("Failed to retrieve PersistentVolume list after retries: %v", err)
 
 		return
 	}
 
 	if len(pvs) == 0 {
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("No PersistentVolumes found in cluster")
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
+		dump.Infof("No PersistentVolumes found in cluster")
+		dump.Infof("========================================")
 
 		return
 	}
 
 	for _, persistentVolume := range pvs {
 		if persistentVolume.Object == nil {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Skipping PersistentVolume with nil Object")
+			dump.Infof("Skipping PersistentVolume with nil Object")
 
 			continue
 		}
 
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("PersistentVolume: %s", persistentVolume.Object.Name)
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("----------------------------------------")
+		dump.Infof("")
+		dump.Infof("PersistentVolume: %s", persistentVolume.Object.Name)
+		dump.Infof("----------------------------------------")
 
 		// Phase and basic info
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Phase: %s", persistentVolume.Object.Status.Phase)
+		dump.Infof("Phase: %s", persistentVolume.Object.Status.Phase)
 
 		// Capacity
 		if capacity, ok := persistentVolume.Object.Spec.Capacity["storage"]; ok {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Capacity: %s", capacity.String())
+			dump.Infof("Capacity: %s", capacity.String())
 		}
 
 		// Access Modes
 		if len(persistentVolume.Object.Spec.AccessModes) > 0 {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Access Modes:")
+			dump.Infof("Access Modes:")
 
 			for _, mode := range persistentVolume.Object.Spec.AccessModes {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  - %s", mode)
+				dump.Infof("  - %s", mode)
 			}
 		}
 
 		// Reclaim Policy
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Reclaim Policy: %s",
+		dump.Infof("Reclaim Policy: %s",
 			persistentVolume.Object.Spec.PersistentVolumeReclaimPolicy)
 
 		// Storage Class
 		if persistentVolume.Object.Spec.StorageClassName != "" {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Storage Class: %s", persistentVolume.Object.Spec.StorageClassName)
+			dump.Infof("Storage Class: %s", persistentVolume.Object.Spec.StorageClassName)
 		}
 
 		// Volume Mode
 		if persistentVolume.Object.Spec.VolumeMode != nil {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Volume Mode: %s", *persistentVolume.Object.Spec.VolumeMode)
+			dump.Infof("Volume Mode: %s", *persistentVolume.Object.Spec.VolumeMode)
 		}
 
 		// Claim Reference (which PVC is bound)
 		if persistentVolume.Object.Spec.ClaimRef != nil {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Claim Reference:")
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Namespace: %s", persistentVolume.Object.Spec.ClaimRef.Namespace)
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Name: %s", persistentVolume.Object.Spec.ClaimRef.Name)
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  UID: %s", persistentVolume.Object.Spec.ClaimRef.UID)
+			dump.Infof("Claim Reference:")
+			dump.Infof("  Namespace: %s", persistentVolume.Object.Spec.ClaimRef.Namespace)
+			dump.Infof("  Name: %s", persistentVolume.Object.Spec.ClaimRef.Name)
+			dump.Infof("  UID: %s", persistentVolume.Object.Spec.ClaimRef.UID)
 		} else {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Claim Reference: <none> (unbound)")
+			dump.Infof("Claim Reference: <none> (unbound)")
 		}
 
 		// Node Affinity (important for local volumes)
 		if persistentVolume.Object.Spec.NodeAffinity != nil && persistentVolume.Object.Spec.NodeAffinity.Required != nil {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Node Affinity:")
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Required NodeSelectorTerms: %d",
+			dump.Infof("")
+			dump.Infof("Node Affinity:")
+			dump.Infof("  Required NodeSelectorTerms: %d",
 				len(persistentVolume.Object.Spec.NodeAffinity.Required.NodeSelectorTerms))
 		}
 
@@ -573,14 +607,14 @@ func DumpPersistentVolumeStatus(ctx SpecContext) {
 			volumeSource = "Local"
 		}
 
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Volume Source: %s", volumeSource)
+		dump.Infof("")
+		dump.Infof("Volume Source: %s", volumeSource)
 	}
 
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("End of PersistentVolume Status Dump")
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
+	dump.Infof("")
+	dump.Infof("========================================")
+	dump.Infof("End of PersistentVolume Status Dump")
+	dump.Infof("========================================")
 }
 
 // DumpPersistentVolumeClaimStatus dumps comprehensive status information for all PersistentVolumeClaims in a namespace.
@@ -591,23 +625,28 @@ func DumpPersistentVolumeStatus(ctx SpecContext) {
 //   - namespace: The namespace to query for PVCs
 //
 // The function creates a fresh context if the spec context is canceled and logs PVC details.
-//
-//nolint:funlen,gocognit
 func DumpPersistentVolumeClaimStatus(ctx SpecContext, namespace string) {
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("PersistentVolumeClaim Status Dump for Namespace: %s", namespace)
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
-
 	// Check if the incoming context was already canceled
 	if ctx.Err() != nil {
 		klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
 			"WARNING: SpecContext was already canceled (%v), using fresh context for dump", ctx.Err())
 	}
 
+	// Use a fresh context to ensure dump works even if spec context is canceled
+	dumpPersistentVolumeClaimStatus(context.Background(), statusDump{}, namespace)
+}
+
+// dumpPersistentVolumeClaimStatus writes the PersistentVolumeClaim status dump to dump, giving up once ctx is done.
+//
+//nolint:funlen,gocognit
+func dumpPersistentVolumeClaimStatus(ctx context.Context, dump statusDump, namespace string) {
+	dump.Infof("========================================")
+	dump.Infof("PersistentVolumeClaim Status Dump for Namespace: %s", namespace)
+	dump.Infof("========================================")
+
 	var pvcs []*storage.PVCBuilder
 
-	// Create a fresh context to ensure dump works even if spec context is canceled
-	dumpCtx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	dumpCtx, cancel := context.WithTimeout(ctx, 1*time.Minute)
 	defer cancel()
 
 	err := wait.PollUntilContextTimeout(dumpCtx, 15*time.Second, 1*time.Minute, true,
@@ -616,7 +655,7 @@ func DumpPersistentVolumeClaimStatus(ctx SpecContext, namespace string) {
 
 			pvcs, listErr = storage.ListPVC(APIClient, namespace)
 			if listErr != nil {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
+				dump.Infof(
 					"Failed to list PersistentVolumeClaims in namespace %q (retrying...): %v", namespace, listErr)
 
 				return false, nil
@@ -625,86 +664,86 @@ func DumpPersistentVolumeClaimStatus(ctx SpecContext, namespace string) {
 			return true, nil
 		})
 	if err != nil {
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof(
+		dump.Infof(
 			"Failed to retrieve PersistentVolumeClaim list for namespace %q after retries: %v", namespace, err)
 
 		return
 	}
 
 	if len(pvcs) == 0 {
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("No PersistentVolumeClaims found in namespace %q", namespace)
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
+		dump.Infof("No PersistentVolumeClaims found in namespace %q", namespace)
+		dump.Infof("========================================")
 
 		return
 	}
 
 	for _, pvc := range pvcs {
 		if pvc.Object == nil {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Skipping PersistentVolumeClaim with nil Object")
+			dump.Infof("Skipping PersistentVolumeClaim with nil Object")
 
 			continue
 		}
 
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("PersistentVolumeClaim: %s", pvc.Object.Name)
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("----------------------------------------")
+		dump.Infof("")
+		dump.Infof("PersistentVolumeClaim: %s", pvc.Object.Name)
+		dump.Infof("----------------------------------------")
 
 		// Phase
-		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Phase: %s", pvc.Object.Status.Phase)
+		dump.Infof("Phase: %s", pvc.Object.Status.Phase)
 
 		// Access Modes
 		if len(pvc.Object.Spec.AccessModes) > 0 {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Access Modes:")
+			dump.Infof("Access Modes:")
 
 			for _, mode := range pvc.Object.Spec.AccessModes {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  - %s", mode)
+				dump.Infof("  - %s", mode)
 			}
 		}
 
 		// Requested Storage
 		if storage, ok := pvc.Object.Spec.Resources.Requests["storage"]; ok {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Requested Storage: %s", storage.String())
+			dump.Infof("Requested Storage: %s", storage.String())
 		}
 
 		// Allocated Storage (actual)
 		if storage, ok := pvc.Object.Status.Capacity["storage"]; ok {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Allocated Storage: %s", storage.String())
+			dump.Infof("Allocated Storage: %s", storage.String())
 		}
 
 		// Storage Class
 		if pvc.Object.Spec.StorageClassName != nil && *pvc.Object.Spec.StorageClassName != "" {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Storage Class: %s", *pvc.Object.Spec.StorageClassName)
+			dump.Infof("Storage Class: %s", *pvc.Object.Spec.StorageClassName)
 		} else {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Storage Class: <default>")
+			dump.Infof("Storage Class: <default>")
 		}
 
 		// Volume Mode
 		if pvc.Object.Spec.VolumeMode != nil {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Volume Mode: %s", *pvc.Object.Spec.VolumeMode)
+			dump.Infof("Volume Mode: %s", *pvc.Object.Spec.VolumeMode)
 		}
 
 		// Volume Name (bound PV)
 		if pvc.Object.Spec.VolumeName != "" {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Volume Name: %s", pvc.Object.Spec.VolumeName)
+			dump.Infof("Volume Name: %s", pvc.Object.Spec.VolumeName)
 		} else {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Volume Name: <none> (not bound)")
+			dump.Infof("Volume Name: <none> (not bound)")
 		}
 
 		// PVC Conditions
 		if len(pvc.Object.Status.Conditions) > 0 {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Conditions:")
+			dump.Infof("")
+			dump.Infof("Conditions:")
 
 			for _, cond := range pvc.Object.Status.Conditions {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  - Type: %s, Status: %s, Reason: %s",
+				dump.Infof("  - Type: %s, Status: %s, Reason: %s",
 					cond.Type, cond.Status, cond.Reason)
 
 				if cond.Message != "" {
-					klog.V(rdscoreparams.RDSCoreLogLevel).Infof("    Message: %s", cond.Message)
+					dump.Infof("    Message: %s", cond.Message)
 				}
 
 				if !cond.LastTransitionTime.IsZero() {
-					klog.V(rdscoreparams.RDSCoreLogLevel).Infof("    Last Transition: %s",
+					dump.Infof("    Last Transition: %s",
 						cond.LastTransitionTime.Format(time.RFC3339))
 				}
 			}
@@ -712,23 +751,23 @@ func DumpPersistentVolumeClaimStatus(ctx SpecContext, namespace string) {
 
 		// Selector (if present)
 		if pvc.Object.Spec.Selector != nil {
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Selector:")
+			dump.Infof("")
+			dump.Infof("Selector:")
 
 			if len(pvc.Object.Spec.Selector.MatchLabels) > 0 {
-				klog.V(rdscoreparams.RDSCoreLogLevel).Infof("  Match Labels:")
+				dump.Infof("  Match Labels:")
 
 				for key, value := range pvc.Object.Spec.Selector.MatchLabels {
-					klog.V(rdscoreparams.RDSCoreLogLevel).Infof("    %s: %s", key, value)
+					dump.Infof("    %s: %s", key, value)
 				}
 			}
 		}
 	}
 
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("")
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("End of PersistentVolumeClaim Status Dump for Namespace: %s", namespace)
-	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
+	dump.Infof("")
+	dump.Infof("========================================")
+	dump.Infof("End of PersistentVolumeClaim Status Dump for Namespace: %s", namespace)
+	dump.Infof("========================================")
 }
 
 // DumpPodLevelBondDeploymentDiagnostics logs comprehensive diagnostics when pod-level bond deployment
@@ -1077,3 +1116,61 @@ func DumpSRIOVSyncDiagnostics(
 	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("End of SRIOV Sync Diagnostics")
 	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("========================================")
 }
+
+// StatusCollectorName is the name StatusCollector is registered with.
+const StatusCollectorName = "status"
+
+// StatusCollector returns a reporter.Collector that saves the node and PersistentVolume status dumps, and the
+// deployment, statefulset, and PersistentVolumeClaim status dumps of each of the namespaces, to files in the directory
+// of the collector. These are the same dumps as DumpNodeStatus and the other Dump*Status functions log.
+func StatusCollector(namespaces []string) reporter.Collector {
+	return reporter.CollectorFunc(func(ctx context.Context, spec reporter.FailedSpec) error {
+		err := writeStatusDump(filepath.Join(spec.Dir, "nodes.log"), func(dump statusDump) {
+			dumpNodeStatus(ctx, dump)
+		})
+		if err != nil {
+			return err
+		}
+
+		err = writeStatusDump(filepath.Join(spec.Dir, "persistentvolumes.log"), func(dump statusDump) {
+			dumpPersistentVolumeStatus(ctx, dump)
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, namespace := range namespaces {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			err = writeStatusDump(filepath.Join(spec.Dir, namespace+".log"), func(dump statusDump) {
+				dumpDeploymentStatus(ctx, dump, namespace)
+				dumpStatefulSetStatus(ctx, dump, namespace)
+				dumpPersistentVolumeClaimStatus(ctx, dump, namespace)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return ctx.Err()
+	})
+}
+
+// writeStatusDump creates the file at path and calls writeDump with a statusDump that writes to it.
+func writeStatusDump(path string, writeDump func(dump statusDump)) error {
+	dumpFile, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create status dump file: %w", err)
+	}
+
+	writeDump(statusDump{out: dumpFile})
+
+	err = dumpFile.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close status dump file %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/link"
 	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/types"
 	corev1 "k8s.io/api/core/v1"
@@ -863,7 +864,7 @@ func getLinkRx(runningPod *pod.Builder, linkName string) (int, error) {
 		backoff,
 		func(ctx context.Context) (bool, error) {
 			linkRawInfo, err = runningPod.ExecCommandWithTimeout(
-				[]string{"/bin/bash", "-c", fmt.Sprintf("ip --json -s link show dev %s", linkName)}, getLinkRxTimeout)
+				[]string{"/bin/bash", "-c", link.ShowCommand(linkName)}, getLinkRxTimeout)
 			if err != nil {
 				klog.V(100).Infof("The link %s info is not available for the pod %s in namespace %s "+
 					"with error %v",
@@ -902,6 +903,10 @@ func getLinkRx(runningPod *pod.Builder, linkName string) (int, error) {
 			linkName, runningPod.Definition.Name, runningPod.Definition.Namespace, err)
 	}
 
+	// The command runs in the pod rather than on the host, so it is recorded under the node of the pod.
+	cluster.RecordExecResult(&cluster.ExecResult{
+		Node: runningPod.Object.Spec.NodeName, Command: link.ShowCommand(linkName), Stdout: linkRawInfo.String()})
+
 	return linkInfo.GetRxByte(), nil
 }
 
@@ -12,10 +12,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 
-	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
-
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -641,50 +638,14 @@ func NewCoreConfig() *CoreConfig {
 
 	log.Printf("Open config file %s", confFile)
 
-	err := readFile(&rdsCoreConf, confFile)
+	err := config.NewLoader().WithDefaultFile(confFile).WithEnvPrefix("ECO_RDSCORE_").Load(&rdsCoreConf)
 	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
+		log.Printf("Error to load config file %s: %v", confFile, err)
 
 		return nil
 	}
 
-	err = readEnv(&rdsCoreConf)
-	if err != nil {
-		log.Print("Error to read environment variables")
-
-		return nil
-	}
+	rdsCoreConf.WorkerLabelListOption = metav1.ListOptions{LabelSelector: rdsCoreConf.WorkerLabel}
 
 	return &rdsCoreConf
 }
-
-func readFile(rdsConfig *CoreConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-
-	err = decoder.Decode(&rdsConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(rdsConfig *CoreConfig) error {
-	err := envconfig.Process("", rdsConfig)
-	if err != nil {
-		return err
-	}
-
-	rdsConfig.WorkerLabelListOption = metav1.ListOptions{LabelSelector: rdsConfig.WorkerLabel}
-
-	return nil
-}
@@ -38,10 +38,12 @@ const (
 	// NodeDiscoveryRetryTimeout total timeout for node discovery operations.
 	NodeDiscoveryRetryTimeout = 20 * time.Second
 
-	// PromQueryCurlTimeout timeout in seconds for curl command.
-	// Set to 30s to exceed Prometheus query timeout (25s) while allowing multiple retries
+	// PromQueryTimeout timeout Prometheus applies to each query on the server side.
+	PromQueryTimeout = 25 * time.Second
+	// PromQueryCurlTimeout timeout for each curl command run in the Prometheus pod.
+	// Set to 30s to exceed Prometheus query timeout (PromQueryTimeout) while allowing multiple retries
 	// within PromQueryRetryTimeout of 120s.
-	PromQueryCurlTimeout = "30"
+	PromQueryCurlTimeout = 30 * time.Second
 )
 
 // Prometheus query templates (node name will be inserted via fmt.Sprintf).
@@ -219,19 +221,6 @@ type CPUAffinityInfo struct {
 	Source       string // Source of detection (e.g., "PerformanceProfile:name")
 }
 
-// PromQueryResponse represents Prometheus API response structure.
-type PromQueryResponse struct {
-	Status string        `json:"status"`
-	Data   PromQueryData `json:"data"`
-	Error  string        `json:"error,omitempty"`
-}
-
-// PromQueryData represents the data section of Prometheus response.
-type PromQueryData struct {
-	ResultType string       `json:"resultType"`
-	Result     []PromMetric `json:"result"`
-}
-
 // PromMetric represents an individual metric from Prometheus.
 type PromMetric struct {
 	Metric map[string]string `json:"metric"`
@@ -1,7 +1,9 @@
 package rds_core_system_test
 
 import (
+	"maps"
 	"runtime"
+	"slices"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -9,6 +11,8 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/reporter"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/retry"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/rdscore/internal/rdscorecommon"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/rdscore/internal/rdscoreparams"
 
 	_ "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/rdscore/tests"
@@ -24,6 +28,13 @@ func TestRDSCore(t *testing.T) {
 	RunSpecs(t, "RDS Core SystemTests Suite", Label(rdscoreparams.Labels...), reporterConfig)
 }
 
+var _ = BeforeSuite(func() {
+	By("Registering the resource status failure collector")
+
+	reporter.RegisterCollector(rdscorecommon.StatusCollectorName, 0,
+		rdscorecommon.StatusCollector(slices.Sorted(maps.Keys(rdscoreparams.ReporterNamespacesToDump))))
+})
+
 var _ = JustAfterEach(func() {
 	reporter.ReportIfFailed(
 		CurrentSpecReport(), currentFile, rdscoreparams.ReporterNamespacesToDump, rdscoreparams.ReporterCRDsToDump)
@@ -32,4 +43,6 @@ var _ = JustAfterEach(func() {
 var _ = ReportAfterSuite("", func(report Report) {
 	reportxml.Create(
 		report, GeneralConfig.GetReportPath(), GeneralConfig.TCPrefix)
+
+	AddReportEntry(retry.ReportEntryName, retry.Report())
 })
@@ -424,14 +424,6 @@ var _ = Describe(
 				reportxml.ID("89775"), func() {
 					rdscorecommon.MeasureMemoryWithDynamicDuration(suiteStartTime)
 				})
-
-			AfterEach(func(ctx SpecContext) {
-				// Check if the test failed using CurrentSpecReport
-				if CurrentSpecReport().Failed() {
-					By("Dumping node status information due to test failure")
-					rdscorecommon.DumpNodeStatus(ctx)
-				}
-			})
 		})
 
 		Context("Ungraceful Cluster Reboot", Ordered, Label("ungraceful-cluster-reboot"), func() {
@@ -832,14 +824,6 @@ var _ = Describe(
 				reportxml.ID("89776"), func() {
 					rdscorecommon.MeasureMemoryWithDynamicDuration(rebootStartTime)
 				})
-
-			AfterEach(func(ctx SpecContext) {
-				// Check if the test failed using CurrentSpecReport
-				if CurrentSpecReport().Failed() {
-					By("Dumping node status information due to test failure")
-					rdscorecommon.DumpNodeStatus(ctx)
-				}
-			})
 		})
 
 		Context("Graceful Cluster Reboot", Ordered, Label("graceful-cluster-reboot"), func() {
@@ -1207,13 +1191,5 @@ var _ = Describe(
 				reportxml.ID("89777"), func() {
 					rdscorecommon.MeasureMemoryWithDynamicDuration(rebootStartTime)
 				})
-
-			AfterEach(func(ctx SpecContext) {
-				// Check if the test failed using CurrentSpecReport
-				if CurrentSpecReport().Failed() {
-					By("Dumping node status information due to test failure")
-					rdscorecommon.DumpNodeStatus(ctx)
-				}
-			})
 		})
 	})
@@ -3,15 +3,13 @@ package vcoreconfig
 import (
 	"fmt"
 	"log"
-	"os"
 	"path/filepath"
 	"runtime"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/systemtestsconfig"
-	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -62,60 +60,24 @@ func NewVCoreConfig() *VCoreConfig {
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultVCoreParamsFile)
 
-	err := readFile(&vcoreConf, confFile)
+	err := config.NewLoader().WithDefaultFile(confFile).WithEnvPrefix("ECO_SYSTEM_VCORE_").Load(&vcoreConf)
 	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
+		log.Printf("Error to load config file %s: %v", confFile, err)
 
 		return nil
 	}
 
-	err = readEnv(&vcoreConf)
-	if err != nil {
-		log.Print("Error to read environment variables")
-
-		return nil
-	}
+	vcoreConf.OdfLabel = fmt.Sprintf("%s/%s", vcoreConf.KubernetesRolePrefix, vcoreConf.OdfMCPName)
+	vcoreConf.VCorePpLabel = fmt.Sprintf("%s/%s", vcoreConf.KubernetesRolePrefix, vcoreConf.VCorePpMCPName)
+	vcoreConf.VCoreCpLabel = fmt.Sprintf("%s/%s", vcoreConf.KubernetesRolePrefix, vcoreConf.VCoreCpMCPName)
+	vcoreConf.ControlPlaneLabelListOption = metav1.ListOptions{LabelSelector: vcoreConf.ControlPlaneLabel}
+	vcoreConf.WorkerLabelListOption = metav1.ListOptions{LabelSelector: vcoreConf.WorkerLabel}
+	vcoreConf.OdfLabelListOption = metav1.ListOptions{LabelSelector: vcoreConf.OdfLabel}
+	vcoreConf.VCorePpLabelListOption = metav1.ListOptions{LabelSelector: vcoreConf.VCorePpLabel}
+	vcoreConf.VCoreCpLabelListOption = metav1.ListOptions{LabelSelector: vcoreConf.VCoreCpLabel}
+	vcoreConf.OdfLabelMap = map[string]string{vcoreConf.OdfLabel: ""}
+	vcoreConf.VCorePpLabelMap = map[string]string{vcoreConf.VCorePpLabel: ""}
+	vcoreConf.VCoreCpLabelMap = map[string]string{vcoreConf.VCoreCpLabel: ""}
 
 	return &vcoreConf
 }
-
-func readFile(vcoreConfig *VCoreConfig, cfgFile string) error {
-	openedCfgFile, err := os.Open(cfgFile)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		_ = openedCfgFile.Close()
-	}()
-
-	decoder := yaml.NewDecoder(openedCfgFile)
-
-	err = decoder.Decode(&vcoreConfig)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func readEnv(vcoreConfig *VCoreConfig) error {
-	err := envconfig.Process("", vcoreConfig)
-	if err != nil {
-		return err
-	}
-
-	vcoreConfig.OdfLabel = fmt.Sprintf("%s/%s", vcoreConfig.KubernetesRolePrefix, vcoreConfig.OdfMCPName)
-	vcoreConfig.VCorePpLabel = fmt.Sprintf("%s/%s", vcoreConfig.KubernetesRolePrefix, vcoreConfig.VCorePpMCPName)
-	vcoreConfig.VCoreCpLabel = fmt.Sprintf("%s/%s", vcoreConfig.KubernetesRolePrefix, vcoreConfig.VCoreCpMCPName)
-	vcoreConfig.ControlPlaneLabelListOption = metav1.ListOptions{LabelSelector: vcoreConfig.ControlPlaneLabel}
-	vcoreConfig.WorkerLabelListOption = metav1.ListOptions{LabelSelector: vcoreConfig.WorkerLabel}
-	vcoreConfig.OdfLabelListOption = metav1.ListOptions{LabelSelector: vcoreConfig.OdfLabel}
-	vcoreConfig.VCorePpLabelListOption = metav1.ListOptions{LabelSelector: vcoreConfig.VCorePpLabel}
-	vcoreConfig.VCoreCpLabelListOption = metav1.ListOptions{LabelSelector: vcoreConfig.VCoreCpLabel}
-	vcoreConfig.OdfLabelMap = map[string]string{vcoreConfig.OdfLabel: ""}
-	vcoreConfig.VCorePpLabelMap = map[string]string{vcoreConfig.VCorePpLabel: ""}
-	vcoreConfig.VCoreCpLabelMap = map[string]string{vcoreConfig.VCoreCpLabel: ""}
-
-	return nil
-}